@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+)
+
+func newCancelCmd() *cobra.Command {
+	var opts clientOptions
+
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Request cancellation of a running workflow",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.requireWorkflowID(); err != nil {
+				return err
+			}
+
+			c, err := opts.connect()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			if err := c.CancelWorkflow(cmd.Context(), opts.WorkflowID, opts.RunID); err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to cancel workflow",
+				}
+			}
+
+			return nil
+		},
+	}
+
+	registerClientFlags(cmd, &opts)
+
+	return cmd
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+	"go.temporal.io/sdk/client"
+)
+
+// clientOptions are shared by the commands that act on workflow executions
+type clientOptions struct {
+	runOptions
+
+	FilePath   string
+	Input      string
+	InputFile  string
+	RunID      string
+	WorkflowID string
+}
+
+func registerClientFlags(cmd *cobra.Command, opts *clientOptions) {
+	registerConnectionFlags(cmd, &opts.runOptions)
+	registerWorkflowIDFlag(cmd, opts)
+
+	cmd.Flags().StringVar(
+		&opts.RunID, "run-id",
+		viper.GetString("run_id"), "Run ID - defaults to the latest run",
+	)
+}
+
+func registerWorkflowFileFlag(cmd *cobra.Command, opts *clientOptions) {
+	cmd.Flags().StringVarP(
+		&opts.FilePath, "file", "f",
+		viper.GetString("workflow_file"), "Path to workflow file",
+	)
+}
+
+func registerWorkflowIDFlag(cmd *cobra.Command, opts *clientOptions) {
+	cmd.Flags().StringVar(
+		&opts.WorkflowID, "workflow-id",
+		viper.GetString("workflow_id"), "Workflow ID",
+	)
+}
+
+func registerInputFlags(cmd *cobra.Command, opts *clientOptions) {
+	cmd.Flags().StringVarP(
+		&opts.Input, "input", "i",
+		viper.GetString("input"), "Input as JSON",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.InputFile, "input-file",
+		viper.GetString("input_file"), `Path to a JSON input file - use "-" to read from stdin`,
+	)
+	cmd.MarkFlagsMutuallyExclusive("input", "input-file")
+}
+
+// readInput returns the input from the flag, file or stdin. No input is nil.
+func (o *clientOptions) readInput(stdin io.Reader) (any, error) {
+	raw := []byte(o.Input)
+
+	switch o.InputFile {
+	case "":
+	case "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("error reading input from stdin: %w", err)
+		}
+		raw = data
+	default:
+		data, err := os.ReadFile(filepath.Clean(o.InputFile))
+		if err != nil {
+			return nil, fmt.Errorf("error reading input file: %w", err)
+		}
+		raw = data
+	}
+
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var input any
+	if err := json.Unmarshal(raw, &input); err != nil {
+		return nil, fmt.Errorf("input must be valid JSON: %w", err)
+	}
+
+	return input, nil
+}
+
+// loadWorkflow loads the workflow file if one is set
+func (o *clientOptions) loadWorkflow() (*model.Workflow, error) {
+	if o.FilePath == "" {
+		return nil, nil
+	}
+
+	wf, err := zigflow.LoadFromFile(o.FilePath)
+	if err != nil {
+		return nil, gh.FatalError{
+			Cause: err,
+			Msg:   "Unable to load workflow file",
+		}
+	}
+
+	return wf, nil
+}
+
+// connect opens a Temporal client for the command
+func (o *clientOptions) connect() (client.Client, error) {
+	c, err := newTemporalClient(&o.runOptions)
+	if err != nil {
+		return nil, gh.FatalError{
+			Cause: err,
+			Msg:   "Unable to create client",
+		}
+	}
+
+	return c, nil
+}
+
+func (o *clientOptions) requireWorkflowID() error {
+	if o.WorkflowID == "" {
+		return gh.FatalError{
+			Msg: "Workflow ID must be set with --workflow-id",
+		}
+	}
+	return nil
+}
+
+// validateListener checks that a listen task declares the handler. Without a
+// workflow file there is nothing to check against.
+func validateListener(wf *model.Workflow, listenerType tasks.ListenTaskType, id string) error {
+	if wf == nil {
+		return nil
+	}
+
+	if zigflow.FindListener(wf, listenerType, id) == nil {
+		available := []string{}
+		for _, l := range zigflow.Listeners(wf) {
+			if l.Type == listenerType {
+				available = append(available, l.ID)
+			}
+		}
+
+		return gh.FatalError{
+			Cause: fmt.Errorf("no listen task declares %s %q - available: %v", listenerType, id, available),
+			Msg:   fmt.Sprintf("Unknown %s", listenerType),
+		}
+	}
+
+	return nil
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+const listenWorkflowYAML = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: approval
+  version: 0.0.1
+do:
+  - wait:
+      listen:
+        to:
+          all:
+            - with:
+                id: approve
+                type: signal
+            - with:
+                id: status
+                type: query
+            - with:
+                id: confirm
+                type: update`
+
+func TestClientOptionsReadInput(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "client_test")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	inputFile := filepath.Join(tmpDir, "input.json")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`{"from": "file"}`), 0o600))
+
+	tests := []struct {
+		Name        string
+		Opts        clientOptions
+		Stdin       string
+		Expected    any
+		ExpectError bool
+	}{
+		{
+			Name: "no input",
+		},
+		{
+			Name:     "flag",
+			Opts:     clientOptions{Input: `{"from": "flag"}`},
+			Expected: map[string]any{"from": "flag"},
+		},
+		{
+			Name:     "file",
+			Opts:     clientOptions{InputFile: inputFile},
+			Expected: map[string]any{"from": "file"},
+		},
+		{
+			Name:     "stdin",
+			Opts:     clientOptions{InputFile: "-"},
+			Stdin:    `["from", "stdin"]`,
+			Expected: []any{"from", "stdin"},
+		},
+		{
+			Name:        "invalid JSON",
+			Opts:        clientOptions{Input: "{"},
+			ExpectError: true,
+		},
+		{
+			Name:        "missing file",
+			Opts:        clientOptions{InputFile: filepath.Join(tmpDir, "missing.json")},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			input, err := test.Opts.readInput(strings.NewReader(test.Stdin))
+			if test.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, input)
+		})
+	}
+}
+
+func TestClientCommandValidation(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "client_test")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	filePath := filepath.Join(tmpDir, "workflow.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte(listenWorkflowYAML), 0o600))
+
+	// Every case fails before a connection to Temporal is attempted
+	tests := []struct {
+		Name  string
+		Cmd   func() *cobra.Command
+		Args  []string
+		Error string
+	}{
+		{
+			Name:  "start without a workflow file",
+			Cmd:   newStartCmd,
+			Error: "Workflow file must be set with --file",
+		},
+		{
+			Name:  "start with an unknown workflow type",
+			Cmd:   newStartCmd,
+			Args:  []string{"-f", filePath, "-w", "unknown"},
+			Error: `workflow "unknown" is not defined`,
+		},
+		{
+			Name:  "start with invalid input",
+			Cmd:   newStartCmd,
+			Args:  []string{"-f", filePath, "-i", "{"},
+			Error: "input must be valid JSON",
+		},
+		{
+			Name:  "signal without a workflow ID",
+			Cmd:   newSignalCmd,
+			Args:  []string{"approve"},
+			Error: "Workflow ID must be set with --workflow-id",
+		},
+		{
+			Name:  "signal not declared in the workflow",
+			Cmd:   newSignalCmd,
+			Args:  []string{"confirm", "--workflow-id", "wf", "-f", filePath},
+			Error: `no listen task declares signal "confirm"`,
+		},
+		{
+			Name:  "query not declared in the workflow",
+			Cmd:   newQueryCmd,
+			Args:  []string{"approve", "--workflow-id", "wf", "-f", filePath},
+			Error: `no listen task declares query "approve"`,
+		},
+		{
+			Name:  "update not declared in the workflow",
+			Cmd:   newUpdateCmd,
+			Args:  []string{"status", "--workflow-id", "wf", "-f", filePath},
+			Error: `no listen task declares update "status"`,
+		},
+		{
+			Name:  "cancel without a workflow ID",
+			Cmd:   newCancelCmd,
+			Error: "Workflow ID must be set with --workflow-id",
+		},
+		{
+			Name:  "result without a workflow ID",
+			Cmd:   newResultCmd,
+			Error: "Workflow ID must be set with --workflow-id",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cmd := test.Cmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(test.Args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.Error)
+		})
+	}
+}
+
+func TestResolveWorkflowType(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "client_test")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	filePath := filepath.Join(tmpDir, "workflow.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte(listenWorkflowYAML), 0o600))
+
+	wf, err := zigflow.LoadFromFile(filePath)
+	require.NoError(t, err)
+
+	workflowType, err := resolveWorkflowType(wf, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "approval", workflowType)
+
+	workflowType, err = resolveWorkflowType(wf, "approval")
+	assert.NoError(t, err)
+	assert.Equal(t, "approval", workflowType)
+
+	_, err = resolveWorkflowType(wf, "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"slices"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+func newConvertCmd() *cobra.Command {
+	var opts struct {
+		To string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "convert <workflow-file>",
+		Short: "Convert a Zigflow workflow between YAML and JSON",
+		Long: `Convert a Zigflow workflow between YAML and JSON.
+
+The workflow is written to stdout in canonical form, as "zigflow fmt" would
+write it. YAML anchors and merge keys are expanded in JSON. Comments are lost
+when converting to JSON.
+
+The converted workflow is loaded and checked to be the same workflow as the
+original, so it can be run, validated and tested in the same way.
+
+Arguments:
+  workflow-file   Path to the Zigflow workflow file to convert`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filePath := args[0]
+
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error reading workflow file",
+				}
+			}
+
+			to := zigflow.Encoding(opts.To)
+			if opts.To == "" {
+				// Default to the other encoding
+				to = zigflow.EncodingJSON
+				if zigflow.DetectEncoding(filePath, data) == zigflow.EncodingJSON {
+					to = zigflow.EncodingYAML
+				}
+			}
+			if !slices.Contains(zigflow.Encodings, to) {
+				return gh.FatalError{
+					Msg: "Unknown encoding",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("to", opts.To).Interface("allowed", zigflow.Encodings)
+					},
+				}
+			}
+
+			out, err := zigflow.Convert(data, to)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error converting workflow file",
+				}
+			}
+
+			if _, err := os.Stdout.Write(out); err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error writing workflow",
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&opts.To, "to",
+		"", "Encoding to convert to (yaml or json). Defaults to the one the file isn't in",
+	)
+
+	return cmd
+}
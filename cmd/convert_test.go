@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConvertCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		File           string
+		Content        string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:           "yaml to json by default",
+			File:           "workflow.yaml",
+			Content:        validWorkflowYAML,
+			OutputContains: []string{"{\n  \"document\": {\n", `"hello": "world"`},
+		},
+		{
+			Name:           "json to yaml by default",
+			File:           "workflow.json",
+			Content:        `{"document": {"dsl": "1.0.0", "namespace": "default", "name": "test", "version": "0.0.1"}, "do": [{"step": {"set": {"hello": "world"}}}]}`,
+			OutputContains: []string{"document:\n  dsl: 1.0.0\n", "hello: world"},
+		},
+		{
+			Name:           "explicit encoding",
+			File:           "workflow.yaml",
+			Content:        unformattedWorkflowYAML,
+			ExtraArgs:      []string{"--to", "yaml"},
+			OutputContains: []string{"document:\n  dsl: 1.0.0\n"},
+		},
+		{
+			Name:        "unknown encoding",
+			File:        "workflow.yaml",
+			Content:     validWorkflowYAML,
+			ExtraArgs:   []string{"--to", "toml"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid YAML",
+			File:        "workflow.yaml",
+			Content:     "invalid content: [",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), test.File)
+			require.NoError(t, os.WriteFile(filePath, []byte(test.Content), 0o600))
+
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newConvertCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{filePath}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+				return
+			}
+			assert.NoError(t, execErr)
+			for _, want := range test.OutputContains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
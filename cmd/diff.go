@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+var diffOutputFormats = []string{"human", "json"}
+
+func newDiffCmd() *cobra.Command {
+	var opts struct {
+		Output string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff <previous-file> <next-file>",
+		Short: "Check whether running workflows can safely move to a new version",
+		Long: `Check whether running workflows can safely move to a new version.
+
+This command compares two versions of a Zigflow workflow and reports every
+change that affects executions already running on the task queue. Temporal
+replays the history of a running execution against the new definition, so
+changes to the tasks it has already run break it.
+
+Breaking changes include:
+  - tasks removed, reordered or inserted before a listen or wait task
+  - renamed for, fork and try tasks, which rename their child workflows
+  - removed or changed signal, query and update IDs
+  - stricter input schemas
+
+Moving the workflow to a new task queue is always safe, as long as a worker
+with the previous definition runs until its executions complete.
+
+This is a static analysis. Use "zigflow replay" to check real histories.
+
+The command exits with a non-zero status code if there are breaking changes.
+
+Arguments:
+  previous-file   Path to the currently deployed workflow file
+  next-file       Path to the workflow file to deploy`,
+		Args: cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(diffOutputFormats, opts.Output) {
+				return gh.FatalError{
+					Msg: "Unknown output format",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("output", opts.Output).Strs("allowed", diffOutputFormats)
+					},
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validator, err := utils.NewValidator()
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error creating validator",
+				}
+			}
+
+			docs := make([]*model.Workflow, 0, len(args))
+			for _, file := range args {
+				doc, err := zigflow.LoadFromFile(file)
+				if err != nil {
+					return gh.FatalError{
+						Cause: err,
+						Msg:   "Unable to load workflow file",
+					}
+				}
+
+				if err := runValidation(validator, doc); err != nil {
+					return err
+				}
+
+				docs = append(docs, doc)
+			}
+
+			report := zigflow.Diff(docs[0], docs[1])
+
+			if opts.Output == "json" {
+				err = renderDiffJSON(os.Stdout, report)
+			} else {
+				err = renderDiffHuman(os.Stdout, report)
+			}
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error rendering result",
+				}
+			}
+
+			if report.Breaking {
+				return gh.FatalError{
+					Msg:    "Breaking changes found",
+					Logger: log.Trace,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	viper.SetDefault("diff_output", diffOutputFormats[0])
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("diff_output"), "Output format (human or json)",
+	)
+
+	return cmd
+}
+
+func renderDiffHuman(w io.Writer, report *zigflow.DiffReport) error {
+	if _, err := fmt.Fprintf(
+		w, "%s %s → %s %s\n\n",
+		report.Previous.Name, report.Previous.Version, report.Next.Name, report.Next.Version,
+	); err != nil {
+		return err
+	}
+
+	if len(report.Changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes affect running executions")
+		return err
+	}
+
+	for _, c := range report.Changes {
+		symbol := "✓"
+		switch c.Severity {
+		case zigflow.ChangeBreaking:
+			symbol = "✗"
+		case zigflow.ChangeWarning:
+			symbol = "!"
+		}
+
+		if _, err := fmt.Fprintf(w, "%s %-8s %s", symbol, c.Severity, c.Message); err != nil {
+			return err
+		}
+		if c.Task != "" && c.Workflow != report.Next.Name {
+			if _, err := fmt.Fprintf(w, " (in %s)", c.Workflow); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	if report.Breaking {
+		_, err := fmt.Fprintf(
+			w, "\nRunning executions on task queue %q cannot move to this version. "+
+				"Deploy it on a new task queue and keep the previous workers until their executions complete.\n",
+			report.Previous.TaskQueue,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func renderDiffJSON(w io.Writer, report *zigflow.DiffReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
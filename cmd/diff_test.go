@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDiffCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Next           string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:           "no changes",
+			Next:           listenWorkflowYAML,
+			OutputContains: []string{"No changes affect running executions"},
+		},
+		{
+			Name:           "breaking change",
+			Next:           strings.Replace(listenWorkflowYAML, "id: approve", "id: accept", 1),
+			ExpectError:    true,
+			OutputContains: []string{`✗ breaking signal "approve" removed`, `✓ safe     signal "accept" added`},
+		},
+		{
+			Name:           "JSON output",
+			Next:           strings.Replace(listenWorkflowYAML, "type: update", "type: signal", 1),
+			ExtraArgs:      []string{"--output", "json"},
+			ExpectError:    true,
+			OutputContains: []string{`"kind": "listener-changed"`, `"breaking": true`},
+		},
+		{
+			Name:        "unknown output format",
+			Next:        listenWorkflowYAML,
+			ExtraArgs:   []string{"-o", "yaml"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid workflow",
+			Next:        workflowMissingName,
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "diff_test")
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, os.RemoveAll(tmpDir))
+			}()
+
+			previous := filepath.Join(tmpDir, "previous.yaml")
+			require.NoError(t, os.WriteFile(previous, []byte(listenWorkflowYAML), 0o600))
+			next := filepath.Join(tmpDir, "next.yaml")
+			require.NoError(t, os.WriteFile(next, []byte(test.Next), 0o600))
+
+			// Capture stdout so we can assert on the generated output.
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newDiffCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{previous, next}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, err = buf.ReadFrom(r)
+			require.NoError(t, err)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+
+			for _, s := range test.OutputContains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
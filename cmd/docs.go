@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/apidocs"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+func newDocsCmd() *cobra.Command {
+	var opts struct {
+		HeadingLevel int
+		Output       string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "docs <workflow-file>",
+		Short: "Generate API reference docs for a Zigflow workflow",
+		Long: `Generate reference documentation for the callers of a Zigflow workflow.
+
+The reference describes how to start each workflow and the input schema it
+validates against, the output it returns, the signals, queries and updates
+exposed by its listen tasks, with their acceptIf conditions and reply data,
+and the CloudEvents emitted while it runs.
+
+Use the --output flag to select the format:
+
+  markdown   Markdown reference, for READMEs and developer portals
+  openapi    OpenAPI 3.1 document of the Temporal HTTP API calls that start
+             the workflows and send them signals, queries and updates
+  asyncapi   AsyncAPI 3.0 document of the signal, query and update handlers
+             and the CloudEvents emitted
+
+The output is written to stdout. Use "zigflow docs inject" to keep the docs
+in a Markdown file up to date.
+
+Arguments:
+  workflow-file   Path to the Zigflow workflow file to document`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, err := generateDocs(args[0], opts.Output, opts.HeadingLevel)
+			if err != nil {
+				return err
+			}
+
+			fmt.Print(output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		string(apidocs.FormatMarkdown), "Output format ("+docsFormats()+")",
+	)
+	cmd.Flags().IntVar(
+		&opts.HeadingLevel, "heading-level",
+		1, "Heading level of the Markdown title",
+	)
+
+	cmd.AddCommand(newDocsInjectCmd())
+
+	return cmd
+}
+
+func generateDocs(workflowFile, format string, headingLevel int) (string, error) {
+	wf, err := zigflow.LoadFromFile(workflowFile)
+	if err != nil {
+		return "", gh.FatalError{Cause: err, Msg: "Unable to load workflow file"}
+	}
+
+	gen, err := apidocs.New(apidocs.Format(format), apidocs.WithHeadingLevel(headingLevel))
+	if err != nil {
+		return "", gh.FatalError{Cause: err, Msg: "Unsupported output format"}
+	}
+
+	output, err := gen.Generate(wf)
+	if err != nil {
+		return "", gh.FatalError{Cause: err, Msg: "Error generating docs"}
+	}
+
+	return output, nil
+}
+
+// docsFormats lists the supported docs formats for flag descriptions
+func docsFormats() string {
+	formats := make([]string, 0, len(apidocs.Formats))
+	for _, f := range apidocs.Formats {
+		formats = append(formats, string(f))
+	}
+	return strings.Join(formats, ", ")
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/apidocs"
+	"github.com/zigflow/zigflow/pkg/graph"
+)
+
+func newDocsInjectCmd() *cobra.Command {
+	var opts struct {
+		HeadingLevel int
+		Output       string
+		WorkflowFile string
+		StartMarker  string
+		EndMarker    string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "inject [flags] <target-file> [target-file...]",
+		Short: "Inject workflow docs into a file between marker comments",
+		Long: `Inject a workflow's reference docs into one or more target files.
+
+This works like "zigflow graph inject". For each target file the command
+replaces everything between a pair of marker comments with freshly generated
+docs. Running inject is idempotent.
+
+The workflow file path is read from the start marker, relative to the target
+file, unless --workflow is set:
+
+    <!-- ZIGFLOW_DOCS_START ./workflow.yaml -->
+    <!-- ZIGFLOW_DOCS_END -->
+
+When no path is embedded the default "` + graph.DefaultWorkflowFile + `" is used. Files
+without a ZIGFLOW_DOCS_START marker are skipped silently.
+
+Markdown is injected as-is, starting at the --heading-level, so it nests
+under the document's title. The OpenAPI and AsyncAPI formats are injected
+as YAML code blocks.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, targetFile := range args {
+				workflowFile := opts.WorkflowFile
+				startMarker := opts.StartMarker
+
+				if workflowFile == "" {
+					data, err := os.ReadFile(targetFile)
+					if err != nil {
+						return gh.FatalError{Cause: err, Msg: "Unable to read target file"}
+					}
+
+					embedded, marker, found := graph.ParseEmbeddedPathWithPrefix(
+						string(data), apidocs.DefaultStartMarkerPrefix, graph.DefaultWorkflowFile,
+					)
+					if !found {
+						continue
+					}
+					workflowFile = filepath.Join(filepath.Dir(targetFile), embedded)
+					startMarker = marker
+				}
+
+				if err := execDocsInject(workflowFile, targetFile, opts.Output, opts.HeadingLevel, startMarker, opts.EndMarker); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		string(apidocs.FormatMarkdown), "Output format ("+docsFormats()+")",
+	)
+	cmd.Flags().IntVar(
+		&opts.HeadingLevel, "heading-level",
+		2, "Heading level of the Markdown title",
+	)
+	cmd.Flags().StringVarP(
+		&opts.WorkflowFile, "workflow", "w",
+		"", "Explicit workflow file (auto-detected from start marker if not set)",
+	)
+	cmd.Flags().StringVarP(
+		&opts.StartMarker, "start-marker", "s",
+		apidocs.DefaultStartMarkerPrefix+" -->", "Start marker comment (requires --workflow)",
+	)
+	cmd.Flags().StringVarP(
+		&opts.EndMarker, "end-marker", "e",
+		apidocs.DefaultEndMarker, "End marker comment",
+	)
+
+	return cmd
+}
+
+func execDocsInject(workflowFile, targetFile, outputFormat string, headingLevel int, startMarker, endMarker string) error {
+	// Resolve to an absolute path to prevent path traversal.
+	absTarget, err := filepath.Abs(targetFile)
+	if err != nil {
+		return gh.FatalError{Cause: err, Msg: "Unable to resolve target file path"}
+	}
+
+	output, err := generateDocs(workflowFile, outputFormat, headingLevel)
+	if err != nil {
+		return err
+	}
+
+	content := strings.TrimSuffix(output, "\n")
+	if apidocs.Format(outputFormat) != apidocs.FormatMarkdown {
+		content = fmt.Sprintf("```yaml\n%s```", output)
+	}
+
+	info, err := os.Stat(absTarget)
+	if err != nil {
+		return gh.FatalError{Cause: err, Msg: "Unable to read target file"}
+	}
+
+	fileData, err := os.ReadFile(absTarget)
+	if err != nil {
+		return gh.FatalError{Cause: err, Msg: "Unable to read target file"}
+	}
+
+	result, err := graph.InjectGraph(string(fileData), startMarker, endMarker, content)
+	if err != nil {
+		return gh.FatalError{Cause: err, Msg: "Unable to inject docs"}
+	}
+
+	// absTarget is resolved via filepath.Abs from a user-supplied CLI argument; path traversal is
+	// intentional and expected for a CLI tool. gosec G703 is a false positive here.
+	if err := os.WriteFile(absTarget, []byte(result), info.Mode()); err != nil { //nolint:gosec
+		return gh.FatalError{Cause: err, Msg: "Unable to write target file"}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDocsCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Content        string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:           "markdown by default",
+			Content:        validWorkflowYAML,
+			OutputContains: []string{"# test\n", "### `test`", "## Events"},
+		},
+		{
+			Name:           "markdown heading level",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"--heading-level", "3"},
+			OutputContains: []string{"### test\n", "##### `test`"},
+		},
+		{
+			Name:           "openapi output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "openapi"},
+			OutputContains: []string{"openapi: 3.1.0", "operationId: startWorkflow"},
+		},
+		{
+			Name:           "asyncapi output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "asyncapi"},
+			OutputContains: []string{"asyncapi: 3.0.0", "address: zigflow.dev/default/test"},
+		},
+		{
+			Name:        "invalid YAML",
+			Content:     "invalid content: [",
+			ExpectError: true,
+		},
+		{
+			Name:        "unsupported output format",
+			Content:     validWorkflowYAML,
+			ExtraArgs:   []string{"-o", "unknown"},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yaml")
+			require.NoError(t, os.WriteFile(filePath, []byte(test.Content), 0o600))
+
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newDocsCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{filePath}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+				return
+			}
+			assert.NoError(t, execErr)
+			for _, want := range test.OutputContains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestNewDocsInjectCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		TargetContent  string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:          "embedded workflow path",
+			TargetContent: "# Workflow\n\n<!-- ZIGFLOW_DOCS_START ./workflow.yaml -->\n<!-- ZIGFLOW_DOCS_END -->\n",
+			OutputContains: []string{
+				"<!-- ZIGFLOW_DOCS_START ./workflow.yaml -->\n## test\n",
+				"| `dev.zigflow.iteration.completed` | A for task completes an iteration | task name | `state`, `while` |\n<!-- ZIGFLOW_DOCS_END -->",
+			},
+		},
+		{
+			Name:          "asyncapi in a code block",
+			TargetContent: "<!-- ZIGFLOW_DOCS_START -->\n<!-- ZIGFLOW_DOCS_END -->\n",
+			ExtraArgs:     []string{"-o", "asyncapi"},
+			OutputContains: []string{
+				"<!-- ZIGFLOW_DOCS_START -->\n```yaml\nasyncapi: 3.0.0",
+				"```\n<!-- ZIGFLOW_DOCS_END -->",
+			},
+		},
+		{
+			Name:           "graph markers are ignored",
+			TargetContent:  targetWithMarkers,
+			OutputContains: []string{targetWithMarkers},
+		},
+		{
+			Name:          "explicit workflow without markers",
+			TargetContent: targetWithoutMarkers,
+			ExtraArgs:     []string{"--workflow", "PLACEHOLDER_WORKFLOW"},
+			ExpectError:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+
+			workflowPath := filepath.Join(tmpDir, "workflow.yaml")
+			require.NoError(t, os.WriteFile(workflowPath, []byte(validWorkflowYAML), 0o600))
+
+			targetPath := filepath.Join(tmpDir, "target.md")
+			require.NoError(t, os.WriteFile(targetPath, []byte(test.TargetContent), 0o600))
+
+			args := []string{targetPath}
+			for _, a := range test.ExtraArgs {
+				if a == "PLACEHOLDER_WORKFLOW" {
+					a = workflowPath
+				}
+				args = append(args, a)
+			}
+
+			cmd := newDocsInjectCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(args)
+			execErr := cmd.Execute()
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+				return
+			}
+			require.NoError(t, execErr)
+
+			written, err := os.ReadFile(targetPath)
+			require.NoError(t, err)
+			for _, want := range test.OutputContains {
+				assert.Contains(t, string(written), want)
+			}
+
+			// Injecting again doesn't change the file
+			cmd = newDocsInjectCmd()
+			cmd.SetArgs(args)
+			require.NoError(t, cmd.Execute())
+			again, err := os.ReadFile(targetPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(written), string(again))
+		})
+	}
+}
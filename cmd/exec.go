@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+var execOutputFormats = []string{"human", "json"}
+
+func newExecCmd() *cobra.Command {
+	var opts struct {
+		EnvPrefix string
+		Input     string
+		Output    string
+		Timeout   time.Duration
+		Workflow  string
+	}
+	// Only the secrets flags are used
+	secretsOpts := &runOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "exec <workflow-file>",
+		Short: "Execute a Zigflow workflow once without a Temporal server",
+		Long: `Execute a Zigflow workflow once without a Temporal server.
+
+This command compiles the workflow exactly as the worker does and runs it
+in-process on Temporal's in-memory test environment. Activities are real - HTTP
+and gRPC calls are made and run tasks are executed on this machine. Secrets
+are read from the provider set by the secrets flags, as with "zigflow run".
+
+Workflow time is skipped, so waits and timers resolve instantly. Listen tasks
+cannot receive events and will time out.
+
+The final workflow output is printed along with a trace of every task that ran.
+The command exits with a non-zero status code if the workflow fails.
+
+Arguments:
+  workflow-file   Path to the Zigflow workflow file to execute`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(execOutputFormats, opts.Output) {
+				return gh.FatalError{
+					Msg: "Unknown output format",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("output", opts.Output).Strs("allowed", execOutputFormats)
+					},
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input any
+			if opts.Input != "" {
+				if err := json.Unmarshal([]byte(opts.Input), &input); err != nil {
+					return gh.FatalError{
+						Cause: err,
+						Msg:   "Input must be valid JSON",
+					}
+				}
+			}
+
+			workflowDefinition, err := zigflow.LoadFromFile(args[0])
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to load workflow file",
+				}
+			}
+
+			validator, err := utils.NewValidator()
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error creating validator",
+				}
+			}
+
+			if err := runValidation(validator, workflowDefinition); err != nil {
+				return err
+			}
+
+			provider, err := newSecretsProvider(secretsOpts)
+			if err != nil {
+				return err
+			}
+
+			res, err := testrunner.Exec(workflowDefinition, testrunner.ExecOptions{
+				Workflow: opts.Workflow,
+				Input:    input,
+				Envvars:  utils.LoadEnvvars(opts.EnvPrefix + "_"),
+				Secrets:  provider,
+				Timeout:  opts.Timeout,
+			})
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to execute workflow",
+				}
+			}
+
+			if opts.Output == "json" {
+				err = renderExecJSON(os.Stdout, res)
+			} else {
+				err = renderExecHuman(os.Stdout, res)
+			}
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error rendering result",
+				}
+			}
+
+			if res.Error != "" {
+				return gh.FatalError{
+					Msg:    "Workflow failed",
+					Logger: log.Trace,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	viper.SetDefault("env_prefix", "ZIGGY")
+	cmd.Flags().StringVar(
+		&opts.EnvPrefix, "env-prefix",
+		viper.GetString("env_prefix"), "Load envvars with this prefix to the workflow",
+	)
+
+	cmd.Flags().StringVarP(
+		&opts.Input, "input", "i",
+		viper.GetString("input"), "Workflow input as JSON",
+	)
+
+	viper.SetDefault("exec_output", execOutputFormats[0])
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("exec_output"), "Output format (human or json)",
+	)
+
+	viper.SetDefault("exec_timeout", time.Minute)
+	cmd.Flags().DurationVar(
+		&opts.Timeout, "timeout",
+		viper.GetDuration("exec_timeout"), "Maximum wall-clock time for the execution",
+	)
+
+	cmd.Flags().StringVarP(
+		&opts.Workflow, "workflow", "w",
+		viper.GetString("exec_workflow"), "Temporal workflow type to execute - defaults to document.name",
+	)
+
+	registerSecretsFlags(cmd, secretsOpts)
+
+	return cmd
+}
+
+func renderExecHuman(w io.Writer, res *testrunner.ExecResult) error {
+	if _, err := fmt.Fprintf(w, "Workflow: %s\n\nTrace:\n", res.Workflow); err != nil {
+		return err
+	}
+
+	for _, e := range res.Trace {
+		symbol := "✓"
+		switch e.Status {
+		case testrunner.TraceStatusFaulted:
+			symbol = "✗"
+		case testrunner.TraceStatusCancelled:
+			symbol = "-"
+		case testrunner.TraceStatusRunning:
+			symbol = "…"
+		}
+
+		if _, err := fmt.Fprintf(
+			w, "  %s%s %s (%s)\n",
+			strings.Repeat("  ", e.Depth), symbol, e.Task, e.Duration.Round(time.Millisecond),
+		); err != nil {
+			return err
+		}
+	}
+
+	if res.Error != "" {
+		_, err := fmt.Fprintf(w, "\nError:\n  %s\n", res.Error)
+		return err
+	}
+
+	output, err := json.MarshalIndent(res.Output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "\nOutput:\n%s\n", output)
+	return err
+}
+
+func renderExecJSON(w io.Writer, res *testrunner.ExecResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
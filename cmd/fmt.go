@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+func newFmtCmd() *cobra.Command {
+	var opts struct {
+		Check bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "fmt <workflow-file>...",
+		Short: "Rewrite Zigflow workflow files in canonical form",
+		Long: `Rewrite Zigflow workflow files in canonical form.
+
+The workflow, document and task keys are put in a fixed order, collections
+are written in block style with two space indentation and runtime
+expressions are written as "${ expr }", only quoted where YAML needs it.
+Comments and anchors are kept. Files are written in the encoding they're in.
+
+Each formatted file is loaded and checked to be the same workflow as the
+original before it's written. The names of the changed files are printed.
+
+With --check, no files are written. The names of the files that aren't
+formatted are printed and the command exits with a non-zero status code,
+making it suitable for CI pipelines.
+
+Arguments:
+  workflow-file   Workflow file, directory or glob pattern`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := zigflow.ResolveFiles(args)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to resolve workflow files",
+				}
+			}
+
+			changed := 0
+			for _, file := range files {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return gh.FatalError{
+						Cause: err,
+						Msg:   "Error reading workflow file",
+						WithParams: func(l *zerolog.Event) *zerolog.Event {
+							return l.Str("file", file)
+						},
+					}
+				}
+
+				formatted, err := zigflow.Format(data, zigflow.DetectEncoding(file, data))
+				if err != nil {
+					return gh.FatalError{
+						Cause: err,
+						Msg:   "Error formatting workflow file",
+						WithParams: func(l *zerolog.Event) *zerolog.Event {
+							return l.Str("file", file)
+						},
+					}
+				}
+
+				if bytes.Equal(data, formatted) {
+					continue
+				}
+				changed++
+				fmt.Println(file)
+
+				if opts.Check {
+					continue
+				}
+				// Keep the file's permissions
+				info, err := os.Stat(file)
+				if err == nil {
+					err = os.WriteFile(file, formatted, info.Mode().Perm())
+				}
+				if err != nil {
+					return gh.FatalError{
+						Cause: err,
+						Msg:   "Error writing workflow file",
+						WithParams: func(l *zerolog.Event) *zerolog.Event {
+							return l.Str("file", file)
+						},
+					}
+				}
+			}
+
+			if opts.Check && changed > 0 {
+				return gh.FatalError{
+					Msg: "Workflow files are not formatted",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Int("files", changed)
+					},
+					Logger: log.Trace,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&opts.Check, "check",
+		false, "List the files that aren't formatted without changing them",
+	)
+
+	return cmd
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unformattedWorkflowYAML = `do:
+  - step:
+      set: {hello: world}
+document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+`
+
+func TestNewFmtCmd(t *testing.T) {
+	tests := []struct {
+		Name            string
+		Content         string
+		ExtraArgs       []string
+		ExpectError     bool
+		ExpectChanged   bool
+		ExpectRewritten bool
+	}{
+		{
+			Name:    "already formatted",
+			Content: validWorkflowYAML + "\n",
+		},
+		{
+			Name:            "rewrites unformatted file",
+			Content:         unformattedWorkflowYAML,
+			ExpectChanged:   true,
+			ExpectRewritten: true,
+		},
+		{
+			Name:          "check fails on unformatted file",
+			Content:       unformattedWorkflowYAML,
+			ExtraArgs:     []string{"--check"},
+			ExpectError:   true,
+			ExpectChanged: true,
+		},
+		{
+			Name:      "check passes on formatted file",
+			Content:   validWorkflowYAML + "\n",
+			ExtraArgs: []string{"--check"},
+		},
+		{
+			Name:        "invalid YAML",
+			Content:     "invalid content: [",
+			ExpectError: true,
+		},
+		{
+			Name:        "unsupported DSL version",
+			Content:     workflowUnsupportedDSL,
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yaml")
+			require.NoError(t, os.WriteFile(filePath, []byte(test.Content), 0o600))
+
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newFmtCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{filePath}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+
+			if test.ExpectChanged {
+				assert.Equal(t, filePath+"\n", buf.String())
+			} else {
+				assert.Empty(t, buf.String())
+			}
+
+			content, err := os.ReadFile(filePath)
+			require.NoError(t, err)
+			if test.ExpectRewritten {
+				assert.NotEqual(t, test.Content, string(content))
+				assert.Contains(t, string(content), "set:\n        hello: world\n")
+			} else {
+				assert.Equal(t, test.Content, string(content))
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/codegen"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+func newGenerateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate code from a Zigflow workflow",
+	}
+
+	cmd.AddCommand(newGenerateClientCmd())
+
+	return cmd
+}
+
+func newGenerateClientCmd() *cobra.Command {
+	var opts struct {
+		Lang    string
+		Package string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "client <workflow-file>",
+		Short: "Generate a typed client for a Zigflow workflow",
+		Long: `Generate a typed Temporal client for a Zigflow workflow.
+
+Types are generated from the JSON schemas of the document's input, the tasks'
+input and output, and the data the listen tasks' signals and updates receive,
+set with the schema key alongside their ID and type. Objects become structs
+or interfaces and string enums become named types. Values without a schema
+are untyped.
+
+Helpers are generated to start each workflow on the document's task queue,
+wait for its result, and call its signals, queries and updates by their
+listen IDs.
+
+Use the --lang flag to select the language:
+
+  go           Go package using the Temporal Go SDK
+  typescript   TypeScript module using @temporalio/client
+
+The output is written to stdout.
+
+Arguments:
+  workflow-file   Path to the Zigflow workflow file to generate a client for`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wf, err := zigflow.LoadFromFile(args[0])
+			if err != nil {
+				return gh.FatalError{Cause: err, Msg: "Unable to load workflow file"}
+			}
+
+			gen, err := codegen.New(codegen.Language(opts.Lang), codegen.WithPackage(opts.Package))
+			if err != nil {
+				return gh.FatalError{Cause: err, Msg: "Unsupported client language"}
+			}
+
+			output, err := gen.Generate(wf)
+			if err != nil {
+				return gh.FatalError{Cause: err, Msg: "Error generating client"}
+			}
+
+			fmt.Print(output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&opts.Lang, "lang",
+		string(codegen.LanguageGo), "Client language ("+clientLanguages()+")",
+	)
+	cmd.Flags().StringVar(
+		&opts.Package, "package",
+		"", "Name of the Go package (defaults to the document name)",
+	)
+
+	return cmd
+}
+
+// clientLanguages lists the supported client languages for flag descriptions
+func clientLanguages() string {
+	languages := make([]string, 0, len(codegen.Languages))
+	for _, l := range codegen.Languages {
+		languages = append(languages, string(l))
+	}
+	return strings.Join(languages, ", ")
+}
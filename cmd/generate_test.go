@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerateClientCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Content        string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:    "go by default",
+			Content: validWorkflowYAML,
+			OutputContains: []string{
+				"package test\n",
+				`const TaskQueue = "default"`,
+				"func StartTest(ctx context.Context, c client.Client, options client.StartWorkflowOptions, input any)",
+			},
+		},
+		{
+			Name:           "go package name",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"--package", "testclient"},
+			OutputContains: []string{"package testclient\n"},
+		},
+		{
+			Name:      "typescript",
+			Content:   validWorkflowYAML,
+			ExtraArgs: []string{"--lang", "typescript"},
+			OutputContains: []string{
+				`export const taskQueue = "default";`,
+				"export async function startTest(",
+			},
+		},
+		{
+			Name:        "invalid YAML",
+			Content:     "invalid content: [",
+			ExpectError: true,
+		},
+		{
+			Name:        "unsupported language",
+			Content:     validWorkflowYAML,
+			ExtraArgs:   []string{"--lang", "cobol"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid package name",
+			Content:     validWorkflowYAML,
+			ExtraArgs:   []string{"--package", "not-valid"},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "workflow.yaml")
+			require.NoError(t, os.WriteFile(filePath, []byte(test.Content), 0o600))
+
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newGenerateCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{"client", filePath}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+				return
+			}
+			assert.NoError(t, execErr)
+			for _, want := range test.OutputContains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
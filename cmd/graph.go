@@ -18,17 +18,30 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/codec"
 	"github.com/zigflow/zigflow/pkg/graph"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow"
+	historypb "go.temporal.io/api/history/v1"
 )
 
+type graphOptions struct {
+	runOptions
+
+	History []string
+	Output  string
+}
+
 func newGraphCmd() *cobra.Command {
-	var opts struct {
-		Output string
-	}
+	var opts graphOptions
 
 	cmd := &cobra.Command{
 		Use:   "graph <workflow-file>",
@@ -47,15 +60,36 @@ tasks show the error-handling path.
 
 Use the --output flag to select the renderer. Currently supported:
 
-  mermaid   Mermaid flowchart (https://mermaid.ai)
+  mermaid    Mermaid flowchart (https://mermaid.ai)
+  dot        Graphviz DOT digraph (https://graphviz.org)
+  plantuml   PlantUML diagram (https://plantuml.com)
+  d2         D2 diagram (https://d2lang.com)
+  json       Renderer-neutral model of nodes, edges and groups, for drawing
+             the workflow with other tools
+  html       Self-contained HTML report that works offline. Selecting a task
+             shows its definition, if condition, input and output schemas,
+             listen handlers and the activity options it runs with
+
+Use the --history flag to draw an execution over the graph. Each history is
+replayed against the workflow and its tasks are coloured by what happened to
+them - completed, retried, failed, cancelled, skipped by an "if" statement or
+still running - and annotated with their attempts and duration. Export
+histories with "temporal workflow show --output json". Tasks in child
+workflows, such as loop iterations, are only drawn if their history is given
+too. If the history was recorded with encrypted payloads, set the same data
+conversion flags as the worker.
 
 The output is written to stdout and can be piped directly into tools or saved
-to a file for use in documentation, pull-request descriptions, or any Mermaid-
+to a file for use in documentation, pull-request descriptions, or any
 compatible renderer.
 
 Arguments:
   workflow-file   Path to the Zigflow workflow file to graph`,
 		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			_, err := codec.ParseCodecType(opts.ConvertData)
+			return err
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filePath := args[0]
 
@@ -67,7 +101,18 @@ Arguments:
 				}
 			}
 
-			gen, err := graph.New(graph.Format(opts.Output))
+			genOpts := []graph.Option{
+				graph.WithEnvvars(utils.LoadEnvvars(opts.EnvPrefix + "_")),
+			}
+			if len(opts.History) > 0 {
+				overlay, err := historyOverlay(workflowDefinition, &opts)
+				if err != nil {
+					return err
+				}
+				genOpts = append(genOpts, graph.WithOverlay(overlay))
+			}
+
+			gen, err := graph.New(graph.Format(opts.Output), genOpts...)
 			if err != nil {
 				return gh.FatalError{
 					Cause: err,
@@ -90,10 +135,72 @@ Arguments:
 
 	cmd.Flags().StringVarP(
 		&opts.Output, "output", "o",
-		string(graph.FormatMermaid), "Output format (mermaid)",
+		string(graph.FormatMermaid), "Output format ("+graphFormats()+")",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&opts.History, "history", []string{},
+		"Workflow history JSON file to draw over the graph (repeatable)",
+	)
+
+	registerCodecFlags(cmd, &opts.runOptions)
+
+	viper.SetDefault("env_prefix", "ZIGGY")
+	cmd.Flags().StringVar(
+		&opts.EnvPrefix, "env-prefix",
+		viper.GetString("env_prefix"), "Load envvars with this prefix to the workflow",
 	)
 
 	cmd.AddCommand(newGraphInjectCmd())
 
 	return cmd
 }
+
+// historyOverlay replays the histories against the workflow to find what
+// happened to each task
+func historyOverlay(wf *model.Workflow, opts *graphOptions) (graph.Overlay, error) {
+	dataConverter, err := newDataConverter(&opts.runOptions)
+	if err != nil {
+		return nil, gh.FatalError{
+			Cause: err,
+			Msg:   "Unable to create data converter",
+		}
+	}
+
+	histories := make([]*historypb.History, 0, len(opts.History))
+	for _, file := range opts.History {
+		history, err := testrunner.LoadHistory(file)
+		if err != nil {
+			return nil, gh.FatalError{
+				Cause: err,
+				Msg:   "Unable to load workflow history",
+				WithParams: func(l *zerolog.Event) *zerolog.Event {
+					return l.Str("file", file)
+				},
+			}
+		}
+		histories = append(histories, history)
+	}
+
+	overlay, err := testrunner.Overlay(wf, histories, testrunner.ReplayOptions{
+		Envvars:       utils.LoadEnvvars(opts.EnvPrefix + "_"),
+		DataConverter: dataConverter,
+	})
+	if err != nil {
+		return nil, gh.FatalError{
+			Cause: err,
+			Msg:   "Unable to draw history over the graph",
+		}
+	}
+
+	return overlay, nil
+}
+
+// graphFormats lists the supported graph formats for flag descriptions
+func graphFormats() string {
+	formats := make([]string, 0, len(graph.Formats))
+	for _, f := range graph.Formats {
+		formats = append(formats, string(f))
+	}
+	return strings.Join(formats, ", ")
+}
@@ -89,7 +89,7 @@ Default markers:
 
 	cmd.Flags().StringVarP(
 		&opts.Output, "output", "o",
-		string(graph.FormatMermaid), "Output format (mermaid)",
+		string(graph.FormatMermaid), "Output format ("+graphFormats()+")",
 	)
 	cmd.Flags().StringVarP(
 		&opts.WorkflowFile, "workflow", "w",
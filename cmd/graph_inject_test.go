@@ -77,6 +77,16 @@ func TestNewGraphInjectCmd(t *testing.T) {
 				"flowchart TD",
 			},
 		},
+		{
+			Name:          "explicit workflow injects dot graph",
+			WorkflowYAML:  validWorkflowYAML,
+			TargetContent: targetWithMarkers,
+			ExtraArgs:     []string{"--workflow", "PLACEHOLDER_WORKFLOW", "--output", "dot"},
+			OutputContains: []string{
+				"```dot",
+				"digraph",
+			},
+		},
 		{
 			// Custom markers require --workflow so the start marker is known.
 			Name:          "custom markers with explicit workflow",
@@ -20,17 +20,30 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// setHistory is the history of validWorkflowYAML, which only sets data
+const setHistory = `{
+  "events": [
+    {"eventId": "1", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED", "workflowExecutionStartedEventAttributes": {"workflowType": {"name": "test"}, "taskQueue": {"name": "default"}, "workflowId": "test-1", "originalExecutionRunId": "run-1", "firstExecutionRunId": "run-1", "attempt": 1}},
+    {"eventId": "2", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_SCHEDULED", "workflowTaskScheduledEventAttributes": {"taskQueue": {"name": "default"}, "startToCloseTimeout": "10s", "attempt": 1}},
+    {"eventId": "3", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_STARTED", "workflowTaskStartedEventAttributes": {"scheduledEventId": "2"}},
+    {"eventId": "4", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_COMPLETED", "workflowTaskCompletedEventAttributes": {"scheduledEventId": "2", "startedEventId": "3"}},
+    {"eventId": "5", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED", "workflowExecutionCompletedEventAttributes": {"workflowTaskCompletedEventId": "4"}}
+  ]
+}`
+
 func TestNewGraphCmd(t *testing.T) {
 	tests := []struct {
 		Name           string
 		Content        string
 		FilePath       string
+		History        string
 		ExtraArgs      []string
 		ExpectError    bool
 		OutputContains []string
@@ -52,6 +65,61 @@ func TestNewGraphCmd(t *testing.T) {
 			ExtraArgs:      []string{"-o", "mermaid"},
 			OutputContains: []string{"flowchart TD"},
 		},
+		{
+			Name:           "dot output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "dot"},
+			OutputContains: []string{"digraph", "->"},
+		},
+		{
+			Name:           "plantuml output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "plantuml"},
+			OutputContains: []string{"@startuml", "@enduml"},
+		},
+		{
+			Name:           "d2 output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "d2"},
+			OutputContains: []string{"direction: down", "->"},
+		},
+		{
+			Name:           "json output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "json"},
+			OutputContains: []string{`"nodes": [`, `"edges": [`},
+		},
+		{
+			Name:           "html output",
+			Content:        validWorkflowYAML,
+			ExtraArgs:      []string{"-o", "html"},
+			OutputContains: []string{"<!DOCTYPE html>", `id="task-test_step"`},
+		},
+		{
+			Name:           "history overlay",
+			Content:        validWorkflowYAML,
+			History:        setHistory,
+			OutputContains: []string{"class test_step completed"},
+		},
+		{
+			Name:        "history from another workflow",
+			Content:     validWorkflowYAML,
+			History:     strings.ReplaceAll(setHistory, `"name": "test"`, `"name": "other"`),
+			ExpectError: true,
+		},
+		{
+			Name:        "non-existent history file",
+			Content:     validWorkflowYAML,
+			ExtraArgs:   []string{"--history", "/nonexistent/path/history.json"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid data conversion mode",
+			Content:     validWorkflowYAML,
+			History:     setHistory,
+			ExtraArgs:   []string{"--convert-data", "unknown"},
+			ExpectError: true,
+		},
 		{
 			Name:        "non-existent file",
 			FilePath:    "/nonexistent/path/workflow.yaml",
@@ -77,6 +145,7 @@ func TestNewGraphCmd(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
+			args := test.ExtraArgs
 			filePath := test.FilePath
 			if filePath == "" {
 				tmpDir, err := os.MkdirTemp("", "graph_test")
@@ -88,6 +157,13 @@ func TestNewGraphCmd(t *testing.T) {
 				filePath = filepath.Join(tmpDir, "workflow.yaml")
 				err = os.WriteFile(filePath, []byte(test.Content), 0o600)
 				require.NoError(t, err)
+
+				if test.History != "" {
+					historyPath := filepath.Join(tmpDir, "history.json")
+					err = os.WriteFile(historyPath, []byte(test.History), 0o600)
+					require.NoError(t, err)
+					args = append(args, "--history", historyPath)
+				}
 			}
 
 			// Capture stdout so we can assert on the generated output.
@@ -99,7 +175,7 @@ func TestNewGraphCmd(t *testing.T) {
 			cmd := newGraphCmd()
 			cmd.SilenceErrors = true
 			cmd.SilenceUsage = true
-			cmd.SetArgs(append([]string{filePath}, test.ExtraArgs...))
+			cmd.SetArgs(append([]string{filePath}, args...))
 			execErr := cmd.Execute()
 
 			assert.NoError(t, w.Close())
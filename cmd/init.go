@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/scaffold"
+)
+
+func newInitCmd() *cobra.Command {
+	var opts struct {
+		scaffold.Options
+		Force bool
+		List  bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "init [directory]",
+		Short: "Create a new Zigflow project from a template",
+		Long: `Create a new Zigflow project from a template.
+
+A workflow.yaml is rendered from the chosen template into the directory, which
+defaults to the current directory. The built-in templates are:
+
+  http-pipeline   fetch a record over HTTP, transform it and send it on
+  approval        wait for a signal or update to approve a request
+  schedule        run a workflow on a schedule
+  fork            call services in parallel and combine the results
+  try-catch       fall back to a default when a task fails
+
+Use --list to see every template, including local ones.
+
+Optional files can be added alongside the workflow:
+  --tests         a workflow.test.yaml suite for "zigflow test"
+  --cloudevents   a cloudevents.yaml client configuration
+  --aes-keys      a keys.yaml with a random key to encrypt Temporal data
+  --dockerfile    a Dockerfile that builds the workflow into the Zigflow image
+
+Templates are Go templates with the .Namespace and .Name of the workflow. Use
+--templates-dir to add your own: each directory in it is a template, with a
+workflow.yaml and, optionally, a workflow.test.yaml. Either may have a .tmpl
+extension. A local template replaces the built-in template with its name.
+
+Arguments:
+  directory   Directory to create the project in`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.List {
+				templates, err := scaffold.Templates(opts.TemplatesDir)
+				if err != nil {
+					return gh.FatalError{Cause: err, Msg: "Unable to load templates"}
+				}
+
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				for _, t := range templates {
+					_, _ = fmt.Fprintf(w, "%s\t%s\n", t.Name, t.Summary)
+				}
+				return w.Flush()
+			}
+
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			if opts.Name == "" {
+				abs, err := filepath.Abs(dir)
+				if err != nil {
+					return gh.FatalError{Cause: err, Msg: "Unable to resolve directory"}
+				}
+				opts.Name = filepath.Base(abs)
+			}
+			if strings.TrimSpace(opts.Namespace) == "" {
+				return gh.FatalError{Msg: "Namespace must be set with --namespace"}
+			}
+
+			files, err := scaffold.Render(opts.Options)
+			if err != nil {
+				return gh.FatalError{Cause: err, Msg: "Unable to render template"}
+			}
+
+			if err := scaffold.Write(dir, files, opts.Force); err != nil {
+				return gh.FatalError{Cause: err, Msg: "Unable to create project"}
+			}
+
+			for _, f := range files {
+				fmt.Println("Created", filepath.Join(dir, f.Path))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(
+		&opts.Template, "template", "t",
+		scaffold.DefaultTemplate, "Template to create the workflow from",
+	)
+	cmd.Flags().StringVar(
+		&opts.Namespace, "namespace",
+		"zigflow", "Namespace of the workflow, used as the Temporal task queue",
+	)
+	cmd.Flags().StringVar(
+		&opts.Name, "name",
+		"", "Name of the workflow (defaults to the directory name)",
+	)
+	cmd.Flags().StringVar(
+		&opts.TemplatesDir, "templates-dir",
+		viper.GetString("templates_dir"), "Directory of local templates",
+	)
+	cmd.Flags().BoolVar(&opts.Tests, "tests", false, "Add a starter test suite")
+	cmd.Flags().BoolVar(&opts.CloudEvents, "cloudevents", false, "Add a CloudEvents configuration")
+	cmd.Flags().BoolVar(&opts.AESKeys, "aes-keys", false, "Add a keys file to encrypt Temporal data with AES")
+	cmd.Flags().BoolVar(&opts.Dockerfile, "dockerfile", false, "Add a Dockerfile for a dedicated image")
+	cmd.Flags().BoolVarP(&opts.Force, "force", "f", false, "Overwrite existing files")
+	cmd.Flags().BoolVar(&opts.List, "list", false, "List the available templates")
+
+	return cmd
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInitCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Args           []string
+		Existing       bool
+		ExpectError    bool
+		Files          []string
+		WorkflowHas    []string
+		OutputContains []string
+	}{
+		{
+			Name:        "default template named after the directory",
+			Files:       []string{"workflow.yaml"},
+			WorkflowHas: []string{"namespace: zigflow\n", "name: project\n", "call: http"},
+		},
+		{
+			Name:        "template with parameters",
+			Args:        []string{"-t", "approval", "--namespace", "team", "--name", "review"},
+			Files:       []string{"workflow.yaml"},
+			WorkflowHas: []string{"namespace: team\n", "name: review\n", "type: signal"},
+		},
+		{
+			Name:  "optional files",
+			Args:  []string{"--tests", "--cloudevents", "--aes-keys", "--dockerfile"},
+			Files: []string{"workflow.yaml", "workflow.test.yaml", "cloudevents.yaml", "keys.yaml", "Dockerfile"},
+		},
+		{
+			Name:           "list templates",
+			Args:           []string{"--list"},
+			OutputContains: []string{"approval ", "http-pipeline ", "try-catch "},
+		},
+		{
+			Name:        "unknown template",
+			Args:        []string{"-t", "unknown"},
+			ExpectError: true,
+		},
+		{
+			Name:        "empty namespace",
+			Args:        []string{"--namespace", ""},
+			ExpectError: true,
+		},
+		{
+			Name:        "existing files are kept",
+			Existing:    true,
+			ExpectError: true,
+		},
+		{
+			Name:        "existing files are replaced when forced",
+			Args:        []string{"--force"},
+			Existing:    true,
+			Files:       []string{"workflow.yaml"},
+			WorkflowHas: []string{"name: project\n"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "project")
+			if test.Existing {
+				require.NoError(t, os.MkdirAll(dir, 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "workflow.yaml"), []byte("existing"), 0o600))
+			}
+
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newInitCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{dir}, test.Args...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+				return
+			}
+			assert.NoError(t, execErr)
+			for _, want := range test.OutputContains {
+				assert.Contains(t, buf.String(), want)
+			}
+
+			for _, f := range test.Files {
+				assert.FileExists(t, filepath.Join(dir, f))
+				assert.Contains(t, buf.String(), "Created "+filepath.Join(dir, f))
+			}
+			if len(test.WorkflowHas) > 0 {
+				data, err := os.ReadFile(filepath.Join(dir, "workflow.yaml"))
+				require.NoError(t, err)
+				for _, want := range test.WorkflowHas {
+					assert.Contains(t, string(data), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+var inspectOutputFormats = []string{"human", "json"}
+
+func newInspectCmd() *cobra.Command {
+	var opts struct {
+		EnvPrefix string
+		Output    string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "inspect <workflow-file>",
+		Short: "Show what a workflow registers with Temporal",
+		Long: `Show what a workflow registers with Temporal.
+
+This command compiles the workflow as "zigflow run" does, but against a worker
+that records what is registered rather than connecting to Temporal. It lists:
+  - every workflow type, including the child workflows generated for for,
+    fork and try tasks, and the child workflows each one starts
+  - the signal, query and update handlers
+  - the activities registered and the activity each task schedules
+  - the activity options each task runs with, once the defaults and the
+    document and task metadata are merged
+  - the schedule
+
+Arguments:
+  workflow-file   Path to the workflow file`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(inspectOutputFormats, opts.Output) {
+				return gh.FatalError{
+					Msg: "Unknown output format",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("output", opts.Output).Strs("allowed", inspectOutputFormats)
+					},
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			validator, err := utils.NewValidator()
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error creating validator",
+				}
+			}
+
+			doc, err := zigflow.LoadDocumentFromFile(args[0])
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to load workflow file",
+				}
+			}
+
+			if err := runValidation(validator, doc.Workflow); err != nil {
+				return err
+			}
+
+			plan, err := zigflow.Inspect(doc.Workflow, utils.LoadEnvvars(opts.EnvPrefix+"_"))
+			if err != nil {
+				return gh.FatalError{
+					Cause: doc.Source.Locate(err),
+					Msg:   "Error compiling workflow",
+				}
+			}
+
+			if opts.Output == "json" {
+				err = renderInspectJSON(os.Stdout, plan)
+			} else {
+				err = renderInspectHuman(os.Stdout, plan)
+			}
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error rendering result",
+				}
+			}
+
+			return nil
+		},
+	}
+
+	viper.SetDefault("env_prefix", "ZIGGY")
+	cmd.Flags().StringVar(
+		&opts.EnvPrefix, "env-prefix",
+		viper.GetString("env_prefix"), "Load envvars with this prefix to the workflow",
+	)
+
+	viper.SetDefault("inspect_output", inspectOutputFormats[0])
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("inspect_output"), "Output format (human or json)",
+	)
+
+	return cmd
+}
+
+func renderInspectHuman(w io.Writer, plan *zigflow.Plan) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s %s on task queue %q\n", plan.Name, plan.Version, plan.TaskQueue)
+
+	for _, wf := range plan.Workflows {
+		fmt.Fprintf(&b, "\nWorkflow %s\n", wf.Name)
+
+		for _, task := range wf.Tasks {
+			fmt.Fprintf(&b, "  %s (%s)", task.Name, task.Type)
+			if task.Activity != "" {
+				fmt.Fprintf(&b, " → activity %s", task.Activity)
+			}
+			if len(task.Children) > 0 {
+				fmt.Fprintf(&b, " → starts %s", strings.Join(task.Children, ", "))
+			}
+			b.WriteString("\n")
+
+			if ao := task.ActivityOptions; ao != nil {
+				for _, opt := range activityOptionLines(ao) {
+					fmt.Fprintf(&b, "      %s\n", opt)
+				}
+			}
+		}
+
+		if len(wf.Handlers) > 0 {
+			b.WriteString("  Handlers:\n")
+			for _, h := range wf.Handlers {
+				fmt.Fprintf(&b, "    %s %s (task %s)\n", h.Type, h.ID, h.Task)
+			}
+		}
+
+		if len(wf.Children) > 0 {
+			fmt.Fprintf(&b, "  Child workflows: %s\n", strings.Join(wf.Children, ", "))
+		}
+	}
+
+	fmt.Fprintf(&b, "\nActivities: %s\n", strings.Join(plan.Activities, ", "))
+
+	if s := plan.Schedule; s != nil {
+		fmt.Fprintf(&b, "\nSchedule %s\n", s.ID)
+		if s.WorkflowName != "" {
+			fmt.Fprintf(&b, "  workflow: %s\n", s.WorkflowName)
+		}
+		if s.Cron != "" {
+			fmt.Fprintf(&b, "  cron: %s\n", s.Cron)
+		}
+		if s.Every != "" {
+			fmt.Fprintf(&b, "  every: %s\n", s.Every)
+		}
+		if len(s.Input) > 0 {
+			input, err := json.Marshal(s.Input)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&b, "  input: %s\n", input)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func activityOptionLines(ao *zigflow.PlanActivityOptions) []string {
+	lines := make([]string, 0)
+
+	for _, opt := range []struct {
+		name  string
+		value string
+	}{
+		{"taskQueue", ao.TaskQueue},
+		{"startToCloseTimeout", ao.StartToCloseTimeout},
+		{"scheduleToCloseTimeout", ao.ScheduleToCloseTimeout},
+		{"scheduleToStartTimeout", ao.ScheduleToStartTimeout},
+		{"heartbeatTimeout", ao.HeartbeatTimeout},
+	} {
+		if opt.value != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", opt.name, opt.value))
+		}
+	}
+
+	if r := ao.RetryPolicy; r != nil {
+		retry := fmt.Sprintf("retryPolicy: initialInterval %s, backoffCoefficient %g", r.InitialInterval, r.BackoffCoefficient)
+		if r.MaximumInterval != "" {
+			retry += ", maximumInterval " + r.MaximumInterval
+		}
+		retry += fmt.Sprintf(", maximumAttempts %d", r.MaximumAttempts)
+		if len(r.NonRetryableErrorTypes) > 0 {
+			retry += ", nonRetryableErrorTypes " + strings.Join(r.NonRetryableErrorTypes, ", ")
+		}
+		lines = append(lines, retry)
+	}
+
+	return lines
+}
+
+func renderInspectJSON(w io.Writer, plan *zigflow.Plan) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(plan)
+}
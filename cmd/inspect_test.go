@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInspectCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Workflow       string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:     "human output",
+			Workflow: listenWorkflowYAML,
+			OutputContains: []string{
+				`approval 0.0.1 on task queue "zigflow"`,
+				"Workflow approval",
+				"signal approve (task wait)",
+				"update confirm (task wait)",
+			},
+		},
+		{
+			Name:           "JSON output",
+			Workflow:       listenWorkflowYAML,
+			ExtraArgs:      []string{"--output", "json"},
+			OutputContains: []string{`"taskQueue": "zigflow"`, `"id": "status"`, `"CallHTTPActivity"`},
+		},
+		{
+			Name:        "unknown output format",
+			Workflow:    listenWorkflowYAML,
+			ExtraArgs:   []string{"-o", "yaml"},
+			ExpectError: true,
+		},
+		{
+			Name:        "invalid workflow",
+			Workflow:    workflowMissingName,
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "inspect_test")
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, os.RemoveAll(tmpDir))
+			}()
+
+			file := filepath.Join(tmpDir, "workflow.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(test.Workflow), 0o600))
+
+			// Capture stdout so we can assert on the generated output.
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newInspectCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{file}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, err = buf.ReadFrom(r)
+			require.NoError(t, err)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+
+			for _, s := range test.OutputContains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
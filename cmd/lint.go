@@ -0,0 +1,168 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"slices"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/lint"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+var lintOutputFormats = []string{"human", "json", "sarif"}
+
+type lintOptions struct {
+	Disable []string
+	FailOn  string
+	Output  string
+}
+
+func newLintCmd() *cobra.Command {
+	var opts lintOptions
+
+	cmd := &cobra.Command{
+		Use:   "lint <workflow-file>...",
+		Short: "Check Zigflow workflows for semantic problems",
+		Long: `Check Zigflow workflows for semantic problems.
+
+The validate command checks that a workflow matches the schema. This command
+checks the things a schema cannot, such as flow directives that point at
+missing tasks, invalid runtime expressions and non-deterministic functions
+used outside of a set task.
+
+Findings can be suppressed with a comment on the task or key:
+
+  # zigflow-lint-disable ZF001
+
+or for the whole file:
+
+  # zigflow-lint-disable-file ZF006
+
+The command exits with a non-zero status code if any finding is at least as
+serious as --fail-on.
+
+Arguments:
+  workflow-file   Workflow file, directory or glob pattern`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(lintOutputFormats, opts.Output) {
+				return gh.FatalError{
+					Msg: "Unknown output format",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("output", opts.Output).Strs("allowed", lintOutputFormats)
+					},
+				}
+			}
+
+			if _, err := lint.ParseSeverity(opts.FailOn); err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Invalid --fail-on severity",
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := zigflow.ResolveFiles(args)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to resolve workflow files",
+				}
+			}
+
+			threshold, _ := lint.ParseSeverity(opts.FailOn)
+
+			results := make([]*lint.Result, 0, len(files))
+			failed := 0
+			for _, file := range files {
+				res := &lint.Result{
+					File:     file,
+					Findings: []*lint.Finding{},
+				}
+				results = append(results, res)
+
+				doc, err := lint.Load(file)
+				if err != nil {
+					res.Error = err.Error()
+					failed++
+					continue
+				}
+
+				res.Findings = lint.Lint(doc, lint.Options{
+					Disable: opts.Disable,
+				})
+				if lint.Failed(res.Findings, threshold) {
+					failed++
+				}
+			}
+
+			switch opts.Output {
+			case "json":
+				err = lint.RenderJSON(os.Stdout, results)
+			case "sarif":
+				err = lint.RenderSARIF(os.Stdout, results, Version)
+			default:
+				err = lint.RenderHuman(os.Stdout, results)
+			}
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error rendering result",
+				}
+			}
+
+			if failed > 0 {
+				return gh.FatalError{
+					Msg: "Lint failed",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Int("failed", failed)
+					},
+					Logger: log.Trace,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&opts.Disable, "disable",
+		viper.GetStringSlice("lint_disable"), "Rule IDs or names to skip",
+	)
+
+	viper.SetDefault("lint_fail_on", string(lint.SeverityError))
+	cmd.Flags().StringVar(
+		&opts.FailOn, "fail-on",
+		viper.GetString("lint_fail_on"), "Lowest severity that fails the command (error, warning or info)",
+	)
+
+	viper.SetDefault("lint_output", lintOutputFormats[0])
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("lint_output"), "Output format (human, json or sarif)",
+	)
+
+	return cmd
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLintCmd(t *testing.T) {
+	missingTarget := strings.Replace(listenWorkflowYAML, "  - wait:", "  - wait:\n      then: approved", 1)
+	unknownMetadata := strings.Replace(listenWorkflowYAML, "  - wait:", "  - wait:\n      metadata:\n        heartbeet: 10s", 1)
+
+	tests := []struct {
+		Name           string
+		Workflow       string
+		Args           []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:           "clean workflow",
+			Workflow:       listenWorkflowYAML,
+			OutputContains: []string{"0 error(s), 0 warning(s), 0 info"},
+		},
+		{
+			Name:           "error finding",
+			Workflow:       missingTarget,
+			ExpectError:    true,
+			OutputContains: []string{`workflow.yaml:8:7: ✗ error then target "approved" does not exist in this task list [ZF001]`},
+		},
+		{
+			Name:           "warning passes by default",
+			Workflow:       unknownMetadata,
+			OutputContains: []string{`unknown metadata key "heartbeet" - did you mean "heartbeat"?`, "0 error(s), 1 warning(s)"},
+		},
+		{
+			Name:        "fail on warnings",
+			Workflow:    unknownMetadata,
+			Args:        []string{"--fail-on", "warning"},
+			ExpectError: true,
+		},
+		{
+			Name:     "disabled rule",
+			Workflow: missingTarget,
+			Args:     []string{"--disable", "missing-then-target"},
+		},
+		{
+			Name:           "JSON output",
+			Workflow:       missingTarget,
+			Args:           []string{"--output", "json"},
+			ExpectError:    true,
+			OutputContains: []string{`"rule": "ZF001"`, `"path": "/do/0/wait/then"`},
+		},
+		{
+			Name:           "SARIF output",
+			Workflow:       missingTarget,
+			Args:           []string{"--output", "sarif"},
+			ExpectError:    true,
+			OutputContains: []string{`"version": "2.1.0"`, `"ruleId": "ZF001"`, `"startLine": 8`},
+		},
+		{
+			Name:        "unknown output",
+			Workflow:    listenWorkflowYAML,
+			Args:        []string{"--output", "xml"},
+			ExpectError: true,
+		},
+		{
+			Name:        "unknown severity",
+			Workflow:    listenWorkflowYAML,
+			Args:        []string{"--fail-on", "fatal"},
+			ExpectError: true,
+		},
+		{
+			Name:           "invalid workflow",
+			Workflow:       "do: [",
+			ExpectError:    true,
+			OutputContains: []string{"✗"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "lint_test")
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, os.RemoveAll(tmpDir))
+			}()
+
+			file := filepath.Join(tmpDir, "workflow.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(test.Workflow), 0o600))
+
+			// Capture stdout so we can assert on the generated output.
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newLintCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{file}, test.Args...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, err = buf.ReadFrom(r)
+			require.NoError(t, err)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+
+			for _, s := range test.OutputContains {
+				assert.Contains(t, buf.String(), s)
+			}
+		})
+	}
+}
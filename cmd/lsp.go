@@ -0,0 +1,72 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/lsp"
+)
+
+func newLSPCmd() *cobra.Command {
+	var opts struct {
+		Stdio bool
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Start a language server for editing workflow files",
+		Long: `Start a language server for editing Zigflow workflow files.
+
+Editors start this command and communicate with it over stdin and stdout
+using the Language Server Protocol. It provides:
+
+  - Diagnostics from the loader, validator and lint rules as you type
+  - Completion for task types, metadata keys and then targets
+  - Go to definition from a then or run.workflow.name to the task
+  - Hover showing the Temporal workflow a task runs in and starts
+
+Logs are written to stderr so they don't interfere with the protocol.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			server, err := lsp.New(os.Stdin, os.Stdout, Version)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to create language server",
+				}
+			}
+
+			if err := server.Run(); err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Language server failed",
+				}
+			}
+
+			return nil
+		},
+	}
+
+	// Editors commonly pass --stdio. It's the only transport, so is accepted
+	// for compatibility.
+	cmd.Flags().BoolVar(&opts.Stdio, "stdio", true, "Communicate over stdin and stdout")
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/spf13/cobra"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+)
+
+func newQueryCmd() *cobra.Command {
+	var opts clientOptions
+
+	cmd := &cobra.Command{
+		Use:   "query <query-id>",
+		Short: "Query a workflow and print the result",
+		Long: `Query a workflow and print the result as JSON.
+
+If a workflow file is given with --file, the query ID is checked against the
+listen tasks in the workflow before it is sent.
+
+Arguments:
+  query-id   ID of the query, as declared in a listen task`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.requireWorkflowID(); err != nil {
+				return err
+			}
+
+			wf, err := opts.loadWorkflow()
+			if err != nil {
+				return err
+			}
+			if err := validateListener(wf, tasks.ListenTaskTypeQuery, args[0]); err != nil {
+				return err
+			}
+
+			input, err := opts.readInput(cmd.InOrStdin())
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to read input",
+				}
+			}
+
+			c, err := opts.connect()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			queryArgs := []any{}
+			if input != nil {
+				queryArgs = append(queryArgs, input)
+			}
+
+			res, err := c.QueryWorkflow(cmd.Context(), opts.WorkflowID, opts.RunID, args[0], queryArgs...)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to query workflow",
+				}
+			}
+
+			var output any
+			if res.HasValue() {
+				if err := res.Get(&output); err != nil {
+					return gh.FatalError{
+						Cause: err,
+						Msg:   "Unable to decode query result",
+					}
+				}
+			}
+
+			return printJSON(os.Stdout, output)
+		},
+	}
+
+	registerClientFlags(cmd, &opts)
+	registerInputFlags(cmd, &opts)
+	registerWorkflowFileFlag(cmd, &opts)
+
+	return cmd
+}
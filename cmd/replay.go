@@ -0,0 +1,232 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/codec"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+var replayOutputFormats = []string{"human", "json"}
+
+type replayOptions struct {
+	runOptions
+
+	FilePath string
+	Output   string
+}
+
+func newReplayCmd() *cobra.Command {
+	var opts replayOptions
+
+	cmd := &cobra.Command{
+		Use:   "replay <history-file>...",
+		Short: "Replay Temporal histories against a Zigflow workflow",
+		Long: `Replay Temporal histories against a Zigflow workflow.
+
+This command compiles the workflow exactly as the worker does and replays
+each exported workflow history through it. No Temporal server is needed and
+no activities are run.
+
+A replay fails if the workflow definition no longer produces the commands
+recorded in the history. Deploying that definition would break any running
+workflow with the same history. The Zigflow task that diverged is reported
+alongside the history event.
+
+Export histories with "temporal workflow show --output json". If the
+history was recorded with encrypted payloads, set the same data conversion
+flags as the worker.
+
+The command exits with a non-zero status code if any replay fails.
+
+Arguments:
+  history-file   Path to an exported workflow history JSON file`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := codec.ParseCodecType(opts.ConvertData); err != nil {
+				return err
+			}
+
+			if opts.FilePath == "" {
+				return gh.FatalError{
+					Msg: "Workflow file must be set with --file",
+				}
+			}
+
+			if !slices.Contains(replayOutputFormats, opts.Output) {
+				return gh.FatalError{
+					Msg: "Unknown output format",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("output", opts.Output).Strs("allowed", replayOutputFormats)
+					},
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workflowDefinition, err := zigflow.LoadFromFile(opts.FilePath)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to load workflow file",
+				}
+			}
+
+			validator, err := utils.NewValidator()
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error creating validator",
+				}
+			}
+
+			if err := runValidation(validator, workflowDefinition); err != nil {
+				return err
+			}
+
+			dataConverter, err := newDataConverter(&opts.runOptions)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to create data converter",
+				}
+			}
+
+			results := make([]*testrunner.ReplayResult, 0, len(args))
+			failed := 0
+			for _, historyFile := range args {
+				res := testrunner.Replay(workflowDefinition, historyFile, testrunner.ReplayOptions{
+					Envvars:       utils.LoadEnvvars(opts.EnvPrefix + "_"),
+					DataConverter: dataConverter,
+				})
+				if !res.Passed {
+					failed++
+				}
+				results = append(results, res)
+			}
+
+			if opts.Output == "json" {
+				err = renderReplayJSON(os.Stdout, results)
+			} else {
+				err = renderReplayHuman(os.Stdout, results)
+			}
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error rendering result",
+				}
+			}
+
+			if failed > 0 {
+				return gh.FatalError{
+					Msg: "Replay failed",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Int("failed", failed)
+					},
+					Logger: log.Trace,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	registerCodecFlags(cmd, &opts.runOptions)
+
+	viper.SetDefault("env_prefix", "ZIGGY")
+	cmd.Flags().StringVar(
+		&opts.EnvPrefix, "env-prefix",
+		viper.GetString("env_prefix"), "Load envvars with this prefix to the workflow",
+	)
+
+	cmd.Flags().StringVarP(
+		&opts.FilePath, "file", "f",
+		viper.GetString("workflow_file"), "Path to workflow file",
+	)
+
+	viper.SetDefault("replay_output", replayOutputFormats[0])
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("replay_output"), "Output format (human or json)",
+	)
+
+	return cmd
+}
+
+func renderReplayHuman(w io.Writer, results []*testrunner.ReplayResult) error {
+	passed := 0
+	for _, res := range results {
+		symbol := "✗"
+		if res.Passed {
+			symbol = "✓"
+			passed++
+		}
+
+		name := res.File
+		if res.WorkflowID != "" {
+			name = fmt.Sprintf("%s (%s)", res.File, res.WorkflowID)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s %s\n", symbol, name); err != nil {
+			return err
+		}
+
+		if res.Passed {
+			continue
+		}
+
+		if res.NonDeterministic {
+			msg := "    Non-deterministic change"
+			if res.Task != "" {
+				msg += fmt.Sprintf(" in task %q", res.Task)
+			}
+			if res.EventID > 0 {
+				msg += fmt.Sprintf(" at history event %d", res.EventID)
+			}
+			if _, err := fmt.Fprintln(w, msg); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "    %s\n", res.Error); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed\n", passed, len(results)-passed)
+	return err
+}
+
+func renderReplayJSON(w io.Writer, results []*testrunner.ReplayResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+)
+
+func TestNewReplayCmd(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Args  []string
+		Error string
+	}{
+		{
+			Name:  "no history files",
+			Args:  []string{"-f", "workflow.yaml"},
+			Error: "requires at least 1 arg(s)",
+		},
+		{
+			Name:  "no workflow file",
+			Args:  []string{"history.json"},
+			Error: "Workflow file must be set with --file",
+		},
+		{
+			Name:  "invalid data conversion",
+			Args:  []string{"-f", "workflow.yaml", "--convert-data", "unknown", "history.json"},
+			Error: "unknown",
+		},
+		{
+			Name:  "unknown output format",
+			Args:  []string{"-f", "workflow.yaml", "-o", "yaml", "history.json"},
+			Error: "Unknown output format",
+		},
+		{
+			Name:  "non-existent workflow file",
+			Args:  []string{"-f", "/nonexistent/path/workflow.yaml", "history.json"},
+			Error: "no such file or directory",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			cmd := newReplayCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(test.Args)
+
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.Error)
+		})
+	}
+}
+
+func TestRenderReplayHuman(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := renderReplayHuman(&buf, []*testrunner.ReplayResult{
+		{
+			File:       "passed.json",
+			WorkflowID: "wf-1",
+			Passed:     true,
+		},
+		{
+			File:             "failed.json",
+			NonDeterministic: true,
+			Task:             "fetch",
+			EventID:          5,
+			Error:            "[TMPRL1100] During replay",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, `✓ passed.json (wf-1)
+✗ failed.json
+    Non-deterministic change in task "fetch" at history event 5
+    [TMPRL1100] During replay
+
+1 passed, 1 failed
+`, buf.String())
+}
@@ -109,6 +109,24 @@ platform.`,
 		newValidateCmd(),
 		newSchemaCmd(),
 		newGraphCmd(),
+		newTestCmd(),
+		newExecCmd(),
+		newStartCmd(),
+		newSignalCmd(),
+		newQueryCmd(),
+		newUpdateCmd(),
+		newCancelCmd(),
+		newResultCmd(),
+		newReplayCmd(),
+		newDiffCmd(),
+		newLintCmd(),
+		newLSPCmd(),
+		newInspectCmd(),
+		newDocsCmd(),
+		newGenerateCmd(),
+		newInitCmd(),
+		newFmtCmd(),
+		newConvertCmd(),
 		newGenerateDocsCmd(rootCmd),
 	)
 
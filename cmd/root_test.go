@@ -36,6 +36,22 @@ func TestNewRootCmd_Subcommands(t *testing.T) {
 	assert.True(t, names["validate"])
 	assert.True(t, names["schema"])
 	assert.True(t, names["generate-docs"])
+	assert.True(t, names["start"])
+	assert.True(t, names["signal"])
+	assert.True(t, names["query"])
+	assert.True(t, names["update"])
+	assert.True(t, names["cancel"])
+	assert.True(t, names["result"])
+	assert.True(t, names["replay"])
+	assert.True(t, names["diff"])
+	assert.True(t, names["lint"])
+	assert.True(t, names["lsp"])
+	assert.True(t, names["inspect"])
+	assert.True(t, names["docs"])
+	assert.True(t, names["generate"])
+	assert.True(t, names["init"])
+	assert.True(t, names["fmt"])
+	assert.True(t, names["convert"])
 }
 
 func TestNewRootCmd_Flags(t *testing.T) {
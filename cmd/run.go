@@ -19,20 +19,22 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
 	gh "github.com/mrsimonemms/golang-helpers"
 	"github.com/mrsimonemms/golang-helpers/temporal"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/zigflow/zigflow/pkg/cloudevents"
 	"github.com/zigflow/zigflow/pkg/codec"
+	"github.com/zigflow/zigflow/pkg/secrets"
 	"github.com/zigflow/zigflow/pkg/telemetry"
 	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
 	"go.temporal.io/sdk/worker"
 )
 
@@ -42,11 +44,15 @@ type runOptions struct {
 	CodecHeaders         map[string]string
 	ConvertData          string
 	ConvertKeyPath       string
+	DrainTimeout         time.Duration
 	EnvPrefix            string
-	FilePath             string
+	FilePaths            []string
 	HealthListenAddress  string
 	MetricsListenAddress string
 	MetricsPrefix        string
+	SecretsDir           string
+	SecretsEnvPrefix     string
+	SecretsProvider      string
 	TemporalAddress      string
 	TemporalAPIKey       string
 	TemporalMTLSCertPath string
@@ -54,7 +60,13 @@ type runOptions struct {
 	TemporalTLSEnabled   bool
 	TemporalNamespace    string
 	Validate             bool
+	VaultAddress         string
+	VaultMount           string
+	VaultPath            string
+	VaultToken           string
+	Watch                bool
 
+	Secrets   secrets.Provider
 	Telemetry *telemetry.Telemetry
 }
 
@@ -103,87 +115,222 @@ func runValidation(validator *utils.Validator, workflowDefinition any) error {
 	return nil
 }
 
-func startWorker(
+// workerSet is the workers for a set of definitions - one worker per task
+// queue
+type workerSet struct {
+	defs       []*zigflow.Definition
+	events     map[*zigflow.Definition]*cloudevents.Events
+	taskQueues []string
+	buildIDs   []string
+	workers    []worker.Worker
+	// fatal receives the error of any worker that stops with a fatal error
+	fatal chan<- error
+}
+
+// newWorkerSet creates a worker for each task queue and registers every
+// definition on it. The workers are not started. A worker that stops with a
+// fatal error sends it to fatal.
+func newWorkerSet(
+	temporalClient client.Client,
+	defs []*zigflow.Definition,
+	envvars map[string]any,
+	events map[*zigflow.Definition]*cloudevents.Events,
+	fatal chan<- error,
+	opts *runOptions,
+) (*workerSet, error) {
+	taskQueues, groups := zigflow.GroupByTaskQueue(defs)
+	set := &workerSet{
+		defs:       defs,
+		events:     events,
+		taskQueues: taskQueues,
+		fatal:      fatal,
+	}
+
+	for _, taskQueue := range taskQueues {
+		temporalWorker, err := newWorker(temporalClient, taskQueue, groups[taskQueue], envvars, events, fatal, opts)
+		if err != nil {
+			return nil, err
+		}
+		set.buildIDs = append(set.buildIDs, zigflow.DefinitionsID(groups[taskQueue]))
+		set.workers = append(set.workers, temporalWorker)
+	}
+
+	return set, nil
+}
+
+// Start starts every worker. If one fails, those already started are stopped.
+func (s *workerSet) Start() error {
+	for i, w := range s.workers {
+		log.Info().Str("task-queue", s.taskQueues[i]).Msg("Starting workflow")
+		if err := w.Start(); err != nil {
+			for _, started := range s.workers[:i] {
+				started.Stop()
+			}
+			s.workers = nil
+
+			return gh.FatalError{
+				Cause: err,
+				Msg:   "Unable to start worker",
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetCurrentVersions routes new workflows on every task queue to these
+// workers. It is only used when the workers are versioned.
+func (s *workerSet) SetCurrentVersions(ctx context.Context, temporalClient client.Client) error {
+	for i, taskQueue := range s.taskQueues {
+		if err := zigflow.SetCurrentVersion(ctx, temporalClient.WorkerDeploymentClient(), taskQueue, s.buildIDs[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop stops every worker, waiting for in-flight tasks to finish
+func (s *workerSet) Stop() {
+	for _, w := range s.workers {
+		w.Stop()
+	}
+	s.workers = nil
+}
+
+func newWorker(
 	temporalClient client.Client,
 	taskQueue string,
-	workflowDefinition *model.Workflow,
+	defs []*zigflow.Definition,
 	envvars map[string]any,
-	events *cloudevents.Events,
-	telem *telemetry.Telemetry,
-) error {
+	events map[*zigflow.Definition]*cloudevents.Events,
+	fatal chan<- error,
+	opts *runOptions,
+) (worker.Worker, error) {
+	log.Debug().Str("task-queue", taskQueue).Str("buildId", zigflow.DefinitionsID(defs)).Msg("Creating worker")
+
+	// Reloaded definitions run alongside the previous ones, so the workers
+	// are versioned to keep each workflow on the definition it started with
+	var deploymentOptions worker.DeploymentOptions
+	if opts.Watch {
+		deploymentOptions = zigflow.DeploymentOptions(taskQueue, defs)
+	}
+
 	pollerAutoscaler := worker.NewPollerBehaviorAutoscaling(worker.PollerBehaviorAutoscalingOptions{})
 	temporalWorker := worker.New(temporalClient, taskQueue, worker.Options{
 		WorkflowTaskPollerBehavior: pollerAutoscaler,
 		ActivityTaskPollerBehavior: pollerAutoscaler,
 		NexusTaskPollerBehavior:    pollerAutoscaler,
+		WorkerStopTimeout:          opts.DrainTimeout,
+		// Activities read secrets and authentication policies from the context
+		// so they never enter the history
+		BackgroundActivityContext: zigflow.WithAuthentications(
+			secrets.WithProvider(context.Background(), opts.Secrets), defs,
+		),
+		DeploymentOptions: deploymentOptions,
+		OnFatalError:      onFatalError(fatal, taskQueue),
 	})
 
-	if err := zigflow.NewWorkflow(temporalWorker, workflowDefinition, envvars, events, telem); err != nil {
-		return gh.FatalError{
-			Cause: err,
-			Msg:   "Unable to build workflow from DSL",
+	for _, d := range defs {
+		log.Debug().Str("file", d.File).Str("task-queue", taskQueue).Msg("Registering workflow")
+		if err := zigflow.RegisterWorkflow(temporalWorker, d.Workflow, envvars, events[d], opts.Telemetry); err != nil {
+			return nil, gh.FatalError{
+				Cause: d.Source.Locate(err),
+				Msg:   "Unable to build workflow from DSL",
+				WithParams: func(l *zerolog.Event) *zerolog.Event {
+					return l.Str("file", d.File)
+				},
+			}
 		}
 	}
+	zigflow.RegisterActivities(temporalWorker)
 
-	if telem != nil {
-		telem.StartWorker()
-		defer telem.Shutdown()
-	}
+	return temporalWorker, nil
+}
 
-	if err := temporalWorker.Run(worker.InterruptCh()); err != nil {
-		return gh.FatalError{
+// onFatalError returns a worker's fatal error callback. The worker stops
+// polling after a fatal error, so the error is sent to fatal to end the
+// process rather than leave it running with nothing to do.
+func onFatalError(fatal chan<- error, taskQueue string) func(error) {
+	return func(err error) {
+		log.Error().Err(err).Str("task-queue", taskQueue).Msg("Worker stopped with a fatal error")
+
+		select {
+		case fatal <- gh.FatalError{
 			Cause: err,
-			Msg:   "Unable to start worker",
+			Msg:   "Worker stopped with a fatal error",
+			WithParams: func(l *zerolog.Event) *zerolog.Event {
+				return l.Str("task-queue", taskQueue)
+			},
+		}:
+		default:
+			// An earlier failure is already ending the process
 		}
 	}
-
-	return nil
 }
 
-func runRunCmd(ctx context.Context, opts *runOptions) error {
-	defer func() {
-		if r := recover(); r != nil {
-			log.Fatal().
-				Str("type", fmt.Sprintf("%T", r)).
-				Str("panicMsg", panicMessage(r)).
-				Msg("Recovered from panic")
-		}
-	}()
-
-	workflowDefinition, err := zigflow.LoadFromFile(opts.FilePath)
-	if err != nil {
-		return gh.FatalError{Cause: err, Msg: "Unable to load workflow file"}
+// waitForWorkers blocks until the process is interrupted or a worker fails.
+// A failed worker marks the health check as failing.
+func waitForWorkers(interrupt <-chan any, fatal <-chan error, health *healthCheck) error {
+	select {
+	case <-interrupt:
+		return nil
+	case err := <-fatal:
+		health.SetFailed(err)
+		return err
 	}
+}
 
-	validator, err := utils.NewValidator()
+// loadDefinitions loads, validates and checks the workflow files and creates
+// a CloudEvents handler for each one
+func loadDefinitions(
+	opts *runOptions,
+	validator *utils.Validator,
+) ([]*zigflow.Definition, map[*zigflow.Definition]*cloudevents.Events, error) {
+	defs, err := zigflow.LoadDefinitions(opts.FilePaths)
 	if err != nil {
-		return gh.FatalError{Cause: err, Msg: "Error creating validator"}
+		return nil, nil, gh.FatalError{Cause: err, Msg: "Unable to load workflow file"}
 	}
 
-	if opts.Validate {
-		if err := runValidation(validator, workflowDefinition); err != nil {
-			return err
+	events := map[*zigflow.Definition]*cloudevents.Events{}
+	for _, d := range defs {
+		if opts.Validate {
+			log.Debug().Str("file", d.File).Msg("Validating workflow file")
+			if err := runValidation(validator, d.Workflow); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		log.Debug().Str("cloudEventsConfig", opts.CloudEventsConfig).Msg("Registering CloudEvents handler")
+		e, err := cloudevents.Load(opts.CloudEventsConfig, validator, d.Workflow)
+		if err != nil {
+			return nil, nil, gh.FatalError{
+				Cause: err,
+				Msg:   "Error creating CloudEvents handler",
+			}
 		}
+		events[d] = e
 	}
 
-	log.Debug().Str("cloudEventsConfig", opts.CloudEventsConfig).Msg("Registering CloudEvents handler")
-	events, err := cloudevents.Load(opts.CloudEventsConfig, validator, workflowDefinition)
-	if err != nil {
-		return gh.FatalError{
+	if err := zigflow.CheckConflicts(defs); err != nil {
+		return nil, nil, gh.FatalError{
 			Cause: err,
-			Msg:   "Error creating CloudEvents handler",
+			Msg:   "Workflow files cannot be loaded together",
 		}
 	}
 
-	codecType, _ := codec.ParseCodecType(opts.ConvertData)
-	dataConverter, err := codec.NewDataConverter(codecType, opts.CodecEndpoint, opts.ConvertKeyPath, opts.CodecHeaders)
+	return defs, events, nil
+}
+
+// newTemporalClient connects to Temporal using the connection flags
+func newTemporalClient(opts *runOptions, extra ...temporal.Options) (client.Client, error) {
+	dataConverter, err := newDataConverter(opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// The client and worker are heavyweight objects that should be created once per process.
 	log.Trace().Msg("Connecting to Temporal")
-	temporalClient, err := temporal.NewConnection(
+	return temporal.NewConnection(append([]temporal.Options{
 		temporal.WithHostPort(opts.TemporalAddress),
 		temporal.WithNamespace(opts.TemporalNamespace),
 		temporal.WithTLS(opts.TemporalTLSEnabled),
@@ -194,6 +341,64 @@ func runRunCmd(ctx context.Context, opts *runOptions) error {
 		),
 		temporal.WithDataConverter(dataConverter),
 		temporal.WithZerolog(&log.Logger),
+	}, extra...)...)
+}
+
+// newSecretsProvider creates the provider configured by the secrets flags
+func newSecretsProvider(opts *runOptions) (secrets.Provider, error) {
+	provider, err := secrets.New(secrets.Options{
+		Type:         secrets.ProviderType(opts.SecretsProvider),
+		EnvPrefix:    opts.SecretsEnvPrefix,
+		Dir:          opts.SecretsDir,
+		VaultAddress: opts.VaultAddress,
+		VaultMount:   opts.VaultMount,
+		VaultPath:    opts.VaultPath,
+		VaultToken:   opts.VaultToken,
+	})
+	if err != nil {
+		return nil, gh.FatalError{
+			Cause: err,
+			Msg:   "Error creating secrets provider",
+		}
+	}
+
+	return provider, nil
+}
+
+// newDataConverter creates the data converter configured by the codec flags
+func newDataConverter(opts *runOptions) (converter.DataConverter, error) {
+	codecType, _ := codec.ParseCodecType(opts.ConvertData)
+	return codec.NewDataConverter(codecType, opts.CodecEndpoint, opts.ConvertKeyPath, opts.CodecHeaders)
+}
+
+func runRunCmd(ctx context.Context, opts *runOptions) error {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Fatal().
+				Str("type", fmt.Sprintf("%T", r)).
+				Str("panicMsg", panicMessage(r)).
+				Msg("Recovered from panic")
+		}
+	}()
+
+	validator, err := utils.NewValidator()
+	if err != nil {
+		return gh.FatalError{Cause: err, Msg: "Error creating validator"}
+	}
+
+	defs, events, err := loadDefinitions(opts, validator)
+	if err != nil {
+		return err
+	}
+
+	log.Debug().Str("provider", opts.SecretsProvider).Msg("Creating secrets provider")
+	if opts.Secrets, err = newSecretsProvider(opts); err != nil {
+		return err
+	}
+
+	// The client and worker are heavyweight objects that should be created once per process.
+	temporalClient, err := newTemporalClient(
+		opts,
 		temporal.WithPrometheusMetrics(opts.MetricsListenAddress, opts.MetricsPrefix, nil),
 	)
 	if err != nil {
@@ -208,34 +413,99 @@ func runRunCmd(ctx context.Context, opts *runOptions) error {
 		log.Trace().Msg("Temporal connection closed")
 	}()
 
-	taskQueue := workflowDefinition.Document.Namespace
 	prefix := opts.EnvPrefix + "_"
 
 	log.Debug().Str("prefix", prefix).Msg("Loading envvars to state")
 	envvars := utils.LoadEnvvars(prefix)
 
 	log.Debug().Msg("Starting health check service")
-	temporal.NewHealthCheck(ctx, taskQueue, opts.HealthListenAddress, temporalClient)
+	taskQueues, _ := zigflow.GroupByTaskQueue(defs)
+	health := newHealthCheck(temporalClient, taskQueues)
+	health.Serve(ctx, opts.HealthListenAddress)
 
 	log.Info().Msg("Updating schedules")
-	if err := zigflow.UpdateSchedules(ctx, temporalClient, workflowDefinition, envvars); err != nil {
+	if err := zigflow.ReconcileSchedules(ctx, temporalClient, defs, envvars); err != nil {
 		return gh.FatalError{
 			Cause: err,
 			Msg:   "Error updating Temporal schedules",
 		}
 	}
 
-	log.Info().Str("task-queue", taskQueue).Msg("Starting workflow")
+	fatal := make(chan error, 1)
+	set, err := newWorkerSet(temporalClient, defs, envvars, events, fatal, opts)
+	if err != nil {
+		return err
+	}
+	if err := set.Start(); err != nil {
+		return err
+	}
+	defer set.Stop()
+
+	if opts.Watch {
+		log.Info().Msg("Setting the current worker versions")
+		if err := set.SetCurrentVersions(ctx, temporalClient); err != nil {
+			return gh.FatalError{
+				Cause: err,
+				Msg:   "Unable to set the current worker versions",
+			}
+		}
+	}
+
+	if opts.Telemetry != nil {
+		opts.Telemetry.StartWorker()
+		defer opts.Telemetry.Shutdown()
+	}
+
+	if opts.Watch {
+		return watchDefinitions(ctx, temporalClient, set, health, fatal, envvars, validator, opts)
+	}
 
-	return startWorker(temporalClient, taskQueue, workflowDefinition, envvars, events, opts.Telemetry)
+	return waitForWorkers(worker.InterruptCh(), fatal, health)
 }
 
-func registerRunFlags(cmd *cobra.Command, opts *runOptions) {
+// registerConnectionFlags registers the flags needed to connect to Temporal
+// and encode data. These are shared by the worker and the client commands.
+func registerConnectionFlags(cmd *cobra.Command, opts *runOptions) {
+	registerCodecFlags(cmd, opts)
+
+	viper.SetDefault("temporal_address", client.DefaultHostPort)
+	cmd.Flags().StringVarP(
+		&opts.TemporalAddress, "temporal-address", "H",
+		viper.GetString("temporal_address"), "Address of the Temporal server",
+	)
+
 	cmd.Flags().StringVar(
-		&opts.CloudEventsConfig, "cloudevents-config",
-		viper.GetString("cloudevents_config"), "Path to CloudEvents config file",
+		&opts.TemporalAPIKey, "temporal-api-key",
+		viper.GetString("temporal_api_key"), "API key for Temporal authentication",
+	)
+	// Hide the default value to avoid spaffing the API to command line
+	gh.HideCommandOutput(cmd, "temporal-api-key")
+
+	cmd.Flags().StringVar(
+		&opts.TemporalMTLSCertPath, "tls-client-cert-path",
+		viper.GetString("temporal_tls_client_cert_path"), "Path to mTLS client cert, usually ending in .pem",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.TemporalMTLSKeyPath, "tls-client-key-path",
+		viper.GetString("temporal_tls_client_key_path"), "Path to mTLS client key, usually ending in .key",
+	)
+
+	viper.SetDefault("temporal_namespace", client.DefaultNamespace)
+	cmd.Flags().StringVarP(
+		&opts.TemporalNamespace, "temporal-namespace", "n",
+		viper.GetString("temporal_namespace"), "Temporal namespace to use",
+	)
+
+	cmd.Flags().BoolVar(
+		&opts.TemporalTLSEnabled, "temporal-tls",
+		viper.GetBool("temporal_tls"), "Enable TLS Temporal connection",
 	)
+}
 
+// registerCodecFlags registers the flags that configure how Temporal data is
+// encoded
+func registerCodecFlags(cmd *cobra.Command, opts *runOptions) {
 	cmd.Flags().StringVar(
 		&opts.CodecEndpoint, "codec-endpoint",
 		viper.GetString("codec_endpoint"), "Remote codec server endpoint",
@@ -257,10 +527,19 @@ func registerRunFlags(cmd *cobra.Command, opts *runOptions) {
 		&opts.ConvertKeyPath, "converter-key-path",
 		viper.GetString("converter_key_path"), "Path to conversion keys to encrypt Temporal data with AES",
 	)
+}
 
-	cmd.Flags().StringVarP(
-		&opts.FilePath, "file", "f",
-		viper.GetString("workflow_file"), "Path to workflow file",
+func registerRunFlags(cmd *cobra.Command, opts *runOptions) {
+	registerConnectionFlags(cmd, opts)
+
+	cmd.Flags().StringArrayVarP(
+		&opts.FilePaths, "file", "f",
+		viper.GetStringSlice("workflow_file"), "Path to a workflow file, directory or glob - may be repeated",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.CloudEventsConfig, "cloudevents-config",
+		viper.GetString("cloudevents_config"), "Path to CloudEvents config file",
 	)
 
 	viper.SetDefault("env_prefix", "ZIGGY")
@@ -269,6 +548,8 @@ func registerRunFlags(cmd *cobra.Command, opts *runOptions) {
 		viper.GetString("env_prefix"), "Load envvars with this prefix to the workflow",
 	)
 
+	registerSecretsFlags(cmd, opts)
+
 	viper.SetDefault("health_listen_address", "0.0.0.0:3000")
 	cmd.Flags().StringVar(
 		&opts.HealthListenAddress, "health-listen-address",
@@ -286,45 +567,67 @@ func registerRunFlags(cmd *cobra.Command, opts *runOptions) {
 		viper.GetString("metrics_prefix"), "Prefix for metrics",
 	)
 
-	viper.SetDefault("temporal_address", client.DefaultHostPort)
-	cmd.Flags().StringVarP(
-		&opts.TemporalAddress, "temporal-address", "H",
-		viper.GetString("temporal_address"), "Address of the Temporal server",
+	viper.SetDefault("validate", true)
+	cmd.Flags().BoolVar(
+		&opts.Validate, "validate",
+		viper.GetBool("validate"), "Run workflow validation",
 	)
 
+	cmd.Flags().BoolVar(
+		&opts.Watch, "watch",
+		viper.GetBool("watch"), "Reload the workers when the workflow files change",
+	)
+
+	viper.SetDefault("drain_timeout", 10*time.Second)
+	cmd.Flags().DurationVar(
+		&opts.DrainTimeout, "drain-timeout",
+		viper.GetDuration("drain_timeout"), "Time to wait for in-flight activities when a worker stops",
+	)
+}
+
+// registerSecretsFlags registers the flags that configure where the values
+// of $secrets are read from
+func registerSecretsFlags(cmd *cobra.Command, opts *runOptions) {
+	viper.SetDefault("secrets_provider", string(secrets.ProviderEnv))
 	cmd.Flags().StringVar(
-		&opts.TemporalAPIKey, "temporal-api-key",
-		viper.GetString("temporal_api_key"), "API key for Temporal authentication",
+		&opts.SecretsProvider, "secrets-provider",
+		viper.GetString("secrets_provider"),
+		fmt.Sprintf("Secrets provider: %q, %q or %q", secrets.ProviderEnv, secrets.ProviderFile, secrets.ProviderVault),
 	)
-	// Hide the default value to avoid spaffing the API to command line
-	gh.HideCommandOutput(cmd, "temporal-api-key")
 
+	viper.SetDefault("secrets_env_prefix", secrets.DefaultEnvPrefix)
 	cmd.Flags().StringVar(
-		&opts.TemporalMTLSCertPath, "tls-client-cert-path",
-		viper.GetString("temporal_tls_client_cert_path"), "Path to mTLS client cert, usually ending in .pem",
+		&opts.SecretsEnvPrefix, "secrets-env-prefix",
+		viper.GetString("secrets_env_prefix"), "Read secrets from envvars with this prefix",
 	)
 
 	cmd.Flags().StringVar(
-		&opts.TemporalMTLSKeyPath, "tls-client-key-path",
-		viper.GetString("temporal_tls_client_key_path"), "Path to mTLS client key, usually ending in .key",
+		&opts.SecretsDir, "secrets-dir",
+		viper.GetString("secrets_dir"), "Read secrets from the files in this directory",
 	)
 
-	viper.SetDefault("temporal_namespace", client.DefaultNamespace)
-	cmd.Flags().StringVarP(
-		&opts.TemporalNamespace, "temporal-namespace", "n",
-		viper.GetString("temporal_namespace"), "Temporal namespace to use",
+	cmd.Flags().StringVar(
+		&opts.VaultAddress, "vault-address",
+		viper.GetString("vault_address"), "Address of the Vault-compatible secrets API",
 	)
 
-	cmd.Flags().BoolVar(
-		&opts.TemporalTLSEnabled, "temporal-tls",
-		viper.GetBool("temporal_tls"), "Enable TLS Temporal connection",
+	viper.SetDefault("vault_mount", secrets.DefaultVaultMount)
+	cmd.Flags().StringVar(
+		&opts.VaultMount, "vault-mount",
+		viper.GetString("vault_mount"), "Mount path of the KV v2 secrets engine",
 	)
 
-	viper.SetDefault("validate", true)
-	cmd.Flags().BoolVar(
-		&opts.Validate, "validate",
-		viper.GetBool("validate"), "Run workflow validation",
+	cmd.Flags().StringVar(
+		&opts.VaultPath, "vault-path",
+		viper.GetString("vault_path"), "Path of the secret to read the fields of",
+	)
+
+	cmd.Flags().StringVar(
+		&opts.VaultToken, "vault-token",
+		viper.GetString("vault_token"), "Token to authenticate with Vault",
 	)
+	// Hide the default value to avoid spaffing the token to command line
+	gh.HideCommandOutput(cmd, "vault-token")
 }
 
 func newRunCmd() *cobra.Command {
@@ -334,12 +637,17 @@ func newRunCmd() *cobra.Command {
 		Use:   "run",
 		Short: "Start the Zigflow workflow worker",
 		Long: `Start a Zigflow workflow worker that connects to Temporal and processes
-workflow executions defined in the provided workflow file.
+workflow executions defined in the provided workflow files.
+
+The worker loads the workflow definitions from the specified files, validates
+them (by default), and registers them with Temporal using each workflow's
+namespace as the task queue. The worker then polls Temporal for workflow and
+activity tasks until interrupted.
 
-The worker loads the workflow definition from the specified file, validates it
-(by default), and registers it with Temporal using the workflow's namespace as
-the task queue. The worker then polls Temporal for workflow and activity tasks
-until interrupted.
+The --file flag may be repeated and accepts directories, which are searched
+recursively, and glob patterns. Files that share a namespace are registered on
+the same worker. Loading fails if two files define the same workflow type on
+the same task queue.
 
 Use this command to deploy and run your Zigflow workflows in any environment,
 from local development to production.`,
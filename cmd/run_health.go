@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// healthCheck reports whether every worker's task queue can be reached. The
+// task queues are replaced when the workers are reloaded.
+type healthCheck struct {
+	client client.Client
+
+	mu         sync.RWMutex
+	taskQueues []string
+	// failure is the error of a worker that has stopped
+	failure error
+}
+
+func newHealthCheck(temporalClient client.Client, taskQueues []string) *healthCheck {
+	return &healthCheck{
+		client:     temporalClient,
+		taskQueues: slices.Clone(taskQueues),
+	}
+}
+
+// SetTaskQueues replaces the task queues that are checked
+func (h *healthCheck) SetTaskQueues(taskQueues []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.taskQueues = slices.Clone(taskQueues)
+}
+
+// SetFailed reports the health check as failing. A worker that stops with a
+// fatal error no longer polls, even though its task queue can still be
+// reached.
+func (h *healthCheck) SetFailed(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failure = err
+}
+
+func (h *healthCheck) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.RLock()
+	taskQueues := h.taskQueues
+	failure := h.failure
+	h.mu.RUnlock()
+
+	if failure != nil {
+		log.Error().Err(failure).Msg("Worker unhealthy")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("Down"))
+		return
+	}
+
+	for _, taskQueue := range taskQueues {
+		if _, err := h.client.DescribeTaskQueue(r.Context(), taskQueue, enums.TASK_QUEUE_TYPE_ACTIVITY); err != nil {
+			log.Error().Err(err).Str("task-queue", taskQueue).Msg("Temporal connection unhealthy")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("Down"))
+			return
+		}
+	}
+
+	log.Debug().Msg("Temporal connection healthy")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// Serve starts the health check server in the background
+func (h *healthCheck) Serve(ctx context.Context, address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/health", h)
+
+	srv := &http.Server{
+		Addr:         address,
+		ReadTimeout:  time.Second,
+		WriteTimeout: time.Second,
+		Handler:      mux,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+
+	go func() {
+		log.Info().Str("address", address).Msg("Starting healthcheck service")
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal().Err(err).Msg("Error serving health check connection")
+		}
+	}()
+}
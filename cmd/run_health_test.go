@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// taskQueueClient can only describe the task queues it knows about
+type taskQueueClient struct {
+	client.Client
+
+	taskQueues []string
+}
+
+func (c *taskQueueClient) DescribeTaskQueue(
+	_ context.Context, taskQueue string, _ enums.TaskQueueType,
+) (*workflowservice.DescribeTaskQueueResponse, error) {
+	for _, t := range c.taskQueues {
+		if t == taskQueue {
+			return &workflowservice.DescribeTaskQueueResponse{}, nil
+		}
+	}
+	return nil, errors.New("task queue not found")
+}
+
+func TestHealthCheck(t *testing.T) {
+	c := &taskQueueClient{taskQueues: []string{"a", "b"}}
+
+	tests := []struct {
+		Name       string
+		TaskQueues []string
+		Expected   int
+	}{
+		{
+			Name:       "every task queue healthy",
+			TaskQueues: []string{"a", "b"},
+			Expected:   http.StatusOK,
+		},
+		{
+			Name:       "second task queue unhealthy",
+			TaskQueues: []string{"a", "c"},
+			Expected:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			h := newHealthCheck(c, test.TaskQueues)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			assert.Equal(t, test.Expected, w.Code)
+		})
+	}
+
+	t.Run("task queues replaced on reload", func(t *testing.T) {
+		h := newHealthCheck(c, []string{"a"})
+		h.SetTaskQueues([]string{"a", "c"})
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
@@ -19,9 +19,13 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	gh "github.com/mrsimonemms/golang-helpers"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/zigflow/zigflow/pkg/codec"
 )
 
@@ -55,6 +59,46 @@ func TestPanicMessage(t *testing.T) {
 	}
 }
 
+func TestWaitForWorkers(t *testing.T) {
+	c := &taskQueueClient{taskQueues: []string{"a"}}
+
+	t.Run("interrupted", func(t *testing.T) {
+		interrupt := make(chan any, 1)
+		interrupt <- struct{}{}
+		health := newHealthCheck(c, []string{"a"})
+
+		assert.NoError(t, waitForWorkers(interrupt, make(chan error), health))
+
+		w := httptest.NewRecorder()
+		health.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("worker fails fatally", func(t *testing.T) {
+		fatal := make(chan error, 1)
+		health := newHealthCheck(c, []string{"a"})
+
+		// The worker calls this when it stops polling
+		callback := onFatalError(fatal, "a")
+		callback(errors.New("namespace not found"))
+		// Later failures don't block the worker
+		callback(errors.New("another error"))
+
+		err := waitForWorkers(make(chan any), fatal, health)
+		require.Error(t, err)
+
+		var fatalErr gh.FatalError
+		require.ErrorAs(t, err, &fatalErr)
+		assert.Equal(t, "Worker stopped with a fatal error", fatalErr.Msg)
+		assert.EqualError(t, fatalErr.Cause, "namespace not found")
+
+		// The task queue can still be reached, but the worker isn't polling it
+		w := httptest.NewRecorder()
+		health.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
 func TestBuildDataConverter(t *testing.T) {
 	tests := []struct {
 		Name         string
@@ -113,6 +157,56 @@ func TestBuildDataConverter(t *testing.T) {
 	}
 }
 
+func TestNewSecretsProvider(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Opts        runOptions
+		ExpectError bool
+	}{
+		{
+			Name: "env",
+			Opts: runOptions{SecretsProvider: "env", SecretsEnvPrefix: "ZIGFLOW_SECRET_"},
+		},
+		{
+			Name: "file",
+			Opts: runOptions{SecretsProvider: "file", SecretsDir: "/var/run/secrets"},
+		},
+		{
+			Name:        "file without directory",
+			Opts:        runOptions{SecretsProvider: "file"},
+			ExpectError: true,
+		},
+		{
+			Name: "vault",
+			Opts: runOptions{SecretsProvider: "vault", VaultAddress: "http://localhost:8200", VaultPath: "zigflow"},
+		},
+		{
+			Name:        "vault without address",
+			Opts:        runOptions{SecretsProvider: "vault", VaultPath: "zigflow"},
+			ExpectError: true,
+		},
+		{
+			Name:        "unknown provider",
+			Opts:        runOptions{SecretsProvider: "aws"},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			provider, err := newSecretsProvider(&test.Opts)
+
+			if test.ExpectError {
+				assert.Error(t, err)
+				assert.Nil(t, provider)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, provider)
+			}
+		})
+	}
+}
+
 func TestNewRunCmd_Flags(t *testing.T) {
 	cmd := newRunCmd()
 
@@ -128,4 +222,11 @@ func TestNewRunCmd_Flags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("env-prefix"))
 	assert.NotNil(t, cmd.Flags().Lookup("health-listen-address"))
 	assert.NotNil(t, cmd.Flags().Lookup("metrics-listen-address"))
+	assert.NotNil(t, cmd.Flags().Lookup("watch"))
+	assert.NotNil(t, cmd.Flags().Lookup("drain-timeout"))
+	assert.NotNil(t, cmd.Flags().Lookup("secrets-provider"))
+	assert.NotNil(t, cmd.Flags().Lookup("secrets-env-prefix"))
+	assert.NotNil(t, cmd.Flags().Lookup("secrets-dir"))
+	assert.NotNil(t, cmd.Flags().Lookup("vault-address"))
+	assert.NotNil(t, cmd.Flags().Lookup("vault-token"))
 }
@@ -0,0 +1,337 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog/log"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+// watchDebounce groups the burst of events editors make when saving a file
+const watchDebounce = 500 * time.Millisecond
+
+// watchDrainInterval is how often the workers of previous definitions are
+// checked to see if the workflows pinned to them have finished
+const watchDrainInterval = 30 * time.Second
+
+// watchDefinitions reloads the workers whenever the workflow files change
+// until interrupted. The running workers are only replaced if the new
+// definitions are valid - otherwise the current definitions are kept.
+func watchDefinitions(
+	ctx context.Context,
+	temporalClient client.Client,
+	set *workerSet,
+	health *healthCheck,
+	fatal <-chan error,
+	envvars map[string]any,
+	validator *utils.Validator,
+	opts *runOptions,
+) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return gh.FatalError{
+			Cause: err,
+			Msg:   "Unable to create file watcher",
+		}
+	}
+	defer func() {
+		if err := watcher.Close(); err != nil {
+			log.Warn().Err(err).Msg("Error closing file watcher")
+		}
+	}()
+
+	// Directories are watched rather than files as editors often save by
+	// replacing the file, which ends a watch on the file itself
+	for _, dir := range watchDirectories(opts, set.defs) {
+		log.Debug().Str("dir", dir).Msg("Watching directory")
+		if err := watcher.Add(dir); err != nil {
+			return gh.FatalError{
+				Cause: err,
+				Msg:   "Unable to watch directory",
+			}
+		}
+	}
+
+	log.Info().Msg("Watching workflow files for changes")
+
+	// The workers of previous definitions keep polling until their workflows
+	// have finished
+	var draining []drainingWorker
+	defer func() {
+		for _, d := range draining {
+			d.worker.Stop()
+		}
+	}()
+
+	drainTicker := time.NewTicker(watchDrainInterval)
+	defer drainTicker.Stop()
+
+	interrupt := worker.InterruptCh()
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-interrupt:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case err := <-fatal:
+			health.SetFailed(err)
+			return err
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Warn().Err(err).Msg("File watcher error")
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isWatchedFile(event.Name, opts, set.defs) {
+				log.Debug().Str("file", event.Name).Str("op", event.Op.String()).Msg("Workflow file changed")
+				debounce = time.After(watchDebounce)
+			}
+		case <-drainTicker.C:
+			draining = stopDrained(ctx, temporalClient.WorkerDeploymentClient(), draining)
+		case <-debounce:
+			debounce = nil
+			previous, err := reloadDefinitions(ctx, temporalClient, set, envvars, validator, opts)
+			if err != nil {
+				log.Error().Err(err).Msg("Keeping the current workflow definitions")
+				continue
+			}
+			draining = append(draining, previous...)
+			health.SetTaskQueues(set.taskQueues)
+		}
+	}
+}
+
+// drainingWorker is a worker for previous definitions. It keeps polling so
+// that the workflows pinned to its version can finish.
+type drainingWorker struct {
+	taskQueue string
+	buildID   string
+	worker    worker.Worker
+}
+
+// stopDrained stops the workers whose versions have drained and returns the
+// workers that are still draining
+func stopDrained(
+	ctx context.Context,
+	deployments client.WorkerDeploymentClient,
+	draining []drainingWorker,
+) []drainingWorker {
+	remaining := make([]drainingWorker, 0, len(draining))
+	for _, d := range draining {
+		drained, err := zigflow.VersionDrained(ctx, deployments, d.taskQueue, d.buildID)
+		if err != nil {
+			log.Warn().Err(err).Str("task-queue", d.taskQueue).Str("buildId", d.buildID).Msg("Unable to check if version has drained")
+		}
+		if !drained {
+			remaining = append(remaining, d)
+			continue
+		}
+
+		log.Info().Str("task-queue", d.taskQueue).Str("buildId", d.buildID).Msg("Previous definitions drained - stopping worker")
+		d.worker.Stop()
+	}
+
+	return remaining
+}
+
+// reloadDefinitions loads the workflow files and, if they are valid and have
+// changed, starts new workers for them. The running workers are only touched
+// once the new workers are polling, so a failed reload never leaves the task
+// queues without a worker.
+//
+// The workers are versioned by their definitions. New workflows go to the new
+// workers, while running workflows stay pinned to the version they started
+// on. The previous workers are returned so they can keep polling until those
+// workflows have finished.
+func reloadDefinitions(
+	ctx context.Context,
+	temporalClient client.Client,
+	set *workerSet,
+	envvars map[string]any,
+	validator *utils.Validator,
+	opts *runOptions,
+) ([]drainingWorker, error) {
+	log.Info().Msg("Reloading workflow files")
+
+	defs, events, err := loadDefinitions(opts, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := zigflow.DefinitionsChanged(set.defs, defs)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		log.Info().Msg("Workflow definitions unchanged")
+		return nil, nil
+	}
+
+	if err := zigflow.CheckVersionBump(set.defs, defs); err != nil {
+		return nil, err
+	}
+
+	next, err := newWorkerSet(temporalClient, defs, envvars, events, set.fatal, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := next.Start(); err != nil {
+		return nil, err
+	}
+
+	log.Info().Msg("Setting the current worker versions")
+	if err := next.SetCurrentVersions(ctx, temporalClient); err != nil {
+		if rErr := set.SetCurrentVersions(ctx, temporalClient); rErr != nil {
+			log.Error().Err(rErr).Msg("Unable to restore the previous worker versions")
+		}
+		next.Stop()
+		return nil, err
+	}
+
+	deployments := temporalClient.WorkerDeploymentClient()
+	draining := make([]drainingWorker, 0, len(set.workers))
+	for i, taskQueue := range set.taskQueues {
+		buildID := set.buildIDs[i]
+
+		j := slices.Index(next.taskQueues, taskQueue)
+		if j >= 0 && next.buildIDs[j] == buildID {
+			// The new worker serves the same version
+			set.workers[i].Stop()
+			continue
+		}
+		if j < 0 {
+			// Nothing is defined on the task queue any more. New workflows are
+			// sent to unversioned workers so that this version can drain.
+			if err := zigflow.SetCurrentVersion(ctx, deployments, taskQueue, ""); err != nil {
+				log.Error().Err(err).Str("task-queue", taskQueue).Msg("Unable to unset the current worker version")
+			}
+		}
+
+		log.Info().Str("task-queue", taskQueue).Str("buildId", buildID).Msg("Draining previous definitions")
+		draining = append(draining, drainingWorker{
+			taskQueue: taskQueue,
+			buildID:   buildID,
+			worker:    set.workers[i],
+		})
+	}
+
+	previous := set.defs
+	*set = *next
+
+	for _, d := range defs {
+		log.Info().
+			Str("file", d.File).
+			Str("version", d.Workflow.Document.Version).
+			Msg("Workflow definition loaded")
+	}
+
+	// The new workers are running so schedule errors are not a reason to roll
+	// back
+	log.Info().Msg("Updating schedules")
+	if err := zigflow.ReconcileSchedules(ctx, temporalClient, defs, envvars); err != nil {
+		log.Error().Err(err).Msg("Error updating Temporal schedules")
+	}
+	if err := zigflow.DeleteRemovedSchedules(ctx, temporalClient, previous, defs, envvars); err != nil {
+		log.Error().Err(err).Msg("Error deleting Temporal schedules")
+	}
+
+	return draining, nil
+}
+
+// watchDirectories returns the directories that may contain workflow files
+func watchDirectories(opts *runOptions, defs []*zigflow.Definition) []string {
+	dirs := make([]string, 0)
+	add := func(dir string) {
+		dir = filepath.Clean(dir)
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, d := range defs {
+		add(filepath.Dir(d.File))
+	}
+
+	for _, p := range opts.FilePaths {
+		if strings.ContainsAny(p, "*?[") {
+			add(filepath.Dir(p))
+			continue
+		}
+		if info, err := os.Stat(p); err == nil && info.IsDir() {
+			// Directories are loaded recursively, so their subdirectories are
+			// watched too
+			_ = filepath.WalkDir(p, func(dir string, e fs.DirEntry, err error) error {
+				if err != nil || !e.IsDir() {
+					return nil
+				}
+				if dir != p && strings.HasPrefix(e.Name(), ".") {
+					return filepath.SkipDir
+				}
+				add(dir)
+				return nil
+			})
+		}
+	}
+
+	return dirs
+}
+
+// isWatchedFile returns true if a change to the file should trigger a reload
+func isWatchedFile(name string, opts *runOptions, defs []*zigflow.Definition) bool {
+	name = filepath.Clean(name)
+
+	for _, d := range defs {
+		if d.File == name {
+			return true
+		}
+	}
+
+	// New files that match the file arguments are picked up too
+	for _, p := range opts.FilePaths {
+		p = filepath.Clean(p)
+		if name == p {
+			return true
+		}
+		if matched, _ := filepath.Match(p, name); matched {
+			return true
+		}
+		if rel, err := filepath.Rel(p, name); err == nil && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) &&
+			slices.Contains(zigflow.WorkflowFileExtensions, filepath.Ext(name)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+func TestIsWatchedFile(t *testing.T) {
+	opts := &runOptions{
+		FilePaths: []string{"workflows", "shared/*.yaml", "single.yaml"},
+	}
+	defs := []*zigflow.Definition{
+		{File: "other/loaded.yaml"},
+	}
+
+	tests := []struct {
+		Name     string
+		File     string
+		Expected bool
+	}{
+		{
+			Name:     "loaded file",
+			File:     "other/loaded.yaml",
+			Expected: true,
+		},
+		{
+			Name:     "new file in a directory",
+			File:     "workflows/new.yml",
+			Expected: true,
+		},
+		{
+			Name:     "new file in a subdirectory",
+			File:     "workflows/team/new.yaml",
+			Expected: true,
+		},
+		{
+			Name: "non-workflow file in a directory",
+			File: "workflows/README.md",
+		},
+		{
+			Name:     "new file matching a glob",
+			File:     "shared/new.yaml",
+			Expected: true,
+		},
+		{
+			Name: "file not matching a glob",
+			File: "shared/new.json",
+		},
+		{
+			Name:     "named file",
+			File:     "single.yaml",
+			Expected: true,
+		},
+		{
+			Name: "unrelated file",
+			File: "other/unrelated.yaml",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, isWatchedFile(test.File, opts, defs))
+		})
+	}
+}
+
+func TestWatchDirectories(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "team", "nested"), 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o750))
+
+	opts := &runOptions{
+		FilePaths: []string{dir, "shared/*.yaml", "single.yaml"},
+	}
+	defs := []*zigflow.Definition{
+		{File: "single.yaml"},
+		{File: "other/loaded.yaml"},
+	}
+
+	assert.Equal(t, []string{
+		".", "other", dir, filepath.Join(dir, "team"), filepath.Join(dir, "team", "nested"), "shared",
+	}, watchDirectories(opts, defs))
+}
+
+func reloadYAML(version, value string) string {
+	return fmt.Sprintf(`document:
+  dsl: 1.0.0
+  namespace: queue
+  name: wf
+  version: %s
+do:
+  - step:
+      set:
+        hello: %s
+`, version, value)
+}
+
+func TestReloadDefinitionsWithoutVersionBump(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "wf.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(reloadYAML("1.0.0", "v1")), 0o600))
+
+	validator, err := utils.NewValidator()
+	require.NoError(t, err)
+
+	opts := &runOptions{FilePaths: []string{file}}
+	defs, events, err := loadDefinitions(opts, validator)
+	require.NoError(t, err)
+
+	running := &stopWorker{}
+	set := &workerSet{
+		defs:       defs,
+		events:     events,
+		taskQueues: []string{"queue"},
+		buildIDs:   []string{"wf@1.0.0"},
+		workers:    []worker.Worker{running},
+	}
+
+	require.NoError(t, os.WriteFile(file, []byte(reloadYAML("1.0.0", "v2")), 0o600))
+
+	// No client is needed as the new definitions are refused before any
+	// worker is created
+	draining, err := reloadDefinitions(context.Background(), nil, set, nil, validator, opts)
+	assert.ErrorIs(t, err, zigflow.ErrVersionNotBumped)
+	assert.Empty(t, draining)
+
+	// The running workers keep the current definitions
+	assert.Equal(t, defs, set.defs)
+	assert.False(t, running.stopped)
+}
+
+// stopWorker records whether it has been stopped
+type stopWorker struct {
+	worker.Worker
+
+	stopped bool
+}
+
+func (w *stopWorker) Stop() {
+	w.stopped = true
+}
+
+// drainageDeployments reports the build IDs in drained as drained
+type drainageDeployments struct {
+	client.WorkerDeploymentClient
+	client.WorkerDeploymentHandle
+
+	drained []string
+}
+
+func (d *drainageDeployments) GetHandle(string) client.WorkerDeploymentHandle {
+	return d
+}
+
+func (d *drainageDeployments) DescribeVersion(
+	_ context.Context, options client.WorkerDeploymentDescribeVersionOptions,
+) (client.WorkerDeploymentVersionDescription, error) {
+	var status client.WorkerDeploymentVersionDrainageStatus = client.WorkerDeploymentVersionDrainageStatusDraining
+	if slices.Contains(d.drained, options.BuildID) {
+		status = client.WorkerDeploymentVersionDrainageStatusDrained
+	}
+
+	return client.WorkerDeploymentVersionDescription{
+		Info: client.WorkerDeploymentVersionInfo{
+			DrainageInfo: &client.WorkerDeploymentVersionDrainageInfo{DrainageStatus: status},
+		},
+	}, nil
+}
+
+func TestStopDrained(t *testing.T) {
+	drained := &stopWorker{}
+	running := &stopWorker{}
+
+	remaining := stopDrained(context.Background(), &drainageDeployments{drained: []string{"wf@1.0.0"}}, []drainingWorker{
+		{taskQueue: "queue", buildID: "wf@1.0.0", worker: drained},
+		{taskQueue: "queue", buildID: "wf@1.1.0", worker: running},
+	})
+
+	assert.True(t, drained.stopped)
+	assert.False(t, running.stopped)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "wf@1.1.0", remaining[0].buildID)
+}
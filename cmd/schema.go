@@ -27,6 +27,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/schema"
 	"sigs.k8s.io/yaml"
 )
 
@@ -39,17 +40,23 @@ func newSchemaCmd() *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "schema",
+		Use:   "schema [workflow|cloudevents|keys]",
 		Short: "Output the Zigflow JSON schema.",
 		Long: `Output the JSON Schema for the Zigflow workflow specification.
 
 The schema can be used by editors, validation tools and AI code
 generators to produce structurally valid Zigflow workflows. It defines
 required fields, supported properties and constraints enforced by the
-Zigflow CLI.
+Zigflow CLI, including the metadata Zigflow reads, such as
+activityOptions and searchAttributes.
 
 By exposing the schema programmatically, Zigflow enables reliable
-validation, structured generation and automated tooling integration.`,
+validation, structured generation and automated tooling integration.
+
+The schemas of the --cloudevents-config file and the AES keys file are
+output with the "cloudevents" and "keys" arguments.`,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{string(schema.KindWorkflow), string(schema.KindCloudEvents), string(schema.KindKeys)},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			v := opts.Output
 
@@ -73,12 +80,16 @@ validation, structured generation and automated tooling integration.`,
 				}
 			}
 
-			// Convert to a map
-			var result map[string]any
-			if err := yaml.Unmarshal(Schema, &result); err != nil {
+			kind := schema.KindWorkflow
+			if len(args) > 0 {
+				kind = schema.Kind(args[0])
+			}
+
+			result, err := schema.Build(kind, Schema)
+			if err != nil {
 				return gh.FatalError{
 					Cause: err,
-					Msg:   "Error converting schema to Go",
+					Msg:   "Error building the schema",
 				}
 			}
 
@@ -36,6 +36,19 @@ func TestNewSchemaCmd(t *testing.T) {
 			Name: "YAML output",
 			Args: []string{"--output", "yaml"},
 		},
+		{
+			Name: "CloudEvents config schema",
+			Args: []string{"cloudevents"},
+		},
+		{
+			Name: "keys schema",
+			Args: []string{"keys", "--output", "yaml"},
+		},
+		{
+			Name:        "unknown schema",
+			Args:        []string{"emails"},
+			ExpectError: true,
+		},
 		{
 			Name:        "unsupported output format",
 			Args:        []string{"--output", "toml"},
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/google/uuid"
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"go.temporal.io/sdk/client"
+)
+
+func newStartCmd() *cobra.Command {
+	var opts clientOptions
+	var startOpts struct {
+		Wait     bool
+		Workflow string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start a workflow execution",
+		Long: `Start a new execution of a Zigflow workflow.
+
+The workflow file is used to resolve the task queue (document.namespace) and the
+workflow type. The type defaults to document.name - use --workflow to select one
+of the workflows when a file defines several.
+
+Input can be passed as JSON with --input, or read from a file or stdin with
+--input-file. If no workflow ID is given, one is generated.
+
+With --wait the command blocks until the workflow completes and prints the
+output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wf, err := opts.loadWorkflow()
+			if err != nil {
+				return err
+			}
+			if wf == nil {
+				return gh.FatalError{
+					Msg: "Workflow file must be set with --file",
+				}
+			}
+
+			workflowType, err := resolveWorkflowType(wf, startOpts.Workflow)
+			if err != nil {
+				return err
+			}
+
+			input, err := opts.readInput(cmd.InOrStdin())
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to read input",
+				}
+			}
+
+			workflowID := opts.WorkflowID
+			if workflowID == "" {
+				workflowID = fmt.Sprintf("%s-%s", workflowType, uuid.NewString())
+			}
+
+			c, err := opts.connect()
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			run, err := c.ExecuteWorkflow(cmd.Context(), client.StartWorkflowOptions{
+				ID:        workflowID,
+				TaskQueue: wf.Document.Namespace,
+			}, workflowType, input)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unable to start workflow",
+				}
+			}
+
+			if !startOpts.Wait {
+				return printJSON(os.Stdout, map[string]string{
+					"workflowId": run.GetID(),
+					"runId":      run.GetRunID(),
+				})
+			}
+
+			var output any
+			if err := run.Get(cmd.Context(), &output); err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Workflow failed",
+				}
+			}
+
+			return printJSON(os.Stdout, output)
+		},
+	}
+
+	registerConnectionFlags(cmd, &opts.runOptions)
+	registerWorkflowIDFlag(cmd, &opts)
+	registerInputFlags(cmd, &opts)
+	registerWorkflowFileFlag(cmd, &opts)
+
+	cmd.Flags().BoolVar(
+		&startOpts.Wait, "wait",
+		viper.GetBool("wait"), "Wait for the workflow to complete and print the output",
+	)
+
+	cmd.Flags().StringVarP(
+		&startOpts.Workflow, "workflow", "w",
+		viper.GetString("start_workflow"), "Temporal workflow type to start - defaults to document.name",
+	)
+
+	return cmd
+}
+
+// resolveWorkflowType returns the workflow type to start. If none is given,
+// the document name is used or the only workflow defined in the file.
+func resolveWorkflowType(wf *model.Workflow, workflowType string) (string, error) {
+	available := zigflow.WorkflowTypes(wf, false)
+
+	if workflowType == "" {
+		if len(available) == 1 {
+			return available[0], nil
+		}
+		workflowType = wf.Document.Name
+	}
+
+	if !slices.Contains(available, workflowType) {
+		return "", gh.FatalError{
+			Cause: fmt.Errorf("workflow %q is not defined - available: %v", workflowType, available),
+			Msg:   "Unknown workflow type",
+		}
+	}
+
+	return workflowType, nil
+}
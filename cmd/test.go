@@ -0,0 +1,127 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+)
+
+func newTestCmd() *cobra.Command {
+	var opts struct {
+		Output       string
+		WorkflowFile string
+	}
+
+	cmd := &cobra.Command{
+		Use:   "test <suite-file>...",
+		Short: "Run declarative test suites against a Zigflow workflow",
+		Long: `Run declarative test suites against a Zigflow workflow definition.
+
+Each suite file is YAML that references a workflow file and describes one or
+more test cases. Every test case runs in-process on Temporal's in-memory test
+environment - no Temporal server or worker is required.
+
+A test case can:
+  - set the workflow input and environment variables
+  - mock HTTP, gRPC and run tasks by task name
+  - mock "call: activity" tasks by activity name
+  - send signals and updates after a given workflow time
+  - assert the workflow output or error
+
+Workflow time is skipped, so timers, waits and listen timeouts resolve
+instantly regardless of their configured duration.
+
+Use the --output flag to select the report format:
+
+  human   Readable summary (default)
+  json    JSON report
+  junit   JUnit XML for CI systems
+
+The command exits with a non-zero status code if any test fails.
+
+Arguments:
+  suite-file   One or more test suite files to run`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			render, err := testReportRenderer(opts.Output)
+			if err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Unsupported output format",
+				}
+			}
+
+			results := make([]*testrunner.SuiteResult, 0, len(args))
+			passed := true
+			for _, file := range args {
+				res := testrunner.Run(file, opts.WorkflowFile)
+				passed = passed && res.Passed()
+				results = append(results, res)
+			}
+
+			if err := render(os.Stdout, results); err != nil {
+				return gh.FatalError{
+					Cause: err,
+					Msg:   "Error rendering test report",
+				}
+			}
+
+			if !passed {
+				return gh.FatalError{
+					Msg:    "Tests failed",
+					Logger: log.Trace,
+				}
+			}
+
+			return nil
+		},
+	}
+
+	viper.SetDefault("test_output", "human")
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("test_output"), "Report format: human, json or junit",
+	)
+
+	cmd.Flags().StringVarP(
+		&opts.WorkflowFile, "workflow", "w",
+		viper.GetString("test_workflow"), "Workflow file to test - overrides the suite's workflow",
+	)
+
+	return cmd
+}
+
+func testReportRenderer(format string) (func(io.Writer, []*testrunner.SuiteResult) error, error) {
+	switch format {
+	case "human":
+		return testrunner.RenderHuman, nil
+	case "json":
+		return testrunner.RenderJSON, nil
+	case "junit":
+		return testrunner.RenderJUnit, nil
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+}
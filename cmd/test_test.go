@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const passingSuiteYAML = `workflow: workflow.yaml
+tests:
+  - name: sets hello
+    expect:
+      output:
+        hello: world`
+
+const failingSuiteYAML = `workflow: workflow.yaml
+tests:
+  - name: wrong output
+    expect:
+      output:
+        hello: everyone`
+
+func TestNewTestCmd(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Suite          string
+		ExtraArgs      []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name:           "passing suite",
+			Suite:          passingSuiteYAML,
+			OutputContains: []string{"✓ sets hello", "1 passed, 0 failed"},
+		},
+		{
+			Name:           "failing suite",
+			Suite:          failingSuiteYAML,
+			ExpectError:    true,
+			OutputContains: []string{"✗ wrong output", "0 passed, 1 failed"},
+		},
+		{
+			Name:           "JSON output",
+			Suite:          passingSuiteYAML,
+			ExtraArgs:      []string{"--output", "json"},
+			OutputContains: []string{`"name": "sets hello"`, `"passed": true`},
+		},
+		{
+			Name:           "JUnit output",
+			Suite:          failingSuiteYAML,
+			ExtraArgs:      []string{"-o", "junit"},
+			ExpectError:    true,
+			OutputContains: []string{`<testsuites tests="1" failures="1"`},
+		},
+		{
+			Name:        "invalid suite",
+			Suite:       "tests: []",
+			ExpectError: true,
+		},
+		{
+			Name:        "unsupported output format",
+			Suite:       passingSuiteYAML,
+			ExtraArgs:   []string{"--output", "unknown"},
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "test_test")
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, os.RemoveAll(tmpDir))
+			}()
+
+			err = os.WriteFile(filepath.Join(tmpDir, "workflow.yaml"), []byte(validWorkflowYAML), 0o600)
+			require.NoError(t, err)
+
+			suitePath := filepath.Join(tmpDir, "suite.yaml")
+			err = os.WriteFile(suitePath, []byte(test.Suite), 0o600)
+			require.NoError(t, err)
+
+			// Capture stdout so we can assert on the generated report.
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			cmd := newTestCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(append([]string{suitePath}, test.ExtraArgs...))
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+			output := buf.String()
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			for _, want := range test.OutputContains {
+				assert.Contains(t, output, want)
+			}
+		})
+	}
+}
@@ -18,51 +18,88 @@ package cmd
 
 import (
 	"os"
+	"slices"
 
 	gh "github.com/mrsimonemms/golang-helpers"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/codec"
+	"github.com/zigflow/zigflow/pkg/lint"
 	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow"
 )
 
+var validateOutputFormats = []string{"human", "json", "junit", "sarif"}
+
+type validateOptions struct {
+	CloudEventsConfig string
+	ConvertKeyPath    string
+	Output            string
+	OutputJSON        bool
+}
+
 func newValidateCmd() *cobra.Command {
-	var opts struct {
-		OutputJSON bool
-	}
+	var opts validateOptions
 
 	cmd := &cobra.Command{
-		Use:   "validate <workflow-file>",
-		Short: "Validate a Zigflow workflow file",
-		Long: `Validate a Zigflow workflow definition written in the Zigflow DSL.
+		Use:   "validate <workflow-file>...",
+		Short: "Validate Zigflow workflow files",
+		Long: `Validate Zigflow workflow definitions written in the Zigflow DSL.
 
-This command parses the provided workflow file and verifies that it is
+This command parses the provided workflow files and verifies that they are
 syntactically valid and structurally correct according to the Zigflow
-specification. It does not execute the workflow or connect to Temporal.
+specification. It does not execute the workflows or connect to Temporal.
 
 Validation includes:
   - DSL syntax checks
   - Schema and structural validation
   - Reference and dependency checks where applicable
+  - Task retry metadata that the worker cannot register (lint rule ZF010)
+
+The CloudEvents config and AES keys files the worker would use are validated
+alongside the workflows. The keys file is only validated if --converter-key-path
+is set, or CONVERT_DATA is "aes".
+
+The results for every file are reported together, as human readable text,
+JSON, JUnit XML or SARIF. SARIF shows each error inline in a pull request in
+code scanning tools.
 
-The command exits with a non-zero status code if validation fails,
+The command exits with a non-zero status code if any file fails validation,
 making it suitable for use in scripts, CI pipelines and automated tooling.
 
+Directories are searched recursively, skipping hidden directories.
+Directories and glob patterns skip test suites (*.test.yaml), files without a
+top-level document key and the files set as --cloudevents-config or
+--converter-key-path.
+
 Arguments:
-  workflow-file   Path to the Zigflow workflow file to validate`,
-		Args: cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			filePath := args[0]
-			result := utils.ValidationResult{
-				File: filePath,
+  workflow-file   Workflow file, directory or glob pattern`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if opts.OutputJSON {
+				opts.Output = "json"
 			}
 
-			workflowDefinition, err := zigflow.LoadFromFile(filePath)
+			if !slices.Contains(validateOutputFormats, opts.Output) {
+				return gh.FatalError{
+					Msg: "Unknown output format",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Str("output", opts.Output).Strs("allowed", validateOutputFormats)
+					},
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := zigflow.ResolveFiles(args, opts.CloudEventsConfig, opts.ConvertKeyPath)
 			if err != nil {
 				return gh.FatalError{
 					Cause: err,
-					Msg:   "Unable to load workflow file",
+					Msg:   "Unable to resolve workflow files",
 				}
 			}
 
@@ -74,26 +111,68 @@ Arguments:
 				}
 			}
 
-			if res, err := validator.ValidateStruct(workflowDefinition); err != nil {
+			results := make([]utils.ValidationResult, 0, len(files)+2)
+			for _, file := range files {
+				results = append(results, validateFile(file, utils.ValidationKindWorkflow, func() ([]utils.ValidationErrors, error) {
+					doc, err := lint.Load(file)
+					if err != nil {
+						return nil, err
+					}
+
+					res, err := validator.ValidateStructSource(doc.Workflow, doc.Positions)
+					if err != nil {
+						return nil, err
+					}
+					return append(res, workerErrors(doc)...), nil
+				}))
+			}
+
+			if opts.CloudEventsConfig != "" {
+				results = append(results, validateFile(opts.CloudEventsConfig, utils.ValidationKindCloudEvents, func() ([]utils.ValidationErrors, error) {
+					return cloudevents.Validate(opts.CloudEventsConfig, validator)
+				}))
+			}
+
+			if opts.ConvertKeyPath != "" {
+				results = append(results, validateFile(opts.ConvertKeyPath, utils.ValidationKindKeys, func() ([]utils.ValidationErrors, error) {
+					return codec.ValidateKeyFile(opts.ConvertKeyPath)
+				}))
+			}
+
+			switch opts.Output {
+			case "json":
+				if opts.OutputJSON && len(results) == 1 {
+					// Scripts using the deprecated flag read a single object
+					err = utils.RenderJSONResult(os.Stdout, results[0])
+				} else {
+					err = utils.RenderJSON(os.Stdout, results)
+				}
+			case "junit":
+				err = utils.RenderJUnit(os.Stdout, results)
+			case "sarif":
+				err = utils.RenderSARIF(os.Stdout, results, Version)
+			default:
+				utils.RenderHuman(os.Stdout, results)
+			}
+			if err != nil {
 				return gh.FatalError{
 					Cause: err,
-					Msg:   "Error creating validation stack",
+					Msg:   "Error rendering result",
 				}
-			} else if res != nil {
-				result.Errors = res
-			} else {
-				result.Valid = true
 			}
 
-			if opts.OutputJSON {
-				_ = utils.RenderJSON(os.Stdout, result)
-			} else {
-				utils.RenderHuman(os.Stdout, result)
+			failed := 0
+			for _, r := range results {
+				if !r.Valid {
+					failed++
+				}
 			}
-
-			if !result.Valid {
+			if failed > 0 {
 				return gh.FatalError{
-					Msg:    "Validation failed",
+					Msg: "Validation failed",
+					WithParams: func(l *zerolog.Event) *zerolog.Event {
+						return l.Int("failed", failed)
+					},
 					Logger: log.Trace,
 				}
 			}
@@ -102,14 +181,80 @@ Arguments:
 		},
 	}
 
+	cmd.Flags().StringVar(
+		&opts.CloudEventsConfig, "cloudevents-config",
+		viper.GetString("cloudevents_config"), "Path to CloudEvents config file to validate",
+	)
+
+	// The worker only reads the keys when encrypting with AES
+	keyPath := ""
+	if codec.CodecType(viper.GetString("convert_data")) == codec.CodecAES {
+		keyPath = viper.GetString("converter_key_path")
+	}
+	cmd.Flags().StringVar(
+		&opts.ConvertKeyPath, "converter-key-path",
+		keyPath, "Path to AES conversion keys to validate",
+	)
+
+	viper.SetDefault("validate_output", validateOutputFormats[0])
+	cmd.Flags().StringVarP(
+		&opts.Output, "output", "o",
+		viper.GetString("validate_output"), "Output format (human, json, junit or sarif)",
+	)
+
 	cmd.Flags().BoolVar(
 		&opts.OutputJSON, "output-json",
 		viper.GetBool("output_json"), "Output as JSON",
 	)
+	_ = cmd.Flags().MarkDeprecated("output-json", "use --output json instead, which prints an array of results")
 
 	return cmd
 }
 
+// workerLintRules are the lint rules for problems that stop the worker
+// registering the workflow, which validation reports too
+var workerLintRules = []string{"ZF010"}
+
+// workerErrors returns the problems that the schema cannot catch but that
+// stop the worker registering the workflow
+func workerErrors(doc *lint.Document) []utils.ValidationErrors {
+	var errs []utils.ValidationErrors
+	for _, f := range lint.Lint(doc, lint.Options{Only: workerLintRules}) {
+		errs = append(errs, utils.ValidationErrors{
+			Key:     f.Rule,
+			Message: f.Message,
+			Path:    f.Path,
+			Position: utils.Position{
+				Pointer: f.Path,
+				Line:    f.Line,
+				Column:  f.Column,
+			},
+		})
+	}
+	return errs
+}
+
+// validateFile runs a validation, recording whether the file could be loaded
+// and any errors found
+func validateFile(file, kind string, validate func() ([]utils.ValidationErrors, error)) utils.ValidationResult {
+	result := utils.ValidationResult{
+		File: file,
+		Kind: kind,
+	}
+
+	res, err := validate()
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case res != nil:
+		result.Errors = res
+	default:
+		result.Valid = true
+	}
+
+	return result
+}
+
 type Error struct {
 	Path    string `json:"path"`
 	Rule    string `json:"rule"`
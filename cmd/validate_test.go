@@ -17,11 +17,13 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const validWorkflowYAML = `document:
@@ -53,6 +55,22 @@ do:
       set:
         hello: world`
 
+const workflowUnsupportedRetry = `document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+use:
+  retries:
+    conditional:
+      when: ${ .status == 503 }
+do:
+  - step:
+      metadata:
+        retry: conditional
+      set:
+        hello: world`
+
 func TestNewValidateCmd(t *testing.T) {
 	tests := []struct {
 		Name        string
@@ -70,6 +88,17 @@ func TestNewValidateCmd(t *testing.T) {
 			Content:   validWorkflowYAML,
 			ExtraArgs: []string{"--output-json"},
 		},
+		{
+			Name:      "valid workflow with SARIF output",
+			Content:   validWorkflowYAML,
+			ExtraArgs: []string{"--output", "sarif"},
+		},
+		{
+			Name:        "unknown output format",
+			Content:     validWorkflowYAML,
+			ExtraArgs:   []string{"--output", "xml"},
+			ExpectError: true,
+		},
 		{
 			Name:        "non-existent file",
 			FilePath:    "/nonexistent/path/workflow.yaml",
@@ -122,3 +151,192 @@ func TestNewValidateCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestNewValidateCmd_Files(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Files map[string]string
+		// Arguments to zigflow init to scaffold a project directory first
+		Init           []string
+		Args           []string
+		ExpectError    bool
+		OutputContains []string
+	}{
+		{
+			Name: "directory of workflows",
+			Files: map[string]string{
+				"workflows/a.yaml": validWorkflowYAML,
+				"workflows/b.yaml": validWorkflowYAML,
+			},
+			Args:           []string{"workflows"},
+			OutputContains: []string{"a.yaml is valid", "b.yaml is valid", "2 valid, 0 invalid"},
+		},
+		{
+			Name: "directory skips test suites and other documents",
+			Files: map[string]string{
+				"workflows/a.yaml":           validWorkflowYAML,
+				"workflows/a.test.yaml":      "tests: []\n",
+				"workflows/cloudevents.yaml": "clients: []\n",
+			},
+			Args:           []string{"workflows"},
+			OutputContains: []string{"a.yaml is valid"},
+		},
+		{
+			Name:           "scaffolded project",
+			Init:           []string{"--tests", "--cloudevents", "--aes-keys"},
+			Args:           []string{"project", "--cloudevents-config", "project/cloudevents.yaml", "--converter-key-path", "project/keys.yaml"},
+			OutputContains: []string{"workflow.yaml is valid", "cloudevents.yaml is valid", "keys.yaml is valid", "3 valid, 0 invalid"},
+		},
+		{
+			Name: "config files are not validated as workflows",
+			Files: map[string]string{
+				"workflow.yaml": validWorkflowYAML,
+				"keys.yaml":     "document: []\n",
+			},
+			Args:           []string{"*.yaml", "--converter-key-path", "keys.yaml"},
+			ExpectError:    true,
+			OutputContains: []string{"workflow.yaml is valid", "1 valid, 1 invalid"},
+		},
+		{
+			Name: "one invalid workflow fails the set",
+			Files: map[string]string{
+				"a.yaml": validWorkflowYAML,
+				"b.yaml": workflowMissingName,
+			},
+			Args:           []string{"*.yaml"},
+			ExpectError:    true,
+			OutputContains: []string{"a.yaml is valid", "Validation failed for", "1 valid, 1 invalid"},
+		},
+		{
+			Name: "task retry the worker cannot register",
+			Files: map[string]string{
+				"workflow.yaml": workflowUnsupportedRetry,
+			},
+			Args:        []string{"workflow.yaml"},
+			ExpectError: true,
+			OutputContains: []string{
+				"/do/0/step/metadata/retry (line 13, column 9): error converting task retry metadata: " +
+					"retry when and exceptWhen are only supported by try.catch.retry",
+			},
+		},
+		{
+			Name: "cloudevents config and keys",
+			Files: map[string]string{
+				"workflow.yaml":    validWorkflowYAML,
+				"cloudevents.yaml": "clients:\n  - name: file\n    protocol: file\n    target: /tmp/events\n",
+				"keys.yaml":        "- id: key0\n  key: passphrasewhichneedstobe32bytes!\n",
+			},
+			Args:           []string{"workflow.yaml", "--cloudevents-config", "cloudevents.yaml", "--converter-key-path", "keys.yaml"},
+			OutputContains: []string{"cloudevents.yaml is valid", "keys.yaml is valid", "3 valid, 0 invalid"},
+		},
+		{
+			Name: "invalid cloudevents config",
+			Files: map[string]string{
+				"workflow.yaml":    validWorkflowYAML,
+				"cloudevents.yaml": "clients:\n  - name: file\n    protocol: ftp\n",
+			},
+			Args:           []string{"workflow.yaml", "--cloudevents-config", "cloudevents.yaml"},
+			ExpectError:    true,
+			OutputContains: []string{"/clients/0/protocol (line 3, column 5): must be one of [file http]"},
+		},
+		{
+			Name: "invalid keys",
+			Files: map[string]string{
+				"workflow.yaml": validWorkflowYAML,
+				"keys.yaml":     "- id: key0\n  key: short\n",
+			},
+			Args:           []string{"workflow.yaml", "--converter-key-path", "keys.yaml"},
+			ExpectError:    true,
+			OutputContains: []string{"/0/key (line 2, column 3): must be 16, 24 or 32 bytes long, not 5"},
+		},
+		{
+			Name: "JSON output",
+			Files: map[string]string{
+				"workflow.yaml": validWorkflowYAML,
+			},
+			Args:           []string{"workflow.yaml", "--output", "json"},
+			OutputContains: []string{"[\n  {\n    \"valid\": true", `"kind": "workflow"`},
+		},
+		{
+			Name: "deprecated JSON flag prints an object for one file",
+			Files: map[string]string{
+				"workflow.yaml": validWorkflowYAML,
+			},
+			Args:           []string{"workflow.yaml", "--output-json"},
+			OutputContains: []string{"{\n  \"valid\": true,\n  \"file\": \"workflow.yaml\""},
+		},
+		{
+			Name: "deprecated JSON flag prints an array for many files",
+			Files: map[string]string{
+				"a.yaml": validWorkflowYAML,
+				"b.yaml": validWorkflowYAML,
+			},
+			Args:           []string{"a.yaml", "b.yaml", "--output-json"},
+			OutputContains: []string{"[\n  {\n    \"valid\": true"},
+		},
+		{
+			Name: "JUnit output",
+			Files: map[string]string{
+				"a.yaml": validWorkflowYAML,
+				"b.yaml": workflowMissingName,
+			},
+			Args:           []string{"a.yaml", "b.yaml", "--output", "junit"},
+			ExpectError:    true,
+			OutputContains: []string{`<testsuites tests="2" failures="1" errors="0">`, `<failure message="1 validation error(s)">`},
+		},
+		{
+			Name: "SARIF output",
+			Files: map[string]string{
+				"workflow.yaml": workflowMissingName,
+			},
+			Args:           []string{"workflow.yaml", "--output", "sarif"},
+			ExpectError:    true,
+			OutputContains: []string{`"ruleId": "required"`, `"startLine": 1`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range test.Files {
+				p := filepath.Join(tmpDir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+				require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+			}
+
+			t.Chdir(tmpDir)
+
+			r, w, err := os.Pipe()
+			require.NoError(t, err)
+			origStdout := os.Stdout
+			os.Stdout = w
+
+			if test.Init != nil {
+				initCmd := newInitCmd()
+				initCmd.SetArgs(append([]string{"project"}, test.Init...))
+				require.NoError(t, initCmd.Execute())
+			}
+
+			cmd := newValidateCmd()
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			cmd.SetArgs(test.Args)
+			execErr := cmd.Execute()
+
+			assert.NoError(t, w.Close())
+			os.Stdout = origStdout
+
+			var buf bytes.Buffer
+			_, _ = buf.ReadFrom(r)
+
+			if test.ExpectError {
+				assert.Error(t, execErr)
+			} else {
+				assert.NoError(t, execErr)
+			}
+			for _, want := range test.OutputContains {
+				assert.Contains(t, buf.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package apidocs generates reference documentation for the callers of a
+// workflow: how to start it, what it returns, the handlers it exposes and the
+// events it emits.
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+)
+
+const (
+	// DefaultStartMarkerPrefix is the opening text of the comment that docs
+	// are injected after. It may embed a workflow file path, for example:
+	//
+	//	<!-- ZIGFLOW_DOCS_START ./workflow.yaml -->
+	DefaultStartMarkerPrefix = "<!-- ZIGFLOW_DOCS_START"
+
+	// DefaultEndMarker is the comment that docs are injected before.
+	DefaultEndMarker = "<!-- ZIGFLOW_DOCS_END -->"
+)
+
+// Format represents a supported documentation format.
+type Format string
+
+const (
+	// FormatMarkdown renders the reference as Markdown.
+	FormatMarkdown Format = "markdown"
+	// FormatOpenAPI renders the Temporal HTTP API calls that start and
+	// interact with the workflows as an OpenAPI 3.1 document.
+	FormatOpenAPI Format = "openapi"
+	// FormatAsyncAPI renders the workflows' handlers and the CloudEvents they
+	// emit as an AsyncAPI 3.0 document.
+	FormatAsyncAPI Format = "asyncapi"
+)
+
+// Formats lists every supported format.
+var Formats = []Format{FormatMarkdown, FormatOpenAPI, FormatAsyncAPI}
+
+// Generator renders a workflow definition's reference documentation.
+type Generator interface {
+	Generate(wf *model.Workflow) (string, error)
+}
+
+// Option configures a Generator.
+type Option func(*options)
+
+type options struct {
+	headingLevel int
+}
+
+// WithHeadingLevel sets the level of the Markdown title, so the reference
+// can be nested in another document. The default is 1.
+func WithHeadingLevel(level int) Option {
+	return func(o *options) {
+		o.headingLevel = level
+	}
+}
+
+// New returns a Generator for the given format.
+func New(format Format, opts ...Option) (Generator, error) {
+	o := &options{headingLevel: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	switch format {
+	case FormatMarkdown:
+		return &markdownGenerator{opts: o}, nil
+	case FormatOpenAPI:
+		return &openAPIGenerator{}, nil
+	case FormatAsyncAPI:
+		return &asyncAPIGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported docs format: %q", format)
+	}
+}
+
+// Reference is what a caller needs to know about a workflow document.
+type Reference struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Title     string `json:"title,omitempty"`
+	Summary   string `json:"summary,omitempty"`
+	TaskQueue string `json:"taskQueue"`
+	// Source is the source of the CloudEvents the workflows emit
+	Source    string                  `json:"source"`
+	Workflows []*Workflow             `json:"workflows"`
+	Events    []cloudevents.EventType `json:"events"`
+}
+
+// Workflow is a Temporal workflow type a caller can start or interact with.
+type Workflow struct {
+	Name string `json:"name"`
+	// Child workflows are started by another workflow's task, not by callers
+	Child   bool          `json:"child,omitempty"`
+	Input   *model.Schema `json:"input,omitempty"`
+	Output  *Output       `json:"output,omitempty"`
+	Signals []*Handler    `json:"signals"`
+	Queries []*Handler    `json:"queries"`
+	Updates []*Handler    `json:"updates"`
+}
+
+// Output is the workflow's result, which is the output of its last task.
+type Output struct {
+	Task   string        `json:"task"`
+	Schema *model.Schema `json:"schema,omitempty"`
+	// As is the task's output expression, if it sets one
+	As any `json:"as,omitempty"`
+}
+
+// Handler is a signal, query or update exposed by a listen task.
+type Handler struct {
+	ID   string `json:"id"`
+	Task string `json:"task"`
+	// AcceptIf is the expression the received data must match for the listen
+	// task to complete
+	AcceptIf any `json:"acceptIf,omitempty"`
+	// Schema is the schema of the data a signal or update receives
+	Schema *model.Schema `json:"schema,omitempty"`
+	// Data is returned to the caller of a query or update
+	Data any `json:"data,omitempty"`
+}
+
+// Build returns the reference for a workflow document. Every workflow a
+// caller can start is included, as are child workflows with handlers.
+func Build(doc *model.Workflow) *Reference {
+	ref := &Reference{
+		Name:      doc.Document.Name,
+		Version:   doc.Document.Version,
+		Title:     doc.Document.Title,
+		Summary:   doc.Document.Summary,
+		TaskQueue: doc.Document.Namespace,
+		Source:    cloudevents.Source(doc),
+		Workflows: make([]*Workflow, 0),
+		Events:    cloudevents.EventTypes,
+	}
+
+	workflows := map[string]*Workflow{}
+	add := func(name string, child bool) *Workflow {
+		if w, ok := workflows[name]; ok {
+			return w
+		}
+		w := &Workflow{
+			Name:    name,
+			Child:   child,
+			Signals: make([]*Handler, 0),
+			Queries: make([]*Handler, 0),
+			Updates: make([]*Handler, 0),
+		}
+		if !child && doc.Input != nil {
+			// The document's input is validated when any workflow starts
+			w.Input = doc.Input.Schema
+		}
+		workflows[name] = w
+		ref.Workflows = append(ref.Workflows, w)
+		return w
+	}
+
+	callable := zigflow.WorkflowTypes(doc, false)
+	for _, name := range callable {
+		add(name, false)
+	}
+
+	// The workflow returns the output of its last task
+	last := map[string]*model.TaskItem{}
+	_ = zigflow.Walk(doc, func(item *model.TaskItem, workflowName string) error {
+		if item.AsDoTask() == nil {
+			last[workflowName] = item
+		}
+		return nil
+	})
+	for name, item := range last {
+		w, ok := workflows[name]
+		if !ok {
+			continue
+		}
+		w.Output = &Output{Task: item.Key}
+		if output := item.GetBase().Output; output != nil {
+			w.Output.Schema = output.Schema
+			if output.As != nil {
+				w.Output.As = output.As.AsStringOrMap()
+			}
+		}
+	}
+
+	for _, l := range zigflow.Listeners(doc) {
+		w := add(l.Workflow, !slices.Contains(callable, l.Workflow))
+
+		h := &Handler{
+			ID:       l.ID,
+			Task:     l.Task,
+			AcceptIf: l.Event.With.Additional["acceptIf"],
+		}
+		if l.Type != tasks.ListenTaskTypeQuery {
+			h.Schema = eventSchema(l.Event)
+		}
+
+		switch l.Type {
+		case tasks.ListenTaskTypeSignal:
+			w.Signals = append(w.Signals, h)
+		case tasks.ListenTaskTypeQuery:
+			h.Data = l.Event.With.Additional["data"]
+			w.Queries = append(w.Queries, h)
+		case tasks.ListenTaskTypeUpdate:
+			h.Data = l.Event.With.Additional["data"]
+			w.Updates = append(w.Updates, h)
+		}
+	}
+
+	return ref
+}
+
+// jsonSchema returns the JSON schema of a workflow schema, referencing it if
+// it is an external resource.
+// eventSchema returns the optional JSON schema of the data a signal or update
+// receives, set with the schema key alongside the event's ID and type. It
+// documents the data for API references and generated clients - the worker
+// does not validate against it. A schema that can't be read is left out.
+func eventSchema(event *model.EventFilter) *model.Schema {
+	raw, ok := event.With.Additional["schema"]
+	if !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+
+	var schema model.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil
+	}
+	schema.ApplyDefaults()
+
+	return &schema
+}
+
+func jsonSchema(schema *model.Schema) any {
+	switch {
+	case schema == nil:
+		return map[string]any{}
+	case schema.Document != nil:
+		return schema.Document
+	case schema.Resource != nil && schema.Resource.Endpoint != nil:
+		return map[string]any{"$ref": schema.Resource.Endpoint.String()}
+	default:
+		return map[string]any{}
+	}
+}
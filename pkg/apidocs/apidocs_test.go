@@ -0,0 +1,200 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apidocs_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/apidocs"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+const workflowYAML = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: approval
+  version: 1.2.3
+  title: Approval
+  summary: Wait for a change to be approved
+input:
+  schema:
+    format: json
+    document:
+      type: object
+      required:
+        - changeId
+      properties:
+        changeId:
+          type: string
+do:
+  - state:
+      listen:
+        to:
+          one:
+            with:
+              id: get_state
+              type: query
+              data:
+                status: ${ $data.status }
+  - approval:
+      listen:
+        to:
+          any:
+            - with:
+                id: approve
+                type: signal
+            - with:
+                id: decide
+                type: update
+                acceptIf: ${ $data.approved }
+                data: decided
+                schema:
+                  document:
+                    type: object
+                    properties:
+                      approved:
+                        type: boolean
+  - result:
+      output:
+        as:
+          approved: ${ $data.approved }
+      set:
+        approved: true
+`
+
+func TestBuild(t *testing.T) {
+	wf, err := zigflow.LoadFromBytes([]byte(workflowYAML))
+	require.NoError(t, err)
+
+	ref := apidocs.Build(wf)
+
+	assert.Equal(t, "approval", ref.Name)
+	assert.Equal(t, "zigflow", ref.TaskQueue)
+	assert.Equal(t, "zigflow.dev/zigflow/approval", ref.Source)
+	assert.NotEmpty(t, ref.Events)
+
+	require.Len(t, ref.Workflows, 1)
+	w := ref.Workflows[0]
+	assert.Equal(t, "approval", w.Name)
+	assert.False(t, w.Child)
+	require.NotNil(t, w.Input)
+	assert.Equal(t, "object", w.Input.Document.(map[string]any)["type"])
+
+	require.NotNil(t, w.Output)
+	assert.Equal(t, "result", w.Output.Task)
+	assert.Equal(t, map[string]any{"approved": "${ $data.approved }"}, w.Output.As)
+
+	require.Len(t, w.Signals, 1)
+	assert.Equal(t, &apidocs.Handler{ID: "approve", Task: "approval"}, w.Signals[0])
+
+	require.Len(t, w.Queries, 1)
+	assert.Equal(t, &apidocs.Handler{
+		ID: "get_state", Task: "state", Data: map[string]any{"status": "${ $data.status }"},
+	}, w.Queries[0])
+
+	require.Len(t, w.Updates, 1)
+	update := w.Updates[0]
+	require.NotNil(t, update.Schema)
+	assert.Equal(t, "object", update.Schema.Document.(map[string]any)["type"])
+	update.Schema = nil
+	assert.Equal(t, &apidocs.Handler{
+		ID: "decide", Task: "approval", AcceptIf: "${ $data.approved }", Data: "decided",
+	}, update)
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		Format   apidocs.Format
+		Options  []apidocs.Option
+		Expected []string
+	}{
+		{
+			Format: apidocs.FormatMarkdown,
+			Expected: []string{
+				"# Approval\n\nWait for a change to be approved\n",
+				"## Workflows\n\n### `approval`\n",
+				"\"required\": [\n    \"changeId\"\n  ]",
+				"The output of the `result` task.",
+				"| `approve` | `approval` |  |",
+				"| `get_state` | `state` | `{\"status\":\"${ $data.status }\"}` |",
+				"| `decide` | `approval` | `${ $data.approved }` | `decided` |",
+				"The `decide` data:\n\n```json\n{\n  \"properties\": {\n    \"approved\": {\n      \"type\": \"boolean\"",
+				"| `dev.zigflow.task.completed` | A task completes successfully | task name |",
+			},
+		},
+		{
+			Format:  apidocs.FormatMarkdown,
+			Options: []apidocs.Option{apidocs.WithHeadingLevel(2)},
+			Expected: []string{
+				"## Approval\n",
+				"### Workflows\n",
+				"#### `approval`\n",
+			},
+		},
+		{
+			Format: apidocs.FormatOpenAPI,
+			Expected: []string{
+				"openapi: 3.1.0",
+				"  /api/v1/namespaces/{namespace}/workflows/{workflowId}:",
+				"  /api/v1/namespaces/{namespace}/workflows/{workflowId}/signal/approve:",
+				"  /api/v1/namespaces/{namespace}/workflows/{workflowId}/query/get_state:",
+				"  /api/v1/namespaces/{namespace}/workflows/{workflowId}/update/decide:",
+				"The task completes when the data matches ${ $data.approved }. Returns decided.",
+				"              const: approval",
+				"              - changeId",
+				"                              approved:\n                                type: boolean",
+			},
+		},
+		{
+			Format: apidocs.FormatAsyncAPI,
+			Expected: []string{
+				"asyncapi: 3.0.0",
+				"  approval.signal.approve:\n    address: approve",
+				"  approval.update.decide:\n    address: decide",
+				"        payload:\n          properties:\n            approved:\n              type: boolean",
+				"$ref: '#/channels/approval.update.decide/messages/reply'",
+				"    address: zigflow.dev/zigflow/approval",
+				"              const: dev.zigflow.workflow.started",
+				"  emitEvents:\n    action: send",
+			},
+		},
+	}
+
+	wf, err := zigflow.LoadFromBytes([]byte(workflowYAML))
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(string(test.Format), func(t *testing.T) {
+			gen, err := apidocs.New(test.Format, test.Options...)
+			require.NoError(t, err)
+
+			out, err := gen.Generate(wf)
+			require.NoError(t, err)
+
+			for _, e := range test.Expected {
+				assert.Contains(t, out, e)
+			}
+		})
+	}
+}
+
+func TestNew_UnknownFormat(t *testing.T) {
+	_, err := apidocs.New("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apidocs
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type asyncAPIGenerator struct{}
+
+type asyncAPIDocument struct {
+	AsyncAPI           string                    `yaml:"asyncapi"`
+	Info               apiInfo                   `yaml:"info"`
+	DefaultContentType string                    `yaml:"defaultContentType"`
+	Channels           map[string]map[string]any `yaml:"channels"`
+	Operations         map[string]map[string]any `yaml:"operations"`
+}
+
+// eventsChannel is the channel the CloudEvents are sent on
+const eventsChannel = "events"
+
+// Generate describes the workflows as an application that receives signals,
+// queries and updates, replying to queries and updates, and sends
+// CloudEvents.
+func (g *asyncAPIGenerator) Generate(wf *model.Workflow) (string, error) {
+	ref := Build(wf)
+
+	doc := &asyncAPIDocument{
+		AsyncAPI:           "3.0.0",
+		Info:               ref.info(),
+		DefaultContentType: "application/json",
+		Channels:           map[string]map[string]any{},
+		Operations:         map[string]map[string]any{},
+	}
+
+	for _, w := range ref.Workflows {
+		for _, h := range w.Signals {
+			doc.handler(w, h, "signal", false)
+		}
+		for _, h := range w.Queries {
+			doc.handler(w, h, "query", true)
+		}
+		for _, h := range w.Updates {
+			doc.handler(w, h, "update", true)
+		}
+	}
+
+	messages := map[string]any{}
+	refs := make([]map[string]any, 0, len(ref.Events))
+	for _, e := range ref.Events {
+		data := map[string]any{}
+		for _, d := range e.Data {
+			data[d] = map[string]any{}
+		}
+
+		properties := map[string]any{
+			"specversion": map[string]any{"const": "1.0"},
+			"type":        map[string]any{"const": e.Type},
+			"source":      map[string]any{"const": ref.Source},
+			"id":          map[string]any{"type": "string", "description": "Workflow ID"},
+			"time":        map[string]any{"type": "string", "format": "date-time"},
+			"data":        map[string]any{"type": "object", "properties": data},
+		}
+		if e.Subject != "" {
+			properties["subject"] = map[string]any{"type": "string", "description": e.Subject}
+		}
+
+		messages[e.Type] = map[string]any{
+			"name":        e.Type,
+			"summary":     e.Summary,
+			"contentType": "application/cloudevents+json",
+			"payload": map[string]any{
+				"type":       "object",
+				"required":   []string{"specversion", "type", "source", "id"},
+				"properties": properties,
+			},
+		}
+		refs = append(refs, map[string]any{"$ref": "#/channels/" + eventsChannel + "/messages/" + e.Type})
+	}
+
+	doc.Channels[eventsChannel] = map[string]any{
+		"address":     ref.Source,
+		"description": "CloudEvents emitted while the workflows run",
+		"messages":    messages,
+	}
+	doc.Operations["emitEvents"] = map[string]any{
+		"action":   "send",
+		"channel":  map[string]any{"$ref": "#/channels/" + eventsChannel},
+		"messages": refs,
+	}
+
+	return marshalYAML(doc)
+}
+
+// handler adds the channel and operation for a signal, query or update.
+func (doc *asyncAPIDocument) handler(w *Workflow, h *Handler, kind string, reply bool) {
+	id := schemaName(w.Name) + "." + kind + "." + schemaName(h.ID)
+	channel := "#/channels/" + id
+
+	messages := map[string]any{
+		"request": map[string]any{
+			"name":    h.ID,
+			"payload": jsonSchema(h.Schema),
+		},
+	}
+	op := map[string]any{
+		"action":      "receive",
+		"channel":     map[string]any{"$ref": channel},
+		"summary":     "The " + h.ID + " " + kind,
+		"description": handlerDescription(w, h),
+		"messages":    []map[string]any{{"$ref": channel + "/messages/request"}},
+	}
+
+	if reply {
+		messages["reply"] = map[string]any{
+			"name":    h.ID + " reply",
+			"payload": map[string]any{},
+		}
+		op["reply"] = map[string]any{
+			"channel":  map[string]any{"$ref": channel},
+			"messages": []map[string]any{{"$ref": channel + "/messages/reply"}},
+		}
+	}
+
+	doc.Channels[id] = map[string]any{
+		"address":     h.ID,
+		"description": "Temporal " + kind + " handled by the " + w.Name + " workflow",
+		"messages":    messages,
+	}
+	doc.Operations[id] = op
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type markdownGenerator struct {
+	opts *options
+}
+
+func (g *markdownGenerator) Generate(wf *model.Workflow) (string, error) {
+	ref := Build(wf)
+	w := &markdownWriter{level: g.opts.headingLevel}
+
+	title := ref.Title
+	if title == "" {
+		title = ref.Name
+	}
+	w.heading(0, title)
+	if ref.Summary != "" {
+		w.para(ref.Summary)
+	}
+	w.line("| | |")
+	w.line("| --- | --- |")
+	w.line("| Name | " + code(ref.Name) + " |")
+	w.line("| Version | " + code(ref.Version) + " |")
+	w.line("| Task queue | " + code(ref.TaskQueue) + " |")
+	w.line("")
+
+	w.heading(1, "Workflows")
+	for _, wfl := range ref.Workflows {
+		if err := w.workflow(ref, wfl); err != nil {
+			return "", err
+		}
+	}
+
+	w.heading(1, "Events")
+	w.para(fmt.Sprintf(
+		"Each workflow emits [CloudEvents](https://cloudevents.io) with the source %s to the configured clients. The event ID is the workflow ID.",
+		code(ref.Source),
+	))
+	w.line("| Type | Emitted when | Subject | Data |")
+	w.line("| --- | --- | --- | --- |")
+	for _, e := range ref.Events {
+		data := make([]string, 0, len(e.Data))
+		for _, d := range e.Data {
+			data = append(data, code(d))
+		}
+		w.line(fmt.Sprintf("| %s | %s | %s | %s |", code(e.Type), e.Summary, e.Subject, strings.Join(data, ", ")))
+	}
+
+	return w.String(), nil
+}
+
+// markdownWriter builds a Markdown document with headings relative to a base
+// level.
+type markdownWriter struct {
+	strings.Builder
+	level int
+}
+
+func (w *markdownWriter) line(s string) {
+	w.WriteString(s + "\n")
+}
+
+func (w *markdownWriter) para(s string) {
+	w.line(s)
+	w.line("")
+}
+
+func (w *markdownWriter) heading(depth int, s string) {
+	w.para(strings.Repeat("#", min(w.level+depth, 6)) + " " + s)
+}
+
+func (w *markdownWriter) block(lang string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	w.line("```" + lang)
+	w.line(string(data))
+	w.para("```")
+	return nil
+}
+
+func (w *markdownWriter) workflow(ref *Reference, wf *Workflow) error {
+	w.heading(2, code(wf.Name))
+	if wf.Child {
+		w.para("Started as a child workflow by the document's tasks.")
+	} else {
+		w.para(fmt.Sprintf("Start with the workflow type %s on the %s task queue.", code(wf.Name), code(ref.TaskQueue)))
+	}
+
+	if !wf.Child {
+		w.para("**Input**")
+		if wf.Input == nil {
+			w.para("No input schema is declared.")
+		} else if err := w.block("json", jsonSchema(wf.Input)); err != nil {
+			return err
+		}
+	}
+
+	if wf.Output != nil {
+		w.para("**Output**")
+		w.para(fmt.Sprintf("The output of the %s task.", code(wf.Output.Task)))
+		switch as := wf.Output.As.(type) {
+		case nil:
+		case string:
+			w.para("It is set by the task's `output.as` expression " + code(as) + ".")
+		default:
+			w.para("It is set by the task's `output.as`:")
+			if err := w.block("json", as); err != nil {
+				return err
+			}
+		}
+		if wf.Output.Schema != nil {
+			if err := w.block("json", jsonSchema(wf.Output.Schema)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(wf.Signals) > 0 {
+		w.para("**Signals**")
+		w.line("| Name | Task | Accepted if |")
+		w.line("| --- | --- | --- |")
+		for _, h := range wf.Signals {
+			w.line(fmt.Sprintf("| %s | %s | %s |", code(h.ID), code(h.Task), inline(h.AcceptIf)))
+		}
+		w.line("")
+		if err := w.handlerSchemas(wf.Signals); err != nil {
+			return err
+		}
+	}
+
+	if len(wf.Queries) > 0 {
+		w.para("**Queries**")
+		w.line("| Name | Task | Returns |")
+		w.line("| --- | --- | --- |")
+		for _, h := range wf.Queries {
+			w.line(fmt.Sprintf("| %s | %s | %s |", code(h.ID), code(h.Task), inline(h.Data)))
+		}
+		w.line("")
+	}
+
+	if len(wf.Updates) > 0 {
+		w.para("**Updates**")
+		w.line("| Name | Task | Accepted if | Returns |")
+		w.line("| --- | --- | --- | --- |")
+		for _, h := range wf.Updates {
+			w.line(fmt.Sprintf("| %s | %s | %s | %s |", code(h.ID), code(h.Task), inline(h.AcceptIf), inline(h.Data)))
+		}
+		w.line("")
+		if err := w.handlerSchemas(wf.Updates); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handlerSchemas writes the schema of the data each handler receives.
+func (w *markdownWriter) handlerSchemas(handlers []*Handler) error {
+	for _, h := range handlers {
+		if h.Schema == nil {
+			continue
+		}
+		w.para(fmt.Sprintf("The %s data:", code(h.ID)))
+		if err := w.block("json", jsonSchema(h.Schema)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// code formats s as inline code that is safe to use in a table.
+func code(s string) string {
+	return "`" + strings.ReplaceAll(s, "|", `\|`) + "`"
+}
+
+// inline formats an expression or object as inline code.
+func inline(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return code(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return code(string(data))
+	}
+}
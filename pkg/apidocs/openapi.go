@@ -0,0 +1,284 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package apidocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// temporalHTTPAPI is the default address of the Temporal frontend's HTTP API
+const temporalHTTPAPI = "http://localhost:7243"
+
+type openAPIGenerator struct{}
+
+type openAPIDocument struct {
+	OpenAPI    string                    `yaml:"openapi"`
+	Info       apiInfo                   `yaml:"info"`
+	Servers    []map[string]any          `yaml:"servers"`
+	Paths      map[string]map[string]any `yaml:"paths"`
+	Components map[string]map[string]any `yaml:"components"`
+}
+
+type apiInfo struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description,omitempty"`
+}
+
+// Generate describes the calls to Temporal's HTTP API that start the
+// workflows and send them signals, queries and updates. Payloads use the HTTP
+// API's JSON shorthand.
+func (g *openAPIGenerator) Generate(wf *model.Workflow) (string, error) {
+	ref := Build(wf)
+
+	doc := &openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info:    ref.info(),
+		Servers: []map[string]any{
+			{"url": temporalHTTPAPI, "description": "Temporal HTTP API"},
+		},
+		Paths: map[string]map[string]any{},
+		Components: map[string]map[string]any{
+			"parameters": {
+				"namespace": map[string]any{
+					"name": "namespace", "in": "path", "required": true,
+					"description": "Temporal namespace",
+					"schema":      map[string]any{"type": "string", "default": "default"},
+				},
+				"workflowId": map[string]any{
+					"name": "workflowId", "in": "path", "required": true,
+					"description": "Workflow ID",
+					"schema":      map[string]any{"type": "string"},
+				},
+			},
+			"schemas": {},
+		},
+	}
+	schemas := doc.Components["schemas"]
+
+	base := "/api/v1/namespaces/{namespace}/workflows/{workflowId}"
+	params := []map[string]any{
+		{"$ref": "#/components/parameters/namespace"},
+		{"$ref": "#/components/parameters/workflowId"},
+	}
+
+	// Every workflow is started from the same path, with its type in the body
+	starts := make([]map[string]any, 0)
+	for _, w := range ref.Workflows {
+		if w.Child {
+			continue
+		}
+		name := "Start_" + schemaName(w.Name)
+		schemas[name] = map[string]any{
+			"type":     "object",
+			"required": []string{"workflowType", "taskQueue"},
+			"properties": map[string]any{
+				"workflowType": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"name": map[string]any{"const": w.Name}},
+				},
+				"taskQueue": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"name": map[string]any{"const": ref.TaskQueue}},
+				},
+				"input": payloads(jsonSchema(w.Input)),
+			},
+		}
+		starts = append(starts, map[string]any{"$ref": "#/components/schemas/" + name})
+	}
+	if len(starts) > 0 {
+		var body map[string]any
+		if len(starts) == 1 {
+			body = starts[0]
+		} else {
+			body = map[string]any{"oneOf": starts}
+		}
+		doc.Paths[base] = map[string]any{
+			"post": map[string]any{
+				"operationId": "startWorkflow",
+				"summary":     "Start a workflow",
+				"parameters":  params,
+				"requestBody": jsonBody(body),
+				"responses":   map[string]any{"200": response("The workflow was started", nil)},
+			},
+		}
+	}
+
+	for _, w := range ref.Workflows {
+		for _, h := range w.Signals {
+			addOperation(doc, base+"/signal/"+h.ID, w, h, map[string]any{
+				"operationId": "signal_" + schemaName(h.ID),
+				"summary":     "Send the " + h.ID + " signal",
+				"description": handlerDescription(w, h),
+				"parameters":  params,
+				"requestBody": jsonBody(map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"input": payloads(jsonSchema(h.Schema))},
+				}),
+				"responses": map[string]any{"200": response("The signal was sent", nil)},
+			})
+		}
+		for _, h := range w.Queries {
+			addOperation(doc, base+"/query/"+h.ID, w, h, map[string]any{
+				"operationId": "query_" + schemaName(h.ID),
+				"summary":     "Run the " + h.ID + " query",
+				"description": handlerDescription(w, h),
+				"parameters":  params,
+				"requestBody": jsonBody(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"query": map[string]any{
+							"type":       "object",
+							"properties": map[string]any{"queryArgs": payloads(map[string]any{})},
+						},
+					},
+				}),
+				"responses": map[string]any{"200": response("The query result", map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"queryResult": payloads(map[string]any{})},
+				})},
+			})
+		}
+		for _, h := range w.Updates {
+			addOperation(doc, base+"/update/"+h.ID, w, h, map[string]any{
+				"operationId": "update_" + schemaName(h.ID),
+				"summary":     "Send the " + h.ID + " update",
+				"description": handlerDescription(w, h),
+				"parameters":  params,
+				"requestBody": jsonBody(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"request": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"input": map[string]any{
+									"type":       "object",
+									"properties": map[string]any{"args": payloads(jsonSchema(h.Schema))},
+								},
+							},
+						},
+					},
+				}),
+				"responses": map[string]any{"200": response("The update outcome", map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"outcome": map[string]any{
+							"type":       "object",
+							"properties": map[string]any{"success": payloads(map[string]any{})},
+						},
+					},
+				})},
+			})
+		}
+	}
+
+	return marshalYAML(doc)
+}
+
+// addOperation adds a handler's operation. Handlers with the same name in
+// different workflows share a path.
+func addOperation(doc *openAPIDocument, path string, w *Workflow, h *Handler, op map[string]any) {
+	if existing, ok := doc.Paths[path]; ok {
+		post := existing["post"].(map[string]any)
+		post["description"] = fmt.Sprintf("%s\n\n%s", post["description"], handlerDescription(w, h))
+		return
+	}
+	doc.Paths[path] = map[string]any{"post": op}
+}
+
+// handlerDescription explains which task handles a signal, query or update
+// and how it responds.
+func handlerDescription(w *Workflow, h *Handler) string {
+	lines := []string{fmt.Sprintf("Handled by the %s task in the %s workflow.", h.Task, w.Name)}
+	if h.AcceptIf != nil {
+		lines = append(lines, "The task completes when the data matches "+expression(h.AcceptIf)+".")
+	}
+	if h.Data != nil {
+		lines = append(lines, "Returns "+expression(h.Data)+".")
+	}
+	return strings.Join(lines, " ")
+}
+
+func (r *Reference) info() apiInfo {
+	title := r.Title
+	if title == "" {
+		title = r.Name
+	}
+	return apiInfo{Title: title, Version: r.Version, Description: r.Summary}
+}
+
+// payloads is a Temporal payloads field in the HTTP API's JSON shorthand,
+// which is an array of values.
+func payloads(schema any) map[string]any {
+	return map[string]any{"type": "array", "items": schema}
+}
+
+func jsonBody(schema any) map[string]any {
+	return map[string]any{
+		"required": true,
+		"content":  map[string]any{"application/json": map[string]any{"schema": schema}},
+	}
+}
+
+func response(description string, schema any) map[string]any {
+	r := map[string]any{"description": description}
+	if schema != nil {
+		r["content"] = map[string]any{"application/json": map[string]any{"schema": schema}}
+	}
+	return r
+}
+
+// schemaName makes a name safe to use as a component or operation ID.
+func schemaName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '.' || r == ' ' || r == '/' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// expression formats an expression or object for a description.
+func expression(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func marshalYAML(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -23,11 +23,11 @@ import (
 )
 
 type ClientConfig struct {
-	Name     string         `json:"name" validate:"required"`
-	Disabled bool           `json:"disabled"`
-	Protocol string         `json:"protocol" validate:"required_if=Enabled true,oneof=file http"`
-	Target   string         `json:"target" validate:"required_if=Enabled true"`
-	Options  map[string]any `json:"options,omitempty"`
+	Name     string         `json:"name" validate:"required" description:"Name of the client, used in logs and metrics"`
+	Disabled bool           `json:"disabled" description:"Stop events being sent to this client"`
+	Protocol string         `json:"protocol" validate:"required_if=Enabled true,oneof=file http" description:"How events are sent"`
+	Target   string         `json:"target" validate:"required_if=Enabled true" description:"Directory for the file protocol or URL for the http protocol"`
+	Options  map[string]any `json:"options,omitempty" description:"Options for the protocol"`
 
 	client sdk.Client `json:"-"`
 }
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	sdk "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/rs/zerolog/log"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/zigflow/zigflow/pkg/observability"
@@ -33,7 +34,7 @@ import (
 )
 
 type Events struct {
-	Clients []*ClientConfig `json:"clients" validate:"dive"`
+	Clients []*ClientConfig `json:"clients" validate:"dive" description:"Clients that every event is sent to"`
 
 	workflow *model.Workflow
 }
@@ -65,10 +66,9 @@ func (e *Events) Emit(ctx context.Context, eventType string, opts ...Option) {
 
 	// Hard-code important things
 	event.SetSpecVersion(sdk.VersionV1)
-	// Format is zigflow.dev/<task-queue>/<workflow>
-	event.SetSource(fmt.Sprintf("zigflow.dev/%s/%s", e.workflow.Document.Namespace, e.workflow.Document.Name))
+	event.SetSource(Source(e.workflow))
 	event.SetTime(time.Now())
-	event.SetType(fmt.Sprintf("dev.zigflow.%s", eventType))
+	event.SetType(fullEventType(eventType))
 
 	for _, c := range e.Clients {
 		if c.Disabled {
@@ -102,24 +102,14 @@ func Load(path string, validator *utils.Validator, workflow *model.Workflow) (*E
 
 	// Allow empty string to be ignored
 	if path != "" {
-		raw, err := os.ReadFile(path)
-		if err != nil {
-			return nil, fmt.Errorf("read config file: %w", err)
-		}
-
-		rendered, err := renderTemplate(raw)
-		if err != nil {
-			return nil, fmt.Errorf("render template: %w", err)
-		}
-
-		if err := yaml.Unmarshal(rendered, &cfg); err != nil {
-			return nil, fmt.Errorf("unmarshal yaml: %w", err)
+		if _, err := readConfig(path, &cfg); err != nil {
+			return nil, err
 		}
 
 		if res, err := validator.ValidateStruct(cfg); err != nil {
 			return nil, fmt.Errorf("error creating validation stack: %w", err)
 		} else if res != nil {
-			return nil, fmt.Errorf("validation failed: %w", err)
+			return nil, fmt.Errorf("validation failed: %d error(s)", len(res))
 		}
 	}
 
@@ -130,6 +120,46 @@ func Load(path string, validator *utils.Validator, workflow *model.Workflow) (*E
 	return &cfg, nil
 }
 
+// Validate checks a config file without loading its clients. Errors in the
+// config are returned with where they are in the rendered file.
+func Validate(path string, validator *utils.Validator) ([]utils.ValidationErrors, error) {
+	var cfg Events
+	rendered, err := readConfig(path, &cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := utils.ParseSource(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("parse source: %w", err)
+	}
+
+	res, err := validator.ValidateStructSource(cfg, src)
+	if err != nil {
+		return nil, fmt.Errorf("error creating validation stack: %w", err)
+	}
+	return res, nil
+}
+
+// readConfig reads, renders and unmarshals a config file
+func readConfig(path string, cfg *Events) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	rendered, err := renderTemplate(raw)
+	if err != nil {
+		return nil, fmt.Errorf("render template: %w", err)
+	}
+
+	if err := yaml.Unmarshal(rendered, cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal yaml: %w", err)
+	}
+
+	return rendered, nil
+}
+
 func envMap() map[string]string {
 	out := make(map[string]string)
 	for _, e := range os.Environ() {
@@ -162,3 +192,19 @@ func renderTemplate(input []byte) ([]byte, error) {
 
 	return buf.Bytes(), nil
 }
+
+// AddSender attaches an additional sender, such as an in-memory collector,
+// alongside any clients loaded from the config file.
+func (e *Events) AddSender(name string, sender protocol.Sender) error {
+	client, err := sdk.NewClient(sender, sdk.WithTimeNow(), sdk.WithUUIDs())
+	if err != nil {
+		return fmt.Errorf("error creating client %s: %w", name, err)
+	}
+
+	e.Clients = append(e.Clients, &ClientConfig{
+		Name:   name,
+		client: client,
+	})
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cloudevents
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// EventType describes an event Zigflow emits, for generated documentation
+type EventType struct {
+	// Type is the full CloudEvent type, such as dev.zigflow.task.started
+	Type    string `json:"type"`
+	Summary string `json:"summary"`
+	// Subject is what the event's subject is set to, if anything
+	Subject string `json:"subject,omitempty"`
+	// Data lists the keys of the event's data
+	Data []string `json:"data"`
+}
+
+// EventTypes lists every event emitted while a workflow runs. The event ID is
+// always the workflow ID.
+var EventTypes = []EventType{
+	{
+		Type:    fullEventType("workflow.started"),
+		Summary: "A workflow begins execution",
+		Data:    []string{"input", "state"},
+	},
+	{
+		Type:    fullEventType("workflow.completed"),
+		Summary: "A workflow completes successfully",
+		Data:    []string{"output"},
+	},
+	{
+		Type:    fullEventType("task.started"),
+		Summary: "A task begins execution",
+		Subject: "task name",
+		Data:    []string{"attempt", "input", "state", "workflowTime"},
+	},
+	{
+		Type:    fullEventType("task.retried"),
+		Summary: "A task is retried after a failure",
+		Subject: "task name",
+		Data:    []string{"attempt"},
+	},
+	{
+		Type:    fullEventType("task.cancelled"),
+		Summary: "A task is cancelled",
+		Subject: "task name",
+		Data:    []string{"workflowTime"},
+	},
+	{
+		Type:    fullEventType("task.faulted"),
+		Summary: "A task fails",
+		Subject: "task name",
+		Data:    []string{"error", "workflowTime"},
+	},
+	{
+		Type:    fullEventType("task.completed"),
+		Summary: "A task completes successfully",
+		Subject: "task name",
+		Data:    []string{"input", "output", "state", "workflowTime"},
+	},
+	{
+		Type:    fullEventType("iteration.completed"),
+		Summary: "A for task completes an iteration",
+		Subject: "task name",
+		Data:    []string{"state", "while"},
+	},
+}
+
+// Source is the source of the events emitted by a workflow, in the format
+// zigflow.dev/<task-queue>/<workflow>
+func Source(workflow *model.Workflow) string {
+	return fmt.Sprintf("zigflow.dev/%s/%s", workflow.Document.Namespace, workflow.Document.Name)
+}
+
+func fullEventType(name string) string {
+	return fmt.Sprintf("dev.zigflow.%s", name)
+}
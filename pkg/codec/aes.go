@@ -18,8 +18,10 @@ package codec
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/mrsimonemms/temporal-codec-server/packages/golang/algorithms/aes"
+	"github.com/zigflow/zigflow/pkg/utils"
 	"go.temporal.io/sdk/converter"
 )
 
@@ -30,3 +32,49 @@ func NewAESConverter(keyPath string) (converter.DataConverter, error) {
 	}
 	return aes.DataConverter(keys), nil
 }
+
+// ValidateKeyFile checks every key in an AES key file has a unique ID and is
+// 16, 24 or 32 bytes long, for AES-128, AES-192 or AES-256
+func ValidateKeyFile(keyPath string) ([]utils.ValidationErrors, error) {
+	keys, err := aes.ReadKeyFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key file %q: %w", keyPath, err)
+	}
+
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key file %q: %w", keyPath, err)
+	}
+	src, err := utils.ParseSource(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse key file %q: %w", keyPath, err)
+	}
+
+	var res []utils.ValidationErrors
+	add := func(i int, field, key, param, message string) {
+		res = append(res, utils.ValidationErrors{
+			Key:      key,
+			Message:  message,
+			Path:     fmt.Sprintf("Keys[%d].%s", i, field),
+			Param:    param,
+			Position: src.Position(fmt.Sprintf("/%d/%s", i, field)),
+		})
+	}
+
+	ids := map[string]bool{}
+	for i, k := range keys {
+		switch {
+		case k.ID == "":
+			add(i, "id", "required", "", "is required")
+		case ids[k.ID]:
+			add(i, "id", "unique", "", fmt.Sprintf("%q is used by another key", k.ID))
+		}
+		ids[k.ID] = true
+
+		if l := len(k.Key); l != 16 && l != 24 && l != 32 {
+			add(i, "key", "len", "16 24 32", fmt.Sprintf("must be 16, 24 or 32 bytes long, not %d", l))
+		}
+	}
+
+	return res, nil
+}
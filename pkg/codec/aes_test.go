@@ -51,3 +51,61 @@ func TestNewAESConverter(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestValidateKeyFile(t *testing.T) {
+	tests := []struct {
+		Name         string
+		Content      string
+		ExpectError  bool
+		ExpectErrors []string
+	}{
+		{
+			Name:    "valid keys",
+			Content: "- id: key0\n  key: passphrasewhichneedstobe32bytes!\n- id: key1\n  key: sixteenbyteskey!\n",
+		},
+		{
+			Name:         "missing ID",
+			Content:      "- key: passphrasewhichneedstobe32bytes!\n",
+			ExpectErrors: []string{"/0/id required"},
+		},
+		{
+			Name:         "duplicate ID",
+			Content:      "- id: key0\n  key: passphrasewhichneedstobe32bytes!\n- id: key0\n  key: passphrasewhichneedstobe32bytes!\n",
+			ExpectErrors: []string{"/1/id unique"},
+		},
+		{
+			Name:         "wrong key length",
+			Content:      "- id: key0\n  key: short\n",
+			ExpectErrors: []string{"/0/key len"},
+		},
+		{
+			Name:        "empty file",
+			Content:     "",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			f, err := os.CreateTemp(t.TempDir(), "keys-*.yaml")
+			require.NoError(t, err)
+			_, err = f.WriteString(test.Content)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			res, err := ValidateKeyFile(f.Name())
+			if test.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			errs := make([]string, 0, len(res))
+			for _, e := range res {
+				assert.NotZero(t, e.Line)
+				errs = append(errs, e.Pointer+" "+e.Key)
+			}
+			assert.ElementsMatch(t, test.ExpectErrors, errs)
+		})
+	}
+}
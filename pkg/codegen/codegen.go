@@ -0,0 +1,235 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package codegen generates typed clients for workflow definitions. The types
+// come from the input, output and listen task JSON schemas, and the helpers
+// start the workflows on their task queue and call their signals, queries and
+// updates.
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/apidocs"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+// Header is the first line of every generated file.
+const Header = "Code generated by zigflow generate client. DO NOT EDIT."
+
+// Language represents a supported client language.
+type Language string
+
+const (
+	// LanguageGo generates a Go package using the Temporal Go SDK.
+	LanguageGo Language = "go"
+	// LanguageTypeScript generates a TypeScript module using the Temporal
+	// TypeScript SDK.
+	LanguageTypeScript Language = "typescript"
+)
+
+// Languages lists every supported language.
+var Languages = []Language{LanguageGo, LanguageTypeScript}
+
+// Generator renders a workflow definition's client.
+type Generator interface {
+	Generate(wf *model.Workflow) (string, error)
+}
+
+// Option configures a Generator.
+type Option func(*options)
+
+type options struct {
+	pkg string
+}
+
+// WithPackage sets the name of the generated Go package. The default is the
+// document name.
+func WithPackage(name string) Option {
+	return func(o *options) {
+		o.pkg = name
+	}
+}
+
+// New returns a Generator for the given language.
+func New(lang Language, opts ...Option) (Generator, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	switch lang {
+	case LanguageGo:
+		return &goGenerator{opts: o}, nil
+	case LanguageTypeScript:
+		return &typeScriptGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported client language: %q", lang)
+	}
+}
+
+// client is the language-neutral view of a workflow document's client.
+type client struct {
+	name      string
+	taskQueue string
+	types     []*namedType
+	workflows []*workflow
+}
+
+type workflow struct {
+	// name is the Temporal workflow type
+	name  string
+	ident string
+	// child workflows are started by another workflow, so have no start helper
+	child   bool
+	input   typeRef
+	output  typeRef
+	signals []*handler
+	queries []*handler
+	updates []*handler
+}
+
+type handler struct {
+	// id is the signal, query or update name
+	id    string
+	ident string
+	task  string
+	data  typeRef
+}
+
+// build converts a workflow document into its client, declaring a type for
+// every schema.
+func build(doc *model.Workflow) (*client, error) {
+	ref := apidocs.Build(doc)
+	t := newTypes()
+
+	c := &client{
+		name:      ref.Name,
+		taskQueue: ref.TaskQueue,
+	}
+
+	for _, w := range ref.Workflows {
+		wf := &workflow{
+			name:  w.Name,
+			ident: exportName(w.Name),
+			child: w.Child,
+			input: t.schema(w.Input, exportName(w.Name)+"Input"),
+		}
+		if w.Output != nil {
+			wf.output = t.schema(w.Output.Schema, exportName(w.Name)+"Output")
+		}
+
+		// Signals and updates are typed by their listen event's schema.
+		// Queries receive no data.
+		for _, h := range w.Signals {
+			wf.signals = append(wf.signals, newHandler(h, t.schema(h.Schema, exportName(h.ID)+"Signal")))
+		}
+		for _, h := range w.Queries {
+			wf.queries = append(wf.queries, newHandler(h, typeRef{}))
+		}
+		for _, h := range w.Updates {
+			wf.updates = append(wf.updates, newHandler(h, t.schema(h.Schema, exportName(h.ID)+"Update")))
+		}
+
+		c.workflows = append(c.workflows, wf)
+	}
+
+	// Tasks may validate their own input and output too
+	if err := zigflow.Walk(doc, func(item *model.TaskItem, _ string) error {
+		base := item.GetBase()
+		if base.Input != nil {
+			t.schema(base.Input.Schema, exportName(item.Key)+"Input")
+		}
+		if base.Output != nil {
+			t.schema(base.Output.Schema, exportName(item.Key)+"Output")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	c.types = t.named
+	return c, nil
+}
+
+func newHandler(h *apidocs.Handler, data typeRef) *handler {
+	return &handler{
+		id:    h.ID,
+		ident: exportName(h.ID),
+		task:  h.Task,
+		data:  data,
+	}
+}
+
+var (
+	nameSplit = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+	initialisms = map[string]bool{
+		"API": true, "HTTP": true, "HTTPS": true, "ID": true, "JSON": true,
+		"SQL": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+	}
+)
+
+// exportName converts a workflow, task or property name into an exported
+// identifier, such as "change_id" and "changeId" into "ChangeID".
+func exportName(name string) string {
+	var b strings.Builder
+	for _, part := range nameSplit.Split(name, -1) {
+		for _, word := range words(part) {
+			if upper := strings.ToUpper(word); initialisms[upper] {
+				b.WriteString(upper)
+				continue
+			}
+			b.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+		}
+	}
+
+	ident := b.String()
+	if ident == "" || (ident[0] >= '0' && ident[0] <= '9') {
+		ident = "X" + ident
+	}
+	return ident
+}
+
+// words splits a camel or Pascal case name into its words, keeping runs of
+// capitals together so "HTTPServer" is "HTTP" and "Server".
+func words(name string) []string {
+	var out []string
+	start := 0
+	for i := 1; i < len(name); i++ {
+		prev, cur := name[i-1], name[i]
+		next := byte(0)
+		if i+1 < len(name) {
+			next = name[i+1]
+		}
+
+		if isUpper(cur) && (!isUpper(prev) || isLower(next)) {
+			out = append(out, name[start:i])
+			start = i
+		}
+	}
+	if start < len(name) {
+		out = append(out, name[start:])
+	}
+	return out
+}
+
+func isUpper(c byte) bool { return c >= 'A' && c <= 'Z' }
+
+func isLower(c byte) bool { return c >= 'a' && c <= 'z' }
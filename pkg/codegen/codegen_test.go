@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+const workflowYAML = `document:
+  dsl: 1.0.0
+  namespace: orders
+  name: order-processing
+  version: 1.0.0
+input:
+  schema:
+    format: json
+    document:
+      type: object
+      description: An order to process
+      required:
+        - orderId
+        - items
+      properties:
+        orderId:
+          type: string
+          description: The order's ID
+        priority:
+          type: string
+          enum: [low, high]
+        items:
+          type: array
+          items:
+            type: object
+            required: [sku]
+            properties:
+              sku:
+                type: string
+              quantity:
+                type: integer
+        customer:
+          type: object
+          properties:
+            email:
+              type: [string, "null"]
+        metadata:
+          type: object
+          additionalProperties:
+            type: string
+        "shipping-address":
+          type: string
+do:
+  - status:
+      listen:
+        to:
+          one:
+            with:
+              id: get_status
+              type: query
+              data: ${ $data }
+  - approval:
+      listen:
+        to:
+          any:
+            - with:
+                id: approve
+                type: signal
+                schema:
+                  document:
+                    type: object
+                    required: [approver]
+                    properties:
+                      approver:
+                        type: string
+            - with:
+                id: set_discount
+                type: update
+                schema:
+                  document:
+                    type: number
+  - charge:
+      output:
+        schema:
+          document:
+            type: object
+            properties:
+              total:
+                type: number
+              paid:
+                type: boolean
+      set:
+        total: 10
+        paid: true
+`
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		Language Language
+		Options  []Option
+		Expected []string
+	}{
+		{
+			Language: LanguageGo,
+			Expected: []string{
+				"// Code generated by zigflow generate client. DO NOT EDIT.\n",
+				"package orderprocessing\n",
+				`const TaskQueue = "orders"`,
+				"// OrderProcessingInput is generated from its JSON schema.\n//\n// An order to process\n",
+				"\tCustomer *OrderProcessingInputCustomer   `json:\"customer,omitempty\"`\n",
+				"\tItems    []OrderProcessingInputItemsItem `json:\"items\"`\n",
+				"\tMetadata map[string]string               `json:\"metadata,omitempty\"`\n",
+				"\t// The order's ID\n\tOrderID         string                       `json:\"orderId\"`\n",
+				"\tPriority        OrderProcessingInputPriority `json:\"priority,omitempty\"`\n",
+				"\tShippingAddress string                       `json:\"shipping-address,omitempty\"`\n",
+				"\tEmail string `json:\"email,omitempty\"`\n",
+				"\tQuantity int64  `json:\"quantity,omitempty\"`\n",
+				"\tOrderProcessingInputPriorityHigh OrderProcessingInputPriority = \"high\"\n",
+				"\tTotal float64 `json:\"total,omitempty\"`\n",
+				"func StartOrderProcessing(ctx context.Context, c client.Client, options client.StartWorkflowOptions, input OrderProcessingInput) (client.WorkflowRun, error) {\n\toptions.TaskQueue = TaskQueue\n\treturn c.ExecuteWorkflow(ctx, options, \"order-processing\", input)\n}",
+				"func GetOrderProcessingResult(ctx context.Context, c client.Client, workflowID, runID string) (OrderProcessingOutput, error) {",
+				"func SignalOrderProcessingApprove(ctx context.Context, c client.Client, workflowID, runID string, data ApproveSignal) error {\n\treturn c.SignalWorkflow(ctx, workflowID, runID, \"approve\", data)\n}",
+				"value, err := c.QueryWorkflow(ctx, workflowID, runID, \"get_status\")",
+				"func UpdateOrderProcessingSetDiscount(ctx context.Context, c client.Client, workflowID, runID string, data float64) (any, error) {",
+				"\t\tUpdateName:   \"set_discount\",\n",
+			},
+		},
+		{
+			Language: LanguageGo,
+			Options:  []Option{WithPackage("orders")},
+			Expected: []string{"package orders\n"},
+		},
+		{
+			Language: LanguageTypeScript,
+			Expected: []string{
+				"// Code generated by zigflow generate client. DO NOT EDIT.\n",
+				`import type { Client, WorkflowHandle, WorkflowOptions } from "@temporalio/client";`,
+				`export const taskQueue = "orders";`,
+				"/**\n * An order to process\n */\nexport interface OrderProcessingInput {\n  customer?: OrderProcessingInputCustomer;\n  items: OrderProcessingInputItemsItem[];\n  metadata?: Record<string, string>;\n",
+				"  \"shipping-address\"?: string;\n",
+				"export type OrderProcessingInputPriority = \"low\" | \"high\";",
+				"  input: OrderProcessingInput,\n): Promise<WorkflowHandle> {\n  return client.workflow.start(\"order-processing\", { ...options, taskQueue, args: [input] });\n}",
+				"export async function getOrderProcessingResult(handle: WorkflowHandle): Promise<OrderProcessingOutput> {",
+				"export interface ApproveSignal {\n  approver: string;\n}",
+				"  await handle.signal(\"approve\", data);",
+				"  return handle.query(\"get_status\");",
+				"export async function updateOrderProcessingSetDiscount(handle: WorkflowHandle, data: number): Promise<unknown> {\n  return handle.executeUpdate(\"set_discount\", { args: [data] });\n}",
+			},
+		},
+	}
+
+	wf, err := zigflow.LoadFromBytes([]byte(workflowYAML))
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(string(test.Language), func(t *testing.T) {
+			gen, err := New(test.Language, test.Options...)
+			require.NoError(t, err)
+
+			out, err := gen.Generate(wf)
+			require.NoError(t, err)
+
+			for _, e := range test.Expected {
+				assert.Contains(t, out, e)
+			}
+		})
+	}
+}
+
+func TestNew_UnknownLanguage(t *testing.T) {
+	_, err := New("unknown")
+	assert.Error(t, err)
+}
+
+func TestExportName(t *testing.T) {
+	tests := map[string]string{
+		"approval":      "Approval",
+		"changeId":      "ChangeID",
+		"get_state":     "GetState",
+		"order-id":      "OrderID",
+		"HTTPServer":    "HTTPServer",
+		"callbackUrl":   "CallbackURL",
+		"2fa":           "X2fa",
+		"":              "X",
+		"already_Upper": "AlreadyUpper",
+	}
+
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, exportName(name))
+		})
+	}
+}
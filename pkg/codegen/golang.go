@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type goGenerator struct {
+	opts *options
+}
+
+func (g *goGenerator) Generate(wf *model.Workflow) (string, error) {
+	c, err := build(wf)
+	if err != nil {
+		return "", err
+	}
+
+	pkg := g.opts.pkg
+	if pkg == "" {
+		pkg = strings.ToLower(nameSplit.ReplaceAllString(c.name, ""))
+		if pkg == "" || (pkg[0] >= '0' && pkg[0] <= '9') {
+			pkg = "workflow" + pkg
+		}
+	}
+
+	w := &goWriter{}
+	w.line("// " + Header)
+	w.line("")
+	w.line(fmt.Sprintf("// Package %s is a Temporal client for the %s workflows.", pkg, c.name))
+	w.line("package " + pkg)
+	w.line("")
+	w.line(`import (`)
+	w.line(`"context"`)
+	w.line("")
+	w.line(`"go.temporal.io/sdk/client"`)
+	w.line(`)`)
+	w.line("")
+	w.line("// TaskQueue is the task queue the workflows run on.")
+	w.line(fmt.Sprintf("const TaskQueue = %q", c.taskQueue))
+
+	for _, nt := range c.types {
+		w.namedType(nt)
+	}
+	for _, wf := range c.workflows {
+		w.workflow(wf)
+	}
+
+	src, err := format.Source(w.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("error formatting go client: %w", err)
+	}
+	return string(src), nil
+}
+
+type goWriter struct {
+	bytes.Buffer
+}
+
+func (w *goWriter) line(s string) {
+	w.WriteString(s + "\n")
+}
+
+// comment writes a doc comment, following the summary with the schema's
+// description.
+func (w *goWriter) comment(ident, summary, description string) {
+	w.line("")
+	w.line("// " + ident + " " + summary)
+	if description != "" {
+		w.line("//")
+		w.description(description)
+	}
+}
+
+func (w *goWriter) description(description string) {
+	for _, l := range strings.Split(strings.TrimSpace(description), "\n") {
+		w.line(strings.TrimSpace("// " + l))
+	}
+}
+
+func (w *goWriter) namedType(nt *namedType) {
+	if nt.enum != nil {
+		w.comment(nt.name, "is one of the allowed values.", nt.description)
+		w.line(fmt.Sprintf("type %s string", nt.name))
+		w.line("")
+		w.line("const (")
+		idents := map[string]bool{}
+		for _, v := range nt.enum {
+			w.line(fmt.Sprintf("%s %s = %q", unique(idents, nt.name+exportName(v)), nt.name, v))
+		}
+		w.line(")")
+		return
+	}
+
+	w.comment(nt.name, "is generated from its JSON schema.", nt.description)
+	w.line(fmt.Sprintf("type %s struct {", nt.name))
+	for _, f := range nt.fields {
+		if f.description != "" {
+			w.description(f.description)
+		}
+
+		typ := goType(f.ref)
+		tag := f.name
+		if !f.required {
+			tag += ",omitempty"
+			if f.ref.kind == kindNamed && !f.ref.enum {
+				// Pointer so the property can be omitted
+				typ = "*" + typ
+			}
+		}
+		w.line(fmt.Sprintf("%s %s `json:%q`", f.ident, typ, tag))
+	}
+	w.line("}")
+}
+
+func (w *goWriter) workflow(wf *workflow) {
+	if !wf.child {
+		w.comment("Start"+wf.ident, fmt.Sprintf("starts the %s workflow on the task queue.", wf.name), "")
+		w.line(fmt.Sprintf(
+			"func Start%s(ctx context.Context, c client.Client, options client.StartWorkflowOptions, input %s) (client.WorkflowRun, error) {",
+			wf.ident, goType(wf.input),
+		))
+		w.line("options.TaskQueue = TaskQueue")
+		w.line(fmt.Sprintf("return c.ExecuteWorkflow(ctx, options, %q, input)", wf.name))
+		w.line("}")
+
+		output := goType(wf.output)
+		w.comment("Get"+wf.ident+"Result", fmt.Sprintf("waits for the %s workflow to complete and returns its output.", wf.name), "")
+		w.line(fmt.Sprintf(
+			"func Get%sResult(ctx context.Context, c client.Client, workflowID, runID string) (%s, error) {",
+			wf.ident, output,
+		))
+		w.line(fmt.Sprintf("var result %s", output))
+		w.line("err := c.GetWorkflow(ctx, workflowID, runID).Get(ctx, &result)")
+		w.line("return result, err")
+		w.line("}")
+	}
+
+	for _, h := range wf.signals {
+		name := "Signal" + wf.ident + h.ident
+		w.comment(name, fmt.Sprintf("sends the %s signal, received by the %s task.", h.id, h.task), "")
+		w.line(fmt.Sprintf(
+			"func %s(ctx context.Context, c client.Client, workflowID, runID string, data %s) error {",
+			name, goType(h.data),
+		))
+		w.line(fmt.Sprintf("return c.SignalWorkflow(ctx, workflowID, runID, %q, data)", h.id))
+		w.line("}")
+	}
+
+	for _, h := range wf.queries {
+		name := "Query" + wf.ident + h.ident
+		w.comment(name, fmt.Sprintf("runs the %s query, answered by the %s task.", h.id, h.task), "")
+		w.line(fmt.Sprintf("func %s(ctx context.Context, c client.Client, workflowID, runID string) (any, error) {", name))
+		w.line(fmt.Sprintf("value, err := c.QueryWorkflow(ctx, workflowID, runID, %q)", h.id))
+		w.line("if err != nil {")
+		w.line("return nil, err")
+		w.line("}")
+		w.line("var result any")
+		w.line("err = value.Get(&result)")
+		w.line("return result, err")
+		w.line("}")
+	}
+
+	for _, h := range wf.updates {
+		name := "Update" + wf.ident + h.ident
+		w.comment(name, fmt.Sprintf("sends the %s update, handled by the %s task, and waits for its result.", h.id, h.task), "")
+		w.line(fmt.Sprintf(
+			"func %s(ctx context.Context, c client.Client, workflowID, runID string, data %s) (any, error) {",
+			name, goType(h.data),
+		))
+		w.line("handle, err := c.UpdateWorkflow(ctx, client.UpdateWorkflowOptions{")
+		w.line("WorkflowID: workflowID,")
+		w.line("RunID: runID,")
+		w.line(fmt.Sprintf("UpdateName: %q,", h.id))
+		w.line("Args: []any{data},")
+		w.line("WaitForStage: client.WorkflowUpdateStageCompleted,")
+		w.line("})")
+		w.line("if err != nil {")
+		w.line("return nil, err")
+		w.line("}")
+		w.line("var result any")
+		w.line("err = handle.Get(ctx, &result)")
+		w.line("return result, err")
+		w.line("}")
+	}
+}
+
+func goType(ref typeRef) string {
+	switch ref.kind {
+	case kindString:
+		return "string"
+	case kindInteger:
+		return "int64"
+	case kindNumber:
+		return "float64"
+	case kindBoolean:
+		return "bool"
+	case kindArray:
+		return "[]" + goType(*ref.elem)
+	case kindMap:
+		return "map[string]" + goType(*ref.elem)
+	case kindNamed:
+		return ref.name
+	default:
+		return "any"
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type typeKind int
+
+const (
+	// kindAny is used when a value has no schema, or its schema can't be
+	// represented as a type
+	kindAny typeKind = iota
+	kindString
+	kindInteger
+	kindNumber
+	kindBoolean
+	kindArray
+	kindMap
+	kindNamed
+)
+
+// typeRef is a type expression.
+type typeRef struct {
+	kind typeKind
+	// name is the declared type of a kindNamed
+	name string
+	// enum is set if the named type is a string enum
+	enum bool
+	// elem is the element type of a kindArray or kindMap
+	elem *typeRef
+}
+
+// namedType is a declared type. It is either an object with fields or a
+// string enum.
+type namedType struct {
+	name        string
+	description string
+	fields      []*field
+	enum        []string
+}
+
+type field struct {
+	// name is the JSON property name
+	name        string
+	ident       string
+	description string
+	ref         typeRef
+	required    bool
+}
+
+// types converts JSON schemas to types, declaring a named type for each
+// object and enum.
+type types struct {
+	named []*namedType
+	names map[string]bool
+	seen  map[*model.Schema]typeRef
+}
+
+func newTypes() *types {
+	return &types{
+		// Reserved for the task queue constant
+		names: map[string]bool{"TaskQueue": true},
+		seen:  map[*model.Schema]typeRef{},
+	}
+}
+
+// schema returns the type of a workflow schema. A schema shared by several
+// workflows or tasks is only declared once, with the first name it's given.
+func (t *types) schema(schema *model.Schema, name string) typeRef {
+	if schema == nil {
+		return typeRef{}
+	}
+	if ref, ok := t.seen[schema]; ok {
+		return ref
+	}
+
+	// External resources aren't fetched, so can only be typed as any
+	ref := typeRef{}
+	if schema.Document != nil {
+		ref = t.resolve(schema.Document, name)
+	}
+	t.seen[schema] = ref
+	return ref
+}
+
+// resolve returns the type of a JSON schema document.
+func (t *types) resolve(doc any, name string) typeRef {
+	schema, ok := doc.(map[string]any)
+	if !ok {
+		return typeRef{}
+	}
+	description, _ := schema["description"].(string)
+
+	if enum, ok := stringEnum(schema["enum"]); ok {
+		return t.declare(&namedType{name: name, description: description, enum: enum})
+	}
+
+	switch schemaType(schema) {
+	case "string":
+		return typeRef{kind: kindString}
+	case "integer":
+		return typeRef{kind: kindInteger}
+	case "number":
+		return typeRef{kind: kindNumber}
+	case "boolean":
+		return typeRef{kind: kindBoolean}
+	case "array":
+		elem := t.resolve(schema["items"], name+"Item")
+		return typeRef{kind: kindArray, elem: &elem}
+	case "object":
+		properties, _ := schema["properties"].(map[string]any)
+		if len(properties) == 0 {
+			elem := t.resolve(schema["additionalProperties"], name+"Value")
+			return typeRef{kind: kindMap, elem: &elem}
+		}
+
+		nt := &namedType{name: name, description: description}
+		ref := t.declare(nt)
+
+		required, _ := schema["required"].([]any)
+		idents := map[string]bool{}
+		keys := make([]string, 0, len(properties))
+		for k := range properties {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			prop, _ := properties[k].(map[string]any)
+			f := &field{
+				name:     k,
+				ident:    unique(idents, exportName(k)),
+				ref:      t.resolve(prop, ref.name+exportName(k)),
+				required: slices.Contains(required, any(k)),
+			}
+			f.description, _ = prop["description"].(string)
+			nt.fields = append(nt.fields, f)
+		}
+		return ref
+	default:
+		return typeRef{}
+	}
+}
+
+// declare adds a named type, renaming it if the name is already taken.
+func (t *types) declare(nt *namedType) typeRef {
+	nt.name = unique(t.names, nt.name)
+	t.named = append(t.named, nt)
+	return typeRef{kind: kindNamed, name: nt.name, enum: nt.enum != nil}
+}
+
+// unique returns the name, with a number appended if it's already used, and
+// marks it as used.
+func unique(used map[string]bool, name string) string {
+	candidate := name
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// schemaType returns the type of a JSON schema, ignoring null in a list of
+// types as a nullable value is typed the same as an optional one.
+func schemaType(schema map[string]any) string {
+	switch v := schema["type"].(type) {
+	case string:
+		return v
+	case []any:
+		var types []string
+		for _, t := range v {
+			if s, ok := t.(string); ok && s != "null" {
+				types = append(types, s)
+			}
+		}
+		if len(types) == 1 {
+			return types[0]
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// stringEnum returns the values of an enum if they're all strings.
+func stringEnum(v any) ([]string, bool) {
+	values, ok := v.([]any)
+	if !ok || len(values) == 0 {
+		return nil, false
+	}
+
+	enum := make([]string, 0, len(values))
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		enum = append(enum, s)
+	}
+	return enum, true
+}
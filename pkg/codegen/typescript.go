@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+type typeScriptGenerator struct{}
+
+func (g *typeScriptGenerator) Generate(wf *model.Workflow) (string, error) {
+	c, err := build(wf)
+	if err != nil {
+		return "", err
+	}
+
+	w := &strings.Builder{}
+	line := func(s string) {
+		w.WriteString(s + "\n")
+	}
+
+	line("// " + Header)
+	line("")
+	line(`import type { Client, WorkflowHandle, WorkflowOptions } from "@temporalio/client";`)
+	line("")
+	line("/** The task queue the workflows run on. */")
+	line(fmt.Sprintf("export const taskQueue = %s;", strconv.Quote(c.taskQueue)))
+
+	for _, nt := range c.types {
+		line("")
+		tsComment(line, "", nt.description)
+
+		if nt.enum != nil {
+			values := make([]string, 0, len(nt.enum))
+			for _, v := range nt.enum {
+				values = append(values, strconv.Quote(v))
+			}
+			line(fmt.Sprintf("export type %s = %s;", nt.name, strings.Join(values, " | ")))
+			continue
+		}
+
+		line(fmt.Sprintf("export interface %s {", nt.name))
+		for _, f := range nt.fields {
+			tsComment(line, "  ", f.description)
+
+			name := f.name
+			if !tsIdentifier.MatchString(name) {
+				name = strconv.Quote(name)
+			}
+			if !f.required {
+				name += "?"
+			}
+			line(fmt.Sprintf("  %s: %s;", name, tsType(f.ref)))
+		}
+		line("}")
+	}
+
+	for _, wf := range c.workflows {
+		if !wf.child {
+			line("")
+			line(fmt.Sprintf("/** Starts the %s workflow on the task queue. */", wf.name))
+			line(fmt.Sprintf("export async function start%s(", wf.ident))
+			line("  client: Client,")
+			line(`  options: Omit<WorkflowOptions, "taskQueue">,`)
+			line(fmt.Sprintf("  input: %s,", tsType(wf.input)))
+			line("): Promise<WorkflowHandle> {")
+			line(fmt.Sprintf("  return client.workflow.start(%s, { ...options, taskQueue, args: [input] });", strconv.Quote(wf.name)))
+			line("}")
+
+			line("")
+			line(fmt.Sprintf("/** Waits for the %s workflow to complete and returns its output. */", wf.name))
+			line(fmt.Sprintf("export async function get%sResult(handle: WorkflowHandle): Promise<%s> {", wf.ident, tsType(wf.output)))
+			line("  return handle.result();")
+			line("}")
+		}
+
+		for _, h := range wf.signals {
+			line("")
+			line(fmt.Sprintf("/** Sends the %s signal, received by the %s task. */", h.id, h.task))
+			line(fmt.Sprintf(
+				"export async function signal%s%s(handle: WorkflowHandle, data: %s): Promise<void> {",
+				wf.ident, h.ident, tsType(h.data),
+			))
+			line(fmt.Sprintf("  await handle.signal(%s, data);", strconv.Quote(h.id)))
+			line("}")
+		}
+
+		for _, h := range wf.queries {
+			line("")
+			line(fmt.Sprintf("/** Runs the %s query, answered by the %s task. */", h.id, h.task))
+			line(fmt.Sprintf("export async function query%s%s(handle: WorkflowHandle): Promise<unknown> {", wf.ident, h.ident))
+			line(fmt.Sprintf("  return handle.query(%s);", strconv.Quote(h.id)))
+			line("}")
+		}
+
+		for _, h := range wf.updates {
+			line("")
+			line(fmt.Sprintf("/** Sends the %s update, handled by the %s task, and waits for its result. */", h.id, h.task))
+			line(fmt.Sprintf(
+				"export async function update%s%s(handle: WorkflowHandle, data: %s): Promise<unknown> {",
+				wf.ident, h.ident, tsType(h.data),
+			))
+			line(fmt.Sprintf("  return handle.executeUpdate(%s, { args: [data] });", strconv.Quote(h.id)))
+			line("}")
+		}
+	}
+
+	return w.String(), nil
+}
+
+func tsComment(line func(string), indent, description string) {
+	if description == "" {
+		return
+	}
+	line(indent + "/**")
+	for _, l := range strings.Split(strings.TrimSpace(description), "\n") {
+		line(strings.TrimRight(indent+" * "+strings.ReplaceAll(l, "*/", "*\\/"), " "))
+	}
+	line(indent + " */")
+}
+
+func tsType(ref typeRef) string {
+	switch ref.kind {
+	case kindString:
+		return "string"
+	case kindInteger, kindNumber:
+		return "number"
+	case kindBoolean:
+		return "boolean"
+	case kindArray:
+		return tsType(*ref.elem) + "[]"
+	case kindMap:
+		return "Record<string, " + tsType(*ref.elem) + ">"
+	case kindNamed:
+		return ref.name
+	default:
+		return "unknown"
+	}
+}
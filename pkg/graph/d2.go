@@ -0,0 +1,121 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type d2Generator struct {
+	opts *options
+}
+
+func (g *d2Generator) Generate(wf *model.Workflow) (string, error) {
+	m := Build(wf, WithOverlay(g.opts.overlay))
+	w := &lineWriter{}
+
+	w.linef(0, "direction: down")
+	d2Group(w, m, 0, "")
+
+	// Edges are declared at the top level, so nodes inside groups are
+	// referenced by their full path.
+	paths := d2Paths(m)
+	for _, e := range m.Edges {
+		line := paths[e.From] + " -> " + paths[e.To]
+		if e.Label != "" {
+			line += ": " + d2Quote(escapeLabel(e.Label))
+		}
+		if e.Style == EdgeDashed {
+			line += " {style.stroke-dash: 3}"
+		}
+		w.linef(0, "%s", line)
+	}
+
+	return w.build(), nil
+}
+
+// d2Group renders the nodes in a group, followed by its child groups as
+// containers. The empty group is the top level of the diagram.
+func d2Group(w *lineWriter, m *Model, indent int, group string) {
+	for _, n := range m.nodes(group) {
+		label, shape := d2Node(n)
+		w.linef(indent, "%s: %s {shape: %s%s}", n.ID, d2Quote(label), shape, d2Colour(n))
+	}
+	for _, g := range m.groups(group) {
+		w.linef(indent, "%s: %s {", g.ID, d2Quote(escapeLabel(g.Label)))
+		d2Group(w, m, indent+1, g.ID)
+		w.linef(indent, "}")
+	}
+}
+
+func d2Node(n *Node) (label, shape string) {
+	switch n.Kind {
+	case NodeStart, NodeEnd:
+		return n.Label, "oval"
+	case NodeAnchor, NodeJoin:
+		return " ", "circle"
+	}
+
+	switch n.Shape {
+	case ShapeDiamond:
+		return nodeText(n, "\n"), "diamond"
+	case ShapeSubroutine:
+		return nodeText(n, "\n"), "step"
+	default:
+		return nodeText(n, "\n"), "rectangle"
+	}
+}
+
+// d2Colour returns the style for a node in the overlay.
+func d2Colour(n *Node) string {
+	if n.Execution == nil {
+		return ""
+	}
+	colours := statusColours[n.Execution.Status]
+	style := fmt.Sprintf("; style.fill: %s; style.stroke: %s", d2Quote(colours[0]), d2Quote(colours[1]))
+	if n.Execution.Status == StatusSkipped {
+		style += "; style.stroke-dash: 3"
+	}
+	return style
+}
+
+// d2Paths maps each node ID to its path from the top of the diagram.
+func d2Paths(m *Model) map[string]string {
+	parents := make(map[string]string, len(m.Groups))
+	for _, g := range m.Groups {
+		parents[g.ID] = g.Parent
+	}
+
+	paths := make(map[string]string, len(m.Nodes))
+	for _, n := range m.Nodes {
+		segments := []string{n.ID}
+		for group := n.Group; group != ""; group = parents[group] {
+			segments = append([]string{group}, segments...)
+		}
+		paths[n.ID] = strings.Join(segments, ".")
+	}
+	return paths
+}
+
+func d2Quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type dotGenerator struct {
+	opts *options
+}
+
+func (g *dotGenerator) Generate(wf *model.Workflow) (string, error) {
+	m := Build(wf, WithOverlay(g.opts.overlay))
+	w := &lineWriter{}
+
+	w.linef(0, "digraph %s {", dotQuote(m.Name))
+	w.linef(1, "rankdir=TB")
+	w.linef(1, "node [shape=box]")
+	dotGroup(w, m, 1, "")
+	for _, e := range m.Edges {
+		attrs := make([]string, 0)
+		if e.Label != "" {
+			attrs = append(attrs, "label="+dotQuote(escapeLabel(e.Label)))
+		}
+		if e.Style == EdgeDashed {
+			attrs = append(attrs, "style=dashed")
+		}
+		if len(attrs) > 0 {
+			w.linef(1, "%s -> %s [%s]", dotQuote(e.From), dotQuote(e.To), strings.Join(attrs, ", "))
+		} else {
+			w.linef(1, "%s -> %s", dotQuote(e.From), dotQuote(e.To))
+		}
+	}
+	w.linef(0, "}")
+
+	return w.build(), nil
+}
+
+// dotGroup renders the nodes in a group, followed by its child groups as
+// clusters. The empty group is the top level of the graph.
+func dotGroup(w *lineWriter, m *Model, indent int, group string) {
+	for _, n := range m.nodes(group) {
+		w.linef(indent, "%s [%s]", dotQuote(n.ID), dotNodeAttrs(n))
+	}
+	for _, g := range m.groups(group) {
+		w.linef(indent, "subgraph %s {", dotQuote("cluster_"+g.ID))
+		w.linef(indent+1, "label=%s", dotQuote(escapeLabel(g.Label)))
+		dotGroup(w, m, indent+1, g.ID)
+		w.linef(indent, "}")
+	}
+}
+
+func dotNodeAttrs(n *Node) string {
+	switch n.Kind {
+	case NodeStart, NodeEnd:
+		return fmt.Sprintf("label=%s, shape=oval", dotQuote(n.Label))
+	case NodeAnchor:
+		return "shape=point"
+	case NodeJoin:
+		return `label="", shape=circle, width=0.2`
+	}
+
+	attrs := "label=" + dotQuote(nodeText(n, "\n"))
+	switch n.Shape {
+	case ShapeDiamond:
+		attrs += ", shape=diamond"
+	case ShapeSubroutine:
+		attrs += ", peripheries=2"
+	}
+
+	if n.Execution != nil {
+		colours := statusColours[n.Execution.Status]
+		style := "filled"
+		if n.Execution.Status == StatusSkipped {
+			style += ",dashed"
+		}
+		attrs += fmt.Sprintf(", style=%s, fillcolor=%s, color=%s", dotQuote(style), dotQuote(colours[0]), dotQuote(colours[1]))
+	}
+
+	return attrs
+}
+
+// dotQuote returns s as a quoted DOT ID.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/graph"
+)
+
+func TestDOT(t *testing.T) {
+	gen, err := graph.New(graph.FormatDOT)
+	require.NoError(t, err)
+
+	out, err := gen.Generate(tryWF())
+	require.NoError(t, err)
+
+	assert.Contains(t, out, `digraph "mywf" {`)
+	assert.Contains(t, out, `subgraph "cluster_try_mywf_safe_try" {`)
+	assert.Contains(t, out, `label="TRY (safe)"`)
+	assert.Contains(t, out, `"mywf_safe_try_loop" [label="FOR (loop)", peripheries=2]`)
+	assert.Contains(t, out, `[label="on error", style=dashed]`)
+	assert.Contains(t, out, `[label="next iteration"]`)
+	assert.Contains(t, out, `"mywf__start" [label="Start", shape=oval]`)
+}
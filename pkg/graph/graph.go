@@ -28,19 +28,69 @@ type Format string
 const (
 	// FormatMermaid renders the workflow as a Mermaid flowchart.
 	FormatMermaid Format = "mermaid"
+	// FormatDOT renders the workflow as a Graphviz DOT digraph.
+	FormatDOT Format = "dot"
+	// FormatPlantUML renders the workflow as a PlantUML diagram.
+	FormatPlantUML Format = "plantuml"
+	// FormatD2 renders the workflow as a D2 diagram.
+	FormatD2 Format = "d2"
+	// FormatJSON renders the workflow as the renderer-neutral graph Model.
+	FormatJSON Format = "json"
+	// FormatHTML renders the workflow as a self-contained HTML report, with
+	// each task's definition and Temporal configuration.
+	FormatHTML Format = "html"
 )
 
+// Formats lists every supported format.
+var Formats = []Format{FormatMermaid, FormatDOT, FormatPlantUML, FormatD2, FormatJSON, FormatHTML}
+
 // Generator renders a workflow definition as a graph.
 type Generator interface {
 	Generate(wf *model.Workflow) (string, error)
 }
 
 // New returns a Generator for the given format.
-func New(format Format) (Generator, error) {
+func New(format Format, opts ...Option) (Generator, error) {
+	o := newOptions(opts)
+
 	switch format {
 	case FormatMermaid:
-		return &mermaidGenerator{}, nil
+		return &mermaidGenerator{opts: o}, nil
+	case FormatDOT:
+		return &dotGenerator{opts: o}, nil
+	case FormatPlantUML:
+		return &plantUMLGenerator{opts: o}, nil
+	case FormatD2:
+		return &d2Generator{opts: o}, nil
+	case FormatJSON:
+		return &jsonGenerator{opts: o}, nil
+	case FormatHTML:
+		return &htmlGenerator{opts: o}, nil
 	default:
 		return nil, fmt.Errorf("unsupported graph format: %q", format)
 	}
 }
+
+// Option configures a Generator.
+type Option func(*options)
+
+type options struct {
+	envvars map[string]any
+	overlay Overlay
+}
+
+// WithEnvvars sets the envvars the workflow is compiled with, for formats that
+// show the workflow's Temporal configuration.
+func WithEnvvars(envvars map[string]any) Option {
+	return func(o *options) {
+		o.envvars = envvars
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
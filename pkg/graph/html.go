@@ -0,0 +1,300 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"bytes"
+	"cmp"
+	_ "embed"
+	"html/template"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed html.tmpl
+var htmlTemplate string
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(htmlTemplate))
+
+type htmlGenerator struct {
+	opts *options
+}
+
+// htmlReport is the data the report template is rendered with.
+type htmlReport struct {
+	Document model.Document
+	Metadata string
+	// Colours is the CSS for each execution status, when there is an overlay
+	Colours  map[Status][2]string
+	Statuses []Status
+	Handlers []*zigflow.Listener
+	Elements []*htmlElement
+	Tasks    []*htmlTask
+}
+
+// htmlElement is a node, a group or a row of fork branches, drawn in order
+// down the page.
+type htmlElement struct {
+	Node     *Node
+	Group    *htmlGroup
+	Branches []*htmlGroup
+}
+
+type htmlGroup struct {
+	*Group
+	Elements []*htmlElement
+}
+
+// htmlTask is the side panel for a task node.
+type htmlTask struct {
+	ID              string
+	Label           string
+	Workflow        string
+	If              string
+	Definition      string
+	ActivityOptions string
+	InputSchema     string
+	OutputSchema    string
+	Handlers        []*zigflow.Listener
+	Transitions     []*htmlTransition
+	Execution       *Execution
+}
+
+type htmlTransition struct {
+	Label string
+	To    string
+}
+
+// Generate renders a single self-contained HTML page, with no external
+// scripts or styles. Selecting a task opens a panel with its definition and
+// the Temporal configuration it compiles to.
+func (g *htmlGenerator) Generate(wf *model.Workflow) (string, error) {
+	plan, err := zigflow.Inspect(wf, g.opts.envvars)
+	if err != nil {
+		return "", err
+	}
+
+	m := Build(wf, WithOverlay(g.opts.overlay))
+
+	report := &htmlReport{
+		Document: wf.Document,
+		Handlers: zigflow.Listeners(wf),
+		Elements: m.htmlElements(""),
+		Tasks:    make([]*htmlTask, 0),
+	}
+	if len(wf.Document.Metadata) > 0 {
+		if report.Metadata, err = toYAML(wf.Document.Metadata); err != nil {
+			return "", err
+		}
+	}
+	if g.opts.overlay != nil {
+		report.Colours = statusColours
+		report.Statuses = statuses
+	}
+
+	// Find the workflow and compiled task for each task
+	workflows := map[*model.TaskItem]string{}
+	planTasks := map[*model.TaskItem]*zigflow.PlanTask{}
+	_ = zigflow.Walk(wf, func(item *model.TaskItem, workflowName string) error {
+		workflows[item] = workflowName
+		for _, w := range plan.Workflows {
+			if w.Name != workflowName {
+				continue
+			}
+			for _, t := range w.Tasks {
+				if t.Name == item.Key {
+					planTasks[item] = t
+					break
+				}
+			}
+		}
+		return nil
+	})
+
+	for _, n := range m.Nodes {
+		if n.Kind != NodeTask || n.item == nil {
+			continue
+		}
+
+		task, err := htmlTaskFor(m, n, workflows[n.item], planTasks[n.item], report.Handlers)
+		if err != nil {
+			return "", err
+		}
+		report.Tasks = append(report.Tasks, task)
+	}
+
+	var buf bytes.Buffer
+	if err := htmlReportTemplate.Execute(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func htmlTaskFor(
+	m *Model, n *Node, workflowName string, planTask *zigflow.PlanTask, handlers []*zigflow.Listener,
+) (*htmlTask, error) {
+	base := n.item.GetBase()
+	task := &htmlTask{
+		ID:          n.ID,
+		Label:       n.Label,
+		Workflow:    workflowName,
+		Handlers:    make([]*zigflow.Listener, 0),
+		Transitions: make([]*htmlTransition, 0),
+		Execution:   n.Execution,
+	}
+
+	var err error
+	if task.Definition, err = toYAML(n.item); err != nil {
+		return nil, err
+	}
+	if base.If != nil {
+		task.If = base.If.Value
+	}
+	if base.Input != nil && base.Input.Schema != nil {
+		if task.InputSchema, err = toYAML(base.Input.Schema); err != nil {
+			return nil, err
+		}
+	}
+	if base.Output != nil && base.Output.Schema != nil {
+		if task.OutputSchema, err = toYAML(base.Output.Schema); err != nil {
+			return nil, err
+		}
+	}
+	if planTask != nil && planTask.ActivityOptions != nil {
+		if task.ActivityOptions, err = toYAML(planTask.ActivityOptions); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, h := range handlers {
+		if h.Task == n.Task && h.Workflow == workflowName {
+			task.Handlers = append(task.Handlers, h)
+		}
+	}
+
+	nodes := map[string]*Node{}
+	for _, node := range m.Nodes {
+		nodes[node.ID] = node
+	}
+	groups := map[string]*Group{}
+	for _, group := range m.Groups {
+		groups[group.ID] = group
+	}
+	for _, e := range m.Edges {
+		if e.From != n.ID || e.Label == "" {
+			continue
+		}
+		task.Transitions = append(task.Transitions, &htmlTransition{
+			Label: e.Label,
+			To:    htmlTarget(nodes[e.To], groups),
+		})
+	}
+
+	return task, nil
+}
+
+// htmlTarget describes where a transition goes.
+func htmlTarget(n *Node, groups map[string]*Group) string {
+	switch n.Kind {
+	case NodeStart:
+		if g, ok := groups[n.Group]; ok {
+			return "start of " + g.Label
+		}
+		return "start"
+	case NodeEnd:
+		return "end"
+	case NodeTask:
+		return n.Label
+	default:
+		if g, ok := groups[n.Group]; ok {
+			return g.Label
+		}
+		return n.ID
+	}
+}
+
+// htmlElements returns the nodes and groups directly inside group, in the
+// order they were declared. Anchors are only needed to draw edges, so are
+// left out, and adjacent fork branches are put side by side.
+func (m *Model) htmlElements(group string) []*htmlElement {
+	type entry struct {
+		order int
+		node  *Node
+		group *Group
+	}
+
+	entries := make([]entry, 0)
+	for _, n := range m.nodes(group) {
+		switch n.Kind {
+		case NodeAnchor:
+		case NodeEnd:
+			// End nodes are added with the start node, before the tasks
+			entries = append(entries, entry{order: math.MaxInt, node: n})
+		default:
+			entries = append(entries, entry{order: n.order, node: n})
+		}
+	}
+	for _, g := range m.groups(group) {
+		entries = append(entries, entry{order: g.order, group: g})
+	}
+	slices.SortFunc(entries, func(a, b entry) int {
+		return cmp.Compare(a.order, b.order)
+	})
+
+	elements := make([]*htmlElement, 0, len(entries))
+	for _, e := range entries {
+		if e.node != nil {
+			elements = append(elements, &htmlElement{Node: e.node})
+			continue
+		}
+
+		g := &htmlGroup{Group: e.group, Elements: m.htmlElements(e.group.ID)}
+		if e.group.Kind != GroupBranch {
+			elements = append(elements, &htmlElement{Group: g})
+			continue
+		}
+
+		if last := len(elements) - 1; last >= 0 && elements[last].Branches != nil {
+			elements[last].Branches = append(elements[last].Branches, g)
+		} else {
+			elements = append(elements, &htmlElement{Branches: []*htmlGroup{g}})
+		}
+	}
+
+	// Fork joins are added with the fork, before its branches
+	for i := 0; i < len(elements)-1; i++ {
+		if elements[i].Node != nil && elements[i].Node.Kind == NodeJoin && elements[i+1].Branches != nil {
+			elements[i], elements[i+1] = elements[i+1], elements[i]
+			i++
+		}
+	}
+
+	return elements
+}
+
+func toYAML(v any) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/graph"
+)
+
+func TestHTML(t *testing.T) {
+	fetch := &model.CallHTTP{
+		Call: "http",
+		With: model.HTTPArguments{Method: "get", Endpoint: model.NewEndpoint("https://example.com/api")},
+	}
+	fetch.If = model.NewRuntimeExpression("${ $input.fetch }")
+	fetch.Input = &model.Input{
+		Schema: &model.Schema{Format: "json", Document: map[string]any{"type": "object"}},
+	}
+
+	branches := model.TaskList([]*model.TaskItem{
+		taskItem("left", waitTaskSeconds(1)),
+		taskItem("right", waitTaskSeconds(2)),
+	})
+
+	wf := makeWF("mywf",
+		taskItem("fetch", fetch),
+		taskItem("check", &model.SwitchTask{
+			Switch: []model.SwitchItem{
+				{"done": model.SwitchCase{
+					When: model.NewRuntimeExpression("${ .done }"),
+					Then: &model.FlowDirective{Value: "end"},
+				}},
+			},
+		}),
+		taskItem("both", &model.ForkTask{Fork: model.ForkTaskConfiguration{Branches: &branches}}),
+	)
+	wf.Document.Title = "My workflow"
+
+	gen, err := graph.New(graph.FormatHTML)
+	require.NoError(t, err)
+
+	out, err := gen.Generate(wf)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>"))
+	assert.NotContains(t, out, "<script")
+	assert.Contains(t, out, "<h1>My workflow</h1>")
+	assert.Contains(t, out, `<a class="node kind-task shape-rect" href="#task-mywf_fetch">CALL_HTTP (fetch) [?]</a>`)
+	assert.Contains(t, out, `<section class="panel" id="task-mywf_fetch">`)
+	assert.Contains(t, out, "<dt>Runs if</dt><dd><code>${ $input.fetch }</code></dd>")
+	assert.Contains(t, out, "<h3>Input schema</h3>\n<pre>document:\n  type: object\nformat: json</pre>")
+	assert.Contains(t, out, "<h3>Activity options</h3>\n<pre>retryPolicy:")
+	assert.Contains(t, out, "<td><code>${ .done }</code></td><td>&rarr; end</td>")
+
+	// The fork is followed by its branches side by side, then the join
+	fork := strings.Index(out, "FORK (both)")
+	branchesAt := strings.Index(out, `<div class="branches">`)
+	join := strings.Index(out, `<span class="node kind-join">`)
+	end := strings.Index(out, `<span class="node kind-end">`)
+	assert.True(t, fork < branchesAt && branchesAt < join && join < end, "fork, branches, join and end out of order")
+}
+
+func TestHTML_Overlay(t *testing.T) {
+	pause := taskItem("pause", waitTaskSeconds(1))
+	wf := makeWF("mywf", pause)
+
+	gen, err := graph.New(graph.FormatHTML, graph.WithOverlay(graph.Overlay{
+		pause: {Status: graph.StatusFailed, Attempts: 2},
+	}))
+	require.NoError(t, err)
+
+	out, err := gen.Generate(wf)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, ".status-failed { background: #f8d7da; border-color: #dc3545; }")
+	assert.Contains(t, out, `<a class="node kind-task shape-rect status-failed" href="#task-mywf_pause">WAIT (pause)<small>2 attempts</small></a>`)
+	assert.Contains(t, out, "<dt>Status</dt><dd>failed</dd>")
+}
@@ -55,11 +55,17 @@ const (
 //   - found: false when no DefaultStartMarkerPrefix exists in src, meaning the
 //     caller should skip the file without error
 func ParseEmbeddedPath(src, defaultPath string) (workflowPath, fullStartMarker string, found bool) {
-	prefixIdx := strings.Index(src, DefaultStartMarkerPrefix)
+	return ParseEmbeddedPathWithPrefix(src, DefaultStartMarkerPrefix, defaultPath)
+}
+
+// ParseEmbeddedPathWithPrefix is ParseEmbeddedPath for a start marker with a
+// different prefix, such as the one used to inject workflow docs.
+func ParseEmbeddedPathWithPrefix(src, prefix, defaultPath string) (workflowPath, fullStartMarker string, found bool) {
+	prefixIdx := strings.Index(src, prefix)
 	if prefixIdx < 0 {
 		return "", "", false
 	}
-	afterPrefix := prefixIdx + len(DefaultStartMarkerPrefix)
+	afterPrefix := prefixIdx + len(prefix)
 
 	// Find the closing "-->" of this HTML comment.
 	closeIdx := strings.Index(src[afterPrefix:], "-->")
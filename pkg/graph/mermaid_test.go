@@ -119,6 +119,18 @@ func TestMermaid_HTTPLabel(t *testing.T) {
 	assert.Contains(t, out, "CALL_HTTP (fetch)")
 }
 
+func TestMermaid_CallFunctionLabel(t *testing.T) {
+	gen, _ := graph.New(graph.FormatMermaid)
+	wf := makeWF("mywf",
+		taskItem("greet", &model.CallFunction{Call: "activity", With: map[string]any{"name": "greet"}}),
+		taskItem("weather", &model.CallFunction{Call: "getWeather", With: map[string]any{"city": "London"}}),
+	)
+	out, err := gen.Generate(wf)
+	require.NoError(t, err)
+	assert.Contains(t, out, "CALL_ACTIVITY (greet)")
+	assert.Contains(t, out, "CALL_FUNCTION (weather)")
+}
+
 func TestMermaid_SwitchNode(t *testing.T) {
 	gen, _ := graph.New(graph.FormatMermaid)
 	then := &model.FlowDirective{Value: "end"}
@@ -357,3 +369,19 @@ func TestMermaid_LabelTruncation(t *testing.T) {
 	assert.NotContains(t, out, longURL)
 	assert.Contains(t, out, "CALL_HTTP (fetch)")
 }
+
+func TestMermaid_DrawsTheModel(t *testing.T) {
+	gen, _ := graph.New(graph.FormatMermaid)
+	wf := tryWF()
+	out, err := gen.Generate(wf)
+	require.NoError(t, err)
+
+	m := graph.Build(wf)
+	for _, n := range m.Nodes {
+		assert.Contains(t, out, n.ID)
+	}
+	for _, g := range m.Groups {
+		assert.Contains(t, out, "subgraph "+g.ID+"[")
+	}
+	assert.Contains(t, out, "mywf_safe_try__end -.->|\"on error\"| mywf_safe_catch__start")
+}
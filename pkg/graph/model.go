@@ -0,0 +1,592 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// NodeKind identifies the role a node plays in the graph.
+type NodeKind string
+
+const (
+	// NodeStart is the start of a workflow.
+	NodeStart NodeKind = "start"
+	// NodeEnd is the end of a workflow.
+	NodeEnd NodeKind = "end"
+	// NodeTask is a task's entry node.
+	NodeTask NodeKind = "task"
+	// NodeJoin is where the branches of a fork reconverge.
+	NodeJoin NodeKind = "join"
+	// NodeAnchor is an unlabelled entry or exit point of a fork branch, loop
+	// body or try/catch block.
+	NodeAnchor NodeKind = "anchor"
+)
+
+// GroupKind identifies what a group of nodes represents.
+type GroupKind string
+
+const (
+	// GroupWorkflow is a Temporal workflow defined in the file.
+	GroupWorkflow GroupKind = "workflow"
+	// GroupBranch is a fork branch.
+	GroupBranch GroupKind = "branch"
+	// GroupLoop is the body of a for task.
+	GroupLoop GroupKind = "loop"
+	// GroupTry is the try block of a try task.
+	GroupTry GroupKind = "try"
+	// GroupCatch is the catch block of a try task.
+	GroupCatch GroupKind = "catch"
+)
+
+// EdgeStyle describes how an edge is drawn.
+type EdgeStyle string
+
+const (
+	// EdgeSolid is the normal control flow.
+	EdgeSolid EdgeStyle = "solid"
+	// EdgeDashed is the error path from a try block to its catch block.
+	EdgeDashed EdgeStyle = "dashed"
+)
+
+// Model is a renderer-neutral description of a workflow graph. It is what
+// every renderer draws.
+type Model struct {
+	Name   string   `json:"name"`
+	Nodes  []*Node  `json:"nodes"`
+	Edges  []*Edge  `json:"edges"`
+	Groups []*Group `json:"groups"`
+}
+
+// Node is a single node in the graph.
+type Node struct {
+	ID    string   `json:"id"`
+	Kind  NodeKind `json:"kind"`
+	Label string   `json:"label,omitempty"`
+	// Task is the task key, for task nodes
+	Task string `json:"task,omitempty"`
+	// Type is the NodeInfo type name, for task nodes
+	Type  string `json:"type,omitempty"`
+	Shape Shape  `json:"shape"`
+	// Group is the ID of the innermost group containing the node
+	Group string `json:"group,omitempty"`
+	// Execution is set for task nodes when there is an overlay
+	Execution *Execution `json:"execution,omitempty"`
+
+	item  *model.TaskItem
+	order int
+}
+
+// Edge connects two nodes.
+type Edge struct {
+	From  string    `json:"from"`
+	To    string    `json:"to"`
+	Label string    `json:"label,omitempty"`
+	Style EdgeStyle `json:"style"`
+}
+
+// Group is a labelled set of nodes, such as a workflow or a loop body.
+type Group struct {
+	ID    string    `json:"id"`
+	Kind  GroupKind `json:"kind"`
+	Label string    `json:"label"`
+	// Parent is the ID of the group containing this one
+	Parent string `json:"parent,omitempty"`
+
+	order int
+}
+
+// String returns the shape's name.
+func (s Shape) String() string {
+	switch s {
+	case ShapeDiamond:
+		return "diamond"
+	case ShapeSubroutine:
+		return "subroutine"
+	default:
+		return "rect"
+	}
+}
+
+// MarshalText renders the shape by name in the JSON model.
+func (s Shape) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Build returns the graph model for a workflow.
+func Build(wf *model.Workflow, opts ...Option) *Model {
+	b := &modelBuilder{
+		overlay: newOptions(opts).overlay,
+		m: &Model{
+			Name:   wf.Document.Name,
+			Nodes:  make([]*Node, 0),
+			Edges:  make([]*Edge, 0),
+			Groups: make([]*Group, 0),
+		},
+		nodes:    make(map[string]*Node),
+		seenKeys: make(map[string]int),
+	}
+	b.generate(wf)
+	b.prune()
+	return b.m
+}
+
+// modelBuilder follows the topology the Zigflow runtime uses: the tasks of
+// each Temporal workflow in order, with switch, fork, for and try tasks
+// branching from it.
+type modelBuilder struct {
+	overlay  Overlay
+	m        *Model
+	nodes    map[string]*Node
+	seenKeys map[string]int
+}
+
+func (b *modelBuilder) nodeID(ctx, key string) string {
+	full := ctx + ":" + key
+	count := b.seenKeys[full]
+	b.seenKeys[full] = count + 1
+	base := sanitizeID(ctx) + "_" + sanitizeID(key)
+	if count > 0 {
+		return fmt.Sprintf("%s_%d", base, count+1)
+	}
+	return base
+}
+
+func (b *modelBuilder) node(n *Node) string {
+	n.order = len(b.m.Nodes) + len(b.m.Groups)
+	b.m.Nodes = append(b.m.Nodes, n)
+	b.nodes[n.ID] = n
+	return n.ID
+}
+
+func (b *modelBuilder) edge(from, to, label string) {
+	b.m.Edges = append(b.m.Edges, &Edge{From: from, To: to, Label: label, Style: EdgeSolid})
+}
+
+func (b *modelBuilder) group(id string, kind GroupKind, label, parent string) string {
+	b.m.Groups = append(b.m.Groups, &Group{
+		ID: id, Kind: kind, Label: label, Parent: parent,
+		order: len(b.m.Nodes) + len(b.m.Groups),
+	})
+	return id
+}
+
+// groups returns the groups directly inside parent, in declaration order.
+func (m *Model) groups(parent string) []*Group {
+	groups := make([]*Group, 0)
+	for _, g := range m.Groups {
+		if g.Parent == parent {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// nodes returns the nodes directly inside group, in declaration order.
+func (m *Model) nodes(group string) []*Node {
+	nodes := make([]*Node, 0)
+	for _, n := range m.Nodes {
+		if n.Group == group {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// prune removes edges to nodes that do not exist, such as a switch case that
+// targets a missing task.
+func (b *modelBuilder) prune() {
+	edges := make([]*Edge, 0, len(b.m.Edges))
+	for _, e := range b.m.Edges {
+		if b.nodes[e.From] != nil && b.nodes[e.To] != nil {
+			edges = append(edges, e)
+		}
+	}
+	b.m.Edges = edges
+}
+
+func (b *modelBuilder) generate(wf *model.Workflow) {
+	if wf.Do == nil || len(*wf.Do) == 0 {
+		return
+	}
+
+	var crossEdges []*Edge
+	if isAllDoTasks(wf.Do) {
+		for _, item := range *wf.Do {
+			if do := item.AsDoTask(); do != nil {
+				b.workflowFlow(item.Key, do.Do, nil, &crossEdges)
+			}
+		}
+	} else {
+		mainTasks, subWFItems := splitTasks(wf.Do)
+		swSet := makeSubWFSet(subWFItems)
+
+		if len(subWFItems) > 0 {
+			b.workflowFlow(wf.Document.Name, sliceToTaskList(mainTasks), swSet, &crossEdges)
+			for _, item := range subWFItems {
+				if do := item.AsDoTask(); do != nil {
+					b.workflowFlow(item.Key, do.Do, nil, &crossEdges)
+				}
+			}
+		} else {
+			startID := b.node(&Node{ID: sanitizeID(wf.Document.Name) + "__start", Kind: NodeStart, Label: "Start"})
+			endID := b.node(&Node{ID: sanitizeID(wf.Document.Name) + "__end", Kind: NodeEnd, Label: "End"})
+			b.sequence(wf.Document.Name, "", mainTasks, nil, startID, endID, &crossEdges)
+		}
+	}
+
+	b.m.Edges = append(b.m.Edges, crossEdges...)
+}
+
+// workflowFlow renders a Temporal workflow as its own group.
+func (b *modelBuilder) workflowFlow(ctx string, tasks *model.TaskList, swNames map[string]bool, crossEdges *[]*Edge) {
+	groupID := b.group("wf_"+sanitizeID(ctx), GroupWorkflow, ctx, "")
+	startID := b.node(&Node{ID: subWFStartID(ctx), Kind: NodeStart, Label: "Start", Group: groupID})
+	endID := b.node(&Node{ID: subWFEndID(ctx), Kind: NodeEnd, Label: "End", Group: groupID})
+
+	var items []*model.TaskItem
+	if tasks != nil {
+		items = *tasks
+	}
+	b.sequence(ctx, groupID, items, swNames, startID, endID, crossEdges)
+}
+
+// sequence chains tasks between a start and end node.
+func (b *modelBuilder) sequence(
+	ctx, group string, tasks []*model.TaskItem, swNames map[string]bool,
+	startID, endID string, crossEdges *[]*Edge,
+) {
+	prevExitID := startID
+	for _, item := range tasks {
+		r := b.task(ctx, group, item, swNames, endID, crossEdges)
+		b.edge(prevExitID, r.entryID, "")
+		prevExitID = r.exitID
+	}
+	if endID != "" {
+		b.edge(prevExitID, endID, "")
+	}
+}
+
+func (b *modelBuilder) task(
+	ctx, group string, item *model.TaskItem,
+	swNames map[string]bool, ctxEndID string, crossEdges *[]*Edge,
+) nodeResult {
+	switch {
+	case item.AsDoTask() != nil:
+		return b.doInline(ctx, group, item, swNames, ctxEndID, crossEdges)
+	case item.AsTryTask() != nil:
+		return b.try(ctx, group, item, crossEdges)
+	case item.AsSwitchTask() != nil:
+		return b.switchTask(ctx, group, item, swNames, ctxEndID, crossEdges)
+	case item.AsForkTask() != nil:
+		return b.fork(ctx, group, item, crossEdges)
+	case item.AsForTask() != nil:
+		return b.forTask(ctx, group, item, crossEdges)
+	default:
+		cond := ""
+		if item.GetBase().If != nil {
+			cond = " [?]"
+		}
+		id := b.taskNode(ctx, group, item, cond)
+		return nodeResult{id, id}
+	}
+}
+
+// taskNode adds a task's entry node, labelled as in the Mermaid output.
+func (b *modelBuilder) taskNode(ctx, group string, item *model.TaskItem, cond string) string {
+	n := &Node{
+		ID:    b.nodeID(ctx, item.Key),
+		Kind:  NodeTask,
+		Label: item.Key + cond,
+		Task:  item.Key,
+		Group: group,
+		item:  item,
+	}
+	if b.overlay != nil {
+		n.Execution = b.overlay[item]
+	}
+	if info, ok := nodeInfoFrom(item); ok {
+		n.Label = info.TypeName + " (" + item.Key + ")" + cond
+		n.Type = info.TypeName
+		n.Shape = info.Shape
+	}
+	return b.node(n)
+}
+
+func (b *modelBuilder) anchor(id, group string) string {
+	return b.node(&Node{ID: id, Kind: NodeAnchor, Group: group})
+}
+
+func (b *modelBuilder) doInline(
+	ctx, group string, item *model.TaskItem,
+	swNames map[string]bool, ctxEndID string, crossEdges *[]*Edge,
+) nodeResult {
+	do := item.AsDoTask()
+	if do.Do == nil || len(*do.Do) == 0 {
+		id := b.taskNode(ctx, group, item, "")
+		return nodeResult{id, id}
+	}
+
+	var entryID, prevExitID string
+	for _, child := range *do.Do {
+		r := b.task(ctx, group, child, swNames, ctxEndID, crossEdges)
+		if entryID == "" {
+			entryID = r.entryID
+		}
+		if prevExitID != "" {
+			b.edge(prevExitID, r.entryID, "")
+		}
+		prevExitID = r.exitID
+	}
+	return nodeResult{entryID, prevExitID}
+}
+
+func (b *modelBuilder) switchTask(
+	ctx, group string, item *model.TaskItem,
+	swNames map[string]bool, ctxEndID string, crossEdges *[]*Edge,
+) nodeResult {
+	task := item.AsSwitchTask()
+	id := b.taskNode(ctx, group, item, "")
+
+	for _, switchItem := range task.Switch {
+		for _, switchCase := range switchItem {
+			if switchCase.Then == nil {
+				continue
+			}
+			then := switchCase.Then
+
+			label := "default"
+			if switchCase.When != nil {
+				label = switchCase.When.Value
+			}
+
+			switch {
+			case then.IsTermination():
+				b.edge(id, ctxEndID, label)
+			case !then.IsEnum():
+				if swNames != nil && swNames[then.Value] {
+					*crossEdges = append(*crossEdges, &Edge{
+						From: id, To: subWFStartID(then.Value), Label: label, Style: EdgeSolid,
+					})
+				} else {
+					b.edge(id, sanitizeID(ctx)+"_"+sanitizeID(then.Value), label)
+				}
+			}
+		}
+	}
+	return nodeResult{id, id}
+}
+
+func (b *modelBuilder) fork(ctx, group string, item *model.TaskItem, crossEdges *[]*Edge) nodeResult {
+	task := item.AsForkTask()
+	cond := ""
+	if task.Fork.Compete {
+		cond = " 🏁"
+	}
+	id := b.taskNode(ctx, group, item, cond)
+	joinID := b.node(&Node{ID: sanitizeID(ctx) + "_" + sanitizeID(item.Key) + "__join", Kind: NodeJoin, Group: group})
+
+	if task.Fork.Branches != nil {
+		for _, branch := range *task.Fork.Branches {
+			branchCtx := ctx + "_" + branch.Key
+			safeCtx := sanitizeID(branchCtx)
+			branchGroup := b.group("fork_"+safeCtx, GroupBranch, branch.Key, group)
+
+			startID := b.anchor(safeCtx+"__start", branchGroup)
+			endID := b.anchor(safeCtx+"__end", branchGroup)
+
+			var children []*model.TaskItem
+			if do := branch.AsDoTask(); do != nil && do.Do != nil {
+				children = *do.Do
+			}
+			b.sequence(branchCtx, branchGroup, children, nil, startID, endID, crossEdges)
+
+			b.edge(id, startID, "")
+			b.edge(endID, joinID, "")
+		}
+	}
+
+	return nodeResult{id, joinID}
+}
+
+func (b *modelBuilder) forTask(ctx, group string, item *model.TaskItem, crossEdges *[]*Edge) nodeResult {
+	task := item.AsForTask()
+	id := b.taskNode(ctx, group, item, "")
+
+	if task.Do != nil && len(*task.Do) > 0 {
+		bodyCtx := ctx + "_" + item.Key + "_body"
+		bodyGroup := b.group("body_"+sanitizeID(bodyCtx), GroupLoop, item.Key+" (loop body)", group)
+		startID := b.anchor(sanitizeID(bodyCtx)+"__start", bodyGroup)
+
+		prevID := startID
+		for _, child := range *task.Do {
+			r := b.task(bodyCtx, bodyGroup, child, nil, "", crossEdges)
+			b.edge(prevID, r.entryID, "")
+			prevID = r.exitID
+		}
+
+		b.edge(id, startID, "")
+		b.edge(prevID, id, "next iteration")
+	}
+
+	return nodeResult{id, id}
+}
+
+func (b *modelBuilder) try(ctx, group string, item *model.TaskItem, crossEdges *[]*Edge) nodeResult {
+	task := item.AsTryTask()
+
+	tryCtx := ctx + "_" + item.Key + "_try"
+	tryGroup := b.group("try_"+sanitizeID(tryCtx), GroupTry, "TRY ("+item.Key+")", group)
+	tryStartID := b.anchor(sanitizeID(tryCtx)+"__start", tryGroup)
+	tryEndID := b.anchor(sanitizeID(tryCtx)+"__end", tryGroup)
+
+	var children []*model.TaskItem
+	if task.Try != nil {
+		children = *task.Try
+	}
+	b.sequence(tryCtx, tryGroup, children, nil, tryStartID, tryEndID, crossEdges)
+
+	if task.Catch != nil && task.Catch.Do != nil && len(*task.Catch.Do) > 0 {
+		catchCtx := ctx + "_" + item.Key + "_catch"
+		catchGroup := b.group("catch_"+sanitizeID(catchCtx), GroupCatch, "CATCH ("+item.Key+")", group)
+		catchStartID := b.anchor(sanitizeID(catchCtx)+"__start", catchGroup)
+		catchEndID := b.anchor(sanitizeID(catchCtx)+"__end", catchGroup)
+
+		b.sequence(catchCtx, catchGroup, *task.Catch.Do, nil, catchStartID, catchEndID, crossEdges)
+
+		b.m.Edges = append(b.m.Edges, &Edge{From: tryEndID, To: catchStartID, Label: "on error", Style: EdgeDashed})
+	}
+
+	return nodeResult{tryStartID, tryEndID}
+}
+
+// nodeResult holds the entry and exit node IDs for a rendered task.
+// For most tasks entry == exit. Tasks with split control flow (Fork, Try)
+// expose different entry and exit nodes for sequential chaining.
+type nodeResult struct {
+	entryID string
+	exitID  string
+}
+
+// subWFStartID returns the deterministic start-node ID for a named sub-workflow.
+// Must be consistent whether called from the main flow (to build cross-edges)
+// or from the sub-workflow's own subgraph render.
+func subWFStartID(name string) string {
+	return "wf_" + sanitizeID(name) + "__start"
+}
+
+func subWFEndID(name string) string {
+	return "wf_" + sanitizeID(name) + "__end"
+}
+
+var nonAlphanumRe = regexp.MustCompile(`[^a-zA-Z0-9]`)
+
+func sanitizeID(s string) string {
+	result := nonAlphanumRe.ReplaceAllString(s, "_")
+	if result == "" {
+		return "n"
+	}
+	return result
+}
+
+// escapeLabel sanitises a string for use inside a Mermaid quoted node label.
+// Double-quotes are replaced with single-quotes; long strings are truncated.
+func escapeLabel(s string) string {
+	s = strings.ReplaceAll(s, `"`, `'`)
+	if len(s) > 50 {
+		s = s[:47] + "..."
+	}
+	return s
+}
+
+// isAllDoTasks reports whether every item in the list is a DoTask.
+func isAllDoTasks(tasks *model.TaskList) bool {
+	if tasks == nil {
+		return false
+	}
+	for _, item := range *tasks {
+		if item.AsDoTask() == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTasks separates a task list into main-flow tasks and sub-workflow tasks,
+// replicating the logic from task_builder_do.go:
+//
+//   - Non-DoTask items → always part of the main flow (sets hasNoDo = true)
+//   - DoTask before any non-DoTask → part of the main flow (inline)
+//   - DoTask after a non-DoTask → sub-workflow (registered separately by the runtime)
+func splitTasks(tasks *model.TaskList) (main, subWFs []*model.TaskItem) {
+	var hasNoDo bool
+	for _, item := range *tasks {
+		if item.AsDoTask() == nil {
+			hasNoDo = true
+			main = append(main, item)
+		} else if hasNoDo {
+			subWFs = append(subWFs, item)
+		} else {
+			main = append(main, item)
+		}
+	}
+	return
+}
+
+// makeSubWFSet builds a name→bool lookup from a slice of sub-workflow items.
+func makeSubWFSet(items []*model.TaskItem) map[string]bool {
+	m := make(map[string]bool, len(items))
+	for _, item := range items {
+		m[item.Key] = true
+	}
+	return m
+}
+
+// sliceToTaskList wraps a []*TaskItem into a *model.TaskList.
+func sliceToTaskList(items []*model.TaskItem) *model.TaskList {
+	tl := model.TaskList(make([]*model.TaskItem, len(items)))
+	copy(tl, items)
+	return &tl
+}
+
+// nodeText returns a task node's label, with the attempts and duration from
+// the overlay on a second line.
+func nodeText(n *Node, newline string) string {
+	label := escapeLabel(n.Label)
+	if a := n.Execution.Annotation(); a != "" {
+		label += newline + a
+	}
+	return label
+}
+
+// lineWriter collects indented lines of output for the text renderers.
+type lineWriter struct {
+	lines []string
+}
+
+func (w *lineWriter) linef(indent int, format string, args ...any) {
+	prefix := strings.Repeat("    ", indent)
+	w.lines = append(w.lines, prefix+fmt.Sprintf(format, args...))
+}
+
+func (w *lineWriter) build() string {
+	return strings.Join(w.lines, "\n") + "\n"
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/graph"
+)
+
+// tryWF is a workflow with a loop inside a try/catch, used by the renderer tests.
+func tryWF() *model.Workflow {
+	body := model.TaskList([]*model.TaskItem{
+		taskItem("step", setTask(map[string]interface{}{"i": 0})),
+	})
+	tryTasks := model.TaskList([]*model.TaskItem{
+		taskItem("loop", &model.ForTask{
+			For: model.ForTaskConfiguration{Each: "item", In: "${ $input.list }"},
+			Do:  &body,
+		}),
+	})
+	catchTasks := model.TaskList([]*model.TaskItem{
+		taskItem("recover", setTask(map[string]interface{}{"err": "caught"})),
+	})
+	return makeWF("mywf",
+		taskItem("safe", &model.TryTask{
+			Try:   &tryTasks,
+			Catch: &model.TryTaskCatch{Do: &catchTasks},
+		}),
+	)
+}
+
+func findNode(m *graph.Model, id string) *graph.Node {
+	for _, n := range m.Nodes {
+		if n.ID == id {
+			return n
+		}
+	}
+	return nil
+}
+
+func hasEdge(m *graph.Model, from, to string) bool {
+	for _, e := range m.Edges {
+		if e.From == from && e.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBuild_SingleWorkflow(t *testing.T) {
+	m := graph.Build(makeWF("mywf",
+		taskItem("step1", setTask(map[string]interface{}{"key": "val"})),
+		taskItem("step2", waitTaskSeconds(5)),
+	))
+
+	assert.Equal(t, "mywf", m.Name)
+	assert.Empty(t, m.Groups)
+	require.Len(t, m.Nodes, 4)
+
+	step := findNode(m, "mywf_step1")
+	require.NotNil(t, step)
+	assert.Equal(t, graph.NodeTask, step.Kind)
+	assert.Equal(t, "SET", step.Type)
+	assert.Equal(t, "SET (step1)", step.Label)
+
+	assert.True(t, hasEdge(m, "mywf__start", "mywf_step1"))
+	assert.True(t, hasEdge(m, "mywf_step1", "mywf_step2"))
+	assert.True(t, hasEdge(m, "mywf_step2", "mywf__end"))
+}
+
+func TestBuild_Groups(t *testing.T) {
+	m := graph.Build(tryWF())
+
+	require.Len(t, m.Groups, 3)
+	assert.Equal(t, graph.GroupTry, m.Groups[0].Kind)
+	assert.Equal(t, "TRY (safe)", m.Groups[0].Label)
+	assert.Equal(t, graph.GroupLoop, m.Groups[1].Kind)
+	assert.Equal(t, m.Groups[0].ID, m.Groups[1].Parent)
+	assert.Equal(t, graph.GroupCatch, m.Groups[2].Kind)
+
+	loop := findNode(m, "mywf_safe_try_loop")
+	require.NotNil(t, loop)
+	assert.Equal(t, graph.ShapeSubroutine, loop.Shape)
+	assert.Equal(t, m.Groups[0].ID, loop.Group)
+
+	var dashed *graph.Edge
+	for _, e := range m.Edges {
+		if e.Style == graph.EdgeDashed {
+			dashed = e
+		}
+	}
+	require.NotNil(t, dashed)
+	assert.Equal(t, "on error", dashed.Label)
+}
+
+func TestBuild_MultipleWorkflows(t *testing.T) {
+	wf1 := model.TaskList([]*model.TaskItem{taskItem("a", waitTaskSeconds(1))})
+	wf2 := model.TaskList([]*model.TaskItem{taskItem("b", waitTaskSeconds(2))})
+	m := graph.Build(makeWF("multi",
+		taskItem("first", &model.DoTask{Do: &wf1}),
+		taskItem("second", &model.DoTask{Do: &wf2}),
+	))
+
+	require.Len(t, m.Groups, 2)
+	for _, g := range m.Groups {
+		assert.Equal(t, graph.GroupWorkflow, g.Kind)
+	}
+	assert.Equal(t, "wf_first", findNode(m, "first_a").Group)
+}
+
+func TestBuild_MissingSwitchTargetIsDropped(t *testing.T) {
+	m := graph.Build(makeWF("mywf",
+		taskItem("router", &model.SwitchTask{
+			Switch: []model.SwitchItem{
+				{"missing": model.SwitchCase{Then: &model.FlowDirective{Value: "nowhere"}}},
+			},
+		}),
+	))
+
+	for _, e := range m.Edges {
+		assert.NotNil(t, findNode(m, e.To), "edge to %s", e.To)
+	}
+}
+
+func TestJSON_Model(t *testing.T) {
+	gen, err := graph.New(graph.FormatJSON)
+	require.NoError(t, err)
+
+	out, err := gen.Generate(tryWF())
+	require.NoError(t, err)
+
+	var m map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &m))
+	assert.Equal(t, "mywf", m["name"])
+	assert.Contains(t, out, `"shape": "subroutine"`)
+	assert.Contains(t, out, `"kind": "loop"`)
+	assert.Contains(t, out, `"style": "dashed"`)
+}
@@ -48,6 +48,9 @@ func nodeInfoFrom(item *model.TaskItem) (NodeInfo, bool) {
 	case item.AsCallGRPCTask() != nil:
 		return NodeInfo{TypeName: "CALL_GRPC"}, true
 	case item.AsCallFunctionTask() != nil:
+		if item.AsCallFunctionTask().Call != "activity" {
+			return NodeInfo{TypeName: "CALL_FUNCTION"}, true
+		}
 		return NodeInfo{TypeName: "CALL_ACTIVITY"}, true
 	case item.AsWaitTask() != nil:
 		return NodeInfo{TypeName: "WAIT"}, true
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+// Status is what happened to a task in an execution.
+type Status string
+
+const (
+	// StatusCompleted tasks ran successfully.
+	StatusCompleted Status = "completed"
+	// StatusRetried tasks ran successfully after more than one attempt.
+	StatusRetried Status = "retried"
+	// StatusFailed tasks returned an error.
+	StatusFailed Status = "failed"
+	// StatusCancelled tasks were cancelled, such as the losing branches of a
+	// competing fork.
+	StatusCancelled Status = "cancelled"
+	// StatusSkipped tasks were passed over because their if expression was
+	// false.
+	StatusSkipped Status = "skipped"
+	// StatusRunning tasks had not finished when the history was exported.
+	StatusRunning Status = "running"
+)
+
+// statusColours are the fill and stroke colours used for each status.
+var statusColours = map[Status][2]string{
+	StatusCompleted: {"#d4edda", "#28a745"},
+	StatusRetried:   {"#fff3cd", "#e0a800"},
+	StatusFailed:    {"#f8d7da", "#dc3545"},
+	StatusCancelled: {"#ffe5d0", "#fd7e14"},
+	StatusSkipped:   {"#e2e3e5", "#6c757d"},
+	StatusRunning:   {"#cce5ff", "#007bff"},
+}
+
+// statuses lists the statuses in a stable order for the renderers.
+var statuses = []Status{
+	StatusCompleted, StatusRetried, StatusFailed, StatusCancelled, StatusSkipped, StatusRunning,
+}
+
+// Execution is what happened to a task across the histories it appears in.
+type Execution struct {
+	Status Status `json:"status"`
+	// Attempts is the highest attempt number of the task's activity, or 1 if
+	// the task ran without one
+	Attempts int `json:"attempts,omitempty"`
+	// Duration is the workflow time the task took
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// MarshalJSON writes the duration in a readable form, such as "1m30s".
+func (e *Execution) MarshalJSON() ([]byte, error) {
+	type execution Execution
+	v := struct {
+		*execution
+		Duration string `json:"duration,omitempty"`
+	}{execution: (*execution)(e)}
+	if e.Duration > 0 {
+		v.Duration = e.Duration.String()
+	}
+	return json.Marshal(v)
+}
+
+// Annotation summarises the attempts and duration for a node label, such as
+// "3 attempts, 1.5s".
+func (e *Execution) Annotation() string {
+	if e == nil {
+		return ""
+	}
+
+	s := ""
+	if e.Attempts > 1 {
+		s = fmt.Sprintf("%d attempts", e.Attempts)
+	}
+	if e.Duration > 0 {
+		if s != "" {
+			s += ", "
+		}
+		s += e.Duration.String()
+	}
+	return s
+}
+
+// Overlay is the execution state of each task, drawn over the graph.
+type Overlay map[*model.TaskItem]*Execution
+
+// WithOverlay colours each node by what happened to its task in an execution
+// and annotates it with the attempts and duration.
+func WithOverlay(overlay Overlay) Option {
+	return func(o *options) {
+		o.overlay = overlay
+	}
+}
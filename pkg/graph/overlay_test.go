@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/graph"
+)
+
+func TestOverlay(t *testing.T) {
+	initTask := taskItem("init", setTask(map[string]interface{}{"msg": "hi"}))
+	pauseTask := taskItem("pause", waitTaskSeconds(1))
+	wf := makeWF("mywf", initTask, pauseTask)
+
+	overlay := graph.Overlay{
+		initTask:  {Status: graph.StatusRetried, Attempts: 3, Duration: 1500 * time.Millisecond},
+		pauseTask: {Status: graph.StatusSkipped},
+	}
+
+	tests := []struct {
+		Format   graph.Format
+		Expected []string
+	}{
+		{
+			Format: graph.FormatMermaid,
+			Expected: []string{
+				`mywf_init["SET (init)<br/>3 attempts, 1.5s"]`,
+				"classDef retried fill:#fff3cd,stroke:#e0a800",
+				"class mywf_init retried",
+				"classDef skipped fill:#e2e3e5,stroke:#6c757d,stroke-dasharray:5 5",
+				"class mywf_pause skipped",
+			},
+		},
+		{
+			Format: graph.FormatDOT,
+			Expected: []string{
+				`"mywf_init" [label="SET (init)\n3 attempts, 1.5s", style="filled", fillcolor="#fff3cd", color="#e0a800"]`,
+				`"mywf_pause" [label="WAIT (pause)", style="filled,dashed", fillcolor="#e2e3e5", color="#6c757d"]`,
+			},
+		},
+		{
+			Format: graph.FormatPlantUML,
+			Expected: []string{
+				`rectangle "SET (init)\n3 attempts, 1.5s" as mywf_init #fff3cd`,
+				`rectangle "WAIT (pause)" as mywf_pause #e2e3e5;line.dashed`,
+			},
+		},
+		{
+			Format: graph.FormatD2,
+			Expected: []string{
+				`mywf_init: "SET (init)\n3 attempts, 1.5s" {shape: rectangle; style.fill: "#fff3cd"; style.stroke: "#e0a800"}`,
+				`mywf_pause: "WAIT (pause)" {shape: rectangle; style.fill: "#e2e3e5"; style.stroke: "#6c757d"; style.stroke-dash: 3}`,
+			},
+		},
+		{
+			Format: graph.FormatJSON,
+			Expected: []string{
+				`"execution": {
+        "status": "retried",
+        "attempts": 3,
+        "duration": "1.5s"
+      }`,
+				`"execution": {
+        "status": "skipped"
+      }`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.Format), func(t *testing.T) {
+			gen, err := graph.New(test.Format, graph.WithOverlay(overlay))
+			require.NoError(t, err)
+
+			out, err := gen.Generate(wf)
+			require.NoError(t, err)
+
+			for _, e := range test.Expected {
+				assert.Contains(t, out, e)
+			}
+
+			plain, err := graph.New(test.Format)
+			require.NoError(t, err)
+
+			out, err = plain.Generate(wf)
+			require.NoError(t, err)
+			assert.NotContains(t, out, "#fff3cd")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+type plantUMLGenerator struct {
+	opts *options
+}
+
+func (g *plantUMLGenerator) Generate(wf *model.Workflow) (string, error) {
+	m := Build(wf, WithOverlay(g.opts.overlay))
+	w := &lineWriter{}
+
+	w.linef(0, "@startuml")
+	w.linef(0, "top to bottom direction")
+	plantUMLGroup(w, m, 0, "")
+	for _, e := range m.Edges {
+		arrow := "-->"
+		if e.Style == EdgeDashed {
+			arrow = "..>"
+		}
+		if e.Label != "" {
+			w.linef(0, "%s %s %s : %s", e.From, arrow, e.To, escapeLabel(e.Label))
+		} else {
+			w.linef(0, "%s %s %s", e.From, arrow, e.To)
+		}
+	}
+	w.linef(0, "@enduml")
+
+	return w.build(), nil
+}
+
+// plantUMLGroup renders the nodes in a group, followed by its child groups as
+// nested rectangles. The empty group is the top level of the diagram.
+func plantUMLGroup(w *lineWriter, m *Model, indent int, group string) {
+	for _, n := range m.nodes(group) {
+		w.linef(indent, `%s "%s" as %s%s`, plantUMLElement(n), plantUMLLabel(n), n.ID, plantUMLColour(n))
+	}
+	for _, g := range m.groups(group) {
+		w.linef(indent, `rectangle "%s" as %s {`, escapeLabel(g.Label), g.ID)
+		plantUMLGroup(w, m, indent+1, g.ID)
+		w.linef(indent, "}")
+	}
+}
+
+// plantUMLElement returns the deployment diagram element used for a node.
+func plantUMLElement(n *Node) string {
+	switch n.Kind {
+	case NodeStart, NodeEnd:
+		return "usecase"
+	case NodeAnchor, NodeJoin:
+		return "circle"
+	}
+
+	switch n.Shape {
+	case ShapeDiamond:
+		return "hexagon"
+	case ShapeSubroutine:
+		return "collections"
+	default:
+		return "rectangle"
+	}
+}
+
+func plantUMLLabel(n *Node) string {
+	if n.Label == "" {
+		return " "
+	}
+	return nodeText(n, `\n`)
+}
+
+// plantUMLColour returns the inline style for a node in the overlay.
+func plantUMLColour(n *Node) string {
+	if n.Execution == nil {
+		return ""
+	}
+	colour := " " + statusColours[n.Execution.Status][0]
+	if n.Execution.Status == StatusSkipped {
+		colour += ";line.dashed"
+	}
+	return colour
+}
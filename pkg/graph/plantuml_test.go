@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/graph"
+)
+
+func TestPlantUML(t *testing.T) {
+	gen, err := graph.New(graph.FormatPlantUML)
+	require.NoError(t, err)
+
+	out, err := gen.Generate(tryWF())
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "@startuml\n")
+	assert.Contains(t, out, "@enduml\n")
+	assert.Contains(t, out, `rectangle "TRY (safe)" as try_mywf_safe_try {`)
+	assert.Contains(t, out, `collections "FOR (loop)" as mywf_safe_try_loop`)
+	assert.Contains(t, out, `..> mywf_safe_catch__start : on error`)
+	assert.Contains(t, out, `usecase "Start" as mywf__start`)
+}
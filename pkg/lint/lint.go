@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+)
+
+// Severity is how serious a finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// rank orders the severities from least to most serious
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 2
+	case SeverityWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast returns true if the severity is as serious as the other
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+// ParseSeverity converts a string to a Severity
+func ParseSeverity(s string) (Severity, error) {
+	switch Severity(s) {
+	case SeverityError, SeverityWarning, SeverityInfo:
+		return Severity(s), nil
+	}
+	return "", fmt.Errorf("unknown severity %q", s)
+}
+
+// Finding is a problem found by a rule
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// JSON pointer to the problem in the workflow file
+	Path   string `json:"path"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+// Rule is a single lint check
+type Rule struct {
+	// Stable identifier used in suppressions and reports
+	ID string
+	// Short kebab-case name that may be used in place of the ID
+	Name        string
+	Description string
+	// Default severity of the rule's findings
+	Severity Severity
+	Check    func(c *Context)
+}
+
+var registry = make([]*Rule, 0)
+
+// Register adds rules to the engine. It panics if a rule ID is reused.
+func Register(rules ...*Rule) {
+	for _, r := range rules {
+		if slices.ContainsFunc(registry, func(e *Rule) bool { return e.ID == r.ID }) {
+			panic(fmt.Sprintf("lint rule %s is already registered", r.ID))
+		}
+		registry = append(registry, r)
+	}
+	slices.SortFunc(registry, func(a, b *Rule) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+// Rules returns every registered rule, ordered by ID
+func Rules() []*Rule {
+	return slices.Clone(registry)
+}
+
+// matches returns true if the rule is referred to by the ID or name
+func (r *Rule) matches(ref string) bool {
+	return strings.EqualFold(ref, r.ID) || ref == r.Name
+}
+
+// Document is a workflow file to lint
+type Document struct {
+	File     string
+	Workflow *model.Workflow
+	// Where everything in the workflow is declared
+	Positions *utils.Source
+	source    *source
+}
+
+// Load reads and parses the workflow file
+func Load(file string) (*Document, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("error loading file: %w", err)
+	}
+
+	return Parse(file, data)
+}
+
+// Parse parses the contents of a workflow file
+func Parse(file string, data []byte) (*Document, error) {
+	loaded, err := zigflow.LoadDocumentFromBytes(data)
+	var retryErr *metadata.TaskRetryError
+	if errors.As(err, &retryErr) {
+		// The workflow can't be built, but the rule for task retries reports
+		// where with the rest of the findings
+		loaded, err = zigflow.DecodeDocumentFromBytes(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := parseSource(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		File:      file,
+		Workflow:  loaded.Workflow,
+		Positions: loaded.Source,
+		source:    src,
+	}, nil
+}
+
+// Context is passed to a rule to inspect the document and report findings
+type Context struct {
+	*Document
+	rule     *Rule
+	findings []*Finding
+}
+
+// Report records a finding at the JSON pointer path
+func (c *Context) Report(path, format string, args ...any) {
+	c.findings = append(c.findings, &Finding{
+		Rule:     c.rule.ID,
+		Severity: c.rule.Severity,
+		Message:  fmt.Sprintf(format, args...),
+		Path:     path,
+	})
+}
+
+// Options configures a lint run
+type Options struct {
+	// Rule IDs or names to skip
+	Disable []string
+	// Rule IDs or names to run - every rule is run if empty
+	Only []string
+}
+
+// Lint runs every registered rule against the document. Findings suppressed
+// by comments in the file are removed.
+func Lint(doc *Document, opts Options) []*Finding {
+	findings := make([]*Finding, 0)
+
+	for _, rule := range registry {
+		if slices.ContainsFunc(opts.Disable, rule.matches) {
+			continue
+		}
+		if len(opts.Only) > 0 && !slices.ContainsFunc(opts.Only, rule.matches) {
+			continue
+		}
+
+		c := &Context{
+			Document: doc,
+			rule:     rule,
+		}
+		rule.Check(c)
+
+		for _, f := range c.findings {
+			if doc.source.suppressed(rule, f.Path) {
+				continue
+			}
+			pos := doc.Positions.Position(f.Path)
+			f.Line, f.Column = pos.Line, pos.Column
+			findings = append(findings, f)
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b *Finding) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+
+	return findings
+}
+
+// Failed returns true if any finding is at least as serious as the threshold
+func Failed(findings []*Finding, threshold Severity) bool {
+	return slices.ContainsFunc(findings, func(f *Finding) bool {
+		return f.Severity.AtLeast(threshold)
+	})
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lintWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: example
+  version: 0.0.1
+do:
+  - first:
+      set:
+        id: ${ uuid }
+  - second:
+      wait:
+        seconds: 1
+  - third:
+      set:
+        done: true`
+
+type expectedFinding struct {
+	Rule   string
+	Line   int
+	Column int
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Replace  [][2]string
+		Opts     Options
+		Expected []expectedFinding
+	}{
+		{
+			Name: "clean workflow",
+		},
+		{
+			Name: "missing then target",
+			Replace: [][2]string{
+				{"      wait:", "      then: fourth\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF001", Line: 11, Column: 7}},
+		},
+		{
+			Name: "then moves backwards",
+			Replace: [][2]string{
+				{"      wait:", "      then: first\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF001", Line: 11, Column: 7}},
+		},
+		{
+			Name: "unreachable task",
+			Replace: [][2]string{
+				{"      wait:", "      then: end\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF007", Line: 14, Column: 5}},
+		},
+		{
+			Name: "switch targets a task",
+			Replace: [][2]string{
+				{"      wait:\n        seconds: 1", "      switch:\n        - default:\n            then: third"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF002", Line: 13, Column: 13}},
+		},
+		{
+			Name: "duplicate listen ID",
+			Replace: [][2]string{
+				{"      wait:\n        seconds: 1", `      listen:
+        to:
+          any:
+            - with:
+                id: approve
+                type: signal
+            - with:
+                id: approve
+                type: signal`},
+			},
+			Expected: []expectedFinding{{Rule: "ZF003", Line: 18, Column: 17}},
+		},
+		{
+			Name: "invalid expression",
+			Replace: [][2]string{
+				{"done: true", "done: ${ .foo | }"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF004", Line: 15, Column: 9}},
+		},
+		{
+			Name: "non-deterministic function in an anchored set task",
+			Replace: [][2]string{
+				{"do:\n  - first:\n      set:\n        id: ${ uuid }", ".anchors:\n  first: &first\n    set:\n      id: ${ uuid }\ndo:\n  - first: *first"},
+			},
+		},
+		{
+			Name: "non-deterministic function in an anchored task",
+			Replace: [][2]string{
+				{"do:", ".anchors:\n  second: &second\n    if: ${ timestamp % 2 == 0 }\n    wait:\n      seconds: 1\ndo:"},
+				{"  - second:\n      wait:\n        seconds: 1", "  - second: *second"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF005", Line: 15, Column: 5}},
+		},
+		{
+			Name: "non-deterministic function outside set",
+			Replace: [][2]string{
+				{"      wait:", "      if: ${ timestamp % 2 == 0 }\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF005", Line: 11, Column: 7}},
+		},
+		{
+			Name: "unknown metadata key",
+			Replace: [][2]string{
+				{"      wait:", "      metadata:\n        heartbeet: 10s\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF006", Line: 12, Column: 9}},
+		},
+		{
+			Name: "unknown activity option",
+			Replace: [][2]string{
+				{"  version: 0.0.1", "  version: 0.0.1\n  metadata:\n    activityOptions:\n      startToCloseTimeoutt: 10s"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF006", Line: 8, Column: 7}},
+		},
+		{
+			Name: "secret used in an activity",
+			Replace: [][2]string{
+				{"do:", "use:\n  secrets:\n    - apiKey\ndo:"},
+				{"      wait:\n        seconds: 1", `      call: http
+      with:
+        method: get
+        endpoint: https://example.com
+        headers:
+          x-api-key: ${ $secrets.apiKey }`},
+			},
+		},
+		{
+			Name: "undeclared secret",
+			Replace: [][2]string{
+				{"      wait:\n        seconds: 1", `      call: http
+      with:
+        method: get
+        endpoint: https://example.com
+        headers:
+          x-api-key: ${ $secrets.apiKey }`},
+			},
+			Expected: []expectedFinding{{Rule: "ZF008", Line: 16, Column: 11}},
+		},
+		{
+			Name: "secret accessed without a name",
+			Replace: [][2]string{
+				{"do:", "use:\n  secrets:\n    - apiKey\ndo:"},
+				{"      wait:\n        seconds: 1", `      call: http
+      with:
+        method: get
+        endpoint: https://example.com
+        headers:
+          x-api-key: ${ $secrets | .apiKey }`},
+			},
+			Expected: []expectedFinding{{Rule: "ZF008", Line: 19, Column: 11}},
+		},
+		{
+			Name: "secret used in the workflow",
+			Replace: [][2]string{
+				{"do:", "use:\n  secrets:\n    - apiKey\ndo:"},
+				{"done: true", "done: ${ $secrets.apiKey }"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF009", Line: 18, Column: 9}},
+		},
+		{
+			Name: "task retry with a named policy that uses jitter",
+			Replace: [][2]string{
+				{"do:", "use:\n  retries:\n    flaky:\n      delay:\n        seconds: 1\n      jitter:\n        from:\n          seconds: 1\n        to:\n          seconds: 2\ndo:"},
+				{"      wait:", "      metadata:\n        retry: flaky\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF010", Line: 22, Column: 9}},
+		},
+		{
+			Name: "task retry with an undefined policy",
+			Replace: [][2]string{
+				{"      wait:", "      metadata:\n        retry: missing\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF010", Line: 12, Column: 9}},
+		},
+		{
+			Name: "task retry with a supported policy",
+			Replace: [][2]string{
+				{"do:", "use:\n  retries:\n    standard:\n      delay:\n        seconds: 1\n      backoff:\n        exponential: {}\ndo:"},
+				{"      wait:", "      metadata:\n        retry: standard\n      wait:"},
+			},
+		},
+		{
+			Name: "only the selected rules",
+			Replace: [][2]string{
+				{"      wait:", "      then: fourth\n      metadata:\n        retry: missing\n      wait:"},
+			},
+			Opts:     Options{Only: []string{"unsupported-task-retry"}},
+			Expected: []expectedFinding{{Rule: "ZF010", Line: 13, Column: 9}},
+		},
+		{
+			Name: "suppressed on the task",
+			Replace: [][2]string{
+				{"  - second:", "  # zigflow-lint-disable ZF001\n  - second:"},
+				{"      wait:", "      then: fourth\n      wait:"},
+			},
+		},
+		{
+			Name: "suppressed by name for the file",
+			Replace: [][2]string{
+				{"document:", "# zigflow-lint-disable-file missing-then-target\ndocument:"},
+				{"      wait:", "      then: fourth\n      wait:"},
+			},
+		},
+		{
+			Name: "suppression does not cover other rules",
+			Replace: [][2]string{
+				{"  - second:", "  # zigflow-lint-disable ZF006\n  - second:"},
+				{"      wait:", "      then: fourth\n      wait:"},
+			},
+			Expected: []expectedFinding{{Rule: "ZF001", Line: 12, Column: 7}},
+		},
+		{
+			Name: "disabled rule",
+			Replace: [][2]string{
+				{"      wait:", "      then: fourth\n      wait:"},
+			},
+			Opts: Options{Disable: []string{"zf001"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "lint_test")
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, os.RemoveAll(tmpDir))
+			}()
+
+			content := lintWorkflow
+			for _, r := range test.Replace {
+				content = strings.Replace(content, r[0], r[1], 1)
+			}
+
+			file := filepath.Join(tmpDir, "workflow.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+			doc, err := Load(file)
+			require.NoError(t, err)
+
+			findings := Lint(doc, test.Opts)
+
+			actual := make([]expectedFinding, 0, len(findings))
+			for _, f := range findings {
+				actual = append(actual, expectedFinding{Rule: f.Rule, Line: f.Line, Column: f.Column})
+			}
+			expected := test.Expected
+			if expected == nil {
+				expected = []expectedFinding{}
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestLint_Examples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "examples", "*", "workflow.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(filepath.Dir(file)), func(t *testing.T) {
+			doc, err := Load(file)
+			require.NoError(t, err)
+
+			assert.Empty(t, Lint(doc, Options{}))
+		})
+	}
+}
+
+func TestFailed(t *testing.T) {
+	findings := []*Finding{{Severity: SeverityWarning}}
+
+	assert.False(t, Failed(findings, SeverityError))
+	assert.True(t, Failed(findings, SeverityWarning))
+	assert.True(t, Failed(findings, SeverityInfo))
+	assert.False(t, Failed(nil, SeverityInfo))
+}
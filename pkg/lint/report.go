@@ -0,0 +1,229 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "zigflow-lint"
+	toolURI      = "https://zigflow.dev"
+)
+
+// Result is the outcome of linting a single file
+type Result struct {
+	File     string     `json:"file"`
+	Error    string     `json:"error,omitempty"`
+	Findings []*Finding `json:"findings"`
+}
+
+// RenderHuman writes a readable summary of the results
+func RenderHuman(w io.Writer, results []*Result) error {
+	counts := map[Severity]int{}
+	for _, r := range results {
+		if r.Error != "" {
+			counts[SeverityError]++
+			if _, err := fmt.Fprintf(w, "%s\n  ✗ %s\n", r.File, r.Error); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, f := range r.Findings {
+			counts[f.Severity]++
+
+			symbol := "i"
+			switch f.Severity {
+			case SeverityError:
+				symbol = "✗"
+			case SeverityWarning:
+				symbol = "!"
+			}
+
+			if _, err := fmt.Fprintf(
+				w, "%s:%d:%d: %s %s %s [%s]\n",
+				r.File, f.Line, f.Column, symbol, f.Severity, f.Message, f.Rule,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		w, "\n%d error(s), %d warning(s), %d info\n",
+		counts[SeverityError], counts[SeverityWarning], counts[SeverityInfo],
+	)
+	return err
+}
+
+// RenderJSON writes the results as JSON
+func RenderJSON(w io.Writer, results []*Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version,omitempty"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   sarifMessage     `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevel converts a severity to a SARIF level
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// RenderSARIF writes the results as a SARIF log for code scanning tools
+func RenderSARIF(w io.Writer, results []*Result, version string) error {
+	run := &sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           toolName,
+				Version:        version,
+				InformationURI: toolURI,
+				Rules:          make([]*sarifRule, 0),
+			},
+		},
+		Results: make([]*sarifResult, 0),
+	}
+
+	for _, r := range Rules() {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &sarifRule{
+			ID:               r.ID,
+			Name:             r.Name,
+			ShortDescription: sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifConfiguration{
+				Level: sarifLevel(r.Severity),
+			},
+		})
+	}
+
+	for _, r := range results {
+		uri := filepath.ToSlash(r.File)
+
+		if r.Error != "" {
+			run.Results = append(run.Results, &sarifResult{
+				RuleID:  "load",
+				Level:   sarifLevel(SeverityError),
+				Message: sarifMessage{Text: r.Error},
+				Locations: []*sarifLocation{
+					{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}},
+				},
+			})
+			continue
+		}
+
+		for _, f := range r.Findings {
+			location := &sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: uri},
+				},
+			}
+			if f.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   f.Line,
+					StartColumn: f.Column,
+				}
+			}
+
+			run.Results = append(run.Results, &sarifResult{
+				RuleID:    f.Rule,
+				Level:     sarifLevel(f.Severity),
+				Message:   sarifMessage{Text: f.Message},
+				Locations: []*sarifLocation{location},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []*sarifRun{run},
+	})
+}
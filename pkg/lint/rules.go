@@ -0,0 +1,612 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+)
+
+func init() {
+	Register(
+		&Rule{
+			ID:          "ZF001",
+			Name:        "missing-then-target",
+			Description: "A then directive must name a later task in the same list",
+			Severity:    SeverityError,
+			Check:       checkThenTargets,
+		},
+		&Rule{
+			ID:          "ZF002",
+			Name:        "unknown-switch-workflow",
+			Description: "A switch case must start a workflow defined in the document",
+			Severity:    SeverityError,
+			Check:       checkSwitchWorkflows,
+		},
+		&Rule{
+			ID:          "ZF003",
+			Name:        "duplicate-listen-id",
+			Description: "Signal, query and update IDs must be unique",
+			Severity:    SeverityError,
+			Check:       checkListenIDs,
+		},
+		&Rule{
+			ID:          "ZF004",
+			Name:        "invalid-expression",
+			Description: "Runtime expressions must be valid jq",
+			Severity:    SeverityError,
+			Check:       checkExpressions,
+		},
+		&Rule{
+			ID:          "ZF005",
+			Name:        "non-deterministic-function",
+			Description: "Non-deterministic functions must only be used in a set task",
+			Severity:    SeverityError,
+			Check:       checkNonDeterministicFunctions,
+		},
+		&Rule{
+			ID:          "ZF006",
+			Name:        "unknown-metadata-key",
+			Description: "Metadata keys must be ones that Zigflow reads",
+			Severity:    SeverityWarning,
+			Check:       checkMetadataKeys,
+		},
+		&Rule{
+			ID:          "ZF007",
+			Name:        "unreachable-task",
+			Description: "Every task must be reachable",
+			Severity:    SeverityWarning,
+			Check:       checkUnreachableTasks,
+		},
+		&Rule{
+			ID:          "ZF008",
+			Name:        "undeclared-secret",
+			Description: "Secrets must be declared in use.secrets and accessed by name",
+			Severity:    SeverityError,
+			Check:       checkSecretsDeclared,
+		},
+		&Rule{
+			ID:          "ZF009",
+			Name:        "secret-outside-activity",
+			Description: "Secrets must only be used in fields that are evaluated in an activity",
+			Severity:    SeverityError,
+			Check:       checkSecretsInActivities,
+		},
+		&Rule{
+			ID:          "ZF010",
+			Name:        "unsupported-task-retry",
+			Description: "A task's retry metadata must be a policy Temporal can run",
+			Severity:    SeverityError,
+			Check:       checkTaskRetries,
+		},
+	)
+}
+
+// checkThenTargets finds then directives that fail at the end of the task
+// list. Flow directives only search forward from the current task.
+func checkThenTargets(c *Context) {
+	for _, ref := range taskRefs(c.Workflow) {
+		then := ref.Item.GetBase().Then
+		if ref.Branch || then == nil || then.IsEnum() {
+			continue
+		}
+
+		index := slices.IndexFunc(*ref.List, func(t *model.TaskItem) bool { return t.Key == then.Value })
+		switch {
+		case index < 0:
+			c.Report(ref.Path+"/then", "then target %q does not exist in this task list", then.Value)
+		case index <= ref.Index:
+			c.Report(ref.Path+"/then", "then target %q is not after task %q - flow directives only move forward", then.Value, ref.Item.Key)
+		}
+	}
+}
+
+// checkSwitchWorkflows finds switch cases that start a workflow that is not
+// registered by the document
+func checkSwitchWorkflows(c *Context) {
+	workflows := zigflow.WorkflowTypes(c.Workflow, true)
+
+	for _, ref := range taskRefs(c.Workflow) {
+		task := ref.Item.AsSwitchTask()
+		if task == nil {
+			continue
+		}
+
+		for i, item := range task.Switch {
+			for name, switchCase := range item {
+				then := switchCase.Then
+				if then == nil || then.IsEnum() || slices.Contains(workflows, then.Value) {
+					continue
+				}
+
+				path := fmt.Sprintf("%s/switch/%d/%s/then", ref.Path, i, utils.EscapePointer(name))
+				if slices.ContainsFunc(*ref.List, func(t *model.TaskItem) bool { return t.Key == then.Value }) {
+					c.Report(path, "switch case %q targets task %q - switch cases start a workflow, not a task", name, then.Value)
+					continue
+				}
+				c.Report(path, "switch case %q starts workflow %q which is not defined", name, then.Value)
+			}
+		}
+	}
+}
+
+// checkListenIDs finds IDs declared more than once in a listen task, or
+// declared as different types in the same workflow
+func checkListenIDs(c *Context) {
+	// Keyed by workflow then ID
+	types := map[string]map[string]tasks.ListenTaskType{}
+
+	for _, ref := range taskRefs(c.Workflow) {
+		task := ref.Item.AsListenTask()
+		if task == nil || task.Listen.To == nil {
+			continue
+		}
+		if types[ref.Workflow] == nil {
+			types[ref.Workflow] = map[string]tasks.ListenTaskType{}
+		}
+
+		to := task.Listen.To
+		events := map[string]*model.EventFilter{}
+		for i, e := range to.All {
+			events[fmt.Sprintf("all/%d", i)] = e
+		}
+		for i, e := range to.Any {
+			events[fmt.Sprintf("any/%d", i)] = e
+		}
+		if to.One != nil {
+			events["one"] = to.One
+		}
+
+		keys := make([]string, 0, len(events))
+		for k := range events {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		seen := map[string]bool{}
+		for _, k := range keys {
+			e := events[k]
+			if e == nil || e.With == nil || e.With.ID == "" {
+				continue
+			}
+
+			id := e.With.ID
+			eventType := tasks.ListenTaskType(e.With.Type)
+			path := fmt.Sprintf("%s/listen/to/%s/with/id", ref.Path, k)
+
+			if seen[id] {
+				c.Report(path, "%q is declared more than once in listen task %q", id, ref.Item.Key)
+				continue
+			}
+			seen[id] = true
+
+			if existing, ok := types[ref.Workflow][id]; ok && existing != eventType {
+				c.Report(path, "%q is declared as both a %s and a %s in workflow %q", id, existing, eventType, ref.Workflow)
+				continue
+			}
+			types[ref.Workflow][id] = eventType
+		}
+	}
+}
+
+// checkExpressions finds runtime expressions that gojq cannot parse
+func checkExpressions(c *Context) {
+	for _, s := range c.source.strings {
+		if !model.IsStrictExpr(s.Value) {
+			continue
+		}
+		if _, err := gojq.Parse(model.SanitizeExpr(s.Value)); err != nil {
+			c.Report(s.Path, "invalid expression %q: %s", s.Value, err)
+		}
+	}
+}
+
+// evaluatedPaths are the JSON pointers to the fields that the workflow
+// evaluates. Other keys, such as YAML anchors, are only used where they are
+// referenced.
+var evaluatedPaths = []string{"/do/", "/input/", "/output/", "/timeout/", "/use/authentications/"}
+
+// evaluatedStrings returns the strings that the workflow evaluates, with
+// aliases resolved to where they are used
+func evaluatedStrings(c *Context) []*scalar {
+	found := make([]*scalar, 0)
+	for _, s := range c.source.resolved {
+		if slices.ContainsFunc(evaluatedPaths, func(p string) bool { return strings.HasPrefix(s.Path, p) }) {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// checkNonDeterministicFunctions finds non-deterministic functions outside
+// of set tasks, where they are not wrapped in a side effect
+func checkNonDeterministicFunctions(c *Context) {
+	names := utils.NonDeterministicFunctions()
+
+	setPaths := make([]string, 0)
+	for _, ref := range taskRefs(c.Workflow) {
+		if ref.Item.AsSetTask() != nil {
+			setPaths = append(setPaths, ref.Path+"/set")
+		}
+	}
+	inSetTask := func(path string) bool {
+		return slices.ContainsFunc(setPaths, func(p string) bool { return path == p || strings.HasPrefix(path, p+"/") })
+	}
+
+	for _, s := range evaluatedStrings(c) {
+		if !model.IsStrictExpr(s.Value) || inSetTask(s.Path) {
+			continue
+		}
+
+		query, err := gojq.Parse(model.SanitizeExpr(s.Value))
+		if err != nil {
+			// Reported by the invalid-expression rule
+			continue
+		}
+
+		for _, fn := range calledFunctions(query) {
+			if slices.Contains(names, fn) {
+				c.Report(s.Path, "%s is non-deterministic and must only be used in a set task", fn)
+			}
+		}
+	}
+}
+
+// calledFunctions returns the names of the functions called in the query
+func calledFunctions(query *gojq.Query) []string {
+	names := make([]string, 0)
+	funcType := reflect.TypeFor[gojq.Func]()
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() {
+				return
+			}
+			if v.Elem().Type() == funcType {
+				name := v.Elem().FieldByName("Name").String()
+				if !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+			}
+			walk(v.Elem())
+		case reflect.Struct:
+			for i := range v.NumField() {
+				walk(v.Field(i))
+			}
+		case reflect.Slice:
+			for i := range v.Len() {
+				walk(v.Index(i))
+			}
+		}
+	}
+	walk(reflect.ValueOf(query))
+
+	return names
+}
+
+var (
+	documentMetadataKeys = []string{
+		metadata.MetadataActivityOptions,
+		metadata.MaxHistoryLengthAttribute,
+		metadata.MetadataScheduleID,
+		metadata.MetadataScheduleInput,
+		metadata.MetadataScheduleWorkflowName,
+		// Read by the documentation site to hide examples
+		"display",
+	}
+	taskMetadataKeys = []string{
+		metadata.MetadataActivityOptions,
+		metadata.MetadataHeartbeat,
+		metadata.MetadataRetry,
+		metadata.MetadataSearchAttribute,
+	}
+	listenMetadataKeys = []string{
+		"timeout",
+	}
+)
+
+// checkMetadataKeys finds metadata keys that Zigflow ignores, which are
+// usually typos
+func checkMetadataKeys(c *Context) {
+	checkMetadata(c, "/document/metadata", c.Workflow.Document.Metadata, documentMetadataKeys)
+
+	for _, ref := range taskRefs(c.Workflow) {
+		known := taskMetadataKeys
+		if ref.Item.AsListenTask() != nil {
+			known = append(slices.Clone(known), listenMetadataKeys...)
+		}
+		checkMetadata(c, ref.Path+"/metadata", ref.Item.GetBase().Metadata, known)
+	}
+}
+
+func checkMetadata(c *Context, path string, data map[string]any, known []string) {
+	for _, key := range sortedKeys(data) {
+		keyPath := path + "/" + utils.EscapePointer(key)
+		if !slices.Contains(known, key) {
+			reportUnknownKey(c, keyPath, "metadata key", key, known)
+			continue
+		}
+
+		if key != metadata.MetadataActivityOptions {
+			continue
+		}
+		if opts, ok := data[key].(map[string]any); ok {
+			fields := jsonFields(reflect.TypeFor[metadata.ActivityOptions]())
+			for _, field := range sortedKeys(opts) {
+				if !slices.Contains(fields, field) {
+					reportUnknownKey(c, keyPath+"/"+utils.EscapePointer(field), "activity option", field, fields)
+				}
+			}
+		}
+	}
+}
+
+func reportUnknownKey(c *Context, path, kind, key string, known []string) {
+	if suggestion := closest(key, known); suggestion != "" {
+		c.Report(path, "unknown %s %q - did you mean %q?", kind, key, suggestion)
+		return
+	}
+	c.Report(path, "unknown %s %q is ignored", kind, key)
+}
+
+func jsonFields(t reflect.Type) []string {
+	fields := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// closest returns the known value within a small edit distance of s
+func closest(s string, known []string) string {
+	best := ""
+	bestDistance := 4
+	for _, k := range known {
+		if d := levenshtein(strings.ToLower(s), strings.ToLower(k)); d < bestDistance {
+			best = k
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(b)]
+}
+
+// checkUnreachableTasks finds tasks that no path through the task list runs
+func checkUnreachableTasks(c *Context) {
+	lists := make([]*model.TaskList, 0)
+	refs := map[*model.TaskList][]*taskRef{}
+	for _, ref := range taskRefs(c.Workflow) {
+		if ref.Branch {
+			continue
+		}
+		if _, ok := refs[ref.List]; !ok {
+			lists = append(lists, ref.List)
+		}
+		refs[ref.List] = append(refs[ref.List], ref)
+	}
+
+	for _, list := range lists {
+		reachable := reachableTasks(*list)
+		for i, ref := range refs[list] {
+			if reachable[i] {
+				continue
+			}
+			c.Report(ref.Path, "task %q is never run - no earlier task continues to it", ref.Item.Key)
+		}
+	}
+}
+
+// reachableTasks follows the flow directives through the list. A task with
+// an if statement may be skipped, so the flow can also continue past it.
+func reachableTasks(list model.TaskList) []bool {
+	reachable := make([]bool, len(list))
+	if len(list) == 0 {
+		return reachable
+	}
+	reachable[0] = true
+
+	for i, item := range list {
+		// Do tasks in a list define separate workflows
+		if item.AsDoTask() != nil {
+			reachable[i] = true
+		}
+		if !reachable[i] || i+1 >= len(list) {
+			continue
+		}
+
+		base := item.GetBase()
+		then := base.Then
+		if base.If != nil || then == nil || then.Value == string(model.FlowDirectiveContinue) {
+			reachable[i+1] = true
+		}
+		if then != nil && !then.IsEnum() {
+			j := slices.IndexFunc(list, func(t *model.TaskItem) bool { return t.Key == then.Value })
+			if j > i {
+				reachable[j] = true
+			} else {
+				// Reported by the missing-then-target rule, so don't repeat it
+				reachable[i+1] = true
+			}
+		}
+	}
+
+	return reachable
+}
+
+// secretExpressions returns the expressions that use $secrets, and the names
+// of the secrets that each one uses
+func secretExpressions(c *Context) map[*scalar][]string {
+	found := map[*scalar][]string{}
+
+	for _, s := range evaluatedStrings(c) {
+		if !model.IsStrictExpr(s.Value) {
+			continue
+		}
+
+		query, err := gojq.Parse(model.SanitizeExpr(s.Value))
+		if err != nil {
+			// Reported by the invalid-expression rule
+			continue
+		}
+
+		names, dynamic := utils.SecretReferences(query)
+		if len(names) > 0 || dynamic {
+			found[s] = names
+			if dynamic {
+				// An empty name marks $secrets used without a name
+				found[s] = append(found[s], "")
+			}
+		}
+	}
+
+	return found
+}
+
+// checkSecretsDeclared finds secrets that are not declared in use.secrets.
+// Only declared secrets are resolved when the workflow runs.
+func checkSecretsDeclared(c *Context) {
+	var declared []string
+	if c.Workflow.Use != nil {
+		declared = c.Workflow.Use.Secrets
+	}
+
+	used := secretExpressions(c)
+	for _, s := range evaluatedStrings(c) {
+		names, ok := used[s]
+		if !ok {
+			continue
+		}
+
+		for _, name := range names {
+			switch {
+			case name == "":
+				c.Report(s.Path, "%s must be accessed by name, such as %s.name", utils.SecretsVariable, utils.SecretsVariable)
+			case !slices.Contains(declared, name):
+				c.Report(s.Path, "secret %q is not declared in use.secrets", name)
+			}
+		}
+	}
+}
+
+// checkSecretsInActivities finds secrets used in fields that are evaluated in
+// the workflow. Secrets are only resolved in activities so that they never
+// enter the workflow history.
+func checkSecretsInActivities(c *Context) {
+	paths := activityPaths(c.Workflow)
+	used := secretExpressions(c)
+
+	for _, s := range evaluatedStrings(c) {
+		if _, ok := used[s]; !ok {
+			continue
+		}
+
+		if !slices.ContainsFunc(paths, func(p string) bool { return strings.HasPrefix(s.Path, p) }) {
+			c.Report(s.Path, "%s can only be used by call and run tasks that are evaluated in an activity", utils.SecretsVariable)
+		}
+	}
+}
+
+// activityPaths returns the JSON pointers to the fields that are evaluated
+// inside an activity
+func activityPaths(doc *model.Workflow) []string {
+	paths := []string{"/use/authentications/"}
+
+	add := func(path string, task model.Task) {
+		switch t := task.(type) {
+		case *model.CallHTTP, *model.CallGRPC, *model.CallOpenAPI:
+			paths = append(paths, path+"/with/")
+		case *model.RunTask:
+			switch {
+			case t.Run.Shell != nil:
+				paths = append(paths, path+"/run/shell/arguments/", path+"/run/shell/environment/")
+			case t.Run.Script != nil:
+				paths = append(paths, path+"/run/script/arguments/", path+"/run/script/environment/")
+			case t.Run.Container != nil:
+				paths = append(paths, path+"/run/container/arguments/")
+			}
+		}
+	}
+
+	for _, ref := range taskRefs(doc) {
+		add(ref.Path, ref.Item.Task)
+	}
+	if doc.Use != nil {
+		for name, fn := range doc.Use.Functions {
+			add("/use/functions/"+utils.EscapePointer(name), fn)
+		}
+	}
+
+	return paths
+}
+
+// checkTaskRetries finds task retry metadata that fails when the worker
+// builds the workflow. A named policy may be valid for try.catch.retry but use
+// conditions, jitter or linear backoff that Temporal's retry policies lack.
+func checkTaskRetries(c *Context) {
+	for _, ref := range taskRefs(c.Workflow) {
+		base := ref.Item.GetBase()
+		if _, ok := base.Metadata[metadata.MetadataRetry]; !ok {
+			continue
+		}
+
+		if _, err := metadata.RetryFor(c.Workflow, base); err != nil {
+			c.Report(ref.Path+"/metadata/"+metadata.MetadataRetry, "%s", err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zigflow/zigflow/pkg/utils"
+	"go.yaml.in/yaml/v3"
+)
+
+// suppressionComment disables rules for a node and everything below it. With
+// no rules listed, every rule is disabled.
+//
+//	# zigflow-lint-disable ZF001 missing-then-target
+//	# zigflow-lint-disable-file ZF006
+var suppressionComment = regexp.MustCompile(`zigflow-lint-disable(-file)?\b([^#\n]*)`)
+
+// scalar is a string value in the file
+type scalar struct {
+	Path  string
+	Value string
+}
+
+type suppression struct {
+	// JSON pointer the suppression applies to - empty for the whole file
+	Path  string
+	Rules []string
+}
+
+func (s *suppression) covers(rule *Rule, path string) bool {
+	if s.Path != "" && path != s.Path && !strings.HasPrefix(path, s.Path+"/") {
+		return false
+	}
+	if len(s.Rules) == 0 {
+		return true
+	}
+	for _, r := range s.Rules {
+		if rule.matches(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// source records the suppression comments and string values in the file.
+// Positions come from the workflow's utils.Source.
+type source struct {
+	suppressions []*suppression
+	// Strings as they are written in the file
+	strings []*scalar
+	// Strings where they are used once aliases and merge keys are resolved
+	resolved []*scalar
+}
+
+func parseSource(data []byte) (*source, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("error parsing yaml: %w", err)
+	}
+
+	s := &source{
+		suppressions: make([]*suppression, 0),
+		strings:      make([]*scalar, 0),
+		resolved:     make([]*scalar, 0),
+	}
+	s.walk(&root, "")
+	s.resolve(&root, "")
+
+	return s, nil
+}
+
+func (s *source) walk(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		s.comments(path, node)
+		for _, n := range node.Content {
+			s.walk(n, path)
+		}
+		return
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			p := path + "/" + utils.EscapePointer(key.Value)
+
+			s.comments(p, key)
+			s.comments(p, value)
+			s.walk(value, p)
+		}
+		return
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			p := path + "/" + strconv.Itoa(i)
+			s.comments(p, n)
+			s.walk(n, p)
+		}
+		return
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			s.strings = append(s.strings, &scalar{Path: path, Value: node.Value})
+		}
+	}
+}
+
+// resolve records the strings in the document as the workflow sees them, with
+// aliases and merge keys replaced by the nodes they refer to
+func (s *source) resolve(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			s.resolve(n, path)
+		}
+	case yaml.AliasNode:
+		s.resolve(node.Alias, path)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				s.merge(value, path)
+				continue
+			}
+			s.resolve(value, path+"/"+utils.EscapePointer(key.Value))
+		}
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			s.resolve(n, path+"/"+strconv.Itoa(i))
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			s.resolved = append(s.resolved, &scalar{Path: path, Value: node.Value})
+		}
+	}
+}
+
+// merge resolves the mappings of a merge key into the mapping at path
+func (s *source) merge(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		s.merge(node.Alias, path)
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			s.merge(n, path)
+		}
+	case yaml.MappingNode:
+		s.resolve(node, path)
+	}
+}
+
+func (s *source) comments(path string, node *yaml.Node) {
+	for _, comment := range []string{node.HeadComment, node.LineComment, node.FootComment} {
+		for _, m := range suppressionComment.FindAllStringSubmatch(comment, -1) {
+			sup := &suppression{
+				Path:  path,
+				Rules: strings.FieldsFunc(m[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }),
+			}
+			if m[1] != "" {
+				sup.Path = ""
+			}
+			s.suppressions = append(s.suppressions, sup)
+		}
+	}
+}
+
+func (s *source) suppressed(rule *Rule, path string) bool {
+	for _, sup := range s.suppressions {
+		if sup.covers(rule, path) {
+			return true
+		}
+	}
+	return false
+}
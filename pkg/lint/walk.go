@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"strconv"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+)
+
+// taskRef is a task and where it is in the document
+type taskRef struct {
+	Item *model.TaskItem
+	// JSON pointer to the task's definition
+	Path string
+	// Temporal workflow type the task executes in
+	Workflow string
+	// The list the task is in and its position in it
+	List  *model.TaskList
+	Index int
+	// Fork branches are run in parallel rather than in sequence
+	Branch bool
+}
+
+// taskRefs returns every task in the document depth-first, in the order they
+// are declared. This follows zigflow.Walk but also records each task's path.
+func taskRefs(doc *model.Workflow) []*taskRef {
+	refs := make([]*taskRef, 0)
+	walkTaskList(doc.Do, "/do", doc.Document.Name, &refs)
+	return refs
+}
+
+func walkTaskList(list *model.TaskList, path, workflowName string, refs *[]*taskRef) {
+	if list == nil {
+		return
+	}
+
+	for i, item := range *list {
+		taskPath := path + "/" + strconv.Itoa(i) + "/" + utils.EscapePointer(item.Key)
+		*refs = append(*refs, &taskRef{
+			Item:     item,
+			Path:     taskPath,
+			Workflow: workflowName,
+			List:     list,
+			Index:    i,
+		})
+
+		walkChildren(item, taskPath, refs)
+	}
+}
+
+func walkChildren(item *model.TaskItem, path string, refs *[]*taskRef) {
+	switch task := item.Task.(type) {
+	case *model.DoTask:
+		walkTaskList(task.Do, path+"/do", item.Key, refs)
+	case *model.ForTask:
+		walkTaskList(task.Do, path+"/do", utils.GenerateChildWorkflowName("for", item.Key), refs)
+	case *model.ForkTask:
+		if task.Fork.Branches == nil {
+			return
+		}
+		branches := path + "/fork/branches"
+		for i, branch := range *task.Fork.Branches {
+			childWorkflowName := utils.GenerateChildWorkflowName("fork", item.Key, branch.Key)
+			branchPath := branches + "/" + strconv.Itoa(i) + "/" + utils.EscapePointer(branch.Key)
+			if do := branch.AsDoTask(); do != nil {
+				*refs = append(*refs, &taskRef{
+					Item:     branch,
+					Path:     branchPath,
+					Workflow: childWorkflowName,
+					List:     task.Fork.Branches,
+					Index:    i,
+					Branch:   true,
+				})
+				walkTaskList(do.Do, branchPath+"/do", childWorkflowName, refs)
+				continue
+			}
+			// Single task branches are wrapped in their own workflow
+			*refs = append(*refs, &taskRef{
+				Item:     branch,
+				Path:     branchPath,
+				Workflow: childWorkflowName,
+				List:     task.Fork.Branches,
+				Index:    i,
+				Branch:   true,
+			})
+			walkChildren(branch, branchPath, refs)
+		}
+	case *model.TryTask:
+		walkTaskList(task.Try, path+"/try", utils.GenerateChildWorkflowName("try", item.Key), refs)
+		if task.Catch != nil {
+			walkTaskList(task.Catch.Do, path+"/catch/do", utils.GenerateChildWorkflowName("catch", item.Key), refs)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+)
+
+var (
+	// thenValuePattern matches a line where the then value is being typed
+	thenValuePattern = regexp.MustCompile(`^(\s*)(?:-\s+)?then:(\s*)[\w.-]*$`)
+	// keyPrefixPattern matches a line where a key is being typed
+	keyPrefixPattern = regexp.MustCompile(`^(\s*)(-\s+)?[\w$-]*$`)
+)
+
+// taskTypes are the keys that set a task's type
+var taskTypes = []CompletionItem{
+	{Label: "call", Detail: "Call an HTTP, gRPC or activity endpoint"},
+	{Label: "do", Detail: "Run a list of tasks in sequence"},
+	{Label: "emit", Detail: "Emit an event"},
+	{Label: "for", Detail: "Run tasks for each item in a collection"},
+	{Label: "fork", Detail: "Run tasks in parallel"},
+	{Label: "listen", Detail: "Wait for a signal, query or update"},
+	{Label: "raise", Detail: "Fail the workflow with an error"},
+	{Label: "run", Detail: "Run a container, script, shell command or workflow"},
+	{Label: "set", Detail: "Set data in the workflow state"},
+	{Label: "switch", Detail: "Start a workflow based on a condition"},
+	{Label: "try", Detail: "Run tasks and handle their errors"},
+	{Label: "wait", Detail: "Pause the workflow for a duration"},
+}
+
+// taskKeys are set on any type of task
+var taskKeys = []CompletionItem{
+	{Label: "if", Detail: "Only run the task if the expression is true"},
+	{Label: "input", Detail: "Filter and validate the task input"},
+	{Label: "output", Detail: "Filter the task output"},
+	{Label: "export", Detail: "Export the task output to the context"},
+	{Label: "timeout", Detail: "Timeout for the task"},
+	{Label: "then", Detail: "Flow directive for the next task"},
+	{Label: "metadata", Detail: "Zigflow settings for the task"},
+}
+
+var (
+	documentMetadataKeys = []CompletionItem{
+		{Label: metadata.MetadataActivityOptions, Detail: "Default options for every activity in the workflow"},
+		{Label: metadata.MaxHistoryLengthAttribute, Detail: "Continue as new once the history reaches this length"},
+		{Label: metadata.MetadataScheduleID, Detail: "ID of the schedule that starts the workflow"},
+		{Label: metadata.MetadataScheduleInput, Detail: "Input for the scheduled workflow"},
+		{Label: metadata.MetadataScheduleWorkflowName, Detail: "Workflow started by the schedule"},
+	}
+	taskMetadataKeys = []CompletionItem{
+		{Label: metadata.MetadataActivityOptions, Detail: "Options for the activities run by this task"},
+		{Label: metadata.MetadataHeartbeat, Detail: "How often the activity heartbeats"},
+		{Label: metadata.MetadataRetry, Detail: "Retry policy for the task's activities or child workflow"},
+		{Label: metadata.MetadataSearchAttribute, Detail: "Search attributes to set when the task runs"},
+	}
+	listenMetadataKeys = []CompletionItem{
+		{Label: "timeout", Detail: "How long to wait for the event"},
+	}
+)
+
+// flowDirectives can be used in place of a task name in then
+var flowDirectives = []CompletionItem{
+	{Label: "continue", Detail: "Run the next task"},
+	{Label: "exit", Detail: "Exit the current task list"},
+	{Label: "end", Detail: "End the workflow"},
+}
+
+func (d *document) completion(pos Position) []CompletionItem {
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return []CompletionItem{}
+	}
+	text := d.lines[pos.Line]
+	prefix := text[:min(max(pos.Character, 0), len(text))]
+
+	if m := thenValuePattern.FindStringSubmatch(prefix); m != nil {
+		items := d.completeThen(pos.Line, len(m[1]))
+		if m[2] == "" {
+			// Triggered by the colon, so add the space
+			for i := range items {
+				items[i].InsertText = " " + items[i].Label
+			}
+		}
+		return items
+	}
+
+	m := keyPrefixPattern.FindStringSubmatchIndex(prefix)
+	if m == nil {
+		return []CompletionItem{}
+	}
+	column := m[3]
+	if m[4] >= 0 {
+		column = m[5]
+	}
+
+	parents := d.ancestors(pos.Line, column)
+	if len(parents) == 0 {
+		return []CompletionItem{}
+	}
+
+	switch {
+	case d.isTask(parents[0]):
+		return d.withoutExisting(parents[0], pos.Line, completionItems(completionKindProperty, taskTypes, taskKeys))
+	case parents[0].Key == "metadata" && len(parents) > 1:
+		if parents[1].Key == "document" {
+			return d.withoutExisting(parents[0], pos.Line, completionItems(completionKindProperty, documentMetadataKeys))
+		}
+		if d.isTask(parents[1]) {
+			keys := [][]CompletionItem{taskMetadataKeys}
+			if slices.ContainsFunc(d.children(parents[1]), func(l *outlineLine) bool { return l.Key == "listen" }) {
+				keys = append(keys, listenMetadataKeys)
+			}
+			return d.withoutExisting(parents[0], pos.Line, completionItems(completionKindProperty, keys...))
+		}
+	}
+
+	return []CompletionItem{}
+}
+
+// completeThen returns the tasks and workflows that a then on the line can
+// move to
+func (d *document) completeThen(line, column int) []CompletionItem {
+	items := completionItems(completionKindValue, flowDirectives)
+
+	parents := d.ancestors(line, column)
+	if len(parents) == 0 {
+		return items
+	}
+
+	// Switch cases start a workflow
+	if len(parents) > 1 && parents[1].Key == "switch" {
+		if d.workflow == nil {
+			return items
+		}
+		for _, name := range zigflow.WorkflowTypes(d.workflow, false) {
+			items = append(items, CompletionItem{
+				Label:  name,
+				Kind:   completionKindReference,
+				Detail: "Workflow",
+			})
+		}
+		return items
+	}
+
+	task := parents[0]
+	if !d.isTask(task) {
+		return items
+	}
+
+	// Flow directives only move forward through the task list
+	list := d.ancestors(task.Number, task.ItemColumn+1)[0]
+	for _, sibling := range d.tasks(list) {
+		if sibling.Number <= task.Number {
+			continue
+		}
+		items = append(items, CompletionItem{
+			Label:  sibling.Key,
+			Kind:   completionKindReference,
+			Detail: "Task",
+		})
+	}
+
+	return items
+}
+
+// withoutExisting removes the keys that the parent already has, except on
+// the line being edited
+func (d *document) withoutExisting(parent *outlineLine, line int, items []CompletionItem) []CompletionItem {
+	for _, child := range d.children(parent) {
+		if child.Number == line {
+			continue
+		}
+		items = slices.DeleteFunc(items, func(item CompletionItem) bool {
+			return item.Label == child.Key
+		})
+	}
+	return items
+}
+
+func completionItems(kind int, lists ...[]CompletionItem) []CompletionItem {
+	items := make([]CompletionItem, 0)
+	for _, list := range lists {
+		for _, item := range list {
+			item.Kind = kind
+			items = append(items, item)
+		}
+	}
+	return items
+}
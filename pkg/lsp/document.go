@@ -0,0 +1,376 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/lint"
+	"github.com/zigflow/zigflow/pkg/utils"
+)
+
+// diagnosticSource is shown by the editor alongside each diagnostic
+const diagnosticSource = "zigflow"
+
+// outlineLinePattern matches a YAML line with a key or a sequence item
+var outlineLinePattern = regexp.MustCompile(`^(\s*)(-\s+)?(?:("[^"]*"|'[^']*'|[^\s:#'"-][^:#]*?)\s*:(?:\s+|$))?(.*)$`)
+
+// yamlErrorLine finds the line number in a YAML parse error
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// outlineLine is a line of the document that has a key or starts a sequence
+// item. Editors need help while the document is half written and can't be
+// parsed, so completion and navigation work from this outline rather than
+// the parsed workflow.
+type outlineLine struct {
+	Number int
+	// Column of the key
+	Column int
+	// Column of the sequence item's dash, or -1 if it's not an item
+	ItemColumn int
+	Key        string
+	Value      string
+	// Column the value starts at
+	ValueColumn int
+}
+
+// container is the column that children of this line must be indented past
+func (l *outlineLine) container() int {
+	if l.ItemColumn >= 0 {
+		return l.ItemColumn
+	}
+	return l.Column
+}
+
+type document struct {
+	uri     string
+	lines   []string
+	outline []*outlineLine
+
+	// The workflow and any error loading it
+	workflow *model.Workflow
+	lint     *lint.Document
+	loadErr  error
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{
+		uri:   uri,
+		lines: strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n"),
+	}
+	d.parseOutline()
+
+	d.lint, d.loadErr = lint.Parse(uri, []byte(text))
+	if d.loadErr == nil {
+		d.workflow = d.lint.Workflow
+	}
+
+	return d
+}
+
+func (d *document) parseOutline() {
+	blockScalar := -1
+	for i, text := range d.lines {
+		trimmed := strings.TrimSpace(text)
+		indent := len(text) - len(strings.TrimLeft(text, " "))
+
+		// Skip the contents of multi-line strings
+		if blockScalar >= 0 {
+			if trimmed == "" || indent > blockScalar {
+				continue
+			}
+			blockScalar = -1
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		m := outlineLinePattern.FindStringSubmatchIndex(text)
+		if m == nil {
+			continue
+		}
+
+		line := &outlineLine{
+			Number:     i,
+			Column:     indent,
+			ItemColumn: -1,
+		}
+		if m[4] >= 0 {
+			line.ItemColumn = indent
+			line.Column = m[5]
+		}
+		if m[6] < 0 {
+			if line.ItemColumn < 0 {
+				// Not a key or an item
+				continue
+			}
+		} else {
+			line.Key = strings.Trim(text[m[6]:m[7]], `"'`)
+			line.ValueColumn = m[8]
+			line.Value = stripComment(text[m[8]:m[9]])
+
+			if strings.HasPrefix(line.Value, "|") || strings.HasPrefix(line.Value, ">") {
+				blockScalar = line.container()
+			}
+		}
+
+		d.outline = append(d.outline, line)
+	}
+}
+
+// stripComment removes a trailing comment and quotes from a value
+func stripComment(value string) string {
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.Trim(strings.TrimSpace(value), `"'`)
+}
+
+// lineAt returns the outline line for the line number, if it has one
+func (d *document) lineAt(number int) *outlineLine {
+	for _, l := range d.outline {
+		if l.Number == number {
+			return l
+		}
+	}
+	return nil
+}
+
+// ancestors returns the keys that contain the column on the line, innermost
+// first
+func (d *document) ancestors(number, column int) []*outlineLine {
+	ancestors := make([]*outlineLine, 0)
+	for i := len(d.outline) - 1; i >= 0; i-- {
+		l := d.outline[i]
+		if l.Number >= number {
+			continue
+		}
+		if l.Column < column && l.Key != "" {
+			ancestors = append(ancestors, l)
+			column = l.container()
+		}
+	}
+	return ancestors
+}
+
+// children returns the lines directly inside the parent
+func (d *document) children(parent *outlineLine) []*outlineLine {
+	children := make([]*outlineLine, 0)
+	childColumn := -1
+	for _, l := range d.outline {
+		if l.Number <= parent.Number {
+			continue
+		}
+		if l.container() <= parent.container() {
+			break
+		}
+		if childColumn < 0 {
+			childColumn = l.container()
+		}
+		if l.container() == childColumn {
+			children = append(children, l)
+		}
+	}
+	return children
+}
+
+// taskListKeys are the keys that hold a list of tasks
+var taskListKeys = []string{"do", "try", "branches"}
+
+// isTask returns true if the line is a task in a task list
+func (d *document) isTask(l *outlineLine) bool {
+	if l.ItemColumn < 0 || l.Key == "" {
+		return false
+	}
+	parents := d.ancestors(l.Number, l.ItemColumn+1)
+	return len(parents) > 0 && isTaskList(parents[0])
+}
+
+func isTaskList(l *outlineLine) bool {
+	for _, key := range taskListKeys {
+		if l.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// tasks returns the tasks in the task list
+func (d *document) tasks(list *outlineLine) []*outlineLine {
+	tasks := make([]*outlineLine, 0)
+	for _, l := range d.children(list) {
+		if l.ItemColumn >= 0 && l.Key != "" {
+			tasks = append(tasks, l)
+		}
+	}
+	return tasks
+}
+
+// allTasks returns every task in the document
+func (d *document) allTasks() []*outlineLine {
+	tasks := make([]*outlineLine, 0)
+	for _, l := range d.outline {
+		if d.isTask(l) {
+			tasks = append(tasks, l)
+		}
+	}
+	return tasks
+}
+
+// keyRange is the range of the key on the line
+func (d *document) keyRange(l *outlineLine) Range {
+	return Range{
+		Start: d.position(l.Number, l.Column),
+		End:   d.position(l.Number, l.Column+len(l.Key)),
+	}
+}
+
+// position converts a byte column on the line to a position. Positions count
+// UTF-16 code units, as that's the encoding every client supports.
+func (d *document) position(line, column int) Position {
+	pos := Position{Line: line, Character: column}
+	if line >= 0 && line < len(d.lines) {
+		text := d.lines[line]
+		pos.Character = len(utf16.Encode([]rune(text[:min(max(column, 0), len(text))])))
+	}
+	return pos
+}
+
+// byteColumn converts the position's UTF-16 code units to a byte column on the
+// line
+func (d *document) byteColumn(pos Position) int {
+	if pos.Line < 0 || pos.Line >= len(d.lines) {
+		return pos.Character
+	}
+
+	text := d.lines[pos.Line]
+	units := 0
+	for i, r := range text {
+		if units >= pos.Character {
+			return i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(text)
+}
+
+func (d *document) diagnostics(validator *utils.Validator) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+
+	if d.loadErr != nil {
+		return append(diagnostics, d.loadErrorDiagnostic())
+	}
+
+	if errs, err := validator.ValidateStructSource(d.workflow, d.lint.Positions); err != nil {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: severityError,
+			Source:   diagnosticSource,
+			Message:  err.Error(),
+		})
+	} else {
+		for _, e := range errs {
+			field := e.Pointer[strings.LastIndex(e.Pointer, "/")+1:]
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    d.rangeAt(e.Line, e.Column),
+				Severity: severityError,
+				Code:     e.Key,
+				Source:   diagnosticSource,
+				Message:  strings.TrimSpace(fmt.Sprintf("%s %s", field, e.HumanMessage())),
+			})
+		}
+	}
+
+	for _, f := range lint.Lint(d.lint, lint.Options{}) {
+		severity := severityInformation
+		switch f.Severity {
+		case lint.SeverityError:
+			severity = severityError
+		case lint.SeverityWarning:
+			severity = severityWarning
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    d.rangeAt(f.Line, f.Column),
+			Severity: severity,
+			Code:     f.Rule,
+			Source:   diagnosticSource,
+			Message:  f.Message,
+		})
+	}
+
+	return diagnostics
+}
+
+func (d *document) loadErrorDiagnostic() Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: severityError,
+		Source:   diagnosticSource,
+		Message:  d.loadErr.Error(),
+	}
+
+	var srcErr *utils.SourceError
+	if errors.As(d.loadErr, &srcErr) {
+		diagnostic.Range = d.rangeAt(srcErr.Line, srcErr.Column)
+		diagnostic.Message = srcErr.Cause().Error()
+		return diagnostic
+	}
+
+	if m := yamlErrorLine.FindStringSubmatch(d.loadErr.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		diagnostic.Range = d.rangeAt(line, 1)
+	}
+
+	return diagnostic
+}
+
+// rangeAt converts a one-based line and column to a range covering the key
+// or value there
+func (d *document) rangeAt(line, column int) Range {
+	if line < 1 || line > len(d.lines) {
+		return Range{}
+	}
+
+	// YAML columns count characters rather than bytes
+	text := d.lines[line-1]
+	start := len(text)
+	chars := 0
+	for i := range text {
+		if chars >= column-1 {
+			start = i
+			break
+		}
+		chars++
+	}
+	end := start
+	for end < len(text) && text[end] != ':' && text[end] != ' ' {
+		end++
+	}
+	if end == start {
+		end = len(text)
+	}
+
+	return Range{
+		Start: d.position(line-1, start),
+		End:   d.position(line-1, end),
+	}
+}
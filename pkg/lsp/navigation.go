@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+// definition finds the task that a then or run.workflow.name refers to
+func (d *document) definition(pos Position) *Location {
+	l := d.lineAt(pos.Line)
+	if l == nil || l.Value == "" || pos.Character < l.ValueColumn {
+		return nil
+	}
+
+	parents := d.ancestors(l.Number, l.Column)
+
+	var target *outlineLine
+	switch {
+	case l.Key == "then":
+		target = d.thenTarget(l, parents)
+	case l.Key == "name" && len(parents) > 1 && parents[0].Key == "workflow" && parents[1].Key == "run":
+		target = d.workflowTask(l.Value)
+	}
+	if target == nil {
+		return nil
+	}
+
+	return &Location{
+		URI:   d.uri,
+		Range: d.keyRange(target),
+	}
+}
+
+func (d *document) thenTarget(then *outlineLine, parents []*outlineLine) *outlineLine {
+	if len(parents) == 0 {
+		return nil
+	}
+
+	// Switch cases start a workflow
+	if len(parents) > 1 && parents[1].Key == "switch" {
+		return d.workflowTask(then.Value)
+	}
+
+	task := parents[0]
+	if !d.isTask(task) {
+		return nil
+	}
+
+	list := d.ancestors(task.Number, task.ItemColumn+1)[0]
+	for _, sibling := range d.tasks(list) {
+		if sibling.Key == then.Value {
+			return sibling
+		}
+	}
+
+	return nil
+}
+
+// workflowTask finds the do task that defines the workflow
+func (d *document) workflowTask(name string) *outlineLine {
+	var fallback *outlineLine
+	for _, task := range d.allTasks() {
+		if task.Key != name {
+			continue
+		}
+		if slices.ContainsFunc(d.children(task), func(l *outlineLine) bool { return l.Key == "do" }) {
+			return task
+		}
+		if fallback == nil {
+			fallback = task
+		}
+	}
+	return fallback
+}
+
+// hover describes the Temporal workflows that a task runs in and starts
+func (d *document) hover(pos Position) *Hover {
+	l := d.lineAt(pos.Line)
+	if l == nil || d.workflow == nil || !d.isTask(l) {
+		return nil
+	}
+	if pos.Character < l.Column || pos.Character > l.Column+len(l.Key) {
+		return nil
+	}
+
+	item, workflowName := d.taskAt(l)
+	if item == nil {
+		return nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s**", item.Key)
+	if taskType := d.taskType(l); taskType != "" {
+		fmt.Fprintf(&b, " (%s task)", taskType)
+	}
+	fmt.Fprintf(&b, "\n\nRuns in Temporal workflow `%s`", workflowName)
+
+	children := zigflow.ChildWorkflows(item)
+	if len(children) > 0 {
+		b.WriteString("\n\nStarts:\n")
+		for _, c := range children {
+			fmt.Fprintf(&b, "\n- `%s`", c)
+		}
+	}
+
+	if do := item.AsDoTask(); do != nil && do.Do != nil &&
+		slices.ContainsFunc(*do.Do, func(t *model.TaskItem) bool { return t.AsDoTask() == nil }) {
+		fmt.Fprintf(&b, "\n\nRegisters Temporal workflow `%s`", item.Key)
+	}
+
+	r := d.keyRange(l)
+	return &Hover{
+		Contents: markupContent{
+			Kind:  "markdown",
+			Value: b.String(),
+		},
+		Range: &r,
+	}
+}
+
+// taskAt returns the parsed task declared on the line and the workflow it
+// runs in
+func (d *document) taskAt(l *outlineLine) (item *model.TaskItem, workflowName string) {
+	if d.lint == nil {
+		return nil, ""
+	}
+
+	_ = zigflow.Walk(d.workflow, func(task *model.TaskItem, name string) error {
+		if item != nil || task.Key != l.Key {
+			return nil
+		}
+		if pos, ok := d.lint.Positions.TaskPosition(task.Task); ok && pos.Line == l.Number+1 {
+			item = task
+			workflowName = name
+		}
+		return nil
+	})
+
+	return item, workflowName
+}
+
+// taskType returns the key that sets the task's type
+func (d *document) taskType(task *outlineLine) string {
+	for _, child := range d.children(task) {
+		if slices.ContainsFunc(taskTypes, func(t CompletionItem) bool { return t.Label == child.Key }) {
+			return child.Key
+		}
+	}
+	return ""
+}
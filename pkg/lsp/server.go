@@ -0,0 +1,351 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/zigflow/zigflow/pkg/utils"
+)
+
+// errExit is returned by a handler when the client asks the server to exit
+var errExit = errors.New("exit")
+
+type handlerFunc func(s *Server, params json.RawMessage) (any, error)
+
+// requestError is returned by a handler to send a JSON-RPC error to the client
+type requestError struct {
+	Code    int
+	Message string
+}
+
+func (e *requestError) Error() string {
+	return e.Message
+}
+
+var handlers = map[string]handlerFunc{
+	"initialize":              (*Server).initialize,
+	"initialized":             nil,
+	"shutdown":                (*Server).shutdown,
+	"exit":                    (*Server).exit,
+	"textDocument/didOpen":    (*Server).didOpen,
+	"textDocument/didChange":  (*Server).didChange,
+	"textDocument/didSave":    nil,
+	"textDocument/didClose":   (*Server).didClose,
+	"textDocument/completion": (*Server).completion,
+	"textDocument/definition": (*Server).definition,
+	"textDocument/hover":      (*Server).hover,
+}
+
+// Server is a language server for Zigflow workflow files. It communicates
+// with the editor over JSON-RPC.
+type Server struct {
+	reader    *bufio.Reader
+	writer    io.Writer
+	version   string
+	validator *utils.Validator
+	documents map[string]*document
+}
+
+// New creates a language server that reads requests from r and writes
+// responses to w
+func New(r io.Reader, w io.Writer, version string) (*Server, error) {
+	validator, err := utils.NewValidator()
+	if err != nil {
+		return nil, fmt.Errorf("error creating validator: %w", err)
+	}
+
+	return &Server{
+		reader:    bufio.NewReader(r),
+		writer:    w,
+		version:   version,
+		validator: validator,
+		documents: map[string]*document{},
+	}, nil
+}
+
+// Run handles requests until the client exits or closes the connection
+func (s *Server) Run() error {
+	for {
+		body, err := s.read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		var msg message
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.replyError(nil, &requestError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.handle(&msg); err != nil {
+			if errors.Is(err, errExit) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	l := log.With().Str("method", msg.Method).Logger()
+	l.Debug().Msg("Handling message")
+
+	handler, ok := handlers[msg.Method]
+	if !ok {
+		if msg.ID == nil {
+			// Unknown notifications can be ignored
+			return nil
+		}
+		return s.replyError(msg.ID, &requestError{
+			Code:    codeMethodNotFound,
+			Message: fmt.Sprintf("method not found: %s", msg.Method),
+		})
+	}
+	if handler == nil {
+		return nil
+	}
+
+	result, err := handler(s, msg.Params)
+	if errors.Is(err, errExit) {
+		return err
+	}
+	if msg.ID == nil {
+		if err != nil {
+			l.Error().Err(err).Msg("Error handling notification")
+		}
+		return nil
+	}
+	if err != nil {
+		var reqErr *requestError
+		if !errors.As(err, &reqErr) {
+			reqErr = &requestError{Code: codeInvalidRequest, Message: err.Error()}
+		}
+		return s.replyError(msg.ID, reqErr)
+	}
+
+	return s.write(&response{
+		JSONRPC: jsonRPCVersion,
+		ID:      msg.ID,
+		Result:  result,
+	})
+}
+
+// read returns the body of the next message. Messages are framed by a
+// Content-Length header.
+func (s *Server) read() ([]byte, error) {
+	headers, err := textproto.NewReader(s.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(strings.TrimSpace(headers.Get("Content-Length")))
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("invalid content length: %q", headers.Get("Content-Length"))
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, body); err != nil {
+		return nil, fmt.Errorf("error reading message: %w", err)
+	}
+
+	return body, nil
+}
+
+func (s *Server) write(v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error encoding message: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) replyError(id *json.RawMessage, err *requestError) error {
+	return s.write(&errorResponse{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Error: &responseError{
+			Code:    err.Code,
+			Message: err.Message,
+		},
+	})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return s.write(&notification{
+		JSONRPC: jsonRPCVersion,
+		Method:  method,
+		Params:  params,
+	})
+}
+
+func decodeParams[T any](params json.RawMessage) (*T, error) {
+	var v T
+	if err := json.Unmarshal(params, &v); err != nil {
+		return nil, &requestError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return &v, nil
+}
+
+func (s *Server) initialize(json.RawMessage) (any, error) {
+	return &initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync: textDocumentSyncFull,
+			CompletionProvider: &completionOptions{
+				TriggerCharacters: []string{":", " "},
+			},
+			DefinitionProvider: true,
+			HoverProvider:      true,
+		},
+		ServerInfo: serverInfo{
+			Name:    "zigflow",
+			Version: s.version,
+		},
+	}, nil
+}
+
+func (s *Server) shutdown(json.RawMessage) (any, error) {
+	return nil, nil
+}
+
+func (s *Server) exit(json.RawMessage) (any, error) {
+	return nil, errExit
+}
+
+func (s *Server) didOpen(params json.RawMessage) (any, error) {
+	p, err := decodeParams[didOpenParams](params)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, s.update(p.TextDocument.URI, p.TextDocument.Text)
+}
+
+func (s *Server) didChange(params json.RawMessage) (any, error) {
+	p, err := decodeParams[didChangeParams](params)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.ContentChanges) == 0 {
+		return nil, nil
+	}
+
+	// Full sync, so the last change is the whole document
+	return nil, s.update(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+}
+
+func (s *Server) didClose(params json.RawMessage) (any, error) {
+	p, err := decodeParams[didCloseParams](params)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.documents, p.TextDocument.URI)
+
+	// Clear the diagnostics for the closed document
+	return nil, s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+		URI:         p.TextDocument.URI,
+		Diagnostics: []Diagnostic{},
+	})
+}
+
+// update stores the new text and publishes its diagnostics
+func (s *Server) update(uri, text string) error {
+	doc := newDocument(uri, text)
+	s.documents[uri] = doc
+
+	return s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: doc.diagnostics(s.validator),
+	})
+}
+
+// document returns the open document and the position being requested
+func (s *Server) document(params json.RawMessage) (*document, Position, error) {
+	p, err := decodeParams[textDocumentPositionParams](params)
+	if err != nil {
+		return nil, Position{}, err
+	}
+
+	doc, ok := s.documents[p.TextDocument.URI]
+	if !ok {
+		return nil, Position{}, &requestError{
+			Code:    codeInvalidParams,
+			Message: fmt.Sprintf("document not open: %s", p.TextDocument.URI),
+		}
+	}
+
+	// The document works in bytes
+	pos := p.Position
+	pos.Character = doc.byteColumn(pos)
+
+	return doc, pos, nil
+}
+
+func (s *Server) completion(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &completionList{
+		Items: doc.completion(pos),
+	}, nil
+}
+
+func (s *Server) definition(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// A nil result tells the client there's no definition
+	if loc := doc.definition(pos); loc != nil {
+		return loc, nil
+	}
+	return nil, nil
+}
+
+func (s *Server) hover(params json.RawMessage) (any, error) {
+	doc, pos, err := s.document(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if h := doc.hover(pos); h != nil {
+		return h, nil
+	}
+	return nil, nil
+}
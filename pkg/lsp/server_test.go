@@ -0,0 +1,448 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testURI = "file:///workflow.yaml"
+
+const testWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: example
+  version: 0.0.1
+  metadata:
+    canMaxHistoryLength: 100
+do:
+  - first:
+      set:
+        hello: world
+      then: third
+  - second:
+      wait:
+        seconds: 1
+  - third:
+      try:
+        - attempt:
+            call: http
+            with:
+              method: get
+              endpoint: https://example.com
+      catch:
+        do:
+          - recover:
+              set:
+                failed: true`
+
+// session sends the messages to a server and returns what it sent back
+func session(t *testing.T, messages ...map[string]any) []map[string]any {
+	t.Helper()
+
+	var in bytes.Buffer
+	for _, m := range messages {
+		m["jsonrpc"] = "2.0"
+		body, err := json.Marshal(m)
+		require.NoError(t, err)
+		_, err = fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+		require.NoError(t, err)
+	}
+
+	var out bytes.Buffer
+	server, err := New(&in, &out, "test")
+	require.NoError(t, err)
+	require.NoError(t, server.Run())
+
+	replies := make([]map[string]any, 0)
+	reader := bufio.NewReader(&out)
+	for {
+		header, err := reader.ReadString('\n')
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		var length int
+		_, err = fmt.Sscanf(header, "Content-Length: %d\r\n", &length)
+		require.NoError(t, err)
+		_, err = reader.ReadString('\n')
+		require.NoError(t, err)
+
+		body := make([]byte, length)
+		_, err = io.ReadFull(reader, body)
+		require.NoError(t, err)
+
+		var reply map[string]any
+		require.NoError(t, json.Unmarshal(body, &reply))
+		replies = append(replies, reply)
+	}
+
+	return replies
+}
+
+func openDocument(text string) map[string]any {
+	return map[string]any{
+		"method": "textDocument/didOpen",
+		"params": map[string]any{
+			"textDocument": map[string]any{"uri": testURI, "languageId": "yaml", "version": 1, "text": text},
+		},
+	}
+}
+
+func positionRequest(method string, line, character int) map[string]any {
+	return map[string]any{
+		"id":     1,
+		"method": method,
+		"params": map[string]any{
+			"textDocument": map[string]any{"uri": testURI},
+			"position":     map[string]any{"line": line, "character": character},
+		},
+	}
+}
+
+// request opens the document and returns the result of the request
+func request(t *testing.T, text, method string, line, character int) any {
+	t.Helper()
+
+	replies := session(t, openDocument(text), positionRequest(method, line, character))
+	require.Len(t, replies, 2)
+	assert.Nil(t, replies[1]["error"])
+
+	return replies[1]["result"]
+}
+
+func TestServerLifecycle(t *testing.T) {
+	replies := session(t,
+		map[string]any{"id": 1, "method": "initialize", "params": map[string]any{}},
+		map[string]any{"method": "initialized", "params": map[string]any{}},
+		map[string]any{"id": 2, "method": "workspace/symbol", "params": map[string]any{}},
+		map[string]any{"id": 3, "method": "shutdown"},
+		map[string]any{"method": "exit"},
+		// Never handled
+		map[string]any{"id": 4, "method": "shutdown"},
+	)
+	require.Len(t, replies, 3)
+
+	capabilities := replies[0]["result"].(map[string]any)["capabilities"].(map[string]any)
+	assert.Equal(t, true, capabilities["hoverProvider"])
+	assert.Equal(t, true, capabilities["definitionProvider"])
+	assert.NotNil(t, capabilities["completionProvider"])
+
+	assert.Equal(t, float64(codeMethodNotFound), replies[1]["error"].(map[string]any)["code"])
+
+	assert.Contains(t, replies[2], "result")
+	assert.Nil(t, replies[2]["result"])
+}
+
+func TestDiagnostics(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Text     string
+		Expected []Diagnostic
+	}{
+		{
+			Name: "lint finding",
+			Text: testWorkflow,
+			Expected: []Diagnostic{
+				{
+					Range:    Range{Start: Position{Line: 12, Character: 4}, End: Position{Line: 12, Character: 10}},
+					Severity: severityWarning,
+					Code:     "ZF007",
+					Source:   diagnosticSource,
+					Message:  `task "second" is never run - no earlier task continues to it`,
+				},
+			},
+		},
+		{
+			Name: "validation error",
+			Text: strings.Replace(strings.Replace(testWorkflow, "method: get", `method: ""`, 1), "      then: third\n", "", 1),
+			Expected: []Diagnostic{
+				{
+					Range:    Range{Start: Position{Line: 19, Character: 14}, End: Position{Line: 19, Character: 20}},
+					Severity: severityError,
+					Code:     "required",
+					Source:   diagnosticSource,
+					Message:  "method is required",
+				},
+			},
+		},
+		{
+			Name: "undefined function",
+			Text: strings.Replace(strings.Replace(testWorkflow, "call: http", "call: myFunction", 1), "      then: third\n", "", 1),
+			Expected: []Diagnostic{
+				{
+					Range:    Range{Start: Position{Line: 16, Character: 10}, End: Position{Line: 16, Character: 17}},
+					Severity: severityError,
+					Source:   diagnosticSource,
+					Message:  "function 'myFunction' is not defined in use.functions",
+				},
+			},
+		},
+		{
+			Name: "invalid yaml",
+			Text: "document:\n  dsl: [\n",
+			Expected: []Diagnostic{
+				{
+					Range:    Range{Start: Position{Line: 1, Character: 0}, End: Position{Line: 1, Character: 8}},
+					Severity: severityError,
+					Source:   diagnosticSource,
+					Message:  "error converting yaml to json: yaml: line 2: did not find expected node content",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			replies := session(t, openDocument(test.Text))
+			require.Len(t, replies, 1)
+			assert.Equal(t, "textDocument/publishDiagnostics", replies[0]["method"])
+
+			body, err := json.Marshal(replies[0]["params"].(map[string]any)["diagnostics"])
+			require.NoError(t, err)
+
+			var diagnostics []Diagnostic
+			require.NoError(t, json.Unmarshal(body, &diagnostics))
+			assert.Equal(t, test.Expected, diagnostics)
+		})
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Text       string
+		Line       int
+		Character  int
+		Contains   []string
+		NotContain []string
+	}{
+		{
+			Name:       "then targets",
+			Text:       testWorkflow,
+			Line:       11,
+			Character:  12,
+			Contains:   []string{"continue", "exit", "end", "second", "third"},
+			NotContain: []string{"first", "attempt"},
+		},
+		{
+			Name:       "task keys",
+			Text:       strings.Replace(testWorkflow, "        seconds: 1\n", "        seconds: 1\n      \n", 1),
+			Line:       15,
+			Character:  6,
+			Contains:   []string{"set", "listen", "if", "then", "metadata"},
+			NotContain: []string{"wait", "heartbeat"},
+		},
+		{
+			Name:       "document metadata",
+			Text:       strings.Replace(testWorkflow, "    canMaxHistoryLength: 100\n", "    canMaxHistoryLength: 100\n    \n", 1),
+			Line:       7,
+			Character:  4,
+			Contains:   []string{"activityOptions", "scheduleId"},
+			NotContain: []string{"canMaxHistoryLength", "heartbeat"},
+		},
+		{
+			Name:       "task metadata",
+			Text:       strings.Replace(testWorkflow, "      wait:\n", "      metadata:\n        \n      wait:\n", 1),
+			Line:       14,
+			Character:  8,
+			Contains:   []string{"activityOptions", "heartbeat", "searchAttributes"},
+			NotContain: []string{"canMaxHistoryLength", "timeout"},
+		},
+		{
+			Name:       "task name",
+			Text:       testWorkflow,
+			Line:       8,
+			Character:  6,
+			NotContain: []string{"set"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			result := request(t, test.Text, "textDocument/completion", test.Line, test.Character)
+
+			labels := make([]string, 0)
+			for _, item := range result.(map[string]any)["items"].([]any) {
+				labels = append(labels, item.(map[string]any)["label"].(string))
+			}
+
+			for _, s := range test.Contains {
+				assert.Contains(t, labels, s)
+			}
+			for _, s := range test.NotContain {
+				assert.NotContains(t, labels, s)
+			}
+		})
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	runWorkflow := strings.Replace(testWorkflow, `      set:
+        hello: world`, `      run:
+        workflow:
+          name: child
+          namespace: zigflow
+          version: 0.0.1`, 1) + `
+  - child:
+      do:
+        - step:
+            wait:
+              seconds: 1`
+
+	tests := []struct {
+		Name      string
+		Text      string
+		Line      int
+		Character int
+		Expected  any
+	}{
+		{
+			Name:      "then target",
+			Text:      testWorkflow,
+			Line:      11,
+			Character: 14,
+			Expected: map[string]any{
+				"uri": testURI,
+				"range": map[string]any{
+					"start": map[string]any{"line": float64(15), "character": float64(4)},
+					"end":   map[string]any{"line": float64(15), "character": float64(9)},
+				},
+			},
+		},
+		{
+			Name:      "run workflow",
+			Text:      runWorkflow,
+			Line:      11,
+			Character: 17,
+			Expected: map[string]any{
+				"uri": testURI,
+				"range": map[string]any{
+					"start": map[string]any{"line": float64(30), "character": float64(4)},
+					"end":   map[string]any{"line": float64(30), "character": float64(9)},
+				},
+			},
+		},
+		{
+			Name:      "on the key",
+			Text:      testWorkflow,
+			Line:      11,
+			Character: 7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, request(t, test.Text, "textDocument/definition", test.Line, test.Character))
+		})
+	}
+}
+
+func TestHover(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Line      int
+		Character int
+		Contains  []string
+	}{
+		{
+			Name:      "top level task",
+			Line:      8,
+			Character: 6,
+			Contains:  []string{"**first** (set task)", "Runs in Temporal workflow `example`"},
+		},
+		{
+			Name:      "task starting child workflows",
+			Line:      15,
+			Character: 5,
+			Contains:  []string{"**third** (try task)", "- `workflow_try_third`", "- `workflow_catch_third`"},
+		},
+		{
+			Name:      "task in child workflow",
+			Line:      24,
+			Character: 12,
+			Contains:  []string{"**recover** (set task)", "Runs in Temporal workflow `workflow_catch_third`"},
+		},
+		{
+			Name:      "not a task",
+			Line:      9,
+			Character: 7,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			result := request(t, testWorkflow, "textDocument/hover", test.Line, test.Character)
+			if len(test.Contains) == 0 {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			value := result.(map[string]any)["contents"].(map[string]any)["value"].(string)
+			for _, s := range test.Contains {
+				assert.Contains(t, value, s)
+			}
+		})
+	}
+}
+
+func TestUTF16Positions(t *testing.T) {
+	// The emoji is four bytes but two UTF-16 code units
+	text := strings.ReplaceAll(testWorkflow, "third", "trois😀")
+
+	t.Run("definition range", func(t *testing.T) {
+		assert.Equal(t, map[string]any{
+			"uri": testURI,
+			"range": map[string]any{
+				"start": map[string]any{"line": float64(15), "character": float64(4)},
+				"end":   map[string]any{"line": float64(15), "character": float64(11)},
+			},
+		}, request(t, text, "textDocument/definition", 11, 14))
+	})
+
+	t.Run("hover on the end of the key", func(t *testing.T) {
+		assert.NotNil(t, request(t, text, "textDocument/hover", 15, 11))
+	})
+
+	t.Run("hover after the key", func(t *testing.T) {
+		assert.Nil(t, request(t, text, "textDocument/hover", 15, 12))
+	})
+
+	t.Run("diagnostic range", func(t *testing.T) {
+		text := strings.Replace(strings.Replace(text, "call: http", "call: myFunction", 1), "attempt", "é😀", 1)
+		replies := session(t, openDocument(text))
+		require.Len(t, replies, 1)
+
+		diagnostics := replies[0]["params"].(map[string]any)["diagnostics"].([]any)
+		require.NotEmpty(t, diagnostics)
+		assert.Equal(t, map[string]any{
+			"start": map[string]any{"line": float64(17), "character": float64(10)},
+			"end":   map[string]any{"line": float64(17), "character": float64(13)},
+		}, diagnostics[len(diagnostics)-1].(map[string]any)["range"])
+	})
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package scaffold creates new Zigflow projects from workflow templates.
+package scaffold
+
+import (
+	"bytes"
+	"crypto/rand"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// WorkflowFile is the workflow every template renders.
+	WorkflowFile = "workflow.yaml"
+	// TestFile is the test suite for the workflow, if the template has one.
+	TestFile = "workflow.test.yaml"
+	// CloudEventsFile is the CloudEvents client configuration.
+	CloudEventsFile = "cloudevents.yaml"
+	// KeysFile holds the keys the worker encrypts Temporal data with.
+	KeysFile = "keys.yaml"
+	// DockerfileFile builds an image with the workflow embedded in it.
+	DockerfileFile = "Dockerfile"
+
+	// DefaultTemplate is used if no template is chosen.
+	DefaultTemplate = "http-pipeline"
+
+	// templateExt is the optional extension of template files
+	templateExt = ".tmpl"
+)
+
+//go:embed templates extras
+var builtin embed.FS
+
+// Template is a workflow template.
+type Template struct {
+	Name    string `json:"name"`
+	Summary string `json:"summary"`
+	// Dir is the local directory the template is loaded from. It's empty for
+	// built-in templates.
+	Dir string `json:"dir,omitempty"`
+
+	fsys fs.FS
+}
+
+// Options configures the project that's created.
+type Options struct {
+	Template  string
+	Namespace string
+	Name      string
+	// TemplatesDir is a directory of local templates. A local template
+	// replaces the built-in template with the same name.
+	TemplatesDir string
+
+	CloudEvents bool
+	AESKeys     bool
+	Dockerfile  bool
+	Tests       bool
+}
+
+// File is a file of the created project.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// params are the values available to the templates.
+type params struct {
+	Namespace   string
+	Name        string
+	CloudEvents bool
+	AESKeys     bool
+	Tests       bool
+	// Key is a random key for the AES keys file
+	Key string
+}
+
+// Templates lists the built-in templates and any in the local templates
+// directory, sorted by name.
+func Templates(templatesDir string) ([]*Template, error) {
+	sub, err := fs.Sub(builtin, "templates")
+	if err != nil {
+		return nil, err
+	}
+	templates, err := loadTemplates(sub, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if templatesDir != "" {
+		local, err := loadTemplates(os.DirFS(templatesDir), templatesDir)
+		if err != nil {
+			return nil, fmt.Errorf("error loading templates from %s: %w", templatesDir, err)
+		}
+		for _, l := range local {
+			templates = slices.DeleteFunc(templates, func(t *Template) bool {
+				return t.Name == l.Name
+			})
+			templates = append(templates, l)
+		}
+	}
+
+	slices.SortFunc(templates, func(a, b *Template) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return templates, nil
+}
+
+// loadTemplates loads each directory with a workflow template as a template.
+func loadTemplates(fsys fs.FS, dir string) ([]*Template, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*Template, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		sub, err := fs.Sub(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if _, err := readTemplate(sub, WorkflowFile); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		t := &Template{Name: e.Name(), fsys: sub}
+		if dir != "" {
+			t.Dir = filepath.Join(dir, e.Name())
+		}
+
+		// The summary is taken from the workflow the template renders
+		workflow, err := t.render(WorkflowFile, &params{Namespace: "zigflow", Name: t.Name})
+		if err != nil {
+			return nil, err
+		}
+		var doc struct {
+			Document struct {
+				Summary string `json:"summary"`
+			} `json:"document"`
+		}
+		if err := yaml.Unmarshal(workflow, &doc); err != nil {
+			return nil, fmt.Errorf("error parsing %s template: %w", t.Name, err)
+		}
+		t.Summary = doc.Document.Summary
+
+		templates = append(templates, t)
+	}
+
+	return templates, nil
+}
+
+// Render renders the files of a new project.
+func Render(opts Options) ([]File, error) {
+	templates, err := Templates(opts.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Template
+	if name == "" {
+		name = DefaultTemplate
+	}
+	idx := slices.IndexFunc(templates, func(t *Template) bool {
+		return t.Name == name
+	})
+	if idx < 0 {
+		return nil, fmt.Errorf("unknown template: %q", name)
+	}
+	t := templates[idx]
+
+	p := &params{
+		Namespace:   opts.Namespace,
+		Name:        opts.Name,
+		CloudEvents: opts.CloudEvents,
+		AESKeys:     opts.AESKeys,
+		Tests:       opts.Tests,
+	}
+	if opts.AESKeys {
+		// AES-256 needs a 32 byte key
+		p.Key = (rand.Text() + rand.Text())[:32]
+	}
+
+	workflow, err := t.render(WorkflowFile, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(workflow); err != nil {
+		return nil, fmt.Errorf("%s template renders an invalid workflow: %w", t.Name, err)
+	}
+	files := []File{{Path: WorkflowFile, Content: workflow}}
+
+	if opts.Tests {
+		tests, err := t.render(TestFile, p)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%s template has no test suite", t.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: TestFile, Content: tests})
+	}
+
+	extras, err := fs.Sub(builtin, "extras")
+	if err != nil {
+		return nil, err
+	}
+	for _, extra := range []struct {
+		enabled bool
+		path    string
+	}{
+		{opts.CloudEvents, CloudEventsFile},
+		{opts.AESKeys, KeysFile},
+		{opts.Dockerfile, DockerfileFile},
+	} {
+		if !extra.enabled {
+			continue
+		}
+		content, err := (&Template{Name: "extras", fsys: extras}).render(extra.path, p)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: extra.path, Content: content})
+	}
+
+	return files, nil
+}
+
+// Write writes the files to the directory. Existing files are only replaced
+// if force is set.
+func Write(dir string, files []File, force bool) error {
+	if !force {
+		for _, f := range files {
+			path := filepath.Join(dir, f.Path)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("file already exists: %s", path)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+
+	for _, f := range files {
+		perm := os.FileMode(0o644)
+		if f.Path == KeysFile {
+			perm = 0o600
+		}
+		if err := os.WriteFile(filepath.Join(dir, f.Path), f.Content, perm); err != nil {
+			return fmt.Errorf("error writing %s: %w", f.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// render executes one of the template's files.
+func (t *Template) render(name string, p *params) ([]byte, error) {
+	src, err := readTemplate(t.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := template.New(name).Option("missingkey=error").Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s template %s: %w", t.Name, name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, p); err != nil {
+		return nil, fmt.Errorf("error rendering %s template %s: %w", t.Name, name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readTemplate reads a template file, which may have the .tmpl extension.
+func readTemplate(fsys fs.FS, name string) ([]byte, error) {
+	src, err := fs.ReadFile(fsys, name+templateExt)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fs.ReadFile(fsys, name)
+	}
+	return src, err
+}
+
+// validate checks a rendered workflow is valid.
+func validate(workflow []byte) error {
+	wf, err := zigflow.LoadFromBytes(workflow)
+	if err != nil {
+		return err
+	}
+
+	validator, err := utils.NewValidator()
+	if err != nil {
+		return err
+	}
+	res, err := validator.ValidateStruct(wf)
+	if err != nil {
+		return err
+	}
+	if len(res) > 0 {
+		return fmt.Errorf("%s: %s", res[0].Path, res[0].Message)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scaffold_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/scaffold"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+func TestTemplates(t *testing.T) {
+	templates, err := scaffold.Templates("")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(templates))
+	for _, tpl := range templates {
+		names = append(names, tpl.Name)
+		assert.NotEmpty(t, tpl.Summary, tpl.Name)
+		assert.Empty(t, tpl.Dir, tpl.Name)
+	}
+	assert.Equal(t, []string{"approval", "fork", "http-pipeline", "schedule", "try-catch"}, names)
+}
+
+func TestTemplates_Local(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"http-pipeline/workflow.yaml.tmpl": localWorkflow,
+		"custom/workflow.yaml":             localWorkflow,
+		"not-a-template/README.md":         "# Not a template",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0o600))
+	}
+
+	templates, err := scaffold.Templates(dir)
+	require.NoError(t, err)
+
+	found := map[string]*scaffold.Template{}
+	for _, tpl := range templates {
+		found[tpl.Name] = tpl
+	}
+	assert.Len(t, found, 6)
+	assert.NotContains(t, found, "not-a-template")
+
+	require.Contains(t, found, "custom")
+	assert.Equal(t, "A local template", found["custom"].Summary)
+	assert.Equal(t, filepath.Join(dir, "custom"), found["custom"].Dir)
+
+	// Local templates replace built-in ones
+	require.Contains(t, found, "http-pipeline")
+	assert.Equal(t, "A local template", found["http-pipeline"].Summary)
+
+	files, err := scaffold.Render(scaffold.Options{
+		Template:     "custom",
+		Namespace:    "team",
+		Name:         "local",
+		TemplatesDir: dir,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, string(files[0].Content), "namespace: team\n  name: local\n")
+}
+
+const localWorkflow = `document:
+  dsl: 1.0.0
+  namespace: {{ .Namespace }}
+  name: {{ .Name }}
+  version: 0.0.1
+  summary: A local template
+do:
+  - step:
+      set:
+        hello: world
+`
+
+func TestRender(t *testing.T) {
+	templates, err := scaffold.Templates("")
+	require.NoError(t, err)
+
+	for _, tpl := range templates {
+		t.Run(tpl.Name, func(t *testing.T) {
+			files, err := scaffold.Render(scaffold.Options{
+				Template:  tpl.Name,
+				Namespace: "team",
+				Name:      "my-workflow",
+				Tests:     true,
+			})
+			require.NoError(t, err)
+			require.Len(t, files, 2)
+			assert.Equal(t, scaffold.WorkflowFile, files[0].Path)
+			assert.Equal(t, scaffold.TestFile, files[1].Path)
+
+			wf, err := zigflow.LoadFromBytes(files[0].Content)
+			require.NoError(t, err)
+			assert.Equal(t, "team", wf.Document.Namespace)
+			assert.Equal(t, "my-workflow", wf.Document.Name)
+
+			// The starter test suite passes against the workflow
+			dir := t.TempDir()
+			require.NoError(t, scaffold.Write(dir, files, false))
+			res := testrunner.Run(filepath.Join(dir, scaffold.TestFile), "")
+			assert.Empty(t, res.Error)
+			assert.NotEmpty(t, res.Cases)
+			for _, c := range res.Cases {
+				assert.True(t, c.Passed, "%s: %v", c.Name, c.Failures)
+			}
+		})
+	}
+}
+
+func TestRender_Extras(t *testing.T) {
+	files, err := scaffold.Render(scaffold.Options{
+		Namespace:   "team",
+		Name:        "my-workflow",
+		CloudEvents: true,
+		AESKeys:     true,
+		Dockerfile:  true,
+	})
+	require.NoError(t, err)
+
+	content := map[string]string{}
+	for _, f := range files {
+		content[f.Path] = string(f.Content)
+	}
+	require.Len(t, content, 4)
+
+	assert.Contains(t, content[scaffold.WorkflowFile], "name: my-workflow")
+	assert.Contains(t, content[scaffold.CloudEventsFile], "target: /tmp/my-workflow-events")
+	assert.Regexp(t, `key: [A-Z2-7]{32}\n`, content[scaffold.KeysFile])
+	assert.Contains(t, content[scaffold.DockerfileFile], "COPY ./workflow.yaml /app/workflow.yaml\n")
+	assert.Contains(t, content[scaffold.DockerfileFile], "ENV CLOUDEVENTS_CONFIG=/app/cloudevents.yaml\n")
+	assert.Contains(t, content[scaffold.DockerfileFile], "ENV CONVERT_DATA=aes\n")
+
+	// The config must load without the optional environment variables set
+	t.Setenv("CLOUDEVENTS_HTTP_TARGET", "")
+	require.NoError(t, os.Unsetenv("CLOUDEVENTS_HTTP_TARGET"))
+
+	file := filepath.Join(t.TempDir(), scaffold.CloudEventsFile)
+	require.NoError(t, os.WriteFile(file, []byte(content[scaffold.CloudEventsFile]), 0o600))
+
+	validator, err := utils.NewValidator()
+	require.NoError(t, err)
+
+	res, err := cloudevents.Validate(file, validator)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestRender_Errors(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Opts  scaffold.Options
+		Error string
+	}{
+		{
+			Name:  "unknown template",
+			Opts:  scaffold.Options{Template: "unknown", Namespace: "team", Name: "test"},
+			Error: `unknown template: "unknown"`,
+		},
+		{
+			Name:  "invalid workflow",
+			Opts:  scaffold.Options{Namespace: "team", Name: ""},
+			Error: "http-pipeline template renders an invalid workflow",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, err := scaffold.Render(test.Opts)
+			assert.ErrorContains(t, err, test.Error)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "project")
+	files := []scaffold.File{
+		{Path: scaffold.WorkflowFile, Content: []byte("first")},
+		{Path: scaffold.KeysFile, Content: []byte("secret")},
+	}
+
+	require.NoError(t, scaffold.Write(dir, files, false))
+
+	info, err := os.Stat(filepath.Join(dir, scaffold.KeysFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Existing files are only replaced when forced
+	files[0].Content = []byte("second")
+	assert.ErrorContains(t, scaffold.Write(dir, files, false), "file already exists")
+
+	require.NoError(t, scaffold.Write(dir, files, true))
+	data, err := os.ReadFile(filepath.Join(dir, scaffold.WorkflowFile))
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+)
+
+var durationType = reflect.TypeFor[model.Duration]()
+
+// fromType builds a schema from a Go type. Structs use their json tags for
+// property names, description tags for descriptions and the required and
+// oneof rules in their validate tags.
+func fromType(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == durationType {
+		return map[string]any{"$ref": "#/$defs/duration"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": fromType(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": fromType(t.Elem())}
+	case reflect.Struct:
+		return fromStruct(t)
+	default:
+		// Anything
+		return map[string]any{}
+	}
+}
+
+func fromStruct(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		p := fromType(f.Type)
+		if d := f.Tag.Get("description"); d != "" {
+			p["description"] = d + "."
+		}
+
+		for rule := range strings.SplitSeq(f.Tag.Get("validate"), ",") {
+			switch {
+			case rule == "required":
+				required = append(required, name)
+			case strings.HasPrefix(rule, "oneof="):
+				p["enum"] = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			}
+		}
+
+		properties[name] = p
+	}
+
+	s := map[string]any{
+		"type":                 "object",
+		"title":                t.Name(),
+		"additionalProperties": false,
+		"properties":           properties,
+	}
+	if len(required) > 0 {
+		slices.Sort(required)
+		s["required"] = required
+	}
+	return s
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+	"sigs.k8s.io/yaml"
+)
+
+// Kind is the type of file a schema describes
+type Kind string
+
+const (
+	KindWorkflow    Kind = "workflow"
+	KindCloudEvents Kind = "cloudevents"
+	KindKeys        Kind = "keys"
+)
+
+// Kinds lists every kind of schema
+var Kinds = []Kind{KindWorkflow, KindCloudEvents, KindKeys}
+
+const (
+	draft = "https://json-schema.org/draft/2020-12/schema"
+
+	// Matches a Go duration, such as 1m30s
+	goDurationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+)
+
+// Build returns the schema of a kind. The workflow schema extends the
+// Serverless Workflow schema, base.
+func Build(kind Kind, base []byte) (map[string]any, error) {
+	switch kind {
+	case KindWorkflow:
+		return Workflow(base)
+	case KindCloudEvents:
+		return CloudEvents(), nil
+	case KindKeys:
+		return Keys(), nil
+	default:
+		return nil, fmt.Errorf("unknown schema: %q", kind)
+	}
+}
+
+// Workflow extends the Serverless Workflow schema with the metadata and
+// event properties Zigflow reads
+func Workflow(base []byte) (map[string]any, error) {
+	var s map[string]any
+	if err := yaml.Unmarshal(base, &s); err != nil {
+		return nil, fmt.Errorf("error parsing workflow schema: %w", err)
+	}
+
+	defs, err := object(s, "$defs")
+	if err != nil {
+		return nil, err
+	}
+
+	defs["zigflowActivityOptions"] = fromType(reflect.TypeFor[metadata.ActivityOptions]())
+
+	searchAttribute := fromType(reflect.TypeFor[metadata.SearchAttribute]())
+	searchAttribute["properties"].(map[string]any)["type"].(map[string]any)["enum"] = metadata.SearchAttributeTypes
+	defs["zigflowSearchAttribute"] = searchAttribute
+
+	defs["zigflowDocumentMetadata"] = map[string]any{
+		"type":                 "object",
+		"title":                "ZigflowDocumentMetadata",
+		"description":          "Zigflow settings for the workflow.",
+		"additionalProperties": true,
+		"properties": map[string]any{
+			metadata.MetadataActivityOptions: map[string]any{
+				"$ref":        "#/$defs/zigflowActivityOptions",
+				"description": "Default options for every activity in the workflow.",
+			},
+			metadata.MaxHistoryLengthAttribute: map[string]any{
+				"type":        "integer",
+				"minimum":     1,
+				"description": "Continue as new once the workflow history reaches this many events.",
+			},
+			metadata.MetadataScheduleID: map[string]any{
+				"type":        "string",
+				"description": `ID of the schedule that starts the workflow. Defaults to "zigflow_<document.name>".`,
+			},
+			metadata.MetadataScheduleWorkflowName: map[string]any{
+				"type":        "string",
+				"description": "Workflow started by the schedule.",
+			},
+			metadata.MetadataScheduleInput: map[string]any{
+				"type":        "array",
+				"description": "Input for the scheduled workflow. Runtime expressions can read environment variables.",
+			},
+		},
+	}
+
+	defs["zigflowTaskMetadata"] = map[string]any{
+		"type":                 "object",
+		"title":                "ZigflowTaskMetadata",
+		"description":          "Zigflow settings for the task.",
+		"additionalProperties": true,
+		"properties": map[string]any{
+			metadata.MetadataActivityOptions: map[string]any{
+				"$ref":        "#/$defs/zigflowActivityOptions",
+				"description": "Options for the activities run by this task.",
+			},
+			metadata.MetadataHeartbeat: map[string]any{
+				"$ref":        "#/$defs/duration",
+				"description": "How often the activity heartbeats.",
+			},
+			metadata.MetadataRetry: map[string]any{
+				"description": "How the task's activities or child workflow are retried. Either the name of a policy in use.retries or a retry policy.",
+				"oneOf": []any{
+					map[string]any{"type": "string"},
+					map[string]any{"$ref": "#/$defs/retryPolicy"},
+				},
+			},
+			metadata.MetadataSearchAttribute: map[string]any{
+				"type":                 "object",
+				"description":          "Search attributes to set when the task runs, keyed by name.",
+				"additionalProperties": map[string]any{"$ref": "#/$defs/zigflowSearchAttribute"},
+			},
+			"timeout": map[string]any{
+				"type":        "string",
+				"pattern":     goDurationPattern,
+				"description": "Listen tasks only. How long to wait for the event, as a Go duration such as 30s.",
+			},
+		},
+	}
+
+	// Use Zigflow's metadata for the document and every task
+	for _, path := range [][]string{
+		{"properties", "document", "properties", "metadata"},
+		{"$defs", "taskBase", "properties", "metadata"},
+	} {
+		m, err := object(s, path...)
+		if err != nil {
+			return nil, err
+		}
+		ref := "#/$defs/zigflowTaskMetadata"
+		if path[1] == "document" {
+			ref = "#/$defs/zigflowDocumentMetadata"
+		}
+		m["$ref"] = ref
+	}
+
+	event, err := object(s, "$defs", "eventProperties", "properties")
+	if err != nil {
+		return nil, err
+	}
+	event["acceptIf"] = map[string]any{
+		"title":       "EventAcceptIf",
+		"description": "Zigflow only. A runtime expression that must be true for a signal or update to be accepted.",
+		"oneOf": []any{
+			map[string]any{"type": "boolean"},
+			map[string]any{"$ref": "#/$defs/runtimeExpression"},
+		},
+	}
+	event["schema"] = map[string]any{
+		"$ref":        "#/$defs/schema",
+		"title":       "EventSchema",
+		"description": "Zigflow only. The schema the data of a signal or update must match.",
+	}
+	if data, ok := event["data"].(map[string]any); ok {
+		data["description"] = "The event's payload data. For a query or update, the data returned to the caller."
+	}
+
+	return s, nil
+}
+
+// CloudEvents is the schema of the --cloudevents-config file
+func CloudEvents() map[string]any {
+	s := fromType(reflect.TypeFor[cloudevents.Events]())
+	s["$schema"] = draft
+	s["title"] = "Zigflow CloudEvents config"
+
+	client := s["properties"].(map[string]any)["clients"].(map[string]any)["items"].(map[string]any)
+	options := client["properties"].(map[string]any)["options"].(map[string]any)
+	options["properties"] = map[string]any{
+		"method": map[string]any{
+			"type":        "string",
+			"description": "HTTP only. Method used to send the event. Defaults to POST.",
+		},
+		"timeout": map[string]any{
+			"type":        "string",
+			"pattern":     goDurationPattern,
+			"description": "HTTP only. Request timeout, as a Go duration such as 5s. Defaults to 1s.",
+		},
+		"headers": map[string]any{
+			"type":                 "object",
+			"description":          "HTTP only. Headers sent with the event.",
+			"additionalProperties": map[string]any{"type": "string"},
+		},
+	}
+
+	return s
+}
+
+// Keys is the schema of the AES key file. The first key encrypts and every
+// key can decrypt.
+func Keys() map[string]any {
+	return map[string]any{
+		"$schema":     draft,
+		"title":       "Zigflow AES keys",
+		"description": "The first key is used to encrypt data. Later keys are only used to decrypt data encrypted with them.",
+		"type":        "array",
+		"minItems":    1,
+		"items": map[string]any{
+			"type":                 "object",
+			"title":                "Key",
+			"additionalProperties": false,
+			"required":             []string{"id", "key"},
+			"properties": map[string]any{
+				"id": map[string]any{
+					"type":        "string",
+					"minLength":   1,
+					"description": "Unique ID of the key, stored with the data it encrypts.",
+				},
+				"key": map[string]any{
+					"type":        "string",
+					"pattern":     "^(.{16}|.{24}|.{32})$",
+					"description": "16, 24 or 32 byte key, for AES-128, AES-192 or AES-256.",
+				},
+			},
+		},
+	}
+}
+
+// object returns the object at a path in a schema
+func object(s map[string]any, path ...string) (map[string]any, error) {
+	for _, p := range path {
+		next, ok := s[p].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("workflow schema has no %q object", p)
+		}
+		s = next
+	}
+	return s, nil
+}
@@ -0,0 +1,300 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	swUtil "github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/schema"
+	"sigs.k8s.io/yaml"
+)
+
+func workflowSchema(t *testing.T) map[string]any {
+	t.Helper()
+
+	base, err := os.ReadFile(filepath.Join("..", "..", "cmd", "schema.yaml"))
+	require.NoError(t, err)
+
+	s, err := schema.Build(schema.KindWorkflow, base)
+	require.NoError(t, err)
+	return s
+}
+
+func validate(t *testing.T, s map[string]any, data string) error {
+	t.Helper()
+
+	var v any
+	require.NoError(t, yaml.Unmarshal([]byte(data), &v))
+	return swUtil.ValidateSchema(v, &model.Schema{Document: s}, "test")
+}
+
+func TestWorkflow_Refs(t *testing.T) {
+	s := workflowSchema(t)
+	defs := s["$defs"].(map[string]any)
+
+	var walk func(v any)
+	walk = func(v any) {
+		switch val := v.(type) {
+		case map[string]any:
+			if ref, ok := val["$ref"].(string); ok && strings.HasPrefix(ref, "#/$defs/") {
+				assert.Contains(t, defs, strings.TrimPrefix(ref, "#/$defs/"), ref)
+			}
+			for _, item := range val {
+				walk(item)
+			}
+		case []any:
+			for _, item := range val {
+				walk(item)
+			}
+		}
+	}
+	walk(s)
+
+	document := s["properties"].(map[string]any)["document"].(map[string]any)["properties"].(map[string]any)["metadata"].(map[string]any)
+	assert.Equal(t, "#/$defs/zigflowDocumentMetadata", document["$ref"])
+
+	task := defs["taskBase"].(map[string]any)["properties"].(map[string]any)["metadata"].(map[string]any)
+	assert.Equal(t, "#/$defs/zigflowTaskMetadata", task["$ref"])
+
+	event := defs["eventProperties"].(map[string]any)["properties"].(map[string]any)
+	assert.Contains(t, event, "acceptIf")
+	assert.Contains(t, event, "schema")
+}
+
+func TestWorkflow_Metadata(t *testing.T) {
+	defs := workflowSchema(t)["$defs"].(map[string]any)
+
+	// The upstream patterns aren't valid Go regular expressions, so only the
+	// Zigflow definitions are checked
+	metadataSchema := func(ref string) map[string]any {
+		return map[string]any{
+			"$ref": "#/$defs/" + ref,
+			"$defs": map[string]any{
+				"zigflowActivityOptions":  defs["zigflowActivityOptions"],
+				"zigflowSearchAttribute":  defs["zigflowSearchAttribute"],
+				"zigflowDocumentMetadata": defs["zigflowDocumentMetadata"],
+				"zigflowTaskMetadata":     defs["zigflowTaskMetadata"],
+				"duration":                map[string]any{"type": []string{"object", "string"}},
+				"retryPolicy":             map[string]any{"type": "object"},
+			},
+		}
+	}
+
+	tests := []struct {
+		Name        string
+		Ref         string
+		Data        string
+		ExpectError bool
+	}{
+		{
+			Name: "document metadata",
+			Ref:  "zigflowDocumentMetadata",
+			Data: `activityOptions:
+  startToCloseTimeout:
+    minutes: 1
+  retryPolicy:
+    maximumAttempts: 3
+canMaxHistoryLength: 100
+scheduleId: daily
+scheduleInput:
+  - hello
+display: false`,
+		},
+		{
+			Name:        "document max history length must be an integer",
+			Ref:         "zigflowDocumentMetadata",
+			Data:        "canMaxHistoryLength: lots",
+			ExpectError: true,
+		},
+		{
+			Name:        "document schedule input must be an array",
+			Ref:         "zigflowDocumentMetadata",
+			Data:        "scheduleInput: hello",
+			ExpectError: true,
+		},
+		{
+			Name: "task metadata",
+			Ref:  "zigflowTaskMetadata",
+			Data: `heartbeat:
+  seconds: 10
+searchAttributes:
+  Step:
+    type: text
+    value: Validate
+timeout: 1m30s`,
+		},
+		{
+			Name: "named retry policy",
+			Ref:  "zigflowTaskMetadata",
+			Data: "retry: default",
+		},
+		{
+			Name: "inline retry policy",
+			Ref:  "zigflowTaskMetadata",
+			Data: "retry:\n  limit:\n    attempt:\n      count: 3",
+		},
+		{
+			Name:        "retry must be a name or policy",
+			Ref:         "zigflowTaskMetadata",
+			Data:        "retry: 3",
+			ExpectError: true,
+		},
+		{
+			Name:        "unknown activity option",
+			Ref:         "zigflowTaskMetadata",
+			Data:        "activityOptions:\n  startToClose: 10s",
+			ExpectError: true,
+		},
+		{
+			Name:        "maximum attempts must be an integer",
+			Ref:         "zigflowTaskMetadata",
+			Data:        "activityOptions:\n  retryPolicy:\n    maximumAttempts: five",
+			ExpectError: true,
+		},
+		{
+			Name:        "unknown search attribute type",
+			Ref:         "zigflowTaskMetadata",
+			Data:        "searchAttributes:\n  Step:\n    type: string",
+			ExpectError: true,
+		},
+		{
+			Name:        "listen timeout must be a Go duration",
+			Ref:         "zigflowTaskMetadata",
+			Data:        "timeout: 1 minute",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := validate(t, metadataSchema(test.Ref), test.Data)
+			if test.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCloudEvents(t *testing.T) {
+	example, err := os.ReadFile(filepath.Join("..", "..", "cloudevents.example.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name        string
+		Data        string
+		ExpectError bool
+	}{
+		{
+			Name: "example",
+			Data: string(example),
+		},
+		{
+			Name: "http options",
+			Data: `clients:
+  - name: http
+    protocol: http
+    target: http://localhost:8080
+    options:
+      method: put
+      timeout: 5s
+      headers:
+        authorization: token`,
+		},
+		{
+			Name:        "unknown protocol",
+			Data:        "clients:\n  - name: ftp\n    protocol: ftp",
+			ExpectError: true,
+		},
+		{
+			Name:        "missing name",
+			Data:        "clients:\n  - protocol: file",
+			ExpectError: true,
+		},
+		{
+			Name:        "non-string header",
+			Data:        "clients:\n  - name: http\n    options:\n      headers:\n        retries: 3",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := validate(t, schema.CloudEvents(), test.Data)
+			if test.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	example, err := os.ReadFile(filepath.Join("..", "..", "keys.example.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name        string
+		Data        string
+		ExpectError bool
+	}{
+		{
+			Name: "example",
+			Data: string(example),
+		},
+		{
+			Name:        "no keys",
+			Data:        "[]",
+			ExpectError: true,
+		},
+		{
+			Name:        "missing ID",
+			Data:        "- key: passphrasewhichneedstobe32bytes!",
+			ExpectError: true,
+		},
+		{
+			Name:        "wrong key length",
+			Data:        "- id: key0\n  key: short",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			err := validate(t, schema.Keys(), test.Data)
+			if test.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuild_UnknownKind(t *testing.T) {
+	_, err := schema.Build(schema.Kind("unknown"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// DefaultEnvPrefix is the prefix of the envvars that secrets are read from
+const DefaultEnvPrefix = "ZIGFLOW_SECRET_"
+
+// EnvProvider reads secrets from environment variables. The secret "token"
+// is read from the envvar "<prefix>token".
+type EnvProvider struct {
+	Prefix string
+}
+
+func (e *EnvProvider) Get(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(e.Prefix + name)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return value, nil
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("ZIGFLOW_TEST_SECRET_token", "abc123")
+	t.Setenv("ZIGFLOW_TEST_SECRET_empty", "")
+
+	p := &EnvProvider{Prefix: "ZIGFLOW_TEST_SECRET_"}
+
+	v, err := p.Get(context.Background(), "token")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", v)
+
+	v, err = p.Get(context.Background(), "empty")
+	assert.NoError(t, err)
+	assert.Empty(t, v)
+
+	_, err = p.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
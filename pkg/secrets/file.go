@@ -0,0 +1,50 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads secrets from a directory with one file per secret, such
+// as a mounted Kubernetes secret. Trailing newlines are removed.
+type FileProvider struct {
+	Dir string
+}
+
+func (f *FileProvider) Get(_ context.Context, name string) (string, error) {
+	// Secret names must not escape the directory
+	if !filepath.IsLocal(name) || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid secret name: %q", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(f.Dir, name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return "", fmt.Errorf("error reading secret %q: %w", name, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
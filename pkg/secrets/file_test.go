@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("abc123\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "multiline"), []byte("line1\nline2\n"), 0o600))
+
+	p := &FileProvider{Dir: dir}
+
+	tests := []struct {
+		Name     string
+		Secret   string
+		Expected string
+		NotFound bool
+		Error    string
+	}{
+		{
+			Name:     "trailing newline is removed",
+			Secret:   "token",
+			Expected: "abc123",
+		},
+		{
+			Name:     "multiline value",
+			Secret:   "multiline",
+			Expected: "line1\nline2",
+		},
+		{
+			Name:     "missing file",
+			Secret:   "missing",
+			NotFound: true,
+		},
+		{
+			Name:   "outside the directory",
+			Secret: "../token",
+			Error:  `invalid secret name: "../token"`,
+		},
+		{
+			Name:   "subdirectory",
+			Secret: "nested/token",
+			Error:  `invalid secret name: "nested/token"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			v, err := p.Get(context.Background(), test.Secret)
+			switch {
+			case test.NotFound:
+				assert.ErrorIs(t, err, ErrNotFound)
+			case test.Error != "":
+				assert.EqualError(t, err, test.Error)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.Expected, v)
+			}
+		})
+	}
+}
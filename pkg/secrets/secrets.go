@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package secrets resolves the values of the secrets declared in a workflow's
+// use.secrets. Values are only read by activities so they never enter the
+// workflow history.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type ProviderType string
+
+const (
+	ProviderEnv   ProviderType = "env"
+	ProviderFile  ProviderType = "file"
+	ProviderVault ProviderType = "vault"
+)
+
+// ErrNotFound is returned when the provider does not have the secret
+var ErrNotFound = errors.New("secret not found")
+
+// Provider returns the value of a secret by its name
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// Options configures the provider. Only the fields for the chosen type are
+// used.
+type Options struct {
+	Type ProviderType
+
+	// Environment variables
+	EnvPrefix string
+
+	// Mounted files
+	Dir string
+
+	// Vault-compatible KV v2 API
+	VaultAddress string
+	VaultMount   string
+	VaultPath    string
+	VaultToken   string
+}
+
+// New creates the provider for the options type
+func New(opts Options) (Provider, error) {
+	switch opts.Type {
+	case ProviderEnv:
+		return &EnvProvider{Prefix: opts.EnvPrefix}, nil
+	case ProviderFile:
+		if opts.Dir == "" {
+			return nil, fmt.Errorf("secrets directory is required for the %q provider", opts.Type)
+		}
+		return &FileProvider{Dir: opts.Dir}, nil
+	case ProviderVault:
+		vault, err := NewVaultProvider(opts.VaultAddress, opts.VaultToken, opts.VaultMount, opts.VaultPath)
+		if err != nil {
+			return nil, err
+		}
+		return vault, nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid secrets provider %q (must be %q, %q or %q)",
+			opts.Type,
+			ProviderEnv,
+			ProviderFile,
+			ProviderVault,
+		)
+	}
+}
+
+type contextKey struct{}
+
+// WithProvider returns a context that carries the provider. Activities get it
+// from the worker's background activity context.
+func WithProvider(ctx context.Context, provider Provider) context.Context {
+	return context.WithValue(ctx, contextKey{}, provider)
+}
+
+// FromContext returns the provider in the context, or nil if there isn't one
+func FromContext(ctx context.Context) Provider {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(contextKey{}).(Provider)
+	return p
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Opts     Options
+		Expected Provider
+		Error    string
+	}{
+		{
+			Name:     "env",
+			Opts:     Options{Type: ProviderEnv, EnvPrefix: "SECRET_"},
+			Expected: &EnvProvider{Prefix: "SECRET_"},
+		},
+		{
+			Name:     "file",
+			Opts:     Options{Type: ProviderFile, Dir: "/var/run/secrets"},
+			Expected: &FileProvider{Dir: "/var/run/secrets"},
+		},
+		{
+			Name:  "file without directory",
+			Opts:  Options{Type: ProviderFile},
+			Error: `secrets directory is required for the "file" provider`,
+		},
+		{
+			Name:  "vault without path",
+			Opts:  Options{Type: ProviderVault, VaultAddress: "http://localhost:8200"},
+			Error: `vault path is required for the "vault" provider`,
+		},
+		{
+			Name:  "unknown provider",
+			Opts:  Options{Type: "aws"},
+			Error: `invalid secrets provider "aws" (must be "env", "file" or "vault")`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			p, err := New(test.Opts)
+			if test.Error != "" {
+				assert.EqualError(t, err, test.Error)
+				assert.Nil(t, p)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, p)
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	p := &EnvProvider{}
+
+	assert.Equal(t, p, FromContext(WithProvider(context.Background(), p)))
+	assert.Nil(t, FromContext(context.Background()))
+}
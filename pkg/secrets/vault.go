@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultVaultMount = "secret"
+
+// VaultProvider reads secrets from the fields of a single secret in a
+// Vault-compatible KV v2 API, such as HashiCorp Vault or OpenBao
+type VaultProvider struct {
+	Address string
+	Mount   string
+	Path    string
+	Token   string
+
+	Client *http.Client
+}
+
+func NewVaultProvider(address, token, mount, path string) (*VaultProvider, error) {
+	if address == "" {
+		return nil, fmt.Errorf("vault address is required for the %q provider", ProviderVault)
+	}
+	if _, err := url.Parse(address); err != nil {
+		return nil, fmt.Errorf("invalid vault address: %w", err)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("vault path is required for the %q provider", ProviderVault)
+	}
+	if mount == "" {
+		mount = DefaultVaultMount
+	}
+
+	return &VaultProvider{
+		Address: strings.TrimSuffix(address, "/"),
+		Mount:   strings.Trim(mount, "/"),
+		Path:    strings.Trim(path, "/"),
+		Token:   token,
+		Client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+func (v *VaultProvider) Get(ctx context.Context, name string) (string, error) {
+	data, err := v.read(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[name]
+	if !ok || value == nil {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	// Return other types as JSON
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("error encoding secret %q: %w", name, err)
+	}
+	return string(b), nil
+}
+
+// read gets the latest version of the secret's fields
+func (v *VaultProvider) read(ctx context.Context) (map[string]any, error) {
+	endpoint := fmt.Sprintf("%s/v1/%s/data/%s", v.Address, v.Mount, v.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating vault request: %w", err)
+	}
+	if v.Token != "" {
+		req.Header.Set("X-Vault-Token", v.Token)
+	}
+
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	// #nosec G704 -- URL is operator-defined by the worker configuration
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling vault: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: vault path %s/%s does not exist", ErrNotFound, v.Mount, v.Path)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("error decoding vault response: %w", err)
+	}
+
+	return body.Data.Data, nil
+}
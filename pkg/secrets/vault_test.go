@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/kv/data/zigflow/prod" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"token":"abc123","port":5432},"metadata":{"version":3}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		Name     string
+		Token    string
+		Path     string
+		Secret   string
+		Expected string
+		NotFound bool
+		Error    string
+	}{
+		{
+			Name:     "string field",
+			Token:    "root",
+			Path:     "/zigflow/prod/",
+			Secret:   "token",
+			Expected: "abc123",
+		},
+		{
+			Name:     "other types are JSON",
+			Token:    "root",
+			Path:     "zigflow/prod",
+			Secret:   "port",
+			Expected: "5432",
+		},
+		{
+			Name:     "missing field",
+			Token:    "root",
+			Path:     "zigflow/prod",
+			Secret:   "password",
+			NotFound: true,
+		},
+		{
+			Name:     "missing path",
+			Token:    "root",
+			Path:     "zigflow/dev",
+			Secret:   "token",
+			NotFound: true,
+		},
+		{
+			Name:   "bad token",
+			Token:  "invalid",
+			Path:   "zigflow/prod",
+			Secret: "token",
+			Error:  "vault returned 403 Forbidden",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			p, err := NewVaultProvider(server.URL+"/", test.Token, "kv", test.Path)
+			require.NoError(t, err)
+
+			v, err := p.Get(context.Background(), test.Secret)
+			switch {
+			case test.NotFound:
+				assert.ErrorIs(t, err, ErrNotFound)
+			case test.Error != "":
+				assert.EqualError(t, err, test.Error)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, test.Expected, v)
+			}
+		})
+	}
+}
+
+func TestNewVaultProvider_DefaultMount(t *testing.T) {
+	p, err := NewVaultProvider("http://localhost:8200", "", "", "zigflow")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultVaultMount, p.Mount)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/secrets"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+// ExecOptions configures a one-shot local execution
+type ExecOptions struct {
+	// Temporal workflow type to execute - defaults to document.name
+	Workflow string
+	Input    any
+	Envvars  map[string]any
+	// Provider of $secrets - defaults to envvars with secrets.DefaultEnvPrefix
+	Secrets secrets.Provider
+	// Wall-clock limit for the execution - workflow time is skipped
+	Timeout time.Duration
+}
+
+// ExecResult is the outcome of a one-shot local execution
+type ExecResult struct {
+	Workflow string        `json:"workflow"`
+	Output   any           `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Trace    []*TraceEntry `json:"trace"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Exec runs the workflow once in the in-memory Temporal test environment with
+// the real activities registered. Workflow errors are reported in the result -
+// the returned error is only set if the workflow cannot be executed.
+func Exec(wf *model.Workflow, opts ExecOptions) (res *ExecResult, err error) {
+	start := time.Now()
+	res = &ExecResult{
+		Workflow: opts.Workflow,
+	}
+	if res.Workflow == "" {
+		res.Workflow = wf.Document.Name
+	}
+
+	provider := opts.Secrets
+	if provider == nil {
+		provider = &secrets.EnvProvider{Prefix: secrets.DefaultEnvPrefix}
+	}
+
+	env := newEnvironment(wf, provider)
+	if opts.Timeout > 0 {
+		env.SetTestTimeout(opts.Timeout)
+	}
+
+	events, err := cloudevents.Load("", nil, wf)
+	if err != nil {
+		return nil, fmt.Errorf("error loading events: %w", err)
+	}
+
+	trace := &traceSender{}
+	if err := events.AddSender("trace", trace); err != nil {
+		return nil, fmt.Errorf("error creating trace: %w", err)
+	}
+
+	if err := zigflow.NewWorkflow(NewEnvironmentWorker(env), wf, opts.Envvars, events, nil); err != nil {
+		return nil, fmt.Errorf("error building workflow: %w", err)
+	}
+
+	defer func() {
+		// The test environment panics if the timeout is exceeded
+		if r := recover(); r != nil {
+			res.Error = fmt.Sprintf("panic: %v", r)
+		}
+		res.Trace = trace.Trace()
+		res.Duration = time.Since(start)
+	}()
+
+	env.ExecuteWorkflow(res.Workflow, opts.Input)
+
+	if !env.IsWorkflowCompleted() {
+		res.Error = "workflow did not complete"
+		return res, nil
+	}
+
+	if wfErr := env.GetWorkflowError(); wfErr != nil {
+		res.Error = wfErr.Error()
+		return res, nil
+	}
+
+	if err := env.GetWorkflowResult(&res.Output); err != nil {
+		res.Error = fmt.Sprintf("error decoding workflow output: %s", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,281 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/secrets"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+type staticSecrets map[string]string
+
+func (s staticSecrets) Get(_ context.Context, name string) (string, error) {
+	return s[name], nil
+}
+
+func TestExec(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Workflow string
+		Opts     testrunner.ExecOptions
+		Output   any
+		Error    string
+		Trace    []testrunner.TraceEntry
+	}{
+		{
+			Name: "runs tasks and child workflows",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: exec
+  version: 0.0.1
+do:
+  - greet:
+      run:
+        shell:
+          command: echo
+          arguments:
+            - ${ $input.name }
+  - pause:
+      wait:
+        hours: 1
+  - wrapper:
+      try:
+        - fail:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/runtime
+                status: 500
+      catch:
+        do:
+          - recover:
+              set:
+                recovered: true
+  - result:
+      output:
+        as: ${ . }
+      set:
+        greeting: ${ $data.greet | rtrimstr("\n") }
+        stage: ${ $env.STAGE }
+`,
+			Opts: testrunner.ExecOptions{
+				Input:   map[string]any{"name": "Alice"},
+				Envvars: map[string]any{"STAGE": "dev"},
+			},
+			Output: map[string]any{"greeting": "Alice", "stage": "dev"},
+			Trace: []testrunner.TraceEntry{
+				{Task: "greet", Status: testrunner.TraceStatusCompleted},
+				{Task: "pause", Status: testrunner.TraceStatusCompleted},
+				{Task: "wrapper", Status: testrunner.TraceStatusCompleted},
+				{Task: "fail", Status: testrunner.TraceStatusFaulted, Depth: 1},
+				{Task: "recover", Status: testrunner.TraceStatusCompleted, Depth: 1},
+				{Task: "result", Status: testrunner.TraceStatusCompleted},
+			},
+		},
+		{
+			Name: "caught error",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: exec
+  version: 0.0.1
+do:
+  - wrapper:
+      try:
+        - fail:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/validation
+                status: 400
+                title: Invalid
+                detail: name is required
+      catch:
+        as: failure
+        errors:
+          with:
+            status: 400
+        do:
+          - recover:
+              output:
+                as: ${ . }
+              set:
+                title: ${ $data.failure.title }
+                detail: ${ $data.failure.detail }
+`,
+			Output: map[string]any{"title": "Invalid", "detail": "name is required"},
+			Trace: []testrunner.TraceEntry{
+				{Task: "wrapper", Status: testrunner.TraceStatusCompleted},
+				{Task: "fail", Status: testrunner.TraceStatusFaulted, Depth: 1},
+				{Task: "recover", Status: testrunner.TraceStatusCompleted, Depth: 1},
+			},
+		},
+		{
+			Name: "caught by instance",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: exec
+  version: 0.0.1
+do:
+  - validate:
+      try:
+        - reject:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/validation
+                status: 400
+      catch:
+        errors:
+          with:
+            instance: /do/0/validate/try/0/reject
+  - wrapper:
+      try:
+        - prepare:
+            set:
+              ready: true
+        - check:
+            set:
+              result: ${ error("check failed") }
+      catch:
+        errors:
+          with:
+            instance: /do/1/wrapper/try/1/check
+        do:
+          - recover:
+              output:
+                as: ${ . }
+              set:
+                instance: ${ $data.error.instance }
+`,
+			Output: map[string]any{"instance": "/do/1/wrapper/try/1/check"},
+			Trace: []testrunner.TraceEntry{
+				{Task: "validate", Status: testrunner.TraceStatusCompleted},
+				{Task: "reject", Status: testrunner.TraceStatusFaulted, Depth: 1},
+				{Task: "wrapper", Status: testrunner.TraceStatusCompleted},
+				{Task: "prepare", Status: testrunner.TraceStatusCompleted, Depth: 1},
+				{Task: "check", Status: testrunner.TraceStatusFaulted, Depth: 1},
+				{Task: "recover", Status: testrunner.TraceStatusCompleted, Depth: 1},
+			},
+		},
+		{
+			Name: "uncaught error",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: exec
+  version: 0.0.1
+do:
+  - wrapper:
+      try:
+        - fail:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/runtime
+                status: 500
+                title: Broken
+      catch:
+        errors:
+          with:
+            type: https://serverlessworkflow.io/spec/1.0.0/errors/communication
+        do:
+          - recover:
+              set:
+                recovered: true
+`,
+			Error: "Broken",
+			Trace: []testrunner.TraceEntry{
+				{Task: "wrapper", Status: testrunner.TraceStatusFaulted},
+				{Task: "fail", Status: testrunner.TraceStatusFaulted, Depth: 1},
+			},
+		},
+		{
+			Name:     "secrets from envvars",
+			Workflow: secretsWorkflow,
+			Output:   map[string]any{"result": "ok"},
+			Trace: []testrunner.TraceEntry{
+				{Task: "check", Status: testrunner.TraceStatusCompleted},
+			},
+		},
+		{
+			Name:     "secrets from the provider",
+			Workflow: secretsWorkflow,
+			Opts: testrunner.ExecOptions{
+				Secrets: staticSecrets{"token": "wrong"},
+			},
+			Error: "Error calling command",
+			Trace: []testrunner.TraceEntry{
+				{Task: "check", Status: testrunner.TraceStatusFaulted},
+			},
+		},
+		{
+			Name: "workflow error",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: exec
+  version: 0.0.1
+do:
+  - fail:
+      raise:
+        error:
+          type: https://serverlessworkflow.io/spec/1.0.0/errors/runtime
+          status: 500
+          title: Broken
+`,
+			Error: "Broken",
+			Trace: []testrunner.TraceEntry{
+				{Task: "fail", Status: testrunner.TraceStatusFaulted},
+			},
+		},
+	}
+
+	t.Setenv(secrets.DefaultEnvPrefix+"token", "abc123")
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"workflow.yaml": test.Workflow})
+
+			wf, err := zigflow.LoadFromFile(filepath.Join(dir, "workflow.yaml"))
+			require.NoError(t, err)
+
+			res, err := testrunner.Exec(wf, test.Opts)
+			require.NoError(t, err)
+
+			if test.Error != "" {
+				assert.Contains(t, res.Error, test.Error)
+			} else {
+				assert.Empty(t, res.Error)
+				assert.Equal(t, test.Output, res.Output)
+			}
+
+			require.Len(t, res.Trace, len(test.Trace))
+			for i, want := range test.Trace {
+				got := res.Trace[i]
+				assert.Equal(t, want.Task, got.Task)
+				assert.Equal(t, want.Status, got.Status, got.Task)
+				assert.Equal(t, want.Depth, got.Depth, got.Task)
+			}
+		})
+	}
+}
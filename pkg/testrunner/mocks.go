@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+var (
+	stateType = reflect.TypeFor[*utils.State]()
+	errorType = reflect.TypeFor[error]()
+)
+
+// registerMocks replaces activity results in the test environment. Task mocks
+// wrap every built-in Zigflow activity with a dispatcher that returns the mock
+// for the named task and calls the real activity for everything else. Activity
+// mocks are served by a dynamic activity so they don't need registering.
+func registerMocks(env *testsuite.TestWorkflowEnvironment, mocks []*Mock) {
+	tasks := map[string]*Mock{}
+	acts := map[string]*Mock{}
+	for _, m := range mocks {
+		if m.Task != "" {
+			tasks[m.Task] = m
+		} else {
+			acts[m.Activity] = m
+		}
+	}
+
+	if len(tasks) > 0 {
+		for _, a := range activities.Registry {
+			mockTaskActivities(env, a, tasks)
+		}
+	}
+
+	if len(acts) > 0 {
+		env.RegisterDynamicActivity(func(ctx context.Context, _ converter.EncodedValues) (any, error) {
+			name := activity.GetInfo(ctx).ActivityType.Name
+			m, ok := acts[name]
+			if !ok {
+				return nil, temporal.NewNonRetryableApplicationError(
+					fmt.Sprintf("no mock registered for activity %q", name), "MissingMock", nil,
+				)
+			}
+			return m.result()
+		}, activity.DynamicRegisterOptions{})
+	}
+}
+
+// mockTaskActivities registers a dispatcher for each exported method of the
+// activity struct. The methods receive the state as an argument, which carries
+// the name of the task being executed.
+func mockTaskActivities(env *testsuite.TestWorkflowEnvironment, a any, tasks map[string]*Mock) {
+	v := reflect.ValueOf(a)
+	t := v.Type()
+
+	for i := range t.NumMethod() {
+		method := t.Method(i)
+		fn := v.Method(i)
+		fnType := fn.Type()
+
+		stateArg := -1
+		for j := range fnType.NumIn() {
+			if fnType.In(j) == stateType {
+				stateArg = j
+			}
+		}
+		if stateArg < 0 || fnType.NumOut() != 2 {
+			continue
+		}
+
+		dispatcher := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+			state, _ := args[stateArg].Interface().(*utils.State)
+			m, ok := tasks[taskName(state)]
+			if !ok {
+				return fn.Call(args)
+			}
+
+			output, err := m.result()
+			return []reflect.Value{valueOf(output, fnType.Out(0)), valueOf(err, errorType)}
+		})
+
+		anyArgs := make([]any, fnType.NumIn())
+		for j := range anyArgs {
+			anyArgs[j] = mock.Anything
+		}
+
+		env.OnActivity(method.Name, anyArgs...).Return(dispatcher.Interface())
+	}
+}
+
+// result returns the mocked output or error
+func (m *Mock) result() (any, error) {
+	if m.Error == nil {
+		return m.Output, nil
+	}
+
+	if m.Error.NonRetryable {
+		return nil, temporal.NewNonRetryableApplicationError(m.Error.Message, m.Error.Type, nil)
+	}
+	return nil, temporal.NewApplicationError(m.Error.Message, m.Error.Type)
+}
+
+// taskName returns the name of the task currently executing
+func taskName(state *utils.State) string {
+	if state == nil {
+		return ""
+	}
+	task, ok := state.Data["task"].(map[string]any)
+	if !ok {
+		return ""
+	}
+	name, _ := task["name"].(string)
+	return name
+}
+
+// valueOf converts v to a reflect.Value of type t, using the zero value for nil
+func valueOf(v any, t reflect.Type) reflect.Value {
+	rv := reflect.New(t).Elem()
+	if v != nil {
+		rv.Set(reflect.ValueOf(v))
+	}
+	return rv
+}
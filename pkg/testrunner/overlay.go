@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"fmt"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/graph"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// statusRank orders the statuses so that, when a task runs more than once,
+// the most significant outcome is shown
+var statusRank = map[graph.Status]int{
+	graph.StatusSkipped:   0,
+	graph.StatusCompleted: 1,
+	graph.StatusRetried:   2,
+	graph.StatusCancelled: 3,
+	graph.StatusFailed:    4,
+	graph.StatusRunning:   5,
+}
+
+// Overlay replays each history against the workflow and returns what happened
+// to every task it ran, to draw over the workflow's graph with
+// graph.WithOverlay. Histories of running executions show how far they have
+// got. Tasks in the child workflows started by for, fork and try tasks are only
+// included if the child's history is given too.
+func Overlay(wf *model.Workflow, histories []*historypb.History, opts ReplayOptions) (graph.Overlay, error) {
+	overlay := graph.Overlay{}
+
+	for _, history := range histories {
+		if err := addHistory(overlay, wf, history, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return overlay, nil
+}
+
+func addHistory(overlay graph.Overlay, wf *model.Workflow, history *historypb.History, opts ReplayOptions) error {
+	res := ReplayHistory(wf, history, opts)
+	if !res.Passed {
+		return fmt.Errorf("error replaying history of %s: %s", res.WorkflowID, res.Error)
+	}
+
+	// Index the tasks that run in this workflow, in the order they're declared
+	declared := make([]*model.TaskItem, 0)
+	byKey := map[string]*model.TaskItem{}
+	anyByKey := map[string]*model.TaskItem{}
+	_ = zigflow.Walk(wf, func(item *model.TaskItem, workflowName string) error {
+		if _, ok := anyByKey[item.Key]; !ok {
+			anyByKey[item.Key] = item
+		}
+		if workflowName != res.WorkflowType {
+			return nil
+		}
+		declared = append(declared, item)
+		if _, ok := byKey[item.Key]; !ok {
+			byKey[item.Key] = item
+		}
+		return nil
+	})
+
+	events := history.GetEvents()
+	lastEventTime := events[len(events)-1].GetEventTime().AsTime()
+
+	dataConverter := opts.DataConverter
+	if dataConverter == nil {
+		dataConverter = converter.GetDefaultDataConverter()
+	}
+	attempts := activityAttempts(events, dataConverter)
+
+	ran := map[*model.TaskItem]bool{}
+	for _, entry := range res.Trace {
+		item, ok := byKey[entry.Task]
+		if !ok {
+			// Fall back to a task of the same name in any workflow
+			if item, ok = anyByKey[entry.Task]; !ok {
+				continue
+			}
+		}
+		ran[item] = true
+
+		exec := &graph.Execution{
+			Status:   traceStatus(entry.Status),
+			Attempts: max(1, attempts[entry.Task]),
+		}
+		if exec.Status == graph.StatusCompleted && exec.Attempts > 1 {
+			exec.Status = graph.StatusRetried
+		}
+
+		finished := entry.workflowFinished
+		if entry.Status == TraceStatusRunning {
+			finished = lastEventTime
+		}
+		if !entry.workflowStarted.IsZero() && finished.After(entry.workflowStarted) {
+			exec.Duration = finished.Sub(entry.workflowStarted)
+		}
+
+		overlay[item] = mergeExecution(overlay[item], exec)
+	}
+
+	// A task with an if statement was skipped if the workflow got past it
+	completed := events[len(events)-1].GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED
+	for i, item := range declared {
+		if ran[item] || item.GetBase().If == nil || overlay[item] != nil {
+			continue
+		}
+
+		passed := completed
+		for _, later := range declared[i+1:] {
+			if ran[later] {
+				passed = true
+				break
+			}
+		}
+		if passed {
+			overlay[item] = &graph.Execution{Status: graph.StatusSkipped}
+		}
+	}
+
+	return nil
+}
+
+// activityAttempts returns the highest attempt of each task's activity. The
+// activity summary is the task name.
+func activityAttempts(events []*historypb.HistoryEvent, dataConverter converter.DataConverter) map[string]int {
+	tasks := map[int64]string{}
+	attempts := map[string]int{}
+
+	for _, event := range events {
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			summary := event.GetUserMetadata().GetSummary()
+			if summary == nil {
+				continue
+			}
+			var task string
+			if err := dataConverter.FromPayload(summary, &task); err == nil {
+				tasks[event.GetEventId()] = task
+			}
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			attrs := event.GetActivityTaskStartedEventAttributes()
+			if task, ok := tasks[attrs.GetScheduledEventId()]; ok {
+				attempts[task] = max(attempts[task], int(attrs.GetAttempt()))
+			}
+		}
+	}
+
+	return attempts
+}
+
+func traceStatus(status string) graph.Status {
+	switch status {
+	case TraceStatusRunning:
+		return graph.StatusRunning
+	case TraceStatusFaulted:
+		return graph.StatusFailed
+	case TraceStatusCancelled:
+		return graph.StatusCancelled
+	default:
+		return graph.StatusCompleted
+	}
+}
+
+// mergeExecution combines the runs of a task that ran more than once, such as
+// in several iterations of a loop
+func mergeExecution(existing, exec *graph.Execution) *graph.Execution {
+	if existing == nil {
+		return exec
+	}
+
+	merged := &graph.Execution{
+		Status:   existing.Status,
+		Attempts: max(existing.Attempts, exec.Attempts),
+		Duration: existing.Duration + exec.Duration,
+	}
+	if statusRank[exec.Status] > statusRank[existing.Status] {
+		merged.Status = exec.Status
+	}
+
+	return merged
+}
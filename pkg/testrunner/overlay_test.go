@@ -0,0 +1,107 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/graph"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	historypb "go.temporal.io/api/history/v1"
+)
+
+func TestOverlay(t *testing.T) {
+	// Stop the history once the timer has started
+	lines := strings.Split(waitHistory, "\n")
+	runningHistory := strings.TrimSuffix(strings.Join(lines[:7], "\n"), ",") + "\n  ]\n}"
+
+	tests := []struct {
+		Name     string
+		History  string
+		Expected map[string]*graph.Execution
+		Error    bool
+	}{
+		{
+			Name:    "completed execution",
+			History: waitHistory,
+			Expected: map[string]*graph.Execution{
+				"notify": {Status: graph.StatusSkipped},
+				"pause":  {Status: graph.StatusCompleted, Attempts: 1, Duration: time.Second},
+			},
+		},
+		{
+			Name:    "running execution",
+			History: runningHistory,
+			Expected: map[string]*graph.Execution{
+				"notify": {Status: graph.StatusSkipped},
+				"pause":  {Status: graph.StatusRunning, Attempts: 1},
+			},
+		},
+		{
+			Name:    "history from a different definition",
+			History: strings.ReplaceAll(waitHistory, `"timerId": "5"`, `"timerId": "6"`),
+			Error:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{
+				"workflow.yaml": `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: replay
+  version: 0.0.1
+do:
+  - notify:
+      if: ${ false }
+      set:
+        notified: true
+  - pause:
+      wait:
+        seconds: 1
+`,
+				"history.json": test.History,
+			})
+
+			wf, err := zigflow.LoadFromFile(filepath.Join(dir, "workflow.yaml"))
+			require.NoError(t, err)
+
+			history, err := testrunner.LoadHistory(filepath.Join(dir, "history.json"))
+			require.NoError(t, err)
+
+			overlay, err := testrunner.Overlay(wf, []*historypb.History{history}, testrunner.ReplayOptions{})
+			if test.Error {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := map[string]*graph.Execution{}
+			for item, exec := range overlay {
+				got[item.Key] = exec
+			}
+			assert.Equal(t, test.Expected, got)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrsimonemms/golang-helpers/temporal"
+	"github.com/nexus-rpc/sdk-go/nexus"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/worker"
+)
+
+// nonDeterminismCode prefixes every non-determinism error raised by the SDK
+const nonDeterminismCode = "[TMPRL1100]"
+
+// nonDeterminismEventID finds the history event the SDK reports on
+var nonDeterminismEventID = regexp.MustCompile(`history event position (\d+)`)
+
+// ReplayOptions configures a replay
+type ReplayOptions struct {
+	Envvars map[string]any
+	// Data converter used when the history was recorded - nil for the default
+	DataConverter converter.DataConverter
+}
+
+// ReplayResult is the outcome of replaying a single history file
+type ReplayResult struct {
+	File             string        `json:"file"`
+	WorkflowType     string        `json:"workflowType,omitempty"`
+	WorkflowID       string        `json:"workflowId,omitempty"`
+	Passed           bool          `json:"passed"`
+	NonDeterministic bool          `json:"nonDeterministic"`
+	Error            string        `json:"error,omitempty"`
+	EventID          int64         `json:"eventId,omitempty"`
+	Task             string        `json:"task,omitempty"`
+	Trace            []*TraceEntry `json:"trace"`
+	Duration         time.Duration `json:"duration"`
+}
+
+// Replay compiles the workflow and replays the exported history through it.
+// If the definition no longer produces the commands in the history, the
+// Zigflow task that was running when the replay diverged is reported.
+func Replay(wf *model.Workflow, historyFile string, opts ReplayOptions) *ReplayResult {
+	history, err := LoadHistory(historyFile)
+	if err != nil {
+		return &ReplayResult{
+			File:  historyFile,
+			Error: err.Error(),
+			Trace: []*TraceEntry{},
+		}
+	}
+
+	res := ReplayHistory(wf, history, opts)
+	res.File = historyFile
+
+	return res
+}
+
+// ReplayHistory replays a history that has already been loaded
+func ReplayHistory(wf *model.Workflow, history *historypb.History, opts ReplayOptions) (res *ReplayResult) {
+	start := time.Now()
+	res = &ReplayResult{
+		Trace: []*TraceEntry{},
+	}
+	trace := &traceSender{}
+
+	defer func() {
+		if r := recover(); r != nil {
+			res.Passed = false
+			res.Error = fmt.Sprintf("panic: %v", r)
+		}
+		res.Trace = trace.Trace()
+		res.Duration = time.Since(start)
+	}()
+
+	if events := history.GetEvents(); len(events) > 0 {
+		attrs := events[0].GetWorkflowExecutionStartedEventAttributes()
+		res.WorkflowType = attrs.GetWorkflowType().GetName()
+		res.WorkflowID = attrs.GetWorkflowId()
+	}
+
+	dataConverter := opts.DataConverter
+	if dataConverter == nil {
+		dataConverter = converter.GetDefaultDataConverter()
+	}
+
+	replayer, err := worker.NewWorkflowReplayerWithOptions(worker.WorkflowReplayerOptions{
+		DataConverter: dataConverter,
+	})
+	if err != nil {
+		res.Error = fmt.Sprintf("error creating replayer: %s", err)
+		return res
+	}
+
+	events, err := cloudevents.Load("", nil, wf)
+	if err != nil {
+		res.Error = fmt.Sprintf("error loading events: %s", err)
+		return res
+	}
+	if err := events.AddSender("trace", trace); err != nil {
+		res.Error = fmt.Sprintf("error creating trace: %s", err)
+		return res
+	}
+
+	if err := zigflow.RegisterWorkflow(NewReplayerWorker(replayer), wf, opts.Envvars, events, nil); err != nil {
+		res.Error = fmt.Sprintf("error building workflow: %s", err)
+		return res
+	}
+
+	// The replayer logs divergences as panics with a stack trace. This repeats
+	// the result so is only shown when debugging.
+	logger := log.Logger
+	if max(logger.GetLevel(), zerolog.GlobalLevel()) > zerolog.DebugLevel {
+		logger = logger.Level(zerolog.Disabled)
+	}
+
+	err = replayer.ReplayWorkflowHistory(temporal.NewZerologHandler(&logger), history)
+	if err == nil {
+		res.Passed = true
+		return res
+	}
+
+	res.Error = err.Error()
+	res.NonDeterministic = strings.Contains(res.Error, nonDeterminismCode)
+	if m := nonDeterminismEventID.FindStringSubmatch(res.Error); m != nil {
+		res.EventID, _ = strconv.ParseInt(m[1], 10, 64)
+	}
+	res.Task = divergedTask(trace.Trace())
+
+	return res
+}
+
+// divergedTask returns the innermost task that was still running when the
+// replay stopped. If every task finished, the divergence happened after the
+// last one.
+func divergedTask(trace []*TraceEntry) string {
+	for i := len(trace) - 1; i >= 0; i-- {
+		if trace[i].Status == TraceStatusRunning {
+			return trace[i].Task
+		}
+	}
+	if len(trace) > 0 {
+		return trace[len(trace)-1].Task
+	}
+	return ""
+}
+
+// LoadHistory reads a history exported with "temporal workflow show --output json"
+func LoadHistory(file string) (h *historypb.History, err error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("error opening history: %w", err)
+	}
+	defer func() {
+		err = closeFile(f, err)
+	}()
+
+	history, err := client.HistoryFromJSON(f, client.HistoryJSONOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error decoding history: %w", err)
+	}
+
+	return history, nil
+}
+
+func closeFile(f *os.File, err error) error {
+	if cErr := f.Close(); cErr != nil && err == nil {
+		return cErr
+	}
+	return err
+}
+
+// replayerWorker adapts the workflow replayer to the worker.Worker interface
+// so that workflows can be compiled with zigflow.RegisterWorkflow. Activities
+// never run during a replay so they are not registered.
+type replayerWorker struct {
+	worker.WorkflowReplayer
+}
+
+func (w *replayerWorker) RegisterActivity(any) {}
+
+func (w *replayerWorker) RegisterActivityWithOptions(any, activity.RegisterOptions) {}
+
+func (w *replayerWorker) RegisterDynamicActivity(any, activity.DynamicRegisterOptions) {}
+
+func (w *replayerWorker) RegisterNexusService(*nexus.Service) {}
+
+func (w *replayerWorker) Start() error {
+	return nil
+}
+
+func (w *replayerWorker) Run(<-chan any) error {
+	return nil
+}
+
+func (w *replayerWorker) Stop() {}
+
+// NewReplayerWorker returns a worker.Worker that registers workflows with the
+// replayer
+func NewReplayerWorker(replayer worker.WorkflowReplayer) worker.Worker {
+	return &replayerWorker{
+		WorkflowReplayer: replayer,
+	}
+}
+
+var _ worker.Worker = &replayerWorker{}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+// waitHistory is the history of a workflow with a single one second wait task
+const waitHistory = `{
+  "events": [
+    {"eventId": "1", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_STARTED", "workflowExecutionStartedEventAttributes": {"workflowType": {"name": "replay"}, "taskQueue": {"name": "zigflow"}, "workflowId": "replay-1", "originalExecutionRunId": "run-1", "firstExecutionRunId": "run-1", "attempt": 1}},
+    {"eventId": "2", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_SCHEDULED", "workflowTaskScheduledEventAttributes": {"taskQueue": {"name": "zigflow"}, "startToCloseTimeout": "10s", "attempt": 1}},
+    {"eventId": "3", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_STARTED", "workflowTaskStartedEventAttributes": {"scheduledEventId": "2"}},
+    {"eventId": "4", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_COMPLETED", "workflowTaskCompletedEventAttributes": {"scheduledEventId": "2", "startedEventId": "3"}},
+    {"eventId": "5", "eventTime": "2026-01-01T00:00:00Z", "eventType": "EVENT_TYPE_TIMER_STARTED", "timerStartedEventAttributes": {"timerId": "5", "startToFireTimeout": "1s", "workflowTaskCompletedEventId": "4"}},
+    {"eventId": "6", "eventTime": "2026-01-01T00:00:01Z", "eventType": "EVENT_TYPE_TIMER_FIRED", "timerFiredEventAttributes": {"timerId": "5", "startedEventId": "5"}},
+    {"eventId": "7", "eventTime": "2026-01-01T00:00:01Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_SCHEDULED", "workflowTaskScheduledEventAttributes": {"taskQueue": {"name": "zigflow"}, "startToCloseTimeout": "10s", "attempt": 1}},
+    {"eventId": "8", "eventTime": "2026-01-01T00:00:01Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_STARTED", "workflowTaskStartedEventAttributes": {"scheduledEventId": "7"}},
+    {"eventId": "9", "eventTime": "2026-01-01T00:00:01Z", "eventType": "EVENT_TYPE_WORKFLOW_TASK_COMPLETED", "workflowTaskCompletedEventAttributes": {"scheduledEventId": "7", "startedEventId": "8"}},
+    {"eventId": "10", "eventTime": "2026-01-01T00:00:01Z", "eventType": "EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED", "workflowExecutionCompletedEventAttributes": {"workflowTaskCompletedEventId": "9"}}
+  ]
+}`
+
+func TestReplay(t *testing.T) {
+	tests := []struct {
+		Name             string
+		Workflow         string
+		History          string
+		Passed           bool
+		NonDeterministic bool
+		EventID          int64
+		Task             string
+	}{
+		{
+			Name: "unchanged definition",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: replay
+  version: 0.0.1
+do:
+  - pause:
+      wait:
+        seconds: 1
+`,
+			History: waitHistory,
+			Passed:  true,
+		},
+		{
+			Name: "renamed task is compatible",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: replay
+  version: 0.0.2
+do:
+  - sleep:
+      wait:
+        seconds: 1
+`,
+			History: waitHistory,
+			Passed:  true,
+		},
+		{
+			Name: "task inserted before the wait",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: replay
+  version: 0.0.2
+do:
+  - fetch:
+      call: http
+      with:
+        method: get
+        endpoint: https://example.com
+  - pause:
+      wait:
+        seconds: 1
+`,
+			History:          waitHistory,
+			NonDeterministic: true,
+			EventID:          5,
+			Task:             "fetch",
+		},
+		{
+			Name: "invalid history",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: replay
+  version: 0.0.1
+do:
+  - pause:
+      wait:
+        seconds: 1
+`,
+			History: "{",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{
+				"workflow.yaml": test.Workflow,
+				"history.json":  test.History,
+			})
+
+			wf, err := zigflow.LoadFromFile(filepath.Join(dir, "workflow.yaml"))
+			require.NoError(t, err)
+
+			res := testrunner.Replay(wf, filepath.Join(dir, "history.json"), testrunner.ReplayOptions{})
+
+			assert.Equal(t, test.Passed, res.Passed, res.Error)
+			assert.Equal(t, test.NonDeterministic, res.NonDeterministic)
+			assert.Equal(t, test.EventID, res.EventID)
+			assert.Equal(t, test.Task, res.Task)
+			if !test.Passed {
+				assert.NotEmpty(t, res.Error)
+			}
+		})
+	}
+}
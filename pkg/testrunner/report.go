@@ -0,0 +1,168 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// RenderHuman writes a readable summary of the results
+func RenderHuman(w io.Writer, results []*SuiteResult) error {
+	var passed, failed int
+	for _, s := range results {
+		if _, err := fmt.Fprintf(w, "%s\n", s.File); err != nil {
+			return err
+		}
+
+		if s.Error != "" {
+			failed++
+			if _, err := fmt.Fprintf(w, "  ✗ %s\n", s.Error); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, c := range s.Cases {
+			if c.Passed {
+				passed++
+				if _, err := fmt.Fprintf(w, "  ✓ %s (%s)\n", c.Name, c.Duration.Round(time.Millisecond)); err != nil {
+					return err
+				}
+				continue
+			}
+
+			failed++
+			if _, err := fmt.Fprintf(w, "  ✗ %s (%s)\n", c.Name, c.Duration.Round(time.Millisecond)); err != nil {
+				return err
+			}
+			for _, f := range c.Failures {
+				if _, err := fmt.Fprintf(w, "      %s\n", indent(f, "      ")); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed\n", passed, failed)
+	return err
+}
+
+// RenderJSON writes the results as JSON
+func RenderJSON(w io.Writer, results []*SuiteResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Time     float64           `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Time      float64          `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// RenderJUnit writes the results as JUnit XML for CI systems
+func RenderJUnit(w io.Writer, results []*SuiteResult) error {
+	report := junitTestSuites{}
+
+	for _, s := range results {
+		suite := &junitTestSuite{
+			Name: s.File,
+			Time: s.Duration.Seconds(),
+		}
+
+		if s.Error != "" {
+			// Record a load failure as a single errored test case
+			suite.Tests = 1
+			suite.Errors = 1
+			suite.TestCases = append(suite.TestCases, &junitTestCase{
+				Name:      "load",
+				ClassName: s.File,
+				Error:     &junitMessage{Message: s.Error},
+			})
+		}
+
+		for _, c := range s.Cases {
+			tc := &junitTestCase{
+				Name:      c.Name,
+				ClassName: s.File,
+				Time:      c.Duration.Seconds(),
+			}
+			if !c.Passed {
+				suite.Failures++
+				tc.Failure = &junitMessage{
+					Message: c.Failures[0],
+					Body:    strings.Join(c.Failures, "\n"),
+				}
+			}
+			suite.Tests++
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Time += suite.Time
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func indent(s, prefix string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
@@ -0,0 +1,343 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/mrsimonemms/golang-helpers/temporal"
+	"github.com/rs/zerolog/log"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/secrets"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+// CaseResult is the outcome of a single test case
+type CaseResult struct {
+	Name     string        `json:"name"`
+	Passed   bool          `json:"passed"`
+	Failures []string      `json:"failures,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+func (c *CaseResult) fail(format string, args ...any) {
+	c.Passed = false
+	c.Failures = append(c.Failures, fmt.Sprintf(format, args...))
+}
+
+// SuiteResult is the outcome of every test case in a suite file
+type SuiteResult struct {
+	File     string        `json:"file"`
+	Workflow string        `json:"workflow"`
+	Error    string        `json:"error,omitempty"`
+	Cases    []*CaseResult `json:"cases"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Passed returns true if the suite loaded and every case passed
+func (s *SuiteResult) Passed() bool {
+	if s.Error != "" {
+		return false
+	}
+	for _, c := range s.Cases {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Run loads the suite file and executes every test case. The workflow file
+// may be overridden with workflowFile - otherwise the suite's workflow is
+// used. Failures are reported in the result rather than returned.
+func Run(file, workflowFile string) *SuiteResult {
+	start := time.Now()
+	res := &SuiteResult{
+		File:  file,
+		Cases: []*CaseResult{},
+	}
+	defer func() {
+		res.Duration = time.Since(start)
+	}()
+
+	suite, err := LoadSuite(file)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	if workflowFile == "" {
+		workflowFile = suite.WorkflowPath()
+	}
+	res.Workflow = workflowFile
+	if workflowFile == "" {
+		res.Error = "no workflow file set in suite or on command line"
+		return res
+	}
+
+	wf, err := loadWorkflow(workflowFile)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	for _, test := range suite.Tests {
+		res.Cases = append(res.Cases, RunCase(wf, suite, test))
+	}
+
+	return res
+}
+
+// RunCase executes a single test case against a fresh in-memory Temporal
+// environment. Workflow time is skipped so timers and waits resolve instantly.
+func RunCase(wf *model.Workflow, suite *Suite, test *TestCase) (res *CaseResult) {
+	start := time.Now()
+	res = &CaseResult{
+		Name:   test.Name,
+		Passed: true,
+	}
+	defer func() {
+		// Unmatched mocks and the like cause the test environment to panic
+		if r := recover(); r != nil {
+			res.fail("panic: %v", r)
+		}
+		res.Duration = time.Since(start)
+	}()
+
+	envvars := map[string]any{}
+	maps.Copy(envvars, suite.Env)
+	maps.Copy(envvars, test.Env)
+
+	env := newEnvironment(wf, envSecrets(envvars))
+	if timeout, err := parseDuration(test.Timeout); err == nil && timeout > 0 {
+		env.SetTestTimeout(timeout)
+	}
+
+	events, err := cloudevents.Load("", nil, wf)
+	if err != nil {
+		res.fail("error loading events: %s", err)
+		return res
+	}
+
+	if err := zigflow.NewWorkflow(NewEnvironmentWorker(env), wf, envvars, events, nil); err != nil {
+		res.fail("error building workflow: %s", err)
+		return res
+	}
+
+	registerMocks(env, test.Mocks)
+	scheduleSignals(env, test.Signals)
+	scheduleUpdates(env, test.Updates, res)
+
+	workflowName := test.Workflow
+	if workflowName == "" {
+		workflowName = wf.Document.Name
+	}
+
+	env.ExecuteWorkflow(workflowName, test.Input)
+
+	if !env.IsWorkflowCompleted() {
+		res.fail("workflow did not complete")
+		return res
+	}
+
+	checkResult(env, test.Expect, res)
+
+	return res
+}
+
+// newEnvironment creates an in-memory Temporal environment that logs through
+// the application logger. Activities read $secrets from the provider and the
+// authentication policies from the workflow.
+func newEnvironment(wf *model.Workflow, provider secrets.Provider) *testsuite.TestWorkflowEnvironment {
+	s := &testsuite.WorkflowTestSuite{}
+	s.SetLogger(temporal.NewZerologHandler(&log.Logger))
+
+	env := s.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: zigflow.WithAuthentications(
+			secrets.WithProvider(context.Background(), provider),
+			[]*zigflow.Definition{{Workflow: wf}},
+		),
+	})
+	return env
+}
+
+// envSecrets reads secrets from a test's envvars so that suites can set them
+// alongside $env. Nothing is read from the host, so a test gets the same
+// result on every machine.
+type envSecrets map[string]any
+
+func (e envSecrets) Get(_ context.Context, name string) (string, error) {
+	v, ok := e[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s is not set in the test env", secrets.ErrNotFound, name)
+	}
+	return fmt.Sprintf("%v", v), nil
+}
+
+func checkResult(env *testsuite.TestWorkflowEnvironment, expect *Expectation, res *CaseResult) {
+	if expect == nil {
+		expect = &Expectation{}
+	}
+
+	wfErr := env.GetWorkflowError()
+	if expect.Error != "" {
+		switch {
+		case wfErr == nil:
+			res.fail("expected error containing %q but workflow succeeded", expect.Error)
+		case !strings.Contains(wfErr.Error(), expect.Error):
+			res.fail("expected error containing %q, got %q", expect.Error, wfErr.Error())
+		}
+		return
+	}
+
+	if wfErr != nil {
+		res.fail("unexpected error: %s", wfErr)
+		return
+	}
+
+	if expect.Output == nil {
+		return
+	}
+
+	var output any
+	if err := env.GetWorkflowResult(&output); err != nil {
+		res.fail("error decoding workflow output: %s", err)
+		return
+	}
+
+	if diff, err := compare(expect.Output, output); err != nil {
+		res.fail("error comparing output: %s", err)
+	} else if diff != "" {
+		res.fail("output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// compare normalises both values through JSON so that numeric types and
+// map types are consistent and returns a diff if they differ
+func compare(want, got any) (string, error) {
+	normalise := func(v any) (any, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		var out any
+		err = json.Unmarshal(b, &out)
+		return out, err
+	}
+
+	w, err := normalise(want)
+	if err != nil {
+		return "", err
+	}
+	g, err := normalise(got)
+	if err != nil {
+		return "", err
+	}
+
+	return cmp.Diff(w, g), nil
+}
+
+func scheduleSignals(env *testsuite.TestWorkflowEnvironment, signals []*Message) {
+	for _, m := range signals {
+		after, _ := parseDuration(m.After)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(m.ID, m.Data)
+		}, after)
+	}
+}
+
+func scheduleUpdates(env *testsuite.TestWorkflowEnvironment, updates []*Message, res *CaseResult) {
+	for i, m := range updates {
+		after, _ := parseDuration(m.After)
+		env.RegisterDelayedCallback(func() {
+			env.UpdateWorkflow(m.ID, fmt.Sprintf("%s-%d", m.ID, i), &testsuite.TestUpdateCallback{
+				OnReject: func(err error) {
+					if m.Expect == nil || m.Expect.Error == "" || !strings.Contains(err.Error(), m.Expect.Error) {
+						res.fail("update %q rejected: %s", m.ID, err)
+					}
+				},
+				OnComplete: func(output any, err error) {
+					checkUpdate(m, output, err, res)
+				},
+			}, m.Data)
+		}, after)
+	}
+}
+
+func checkUpdate(m *Message, output any, err error, res *CaseResult) {
+	if m.Expect == nil {
+		if err != nil {
+			res.fail("update %q failed: %s", m.ID, err)
+		}
+		return
+	}
+
+	if m.Expect.Error != "" {
+		if err == nil || !strings.Contains(err.Error(), m.Expect.Error) {
+			res.fail("update %q expected error containing %q, got %v", m.ID, m.Expect.Error, err)
+		}
+		return
+	}
+
+	if err != nil {
+		res.fail("update %q failed: %s", m.ID, err)
+		return
+	}
+
+	if m.Expect.Output == nil {
+		return
+	}
+
+	if diff, err := compare(m.Expect.Output, output); err != nil {
+		res.fail("error comparing update %q output: %s", m.ID, err)
+	} else if diff != "" {
+		res.fail("update %q output mismatch (-want +got):\n%s", m.ID, diff)
+	}
+}
+
+func loadWorkflow(file string) (*model.Workflow, error) {
+	wf, err := zigflow.LoadFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error loading workflow: %w", err)
+	}
+
+	validator, err := utils.NewValidator()
+	if err != nil {
+		return nil, fmt.Errorf("error creating validator: %w", err)
+	}
+
+	res, err := validator.ValidateStruct(wf)
+	if err != nil {
+		return nil, fmt.Errorf("error creating validation stack: %w", err)
+	}
+	if len(res) > 0 {
+		return nil, fmt.Errorf("invalid workflow: %s %s", res[0].Path, res[0].Message)
+	}
+
+	return wf, nil
+}
@@ -0,0 +1,416 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/secrets"
+	"github.com/zigflow/zigflow/pkg/testrunner"
+)
+
+const approvalWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: approval
+  version: 0.0.1
+do:
+  - getUser:
+      call: http
+      with:
+        method: get
+        endpoint: https://example.invalid/users/1
+  - approve:
+      listen:
+        to:
+          one:
+            with:
+              id: approve
+              type: signal
+  - pause:
+      wait:
+        hours: 24
+  - result:
+      output:
+        as: ${ . }
+      set:
+        user: ${ $data.getUser.name }
+        approved: ${ $data.approve.approved }
+        env: ${ $env.STAGE }
+`
+
+const updateWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: updates
+  version: 0.0.1
+do:
+  - charge:
+      call: activity
+      with:
+        name: ChargeCard
+        taskQueue: payments
+        arguments:
+          - ${ $input.amount }
+  - temperature:
+      listen:
+        to:
+          one:
+            with:
+              id: temperature
+              type: update
+              acceptIf: ${ $data.temperature > 38 }
+  - result:
+      output:
+        as: ${ . }
+      set:
+        receipt: ${ $data.charge }
+        temperature: ${ $data.temperature }
+`
+
+const secretsWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: secrets
+  version: 0.0.1
+use:
+  secrets:
+    - token
+do:
+  - check:
+      run:
+        shell:
+          command: sh
+          arguments:
+            - -c
+            - test "$TOKEN" = abc123 && echo ok
+          environment:
+            TOKEN: ${ $secrets.token }
+      output:
+        as:
+          result: ${ . }
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "testrunner")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	})
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	// Suites must not see the secrets of the machine they run on
+	t.Setenv(secrets.DefaultEnvPrefix+"token", "abc123")
+
+	tests := []struct {
+		Name     string
+		Workflow string
+		Suite    string
+		Error    string
+		Passed   []bool
+		Failures []string
+	}{
+		{
+			Name:     "signal with task mock",
+			Workflow: approvalWorkflow,
+			Suite: `workflow: workflow.yaml
+env:
+  STAGE: test
+tests:
+  - name: approved
+    mocks:
+      - task: getUser
+        output:
+          name: Alice
+    signals:
+      - id: approve
+        after: 30s
+        data:
+          approved: true
+    expect:
+      output:
+        user: Alice
+        approved: true
+        env: test
+  - name: env override
+    env:
+      STAGE: prod
+    mocks:
+      - task: getUser
+        output:
+          name: Bob
+    signals:
+      - id: approve
+        data:
+          approved: false
+    expect:
+      output:
+        user: Bob
+        approved: false
+        env: prod
+`,
+			Passed: []bool{true, true},
+		},
+		{
+			Name:     "secrets from the suite env",
+			Workflow: secretsWorkflow,
+			Suite: `workflow: workflow.yaml
+env:
+  token: abc123
+tests:
+  - name: matches
+    expect:
+      output:
+        result: ok
+  - name: wrong secret
+    env:
+      token: wrong
+    expect:
+      error: Error calling command
+`,
+			Passed: []bool{true, true},
+		},
+		{
+			Name:     "secrets are not read from the host",
+			Workflow: secretsWorkflow,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: missing secret
+    expect:
+      error: "secret not found: token is not set in the test env"
+`,
+			Passed: []bool{true},
+		},
+		{
+			Name:     "mocked error",
+			Workflow: approvalWorkflow,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: http fails
+    mocks:
+      - task: getUser
+        error:
+          message: service unavailable
+          nonRetryable: true
+    expect:
+      error: service unavailable
+`,
+			Passed: []bool{true},
+		},
+		{
+			Name:     "output mismatch",
+			Workflow: approvalWorkflow,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: wrong user
+    mocks:
+      - task: getUser
+        output:
+          name: Bob
+    signals:
+      - id: approve
+        data:
+          approved: true
+    expect:
+      output:
+        user: Alice
+        approved: true
+`,
+			Passed:   []bool{false},
+			Failures: []string{"output mismatch"},
+		},
+		{
+			Name:     "listen timeout",
+			Workflow: approvalWorkflow,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: signal too late
+    mocks:
+      - task: getUser
+        output:
+          name: Alice
+    signals:
+      - id: approve
+        after: 1h
+    expect:
+      output:
+        user: Alice
+`,
+			Passed:   []bool{false},
+			Failures: []string{"unexpected error"},
+		},
+		{
+			Name:     "update with activity mock",
+			Workflow: updateWorkflow,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: accepted
+    input:
+      amount: 10
+    mocks:
+      - activity: ChargeCard
+        output: receipt-1
+    updates:
+      - id: temperature
+        after: 5s
+        data: 35
+      - id: temperature
+        after: 10s
+        data: 39
+    expect:
+      output:
+        receipt: receipt-1
+        temperature: 39
+`,
+			Passed: []bool{true},
+		},
+		{
+			Name:     "missing activity mock",
+			Workflow: updateWorkflow,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: unmocked
+    mocks:
+      - activity: SomethingElse
+    expect:
+      error: no mock registered for activity "ChargeCard"
+`,
+			Passed: []bool{true},
+		},
+		{
+			Name: "unmocked task runs real activity",
+			Workflow: `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: mixed
+  version: 0.0.1
+do:
+  - greet:
+      run:
+        shell:
+          command: echo
+          arguments:
+            - hello
+  - getUser:
+      call: http
+      with:
+        method: get
+        endpoint: https://example.invalid/users/1
+  - result:
+      output:
+        as: ${ . }
+      set:
+        greeting: ${ $data.greet }
+        user: ${ $data.getUser.name }
+`,
+			Suite: `workflow: workflow.yaml
+tests:
+  - name: mixed
+    mocks:
+      - task: getUser
+        output:
+          name: Alice
+    expect:
+      output:
+        greeting: hello
+        user: Alice
+`,
+			Passed: []bool{true},
+		},
+		{
+			Name:     "invalid suite",
+			Workflow: approvalWorkflow,
+			Suite: `workflow: workflow.yaml
+tests: []
+`,
+			Error: "invalid test suite",
+		},
+		{
+			Name:  "missing workflow",
+			Suite: `tests: [{name: test}]`,
+			Error: "no workflow file set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			files := map[string]string{"suite.yaml": test.Suite}
+			if test.Workflow != "" {
+				files["workflow.yaml"] = test.Workflow
+			}
+			dir := writeFiles(t, files)
+
+			res := testrunner.Run(filepath.Join(dir, "suite.yaml"), "")
+
+			if test.Error != "" {
+				assert.Contains(t, res.Error, test.Error)
+				assert.False(t, res.Passed())
+				return
+			}
+
+			require.Empty(t, res.Error)
+			require.Len(t, res.Cases, len(test.Passed))
+			for i, c := range res.Cases {
+				assert.Equal(t, test.Passed[i], c.Passed, c.Failures)
+			}
+
+			for _, f := range test.Failures {
+				require.NotEmpty(t, res.Cases[0].Failures)
+				assert.Contains(t, res.Cases[0].Failures[0], f)
+			}
+		})
+	}
+}
+
+func TestRenderJUnit(t *testing.T) {
+	results := []*testrunner.SuiteResult{
+		{
+			File: "suite.yaml",
+			Cases: []*testrunner.CaseResult{
+				{Name: "passes", Passed: true},
+				{Name: "fails", Failures: []string{"output mismatch"}},
+			},
+		},
+		{
+			File:  "broken.yaml",
+			Error: "error loading test suite",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, testrunner.RenderJUnit(&buf, results))
+
+	out := buf.String()
+	assert.Contains(t, out, `<testsuites tests="3" failures="1" errors="1"`)
+	assert.Contains(t, out, `<testcase name="passes" classname="suite.yaml"`)
+	assert.Contains(t, out, `<failure message="output mismatch">output mismatch</failure>`)
+	assert.Contains(t, out, `<error message="error loading test suite"></error>`)
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zigflow/zigflow/pkg/utils"
+	"sigs.k8s.io/yaml"
+)
+
+// Suite is a declarative collection of test cases for a single workflow file.
+type Suite struct {
+	// Path to the workflow file, relative to the suite file
+	Workflow string `json:"workflow,omitempty"`
+	// Envvars made available to every test case as $env. Secrets are read
+	// from here too.
+	Env   map[string]any `json:"env,omitempty"`
+	Tests []*TestCase    `json:"tests" validate:"required,min=1,dive"`
+
+	// Path the suite was loaded from
+	file string
+}
+
+// TestCase describes a single workflow execution and its expected result.
+type TestCase struct {
+	Name string `json:"name" validate:"required"`
+	// Temporal workflow type to execute - defaults to document.name
+	Workflow string         `json:"workflow,omitempty"`
+	Input    any            `json:"input,omitempty"`
+	Env      map[string]any `json:"env,omitempty"`
+	Mocks    []*Mock        `json:"mocks,omitempty" validate:"dive"`
+	Signals  []*Message     `json:"signals,omitempty" validate:"dive"`
+	Updates  []*Message     `json:"updates,omitempty" validate:"dive"`
+	// Wall-clock limit for the test case - workflow time is skipped
+	Timeout string       `json:"timeout,omitempty"`
+	Expect  *Expectation `json:"expect,omitempty"`
+}
+
+// Mock replaces an activity result. Task mocks match the built-in Zigflow
+// activities (HTTP, gRPC and run) by task name. Activity mocks match a
+// "call: activity" task by the Temporal activity name.
+type Mock struct {
+	Task     string     `json:"task,omitempty" validate:"required_without=Activity"`
+	Activity string     `json:"activity,omitempty" validate:"required_without=Task"`
+	Output   any        `json:"output,omitempty"`
+	Error    *MockError `json:"error,omitempty"`
+}
+
+// MockError is returned by a mock as a Temporal application error.
+type MockError struct {
+	Message      string `json:"message" validate:"required"`
+	Type         string `json:"type,omitempty"`
+	NonRetryable bool   `json:"nonRetryable,omitempty"`
+}
+
+// Message is a signal or update delivered to the running workflow.
+type Message struct {
+	ID string `json:"id" validate:"required"`
+	// Workflow time after which the message is delivered, eg "5s"
+	After string `json:"after,omitempty"`
+	Data  any    `json:"data,omitempty"`
+	// Expected update response - ignored for signals
+	Expect *Expectation `json:"expect,omitempty"`
+}
+
+// Expectation is the expected output or error. When Error is set, the
+// execution must fail with an error containing that text.
+type Expectation struct {
+	Output any    `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// WorkflowPath returns the workflow file path resolved relative to the
+// suite file's directory.
+func (s *Suite) WorkflowPath() string {
+	if s.Workflow == "" || filepath.IsAbs(s.Workflow) {
+		return s.Workflow
+	}
+	return filepath.Join(filepath.Dir(s.file), s.Workflow)
+}
+
+func (s *Suite) validate(validator *utils.Validator) error {
+	res, err := validator.ValidateStruct(s)
+	if err != nil {
+		return fmt.Errorf("error creating validation stack: %w", err)
+	}
+	if len(res) > 0 {
+		return fmt.Errorf("invalid test suite: %s %s", res[0].Path, res[0].Message)
+	}
+
+	for _, test := range s.Tests {
+		if _, err := parseDuration(test.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout for test %q: %w", test.Name, err)
+		}
+		for _, m := range append(append([]*Message{}, test.Signals...), test.Updates...) {
+			if _, err := parseDuration(m.After); err != nil {
+				return fmt.Errorf("invalid delay for message %q in test %q: %w", m.ID, test.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSuite reads and validates a test suite file.
+func LoadSuite(file string) (*Suite, error) {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("error loading test suite: %w", err)
+	}
+
+	var suite Suite
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("error unmarshaling test suite: %w", err)
+	}
+	suite.file = file
+
+	validator, err := utils.NewValidator()
+	if err != nil {
+		return nil, fmt.Errorf("error creating validator: %w", err)
+	}
+
+	if err := suite.validate(validator); err != nil {
+		return nil, err
+	}
+
+	return &suite, nil
+}
+
+// parseDuration parses a Go duration string, treating an empty string as zero
+func parseDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(v)
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/cloudevents/sdk-go/v2/protocol"
+)
+
+const (
+	TraceStatusRunning   = "running"
+	TraceStatusCompleted = "completed"
+	TraceStatusFaulted   = "faulted"
+	TraceStatusCancelled = "cancelled"
+)
+
+// TraceEntry records the execution of a single task
+type TraceEntry struct {
+	Task       string        `json:"task"`
+	WorkflowID string        `json:"workflowId"`
+	Status     string        `json:"status"`
+	Depth      int           `json:"depth"`
+	Output     any           `json:"output,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+
+	started time.Time
+	// Workflow time the task started and finished - unlike the event time,
+	// this is the same when a history is replayed
+	workflowStarted  time.Time
+	workflowFinished time.Time
+}
+
+// traceSender collects the task lifecycle events emitted by the workflow and
+// turns them into a trace. Tasks that start while another is still running,
+// such as the tasks of a child workflow, are nested beneath it.
+type traceSender struct {
+	mu      sync.Mutex
+	entries []*TraceEntry
+	open    []*TraceEntry
+}
+
+func (s *traceSender) Send(ctx context.Context, m binding.Message, transformers ...binding.Transformer) error {
+	defer func() {
+		_ = m.Finish(nil)
+	}()
+
+	event, err := binding.ToEvent(ctx, m, transformers...)
+	if err != nil {
+		return fmt.Errorf("failed to convert message to event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch event.Type() {
+	case "dev.zigflow.task.started":
+		s.start(event)
+	case "dev.zigflow.task.completed":
+		s.finish(event, TraceStatusCompleted)
+	case "dev.zigflow.task.faulted":
+		s.finish(event, TraceStatusFaulted)
+	case "dev.zigflow.task.cancelled":
+		s.finish(event, TraceStatusCancelled)
+	}
+
+	return nil
+}
+
+func (s *traceSender) start(event *sdk.Event) {
+	entry := &TraceEntry{
+		Task:       event.Subject(),
+		WorkflowID: event.ID(),
+		Status:     TraceStatusRunning,
+		Depth:      len(s.open),
+		started:    event.Time(),
+	}
+
+	var data map[string]any
+	if err := event.DataAs(&data); err == nil {
+		entry.workflowStarted = workflowTime(data)
+	}
+
+	s.entries = append(s.entries, entry)
+	s.open = append(s.open, entry)
+}
+
+func (s *traceSender) finish(event *sdk.Event, status string) {
+	for i := len(s.open) - 1; i >= 0; i-- {
+		entry := s.open[i]
+		if entry.Task != event.Subject() || entry.WorkflowID != event.ID() {
+			continue
+		}
+
+		entry.Status = status
+		entry.Duration = event.Time().Sub(entry.started)
+
+		var data map[string]any
+		if err := event.DataAs(&data); err == nil {
+			entry.Output = data["output"]
+			if e, ok := data["error"].(string); ok {
+				entry.Error = e
+			}
+			entry.workflowFinished = workflowTime(data)
+		}
+
+		s.open = append(s.open[:i], s.open[i+1:]...)
+		return
+	}
+}
+
+// workflowTime reads the workflow time from the data of a task event
+func workflowTime(data map[string]any) time.Time {
+	v, _ := data["workflowTime"].(string)
+	t, _ := time.Parse(time.RFC3339Nano, v)
+	return t
+}
+
+// Trace returns the entries in the order the tasks started
+func (s *traceSender) Trace() []*TraceEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*TraceEntry{}, s.entries...)
+}
+
+var _ protocol.Sender = (*traceSender)(nil)
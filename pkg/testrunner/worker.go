@@ -0,0 +1,48 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testrunner
+
+import (
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+// environmentWorker adapts the in-memory test environment to the worker.Worker
+// interface so that workflows can be compiled with zigflow.NewWorkflow. The
+// environment already implements the registry - there is nothing to poll.
+type environmentWorker struct {
+	*testsuite.TestWorkflowEnvironment
+}
+
+func (w *environmentWorker) Start() error {
+	return nil
+}
+
+func (w *environmentWorker) Run(<-chan any) error {
+	return nil
+}
+
+func (w *environmentWorker) Stop() {}
+
+// NewEnvironmentWorker returns a worker.Worker that registers everything in env
+func NewEnvironmentWorker(env *testsuite.TestWorkflowEnvironment) worker.Worker {
+	return &environmentWorker{
+		TestWorkflowEnvironment: env,
+	}
+}
+
+var _ worker.Worker = &environmentWorker{}
@@ -28,6 +28,8 @@ type LogWriter struct {
 	Level  string
 	Msg    string
 	Fields []any
+	// Redact is applied to each line before it is logged
+	Redact func(string) string
 }
 
 func (w LogWriter) AddFields(args []any) LogWriter {
@@ -43,6 +45,9 @@ func (w LogWriter) Write(p []byte) (n int, err error) {
 	if line == "" {
 		return
 	}
+	if w.Redact != nil {
+		line = w.Redact(line)
+	}
 
 	msg := "New line"
 	if w.Msg != "" {
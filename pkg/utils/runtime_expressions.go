@@ -64,6 +64,16 @@ var jqFuncs []jqFunc = []jqFunc{
 	},
 }
 
+// NonDeterministicFunctions returns the names of the custom jq functions. These
+// must only be used in a set task.
+func NonDeterministicFunctions() []string {
+	names := make([]string, 0, len(jqFuncs))
+	for _, fn := range jqFuncs {
+		names = append(names, fn.Name)
+	}
+	return names
+}
+
 // The return value could be any value depending upon how it's parsed
 func EvaluateString(str string, ctx any, state *State, evaluationWrapper ...ExpressionWrapperFunc) (any, error) {
 	// Check if the string is a runtime expression (e.g., ${ .some.path })
@@ -208,8 +218,23 @@ func evaluateJQExpression(expression string, ctx any, state *State) (any, error)
 		return nil, fmt.Errorf("failed to parse jq expression: %s, error: %w", expression, err)
 	}
 
+	vars := state.GetAsMap()
+
+	// Secrets are resolved only when used so unused ones are never read
+	secretNames, dynamic := SecretReferences(query)
+	if dynamic {
+		return nil, fmt.Errorf("%s must be accessed by name, such as %s.name: %s", SecretsVariable, SecretsVariable, expression)
+	}
+	if len(secretNames) > 0 {
+		secretValues, err := state.resolveSecrets(secretNames)
+		if err != nil {
+			return nil, err
+		}
+		vars[SecretsVariable] = secretValues
+	}
+
 	// Get the variable names & values in a single pass:
-	names, values := getVariableNamesAndValues(state.GetAsMap())
+	names, values := getVariableNamesAndValues(vars)
 
 	fns := []gojq.CompilerOption{
 		gojq.WithVariables(names),
@@ -248,19 +273,44 @@ func CheckIfStatement(ifStatement *model.RuntimeExpression, state *State) (bool,
 		return false, temporal.NewNonRetryableApplicationError("Error parsing if statement", "If statement error", err)
 	}
 
-	// Response can be a boolean, "TRUE" (case-insensitive) or "1"
-	switch r := res.(type) {
-	case bool:
-		return r, nil
-	case string:
-		return strings.EqualFold(r, "TRUE") || r == "1", nil
-	default:
+	b, ok := toBool(res)
+	if !ok {
 		return false, temporal.NewNonRetryableApplicationError(
 			"If statement response type unknown",
 			"If statement error",
 			fmt.Errorf("response not string or bool"),
 		)
 	}
+
+	return b, nil
+}
+
+// EvaluateBool evaluates the expression against the context, in the same way
+// as an if statement
+func EvaluateBool(str string, ctx any, state *State) (bool, error) {
+	res, err := EvaluateString(str, ctx, state)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := toBool(res)
+	if !ok {
+		return false, fmt.Errorf("expression %q response not string or bool", str)
+	}
+
+	return b, nil
+}
+
+// Response can be a boolean, "TRUE" (case-insensitive) or "1"
+func toBool(res any) (value, ok bool) {
+	switch r := res.(type) {
+	case bool:
+		return r, true
+	case string:
+		return strings.EqualFold(r, "TRUE") || r == "1", true
+	default:
+		return false, false
+	}
 }
 
 func getVariableNamesAndValues(vars map[string]any) (names []string, values []any) {
@@ -635,3 +635,44 @@ func TestCheckIfStatement(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluateBool(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Expression  string
+		Ctx         any
+		Expected    bool
+		ErrContains string
+	}{
+		{
+			Name:       "context is the input",
+			Expression: `${ .type == "Timeout" }`,
+			Ctx:        map[string]any{"type": "Timeout"},
+			Expected:   true,
+		},
+		{
+			Name:       "string response",
+			Expression: `${ "false" }`,
+			Expected:   false,
+		},
+		{
+			Name:        "numeric result returns error",
+			Expression:  "${ 42 }",
+			ErrContains: "response not string or bool",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			result, err := EvaluateBool(test.Expression, test.Ctx, NewState())
+			if test.ErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.ErrContains)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/itchyny/gojq"
+	"github.com/zigflow/zigflow/pkg/secrets"
+)
+
+// SecretsVariable is the expression variable that secrets are read from
+const SecretsVariable = "$secrets"
+
+const redactedSecret = "********"
+
+// secretStore resolves secrets for a single activity and remembers their
+// values so they can be redacted
+type secretStore struct {
+	get func(name string) (string, error)
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func newSecretStore(ctx context.Context, provider secrets.Provider) *secretStore {
+	return &secretStore{
+		get: func(name string) (string, error) {
+			return provider.Get(ctx, name)
+		},
+		values: map[string]string{},
+	}
+}
+
+func (s *secretStore) resolve(name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.values[name]; ok {
+		return v, nil
+	}
+
+	v, err := s.get(name)
+	if err != nil {
+		return "", err
+	}
+	s.values[name] = v
+
+	return v, nil
+}
+
+// resolveSecrets returns the values of the named secrets. Secrets are only
+// available inside activities and must be declared in use.secrets.
+func (s *State) resolveSecrets(names []string) (map[string]any, error) {
+	if s.secrets == nil {
+		return nil, fmt.Errorf("%s is only available to tasks that run as activities", SecretsVariable)
+	}
+
+	values := make(map[string]any, len(names))
+	for _, name := range names {
+		if !slices.Contains(s.Secrets, name) {
+			return nil, fmt.Errorf("secret %q is not declared in use.secrets", name)
+		}
+
+		v, err := s.secrets.resolve(name)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving secret %q: %w", name, err)
+		}
+		values[name] = v
+	}
+
+	return values, nil
+}
+
+// Secret returns the value of a secret declared in use.secrets. Secrets are
+// only available inside activities.
+func (s *State) Secret(name string) (string, error) {
+	values, err := s.resolveSecrets([]string{name})
+	if err != nil {
+		return "", err
+	}
+	return values[name].(string), nil
+}
+
+// RedactSecrets replaces the values of any secrets resolved by this state,
+// including where they are escaped in a URL
+func (s *State) RedactSecrets(str string) string {
+	if s.secrets == nil {
+		return str
+	}
+
+	s.secrets.mu.Lock()
+	defer s.secrets.mu.Unlock()
+
+	for _, v := range s.secrets.values {
+		for _, form := range secretForms(v) {
+			str = strings.ReplaceAll(str, form, redactedSecret)
+		}
+	}
+
+	return str
+}
+
+// secretForms returns the ways a secret may be written. A secret in a URL is
+// escaped, and the query of a request is parsed and escaped again, which
+// turns a "+" into a space.
+func secretForms(v string) []string {
+	forms := make([]string, 0, 3)
+	add := func(form string) {
+		if form != "" && !slices.Contains(forms, form) {
+			forms = append(forms, form)
+		}
+	}
+
+	add(v)
+	add(url.QueryEscape(v))
+	if unescaped, err := url.QueryUnescape(v); err == nil {
+		add(url.QueryEscape(unescaped))
+	}
+
+	return forms
+}
+
+// SecretReferences returns the names of the secrets used by the query. Secrets
+// must be accessed by a fixed name, such as $secrets.name or $secrets["name"],
+// so that only those are resolved - dynamic is true if the query uses $secrets
+// in any other way.
+func SecretReferences(query *gojq.Query) (names []string, dynamic bool) {
+	names = make([]string, 0)
+	termType := reflect.TypeFor[gojq.Term]()
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			if v.IsNil() {
+				return
+			}
+			if v.Elem().Type() == termType {
+				if term := v.Interface().(*gojq.Term); term.Func != nil && term.Func.Name == SecretsVariable {
+					name, ok := secretName(term)
+					switch {
+					case !ok:
+						dynamic = true
+					case !slices.Contains(names, name):
+						names = append(names, name)
+					}
+				}
+			}
+			walk(v.Elem())
+		case reflect.Struct:
+			for i := range v.NumField() {
+				walk(v.Field(i))
+			}
+		case reflect.Slice:
+			for i := range v.Len() {
+				walk(v.Index(i))
+			}
+		}
+	}
+	walk(reflect.ValueOf(query))
+
+	return names, dynamic
+}
+
+// secretName returns the name that the term indexes $secrets with
+func secretName(term *gojq.Term) (string, bool) {
+	if len(term.SuffixList) == 0 || term.SuffixList[0].Index == nil {
+		return "", false
+	}
+
+	index := term.SuffixList[0].Index
+	switch {
+	case index.Name != "":
+		// $secrets.name
+		return index.Name, true
+	case index.Str != nil && len(index.Str.Queries) == 0:
+		// $secrets."name"
+		return index.Str.Str, true
+	case index.Start != nil && index.End == nil && !index.IsSlice:
+		// $secrets["name"]
+		if t := index.Start.Term; index.Start.Op == 0 && t != nil && t.Str != nil &&
+			len(t.Str.Queries) == 0 && len(t.SuffixList) == 0 {
+			return t.Str.Str, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/itchyny/gojq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/secrets"
+)
+
+type mapProvider map[string]string
+
+func (m mapProvider) Get(_ context.Context, name string) (string, error) {
+	v, ok := m[name]
+	if !ok {
+		return "", secrets.ErrNotFound
+	}
+	return v, nil
+}
+
+func newSecretsState(declared ...string) *State {
+	s := NewState()
+	s.Secrets = declared
+	s.secrets = newSecretStore(context.Background(), mapProvider{
+		"token":     "abc123",
+		"password":  "hunter2",
+		"signature": "a/b+c",
+	})
+	return s
+}
+
+func TestSecretReferences(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Expr     string
+		Expected []string
+		Dynamic  bool
+	}{
+		{
+			Name:     "no secrets",
+			Expr:     "$env.token",
+			Expected: []string{},
+		},
+		{
+			Name:     "dot access",
+			Expr:     "$secrets.token",
+			Expected: []string{"token"},
+		},
+		{
+			Name:     "string index",
+			Expr:     `$secrets["token"]`,
+			Expected: []string{"token"},
+		},
+		{
+			Name:     "quoted field",
+			Expr:     `$secrets."token"`,
+			Expected: []string{"token"},
+		},
+		{
+			Name:     "many secrets",
+			Expr:     `"Basic " + ($secrets.username + ":" + $secrets.password | @base64) + $secrets.username`,
+			Expected: []string{"username", "password"},
+		},
+		{
+			Name:     "whole object",
+			Expr:     "$secrets | keys",
+			Expected: []string{},
+			Dynamic:  true,
+		},
+		{
+			Name:     "dynamic index",
+			Expr:     "$secrets[$input.name]",
+			Expected: []string{},
+			Dynamic:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			query, err := gojq.Parse(test.Expr)
+			require.NoError(t, err)
+
+			names, dynamic := SecretReferences(query)
+			assert.Equal(t, test.Expected, names)
+			assert.Equal(t, test.Dynamic, dynamic)
+		})
+	}
+}
+
+func TestEvaluateString_Secrets(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Str      string
+		State    *State
+		Expected any
+		Error    string
+	}{
+		{
+			Name:     "declared secret",
+			Str:      `${ "Bearer " + $secrets.token }`,
+			State:    newSecretsState("token"),
+			Expected: "Bearer abc123",
+		},
+		{
+			Name:  "undeclared secret",
+			Str:   "${ $secrets.password }",
+			State: newSecretsState("token"),
+			Error: `secret "password" is not declared in use.secrets`,
+		},
+		{
+			Name:  "missing secret",
+			Str:   "${ $secrets.apiKey }",
+			State: newSecretsState("apiKey"),
+			Error: `error resolving secret "apiKey": secret not found`,
+		},
+		{
+			Name:  "dynamic access",
+			Str:   "${ $secrets | keys }",
+			State: newSecretsState("token"),
+			Error: "$secrets must be accessed by name",
+		},
+		{
+			Name:  "outside an activity",
+			Str:   "${ $secrets.token }",
+			State: &State{Secrets: []string{"token"}},
+			Error: "$secrets is only available to tasks that run as activities",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res, err := EvaluateString(test.Str, nil, test.State)
+			if test.Error != "" {
+				assert.ErrorContains(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, res)
+		})
+	}
+}
+
+func TestState_Secrets(t *testing.T) {
+	s := newSecretsState("token")
+
+	_, err := EvaluateString("${ $secrets.token }", nil, s)
+	require.NoError(t, err)
+
+	// Clones share the resolved secrets but never serialise them
+	clone := s.Clone()
+	assert.Equal(t, "Bearer ********", clone.RedactSecrets("Bearer abc123"))
+	assert.Equal(t, "hunter2", clone.RedactSecrets("hunter2"))
+	assert.NotContains(t, clone.GetAsMap(), SecretsVariable)
+
+	b, err := json.Marshal(clone)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "abc123")
+
+	assert.Equal(t, "abc123", NewState().RedactSecrets("abc123"))
+}
+
+func TestState_RedactSecretsInURL(t *testing.T) {
+	s := newSecretsState("signature")
+
+	_, err := EvaluateString("${ $secrets.signature }", nil, s)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com?sig=********", s.RedactSecrets("https://example.com?sig=a/b+c"))
+	assert.Equal(t, "https://example.com?sig=********", s.RedactSecrets("https://example.com?sig=a%2Fb%2Bc"))
+	// Parsing the query reads the "+" as a space
+	assert.Equal(t, "https://example.com?sig=********", s.RedactSecrets("https://example.com?sig=a%2Fb+c"))
+}
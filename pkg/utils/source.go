@@ -0,0 +1,400 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"go.yaml.in/yaml/v3"
+)
+
+// Position is a location in a workflow file
+type Position struct {
+	// JSON pointer to the value, such as /do/1/fetch/call
+	Pointer string `json:"pointer,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+func (p Position) Location() string {
+	if p.Line == 0 {
+		return p.Pointer
+	}
+	return fmt.Sprintf("%s (line %d, column %d)", p.Pointer, p.Line, p.Column)
+}
+
+// Source maps the values in a workflow file to where they are declared. The
+// workflow is converted to JSON before it's unmarshalled, so this is the only
+// record of where things came from.
+type Source struct {
+	positions map[string]Position
+	tasks     map[model.Task]string
+}
+
+// ParseSource records the position of every node in the YAML or JSON file
+func ParseSource(data []byte) (*Source, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("error parsing yaml: %w", err)
+	}
+
+	s := &Source{
+		positions: map[string]Position{},
+		tasks:     map[model.Task]string{},
+	}
+	s.walk(&root, "")
+
+	return s, nil
+}
+
+func (s *Source) walk(node *yaml.Node, pointer string) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			s.walk(n, pointer)
+		}
+		return
+	case yaml.MappingNode:
+		s.record(pointer, node)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			p := pointer + "/" + EscapePointer(key.Value)
+
+			// Entries are positioned at their key
+			s.record(p, key)
+			s.walk(value, p)
+		}
+		return
+	case yaml.SequenceNode:
+		s.record(pointer, node)
+		for i, n := range node.Content {
+			s.walk(n, pointer+"/"+strconv.Itoa(i))
+		}
+		return
+	}
+	s.record(pointer, node)
+}
+
+func (s *Source) record(pointer string, node *yaml.Node) {
+	if _, ok := s.positions[pointer]; !ok {
+		s.positions[pointer] = Position{Pointer: pointer, Line: node.Line, Column: node.Column}
+	}
+}
+
+// Position returns where the JSON pointer is declared. If it's not in the
+// file, such as a default value, the closest parent is used.
+func (s *Source) Position(pointer string) Position {
+	pos := Position{Pointer: pointer}
+	if s == nil {
+		return pos
+	}
+
+	for p := pointer; ; {
+		if found, ok := s.positions[p]; ok {
+			pos.Line = found.Line
+			pos.Column = found.Column
+			return pos
+		}
+		i := strings.LastIndex(p, "/")
+		if i < 0 {
+			return pos
+		}
+		p = p[:i]
+	}
+}
+
+// AddTasks records the JSON pointer of each task in the workflow so that
+// errors can be located from the task alone
+func (s *Source) AddTasks(wf *model.Workflow) {
+	walkTasks(wf.Do, "/do", func(task model.Task, pointer string) bool {
+		s.tasks[task] = pointer
+		return true
+	})
+}
+
+// TaskReference returns the JSON pointer of the task in the workflow, such as
+// /do/0/callApi, or an empty string if the task isn't in the workflow
+func TaskReference(wf *model.Workflow, task model.Task) (reference string) {
+	if wf == nil || task == nil {
+		return ""
+	}
+
+	walkTasks(wf.Do, "/do", func(t model.Task, pointer string) bool {
+		if t == task {
+			reference = pointer
+			return false
+		}
+		return true
+	})
+
+	return reference
+}
+
+// walkTasks calls fn with each task in the list, and the tasks nested in them,
+// until fn returns false
+func walkTasks(list *model.TaskList, pointer string, fn func(task model.Task, pointer string) bool) bool {
+	if list == nil {
+		return true
+	}
+
+	for i, item := range *list {
+		p := pointer + "/" + strconv.Itoa(i) + "/" + EscapePointer(item.Key)
+		if item.Task == nil {
+			continue
+		}
+		if !fn(item.Task, p) || !walkTask(item.Task, p, fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func walkTask(task model.Task, pointer string, fn func(task model.Task, pointer string) bool) bool {
+	switch task := task.(type) {
+	case *model.DoTask:
+		return walkTasks(task.Do, pointer+"/do", fn)
+	case *model.ForTask:
+		return walkTasks(task.Do, pointer+"/do", fn)
+	case *model.ForkTask:
+		return walkTasks(task.Fork.Branches, pointer+"/fork/branches", fn)
+	case *model.TryTask:
+		if !walkTasks(task.Try, pointer+"/try", fn) {
+			return false
+		}
+		if task.Catch != nil {
+			return walkTasks(task.Catch.Do, pointer+"/catch/do", fn)
+		}
+	}
+
+	return true
+}
+
+// TaskPosition returns where the task is declared
+func (s *Source) TaskPosition(task model.Task) (Position, bool) {
+	if s == nil || task == nil {
+		return Position{}, false
+	}
+	pointer, ok := s.tasks[task]
+	if !ok {
+		return Position{}, false
+	}
+	return s.Position(pointer), true
+}
+
+// SourceError is an error caused by a task declared in the workflow file. It
+// is located once the error reaches the loader, which knows where the task is.
+type SourceError struct {
+	Position
+	Err  error
+	task model.Task
+}
+
+func (e *SourceError) Error() string {
+	if e.Pointer == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.Location(), e.Err)
+}
+
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the task's error, without the context added as it was
+// returned to the loader
+func (e *SourceError) Cause() error {
+	var inner *SourceError
+	if errors.As(e.Err, &inner) {
+		return inner.Cause()
+	}
+	return e.Err
+}
+
+// NewSourceError records the task that caused the error. An error that already
+// has a task is returned as-is so the innermost task is reported.
+func NewSourceError(task model.Task, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var srcErr *SourceError
+	if errors.As(err, &srcErr) {
+		return err
+	}
+
+	return &SourceError{
+		Err:  err,
+		task: task,
+	}
+}
+
+// Locate returns the error with the position of the task that caused it, if
+// it's a SourceError
+func (s *Source) Locate(err error) error {
+	var srcErr *SourceError
+	if !errors.As(err, &srcErr) || srcErr.Pointer != "" {
+		return err
+	}
+
+	pos, ok := s.TaskPosition(srcErr.task)
+	if !ok {
+		return err
+	}
+
+	return &SourceError{
+		Position: pos,
+		Err:      err,
+		task:     srcErr.task,
+	}
+}
+
+// EscapePointer escapes a key for use in a JSON pointer
+func EscapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+var taskItemType = reflect.TypeFor[model.TaskItem]()
+
+// namespacePointer converts a validator namespace, such as
+// Workflow.Do[1].Task.CallHTTP.With.Method, to a JSON pointer by following the
+// namespace through the validated value. It stops at anything that doesn't
+// appear in the JSON, returning the closest pointer.
+func namespacePointer(root any, namespace string) string {
+	var pointer strings.Builder
+	v := reflect.ValueOf(root)
+
+	segments := splitNamespace(namespace)
+	if len(segments) == 0 {
+		return ""
+	}
+
+	// The first segment is the root type
+	for _, segment := range segments[1:] {
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return pointer.String()
+			}
+			v = v.Elem()
+		}
+
+		if segment.Index == nil {
+			if v.Kind() != reflect.Struct {
+				return pointer.String()
+			}
+
+			// Tasks are keyed by their name
+			if v.Type() == taskItemType && segment.Name == "Task" {
+				pointer.WriteString("/" + EscapePointer(v.FieldByName("Key").String()))
+				v = v.FieldByName("Task")
+				continue
+			}
+
+			field, ok := v.Type().FieldByName(segment.Name)
+			if !ok {
+				// Interfaces are shown by their concrete type
+				if segment.Name == v.Type().Name() {
+					continue
+				}
+				return pointer.String()
+			}
+
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return pointer.String()
+			}
+			if name != "" && !field.Anonymous {
+				pointer.WriteString("/" + EscapePointer(name))
+			}
+
+			next, err := v.FieldByIndexErr(field.Index)
+			if err != nil {
+				return pointer.String()
+			}
+			v = next
+			continue
+		}
+
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(*segment.Index)
+			if err != nil || i < 0 || i >= v.Len() {
+				return pointer.String()
+			}
+			pointer.WriteString("/" + *segment.Index)
+			v = v.Index(i)
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return pointer.String()
+			}
+			pointer.WriteString("/" + EscapePointer(*segment.Index))
+			v = v.MapIndex(reflect.ValueOf(*segment.Index).Convert(v.Type().Key()))
+			if !v.IsValid() {
+				return pointer.String()
+			}
+		default:
+			return pointer.String()
+		}
+	}
+
+	return pointer.String()
+}
+
+type namespaceSegment struct {
+	Name  string
+	Index *string
+}
+
+// splitNamespace splits Do[1].Task into Do, [1] and Task. Map keys are
+// wrapped in brackets and may contain dots.
+func splitNamespace(namespace string) []namespaceSegment {
+	segments := make([]namespaceSegment, 0)
+
+	var name strings.Builder
+	flush := func() {
+		if name.Len() > 0 {
+			segments = append(segments, namespaceSegment{Name: name.String()})
+			name.Reset()
+		}
+	}
+
+	for i := 0; i < len(namespace); i++ {
+		switch c := namespace[i]; c {
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(namespace[i:], ']')
+			if end < 0 {
+				end = len(namespace) - i
+			}
+			index := namespace[i+1 : i+end]
+			segments = append(segments, namespaceSegment{Index: &index})
+			i += end
+		default:
+			name.WriteByte(c)
+		}
+	}
+	flush()
+
+	return segments
+}
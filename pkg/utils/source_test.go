@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourcePosition(t *testing.T) {
+	src, err := ParseSource([]byte(`do:
+  - a/b:
+      set:
+        hello: world
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		Pointer  string
+		Expected Position
+	}{
+		{
+			Pointer:  "/do/0/a~1b/set/hello",
+			Expected: Position{Pointer: "/do/0/a~1b/set/hello", Line: 4, Column: 9},
+		},
+		{
+			Pointer:  "/do/0",
+			Expected: Position{Pointer: "/do/0", Line: 2, Column: 5},
+		},
+		{
+			Pointer:  "/do/0/a~1b/if",
+			Expected: Position{Pointer: "/do/0/a~1b/if", Line: 2, Column: 5},
+		},
+		{
+			Pointer:  "/missing",
+			Expected: Position{Pointer: "/missing", Line: 1, Column: 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Pointer, func(t *testing.T) {
+			assert.Equal(t, test.Expected, src.Position(test.Pointer))
+		})
+	}
+
+	var noSource *Source
+	assert.Equal(t, Position{Pointer: "/do"}, noSource.Position("/do"))
+}
+
+func TestTaskReference(t *testing.T) {
+	callAPI := &model.CallHTTP{Call: "http"}
+	cleanup := &model.SetTask{Set: map[string]any{"done": true}}
+	try := &model.TryTask{
+		Try: &model.TaskList{{Key: "callApi", Task: callAPI}},
+		Catch: &model.TryTaskCatch{
+			Do: &model.TaskList{{Key: "clean/up", Task: cleanup}},
+		},
+	}
+	wf := &model.Workflow{
+		Do: &model.TaskList{
+			{Key: "start", Task: &model.SetTask{Set: map[string]any{}}},
+			{Key: "attempt", Task: try},
+		},
+	}
+
+	tests := []struct {
+		Name     string
+		Task     model.Task
+		Expected string
+	}{
+		{Name: "top level", Task: try, Expected: "/do/1/attempt"},
+		{Name: "try", Task: callAPI, Expected: "/do/1/attempt/try/0/callApi"},
+		{Name: "catch", Task: cleanup, Expected: "/do/1/attempt/catch/do/0/clean~1up"},
+		{Name: "not in the workflow", Task: &model.SetTask{}},
+		{Name: "no task"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, TaskReference(wf, test.Task))
+		})
+	}
+}
+
+func TestSourceLocate(t *testing.T) {
+	data := []byte(`do:
+  - first:
+      set: {}
+  - second:
+      wait:
+        seconds: 1
+`)
+	src, err := ParseSource(data)
+	require.NoError(t, err)
+
+	second := &model.WaitTask{}
+	src.AddTasks(&model.Workflow{
+		Do: &model.TaskList{
+			{Key: "first", Task: &model.SetTask{}},
+			{Key: "second", Task: second},
+		},
+	})
+
+	cause := errors.New("invalid duration")
+	err = fmt.Errorf("error building task: %w", NewSourceError(second, cause))
+	assert.EqualError(t, err, "error building task: invalid duration")
+
+	err = src.Locate(err)
+	assert.EqualError(t, err, "/do/1/second (line 4, column 5): error building task: invalid duration")
+	assert.ErrorIs(t, err, cause)
+
+	var srcErr *SourceError
+	if assert.ErrorAs(t, err, &srcErr) {
+		assert.Equal(t, cause, srcErr.Cause())
+	}
+
+	var noSource *Source
+	err = fmt.Errorf("error building task: %w", NewSourceError(second, cause))
+	assert.EqualError(t, noSource.Locate(err), "error building task: invalid duration")
+}
+
+func TestNamespacePointer(t *testing.T) {
+	wf := &model.Workflow{
+		Document: model.Document{Name: "test"},
+		Do: &model.TaskList{
+			{
+				Key: "fetch",
+				Task: &model.CallHTTP{
+					Call: "http",
+					With: model.HTTPArguments{Method: "get"},
+				},
+			},
+			{
+				Key: "check",
+				Task: &model.SwitchTask{
+					Switch: []model.SwitchItem{
+						{"case.one": {}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		Namespace string
+		Expected  string
+	}{
+		{Namespace: "Workflow.Document.Name", Expected: "/document/name"},
+		{Namespace: "Workflow.Do[0].Task.With.Method", Expected: "/do/0/fetch/with/method"},
+		{Namespace: "Workflow.Do[0].Task.CallHTTP.With.Method", Expected: "/do/0/fetch/with/method"},
+		{Namespace: "Workflow.Do[0].Task.With.Endpoint.RuntimeExpression.Value", Expected: "/do/0/fetch/with/endpoint"},
+		{Namespace: "Workflow.Do[1].Task.Switch[0][case.one].Then", Expected: "/do/1/check/switch/0/case.one/then"},
+		{Namespace: "Workflow.Do[5].Task", Expected: "/do"},
+		{Namespace: "", Expected: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Namespace, func(t *testing.T) {
+			assert.Equal(t, test.Expected, namespacePointer(wf, test.Namespace))
+		})
+	}
+}
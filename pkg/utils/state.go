@@ -19,19 +19,26 @@ package utils
 import (
 	"context"
 	"maps"
+	"slices"
 
 	swUtils "github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/zigflow/zigflow/pkg/secrets"
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/workflow"
 )
 
 type State struct {
+	Arguments    map[string]any `json:"arguments,omitempty"`    // Arguments given to a custom function
 	CANStartFrom *string        `json:"canStartFrom,omitempty"` // Continue-as-new from here
 	Context      any            `json:"context"`                // Output data exported to later tasks output
 	Data         map[string]any `json:"data"`                   // Data stored along the way
 	Env          map[string]any `json:"env"`                    // Available environment variables
 	Input        any            `json:"input,omitempty"`        // The input given by the caller
 	Output       any            `json:"output"`                 // What will be output to the caller
+	Secrets      []string       `json:"secrets,omitempty"`      // Names of the secrets declared in use.secrets
+
+	// Resolves secret values inside an activity. This is never serialised.
+	secrets *secretStore
 }
 
 func (s *State) init() *State {
@@ -81,6 +88,10 @@ func (s *State) AddActivityInfo(ctx context.Context) *State {
 		"activity": activityData,
 	})
 
+	if p := secrets.FromContext(ctx); p != nil {
+		s.secrets = newSecretStore(ctx, p)
+	}
+
 	return s
 }
 
@@ -130,11 +141,14 @@ func (s *State) ClearOutput() *State {
 func (s *State) Clone() *State {
 	s1 := NewState()
 
+	s1.Arguments = swUtils.DeepClone(s.Arguments)
 	s1.Context = swUtils.DeepCloneValue(s.Context)
 	s1.Data = swUtils.DeepClone(s.Data)
 	s1.Env = swUtils.DeepClone(s.Env)
 	s1.Input = swUtils.DeepCloneValue(s.Input)
 	s1.Output = swUtils.DeepCloneValue(s.Output)
+	s1.Secrets = slices.Clone(s.Secrets)
+	s1.secrets = s.secrets
 
 	return s1
 }
@@ -144,11 +158,12 @@ func (s *State) GetAsMap() map[string]any {
 	s1 := s.Clone()
 
 	return map[string]any{
-		"$context": s1.Context,
-		"$data":    s1.Data,
-		"$env":     s1.Env,
-		"$input":   s1.Input,
-		"$output":  s1.Output,
+		"$arguments": s1.Arguments,
+		"$context":   s1.Context,
+		"$data":      s1.Data,
+		"$env":       s1.Env,
+		"$input":     s1.Input,
+		"$output":    s1.Output,
 	}
 }
 
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 
 	"github.com/go-playground/locales/en"
 	ut "github.com/go-playground/universal-translator"
@@ -30,18 +32,30 @@ import (
 
 var ErrUnknownValidationError = fmt.Errorf("unknown validation error")
 
+// Kinds of file that can be validated
+const (
+	ValidationKindWorkflow    = "workflow"
+	ValidationKindCloudEvents = "cloudevents"
+	ValidationKindKeys        = "keys"
+)
+
 type ValidationResult struct {
-	Valid  bool               `json:"valid"`
-	File   string             `json:"file"`
+	Valid bool   `json:"valid"`
+	File  string `json:"file"`
+	Kind  string `json:"kind"`
+	// Error is set if the file couldn't be loaded
+	Error  string             `json:"error,omitempty"`
 	Errors []ValidationErrors `json:"errors,omitempty"`
 }
 
 type ValidationErrors struct {
-	Key     string               `json:"key"`
-	Message string               `json:"message"`
-	Path    string               `json:"path"`
-	Param   string               `json:"param,omitempty"`
-	Error   validator.FieldError `json:"-"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+	Path    string `json:"path"`
+	Param   string `json:"param,omitempty"`
+	// Where the error is in the workflow file
+	Position
+	Error validator.FieldError `json:"-"`
 }
 
 type Validator struct {
@@ -49,7 +63,15 @@ type Validator struct {
 	trans    ut.Translator
 }
 
+// ValidateStruct validates the data. Errors have a JSON pointer, but no line
+// or column - use ValidateStructSource if the data was loaded from a file.
 func (v *Validator) ValidateStruct(data any) ([]ValidationErrors, error) {
+	return v.ValidateStructSource(data, nil)
+}
+
+// ValidateStructSource validates the data, finding where each error is in the
+// file the data was loaded from
+func (v *Validator) ValidateStructSource(data any, src *Source) ([]ValidationErrors, error) {
 	// Store validation errors
 	var vErrs []ValidationErrors
 
@@ -58,13 +80,25 @@ func (v *Validator) ValidateStruct(data any) ([]ValidationErrors, error) {
 		if validationError, ok := err.(validator.ValidationErrors); !ok {
 			return nil, fmt.Errorf("%s: %w", ErrUnknownValidationError, err)
 		} else {
+
 			for _, e := range validationError {
+				pos := src.Position(namespacePointer(data, e.Namespace()))
+
+				// Tasks are validated as both the interface and the concrete type, so
+				// the same error can be reported more than once and less precisely
+				if slices.ContainsFunc(validationError, func(other validator.FieldError) bool {
+					return isDuplicateError(data, e, other, pos.Pointer)
+				}) {
+					continue
+				}
+
 				vErrs = append(vErrs, ValidationErrors{
-					Key:     e.Tag(),
-					Message: e.Translate(v.trans),
-					Path:    e.StructNamespace(),
-					Param:   e.Param(),
-					Error:   e,
+					Key:      e.Tag(),
+					Message:  e.Translate(v.trans),
+					Path:     e.StructNamespace(),
+					Param:    e.Param(),
+					Position: pos,
+					Error:    e,
 				})
 			}
 		}
@@ -73,6 +107,30 @@ func (v *Validator) ValidateStruct(data any) ([]ValidationErrors, error) {
 	return vErrs, nil
 }
 
+// isDuplicateError returns true if other is the same error as e, at the same
+// position or a more precise one. Errors with no position are always kept.
+func isDuplicateError(data any, e, other validator.FieldError, pointer string) bool {
+	if pointer == "" || e == other || e.Tag() != other.Tag() {
+		return false
+	}
+
+	otherPointer := namespacePointer(data, other.Namespace())
+	if otherPointer == pointer {
+		// Keep the first of the same errors
+		return len(other.Namespace()) < len(e.Namespace()) ||
+			(len(other.Namespace()) == len(e.Namespace()) && other.Namespace() < e.Namespace())
+	}
+	return strings.HasPrefix(otherPointer, pointer+"/")
+}
+
+// HumanMessage describes the error in plain English
+func (e ValidationErrors) HumanMessage() string {
+	if e.Error == nil {
+		return e.Message
+	}
+	return humanMessage(e.Error)
+}
+
 func NewValidator() (*Validator, error) {
 	enTrans := en.New()
 	uni := ut.New(enTrans)
@@ -89,21 +147,49 @@ func NewValidator() (*Validator, error) {
 	}, nil
 }
 
-func RenderHuman(w io.Writer, result ValidationResult) {
-	if result.Valid {
-		_, _ = fmt.Fprintf(w, "✅ %s is valid\n", result.File)
-		return
-	}
+func RenderHuman(w io.Writer, results []ValidationResult) {
+	invalid := 0
+	for i, result := range results {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		if result.Valid {
+			_, _ = fmt.Fprintf(w, "✅ %s is valid\n", result.File)
+			continue
+		}
+		invalid++
+
+		_, _ = fmt.Fprintf(w, "❌ Validation failed for %s\n\n", result.File)
+		if result.Error != "" {
+			_, _ = fmt.Fprintf(w, "%s\n", result.Error)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%d validation error(s):\n\n", len(result.Errors))
 
-	_, _ = fmt.Fprintf(w, "❌ Validation failed for %s\n\n", result.File)
-	_, _ = fmt.Fprintf(w, "%d validation error(s):\n\n", len(result.Errors))
+		for i, err := range result.Errors {
+			location := err.Path
+			if err.Pointer != "" {
+				location = err.Location()
+			}
+			_, _ = fmt.Fprintf(w, "%d. %s: %s\n", i+1, location, err.HumanMessage())
+		}
+	}
 
-	for i, err := range result.Errors {
-		_, _ = fmt.Fprintf(w, "%d. %s: %s\n", i+1, err.Path, humanMessage(err.Error))
+	if len(results) > 1 {
+		_, _ = fmt.Fprintf(w, "\n%d valid, %d invalid\n", len(results)-invalid, invalid)
 	}
 }
 
-func RenderJSON(w io.Writer, result ValidationResult) error {
+func RenderJSON(w io.Writer, results []ValidationResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// RenderJSONResult writes a single result as a JSON object. This is the
+// output of the deprecated --output-json flag.
+func RenderJSONResult(w io.Writer, result ValidationResult) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(result)
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	validationJUnitName = "zigflow validate"
+	validationSARIFName = "zigflow-validate"
+	validationLoadRule  = "load"
+)
+
+type validationJUnitSuites struct {
+	XMLName  xml.Name                `xml:"testsuites"`
+	Tests    int                     `xml:"tests,attr"`
+	Failures int                     `xml:"failures,attr"`
+	Errors   int                     `xml:"errors,attr"`
+	Suites   []*validationJUnitSuite `xml:"testsuite"`
+}
+
+type validationJUnitSuite struct {
+	Name      string                 `xml:"name,attr"`
+	Tests     int                    `xml:"tests,attr"`
+	Failures  int                    `xml:"failures,attr"`
+	Errors    int                    `xml:"errors,attr"`
+	TestCases []*validationJUnitCase `xml:"testcase"`
+}
+
+type validationJUnitCase struct {
+	Name      string                  `xml:"name,attr"`
+	ClassName string                  `xml:"classname,attr"`
+	Failure   *validationJUnitMessage `xml:"failure,omitempty"`
+	Error     *validationJUnitMessage `xml:"error,omitempty"`
+}
+
+type validationJUnitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// RenderJUnit writes the results as JUnit XML, with a test case per file
+func RenderJUnit(w io.Writer, results []ValidationResult) error {
+	suite := &validationJUnitSuite{
+		Name:      validationJUnitName,
+		TestCases: make([]*validationJUnitCase, 0, len(results)),
+	}
+
+	for _, r := range results {
+		tc := &validationJUnitCase{
+			Name:      r.File,
+			ClassName: r.Kind,
+		}
+
+		switch {
+		case r.Error != "":
+			suite.Errors++
+			tc.Error = &validationJUnitMessage{Message: r.Error}
+		case !r.Valid:
+			suite.Failures++
+			lines := make([]string, 0, len(r.Errors))
+			for _, e := range r.Errors {
+				lines = append(lines, validationErrorLocation(e)+": "+e.HumanMessage())
+			}
+			tc.Failure = &validationJUnitMessage{
+				Message: fmt.Sprintf("%d validation error(s)", len(r.Errors)),
+				Body:    strings.Join(lines, "\n"),
+			}
+		}
+
+		suite.Tests++
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(validationJUnitSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Suites:   []*validationJUnitSuite{suite},
+	}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type validationSARIFLog struct {
+	Schema  string                `json:"$schema"`
+	Version string                `json:"version"`
+	Runs    []*validationSARIFRun `json:"runs"`
+}
+
+type validationSARIFRun struct {
+	Tool    validationSARIFTool      `json:"tool"`
+	Results []*validationSARIFResult `json:"results"`
+}
+
+type validationSARIFTool struct {
+	Driver validationSARIFDriver `json:"driver"`
+}
+
+type validationSARIFDriver struct {
+	Name           string                 `json:"name"`
+	Version        string                 `json:"version,omitempty"`
+	InformationURI string                 `json:"informationUri"`
+	Rules          []*validationSARIFRule `json:"rules"`
+}
+
+type validationSARIFRule struct {
+	ID               string                 `json:"id"`
+	ShortDescription validationSARIFMessage `json:"shortDescription"`
+}
+
+type validationSARIFMessage struct {
+	Text string `json:"text"`
+}
+
+type validationSARIFResult struct {
+	RuleID    string                     `json:"ruleId"`
+	Level     string                     `json:"level"`
+	Message   validationSARIFMessage     `json:"message"`
+	Locations []*validationSARIFLocation `json:"locations"`
+}
+
+type validationSARIFLocation struct {
+	PhysicalLocation validationSARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+type validationSARIFPhysicalLocation struct {
+	ArtifactLocation validationSARIFArtifactLocation `json:"artifactLocation"`
+	Region           *validationSARIFRegion          `json:"region,omitempty"`
+}
+
+type validationSARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type validationSARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// RenderSARIF writes the results as a SARIF log for code scanning tools. Each
+// validation rule that failed, such as "required", is a SARIF rule.
+func RenderSARIF(w io.Writer, results []ValidationResult, version string) error {
+	run := &validationSARIFRun{
+		Tool: validationSARIFTool{
+			Driver: validationSARIFDriver{
+				Name:           validationSARIFName,
+				Version:        version,
+				InformationURI: "https://zigflow.dev",
+				Rules:          make([]*validationSARIFRule, 0),
+			},
+		},
+		Results: make([]*validationSARIFResult, 0),
+	}
+
+	rules := map[string]bool{}
+	addRule := func(id, description string) {
+		if !rules[id] {
+			rules[id] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &validationSARIFRule{
+				ID:               id,
+				ShortDescription: validationSARIFMessage{Text: description},
+			})
+		}
+	}
+
+	for _, r := range results {
+		uri := filepath.ToSlash(r.File)
+
+		if r.Error != "" {
+			addRule(validationLoadRule, "The file can be loaded")
+			run.Results = append(run.Results, &validationSARIFResult{
+				RuleID:  validationLoadRule,
+				Level:   "error",
+				Message: validationSARIFMessage{Text: r.Error},
+				Locations: []*validationSARIFLocation{
+					{PhysicalLocation: validationSARIFPhysicalLocation{ArtifactLocation: validationSARIFArtifactLocation{URI: uri}}},
+				},
+			})
+			continue
+		}
+
+		for _, e := range r.Errors {
+			addRule(e.Key, fmt.Sprintf("Values pass the %q validation rule", e.Key))
+
+			location := &validationSARIFLocation{
+				PhysicalLocation: validationSARIFPhysicalLocation{
+					ArtifactLocation: validationSARIFArtifactLocation{URI: uri},
+				},
+			}
+			if e.Line > 0 {
+				location.PhysicalLocation.Region = &validationSARIFRegion{
+					StartLine:   e.Line,
+					StartColumn: e.Column,
+				}
+			}
+
+			run.Results = append(run.Results, &validationSARIFResult{
+				RuleID:    e.Key,
+				Level:     "error",
+				Message:   validationSARIFMessage{Text: validationErrorLocation(e) + ": " + e.HumanMessage()},
+				Locations: []*validationSARIFLocation{location},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&validationSARIFLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []*validationSARIFRun{run},
+	})
+}
+
+// validationErrorLocation is the pointer to the error, or the struct path if
+// it has no position
+func validationErrorLocation(e ValidationErrors) string {
+	if e.Pointer != "" {
+		return e.Pointer
+	}
+	return e.Path
+}
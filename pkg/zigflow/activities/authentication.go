@@ -0,0 +1,703 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package activities
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // #nosec G501 -- MD5 is the default digest authentication algorithm
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/models"
+	"go.temporal.io/sdk/temporal"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+	// Refresh tokens this long before they expire
+	tokenExpiryDelta = 30 * time.Second
+
+	// Forget the tokens of policies that haven't been used for this long, such
+	// as those made from a secret that has since been rotated
+	tokenCacheTTL = time.Hour
+)
+
+var (
+	// OAuth2 grants that can be used without user interaction
+	supportedOAuth2Grants = []model.OAuth2AuthenticationDataGrant{
+		model.ClientCredentialsGrant,
+		model.PasswordGrant,
+	}
+
+	supportedOAuth2ClientAuthentications = []model.OAuthClientAuthenticationType{
+		"",
+		model.OAuthClientAuthClientSecretBasic,
+		model.OAuthClientAuthClientSecretPost,
+		model.OAuthClientAuthNone,
+	}
+)
+
+type (
+	authenticationsKey struct{}
+	tokenCacheKey      struct{}
+)
+
+// WithAuthentications returns a context that carries the use.authentications
+// policies of the worker's workflows. Activities get it from the worker's
+// background activity context and look up the policies that calls reference
+// by name, so the credentials never enter the workflow history. The context
+// also carries the worker's cache of OAuth2 and OIDC tokens.
+func WithAuthentications(ctx context.Context, policies map[string]*model.AuthenticationPolicy) context.Context {
+	ctx = context.WithValue(ctx, tokenCacheKey{}, &tokenCache{sources: map[string]*tokenSource{}})
+	return context.WithValue(ctx, authenticationsKey{}, policies)
+}
+
+type oauth2Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+
+	expiry time.Time
+}
+
+func (t *oauth2Token) valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.expiry.IsZero() || time.Now().Before(t.expiry.Add(-tokenExpiryDelta))
+}
+
+// tokenCache holds a worker's tokens, keyed by the resolved policy
+type tokenCache struct {
+	mu      sync.Mutex
+	sources map[string]*tokenSource
+}
+
+// tokenSource gets the tokens of a policy. Only one call fetches a token at a
+// time and the others wait to use it.
+type tokenSource struct {
+	// lock is held while getting a token. It is a channel so that waiting can
+	// be cancelled by the activity's context.
+	lock          chan struct{}
+	lastUsed      time.Time
+	token         *oauth2Token
+	tokenEndpoint string
+}
+
+func (s *tokenSource) acquire(ctx context.Context) error {
+	select {
+	case s.lock <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *tokenSource) release() {
+	<-s.lock
+}
+
+// resolveAuthentication returns the call's authentication policy with any
+// secrets and runtime expressions resolved
+func resolveAuthentication(
+	ctx context.Context, ref *model.ReferenceableAuthenticationPolicy, state *utils.State,
+) (*models.Authentication, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	policy := ref.AuthenticationPolicy
+	if ref.Use != nil {
+		policies, _ := ctx.Value(authenticationsKey{}).(map[string]*model.AuthenticationPolicy)
+		if policy = policies[*ref.Use]; policy == nil {
+			return nil, fmt.Errorf("authentication '%s' is not defined in use.authentications", *ref.Use)
+		}
+	}
+	if policy == nil {
+		return nil, nil
+	}
+
+	policy, err := secretPolicy(policy, state)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := NewAuthentication(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateAuthentication(auth, state)
+}
+
+// secretPolicy returns the policy with its properties read from the secret it
+// uses, if any. The secret is a JSON or YAML object of the policy's properties,
+// or just the token for a bearer policy.
+func secretPolicy(policy *model.AuthenticationPolicy, state *utils.State) (*model.AuthenticationPolicy, error) {
+	name := PolicySecret(policy)
+	if name == "" {
+		return policy, nil
+	}
+
+	value, err := state.Secret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.YAMLToJSON([]byte(value))
+	if err != nil || !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		if policy.Bearer != nil {
+			return &model.AuthenticationPolicy{Bearer: &model.BearerAuthenticationPolicy{Token: value}}, nil
+		}
+		return nil, fmt.Errorf("secret %q must be an object of the authentication policy's properties", name)
+	}
+
+	resolved := &model.AuthenticationPolicy{}
+	switch {
+	case policy.Basic != nil:
+		resolved.Basic = &model.BasicAuthenticationPolicy{}
+		err = json.Unmarshal(data, resolved.Basic)
+	case policy.Bearer != nil:
+		resolved.Bearer = &model.BearerAuthenticationPolicy{}
+		err = json.Unmarshal(data, resolved.Bearer)
+	case policy.Digest != nil:
+		resolved.Digest = &model.DigestAuthenticationPolicy{}
+		err = json.Unmarshal(data, resolved.Digest)
+	case policy.OAuth2 != nil:
+		resolved.OAuth2 = &model.OAuth2AuthenticationPolicy{}
+		err = json.Unmarshal(data, resolved.OAuth2)
+	case policy.OIDC != nil:
+		resolved.OIDC = &model.OpenIdConnectAuthenticationPolicy{Properties: &model.OAuth2AuthenticationProperties{}}
+		err = json.Unmarshal(data, resolved.OIDC.Properties)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("secret %q is not a valid authentication policy: %w", name, err)
+	}
+
+	return resolved, nil
+}
+
+// PolicySecret returns the name of the secret the policy is read from, or an
+// empty string if the policy is written in the workflow
+func PolicySecret(policy *model.AuthenticationPolicy) string {
+	switch {
+	case policy == nil:
+		return ""
+	case policy.Basic != nil:
+		return policy.Basic.Use
+	case policy.Bearer != nil:
+		return policy.Bearer.Use
+	case policy.Digest != nil:
+		return policy.Digest.Use
+	case policy.OAuth2 != nil:
+		return policy.OAuth2.Use
+	case policy.OIDC != nil:
+		return policy.OIDC.Use
+	}
+	return ""
+}
+
+// NewAuthentication converts the policy into the credentials used to make a
+// call. Policies read from a secret must be resolved first.
+func NewAuthentication(policy *model.AuthenticationPolicy) (*models.Authentication, error) {
+	switch {
+	case policy.Basic != nil:
+		return &models.Authentication{
+			Basic: &models.BasicAuthentication{
+				Username: policy.Basic.Username,
+				Password: policy.Basic.Password,
+			},
+		}, nil
+	case policy.Bearer != nil:
+		return &models.Authentication{
+			Bearer: &models.BearerAuthentication{
+				Token: policy.Bearer.Token,
+			},
+		}, nil
+	case policy.Digest != nil:
+		return &models.Authentication{
+			Digest: &models.BasicAuthentication{
+				Username: policy.Digest.Username,
+				Password: policy.Digest.Password,
+			},
+		}, nil
+	case policy.OAuth2 != nil:
+		auth, err := newOAuth2Authentication(policy.OAuth2.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("invalid oauth2 authentication: %w", err)
+		}
+
+		auth.TokenEndpoint = model.OAuth2DefaultTokenURI
+		if e := policy.OAuth2.Endpoints; e != nil && e.Token != "" {
+			auth.TokenEndpoint = e.Token
+		}
+
+		return &models.Authentication{OAuth2: auth}, nil
+	case policy.OIDC != nil:
+		if policy.OIDC.Properties == nil {
+			// The SDK drops the properties of inline OIDC policies
+			return nil, fmt.Errorf("oidc authentication must be declared in use.authentications")
+		}
+		auth, err := newOAuth2Authentication(policy.OIDC.Properties)
+		if err != nil {
+			return nil, fmt.Errorf("invalid oidc authentication: %w", err)
+		}
+
+		return &models.Authentication{OIDC: auth}, nil
+	default:
+		return nil, fmt.Errorf("unknown authentication policy")
+	}
+}
+
+func newOAuth2Authentication(props *model.OAuth2AuthenticationProperties) (*models.OAuth2Authentication, error) {
+	if props == nil || props.Authority == nil || props.Authority.String() == "" {
+		return nil, fmt.Errorf("authority is required")
+	}
+
+	if !slices.Contains(supportedOAuth2Grants, props.Grant) {
+		return nil, fmt.Errorf("unsupported grant '%s'", props.Grant)
+	}
+
+	auth := &models.OAuth2Authentication{
+		Authority: props.Authority.String(),
+		Grant:     string(props.Grant),
+		Scopes:    props.Scopes,
+		Audiences: props.Audiences,
+		Username:  props.Username,
+		Password:  props.Password,
+	}
+
+	if c := props.Client; c != nil {
+		if !slices.Contains(supportedOAuth2ClientAuthentications, c.Authentication) {
+			return nil, fmt.Errorf("unsupported client authentication '%s'", c.Authentication)
+		}
+		auth.Client = &models.OAuth2Client{
+			ID:             c.ID,
+			Secret:         c.Secret,
+			Authentication: string(c.Authentication),
+		}
+	}
+
+	if r := props.Request; r != nil {
+		auth.Encoding = string(r.Encoding)
+	}
+
+	return auth, nil
+}
+
+// evaluateAuthentication interpolates the policy through the state, so
+// credentials can be read from $secrets or the environment when the call is
+// made
+func evaluateAuthentication(auth *models.Authentication, state *utils.State) (*models.Authentication, error) {
+	var data map[string]any
+	if err := utils.ToType(auth, &data); err != nil {
+		return nil, err
+	}
+
+	obj, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(data), nil, state)
+	if err != nil {
+		return nil, fmt.Errorf("error interpolating authentication: %w", err)
+	}
+
+	var result models.Authentication
+	if err := utils.ToType(obj, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// authorization returns the Authorization header for the policy. Digest
+// authentication needs a challenge from the server, so is handled by
+// digestAuthorization instead.
+func authorization(ctx context.Context, client *http.Client, auth *models.Authentication) (string, error) {
+	switch {
+	case auth == nil:
+		return "", nil
+	case auth.Basic != nil:
+		creds := auth.Basic.Username + ":" + auth.Basic.Password
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds)), nil
+	case auth.Bearer != nil:
+		return "Bearer " + auth.Bearer.Token, nil
+	case auth.OAuth2 != nil, auth.OIDC != nil:
+		token, err := getTokenSource(ctx, auth).get(ctx, client, auth)
+		if err != nil {
+			return "", err
+		}
+		return "Bearer " + token, nil
+	}
+
+	return "", nil
+}
+
+// invalidateToken removes a cached token that the server rejected
+func invalidateToken(ctx context.Context, auth *models.Authentication) error {
+	if auth == nil || (auth.OAuth2 == nil && auth.OIDC == nil) {
+		return nil
+	}
+
+	s := getTokenSource(ctx, auth)
+	if err := s.acquire(ctx); err != nil {
+		return err
+	}
+	s.token = nil
+	s.release()
+
+	return nil
+}
+
+// getTokenSource returns the worker's token source for the policy. Without a
+// worker's cache, such as in an activity run on its own, tokens aren't reused.
+func getTokenSource(ctx context.Context, auth *models.Authentication) *tokenSource {
+	cache, _ := ctx.Value(tokenCacheKey{}).(*tokenCache)
+	if cache == nil {
+		return &tokenSource{lock: make(chan struct{}, 1)}
+	}
+
+	key, _ := json.Marshal(auth)
+	sum := sha256.Sum256(key)
+	id := hex.EncodeToString(sum[:])
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := time.Now()
+	for k, s := range cache.sources {
+		if now.Sub(s.lastUsed) > tokenCacheTTL {
+			delete(cache.sources, k)
+		}
+	}
+
+	s, ok := cache.sources[id]
+	if !ok {
+		s = &tokenSource{lock: make(chan struct{}, 1)}
+		cache.sources[id] = s
+	}
+	s.lastUsed = now
+
+	return s
+}
+
+func (s *tokenSource) get(ctx context.Context, client *http.Client, auth *models.Authentication) (string, error) {
+	if err := s.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer s.release()
+
+	if s.token.valid() {
+		return s.token.AccessToken, nil
+	}
+
+	policy := auth.OAuth2
+	if policy == nil {
+		policy = auth.OIDC
+	}
+
+	if s.tokenEndpoint == "" {
+		endpoint, err := tokenEndpoint(ctx, client, auth)
+		if err != nil {
+			return "", err
+		}
+		s.tokenEndpoint = endpoint
+	}
+
+	// Try refreshing the token before going through the grant again
+	if s.token != nil && s.token.RefreshToken != "" {
+		token, err := requestToken(ctx, client, s.tokenEndpoint, policy, url.Values{
+			"grant_type":    {string(model.RefreshTokenGrant)},
+			"refresh_token": {s.token.RefreshToken},
+		})
+		if err == nil {
+			s.token = token
+			return token.AccessToken, nil
+		}
+	}
+
+	params := url.Values{
+		"grant_type": {policy.Grant},
+	}
+	if policy.Grant == string(model.PasswordGrant) {
+		params.Set("username", policy.Username)
+		params.Set("password", policy.Password)
+	}
+	if len(policy.Scopes) > 0 {
+		params.Set("scope", strings.Join(policy.Scopes, " "))
+	}
+	if len(policy.Audiences) > 0 {
+		params.Set("audience", strings.Join(policy.Audiences, " "))
+	}
+
+	token, err := requestToken(ctx, client, s.tokenEndpoint, policy, params)
+	if err != nil {
+		s.token = nil
+		return "", err
+	}
+	s.token = token
+
+	return token.AccessToken, nil
+}
+
+// tokenEndpoint returns the OAuth2 token endpoint. The OIDC token endpoint is
+// discovered from the authority.
+func tokenEndpoint(ctx context.Context, client *http.Client, auth *models.Authentication) (string, error) {
+	if auth.OAuth2 != nil {
+		return joinURL(auth.OAuth2.Authority, auth.OAuth2.TokenEndpoint), nil
+	}
+
+	discovery := joinURL(auth.OIDC.Authority, oidcDiscoveryPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+
+	// #nosec G704 -- URL is operator-defined in workflow YAML; SSRF is a deployment concern, not a code defect
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error discovering oidc configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error discovering oidc configuration: %s", resp.Status)
+	}
+
+	var config struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return "", fmt.Errorf("error decoding oidc configuration: %w", err)
+	}
+	if config.TokenEndpoint == "" {
+		return "", fmt.Errorf("oidc configuration has no token endpoint: %s", discovery)
+	}
+
+	return config.TokenEndpoint, nil
+}
+
+func requestToken(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	policy *models.OAuth2Authentication,
+	params url.Values,
+) (*oauth2Token, error) {
+	var clientID, clientSecret, clientAuth string
+	if c := policy.Client; c != nil {
+		clientID, clientSecret, clientAuth = c.ID, c.Secret, c.Authentication
+	}
+
+	if clientID != "" && clientAuth != string(model.OAuthClientAuthClientSecretBasic) {
+		params.Set("client_id", clientID)
+		if clientSecret != "" && clientAuth != string(model.OAuthClientAuthNone) {
+			params.Set("client_secret", clientSecret)
+		}
+	}
+
+	contentType := string(model.EncodingTypeFormUrlEncoded)
+	body := []byte(params.Encode())
+	if policy.Encoding == string(model.EncodingTypeApplicationJson) {
+		data := map[string]string{}
+		for k := range params {
+			data[k] = params.Get(k)
+		}
+
+		var err error
+		if body, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+		contentType = policy.Encoding
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	if clientAuth == string(model.OAuthClientAuthClientSecretBasic) {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	// #nosec G704 -- URL is operator-defined in workflow YAML; SSRF is a deployment concern, not a code defect
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading token response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		// The credentials are wrong - retrying won't help
+		return nil, temporal.NewNonRetryableApplicationError(
+			"token request returned 4xx status code",
+			"Authentication error",
+			errors.New(resp.Status),
+			string(content),
+		)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("token request returned %s", resp.Status)
+	}
+
+	var token oauth2Token
+	if err := json.Unmarshal(content, &token); err != nil {
+		return nil, fmt.Errorf("error decoding token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access token")
+	}
+	if token.ExpiresIn > 0 {
+		token.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	return &token, nil
+}
+
+// digestAuthorization answers a digest challenge from the WWW-Authenticate
+// header, as described in RFC 7616
+func digestAuthorization(auth *models.BasicAuthentication, method, uri, challenge string) (string, error) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	if !strings.EqualFold(scheme, "digest") {
+		return "", fmt.Errorf("unsupported authentication challenge: %s", scheme)
+	}
+
+	params := parseChallenge(rest)
+
+	var newHash func() hash.Hash
+	algorithm := params["algorithm"]
+	switch strings.ToUpper(algorithm) {
+	case "", "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm: %s", algorithm)
+	}
+
+	h := func(s string) string {
+		d := newHash()
+		_, _ = d.Write([]byte(s))
+		return hex.EncodeToString(d.Sum(nil))
+	}
+
+	realm, nonce := params["realm"], params["nonce"]
+	ha1 := h(auth.Username + ":" + realm + ":" + auth.Password)
+	ha2 := h(method + ":" + uri)
+
+	fields := []string{
+		fmt.Sprintf("username=%q", auth.Username),
+		fmt.Sprintf("realm=%q", realm),
+		fmt.Sprintf("nonce=%q", nonce),
+		fmt.Sprintf("uri=%q", uri),
+	}
+	if algorithm != "" {
+		fields = append(fields, "algorithm="+algorithm)
+	}
+
+	qop := ""
+	for q := range strings.SplitSeq(params["qop"], ",") {
+		if strings.TrimSpace(q) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	if qop == "" {
+		fields = append(fields, fmt.Sprintf("response=%q", h(ha1+":"+nonce+":"+ha2)))
+	} else {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		cnonce := hex.EncodeToString(b)
+		nc := "00000001"
+
+		fields = append(fields,
+			fmt.Sprintf("response=%q", h(strings.Join([]string{ha1, nonce, nc, cnonce, qop, ha2}, ":"))),
+			"qop="+qop,
+			"nc="+nc,
+			fmt.Sprintf("cnonce=%q", cnonce),
+		)
+	}
+	if opaque, ok := params["opaque"]; ok {
+		fields = append(fields, fmt.Sprintf("opaque=%q", opaque))
+	}
+
+	return "Digest " + strings.Join(fields, ", "), nil
+}
+
+// parseChallenge reads the comma-separated key=value pairs of a challenge,
+// allowing for commas in quoted values
+func parseChallenge(s string) map[string]string {
+	params := map[string]string{}
+
+	for s != "" {
+		key, rest, ok := strings.Cut(s, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(strings.TrimLeft(key, ", ")))
+		rest = strings.TrimSpace(rest)
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			value = rest[1 : end+1]
+			rest = rest[min(end+2, len(rest)):]
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+			value = strings.TrimSpace(value)
+		}
+
+		params[key] = value
+		s = strings.TrimLeft(rest, ", ")
+	}
+
+	return params
+}
+
+func joinURL(base, path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
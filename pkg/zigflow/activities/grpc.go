@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -48,6 +49,8 @@ func (c *CallGRPC) CallGRPCActivity(
 	stopHeartbeat := metadata.StartActivityHeartbeat(ctx, task.GetBase())
 	defer stopHeartbeat()
 
+	state = state.Clone().AddActivityInfo(ctx)
+
 	ob, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(map[string]any{
 		"service": task.With.Service,
 		"args":    task.With.Arguments,
@@ -67,6 +70,26 @@ func (c *CallGRPC) CallGRPCActivity(
 
 	address := fmt.Sprintf("%s:%d", service.Host, service.Port)
 
+	ref := task.With.Authentication
+	if ref == nil {
+		ref = task.With.Service.Authentication
+	}
+
+	auth, err := resolveAuthentication(ctx, ref, state)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError("error resolving grpc authentication", "CallGRPC error", err)
+	}
+
+	var headers []string
+	header, err := authorization(ctx, &http.Client{Timeout: activity.GetInfo(ctx).StartToCloseTimeout}, auth)
+	if err != nil {
+		logger.Error("Error authenticating gRPC call", "error", err)
+		return nil, err
+	}
+	if header != "" {
+		headers = append(headers, "authorization: "+header)
+	}
+
 	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		logger.Error("Error creating gRPC connection", "error", err)
@@ -116,7 +139,7 @@ func (c *CallGRPC) CallGRPCActivity(
 		descriptorSource,
 		conn,
 		methodFullName,
-		[]string{},
+		headers,
 		eventHandler,
 		rf.Next,
 	); err != nil {
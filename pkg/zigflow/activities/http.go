@@ -40,6 +40,10 @@ func init() {
 	Registry = append(Registry, &CallHTTP{})
 }
 
+// CallHTTPErrorType is the type of the error returned for a 3xx, 4xx or 5xx
+// response. Its cause is the response status, such as "404 Not Found".
+const CallHTTPErrorType = "CallHTTP error"
+
 // @link: https://github.com/serverlessworkflow/specification/blob/main/dsl-reference.md#http-response
 type HTTPResponse struct {
 	Request    HTTPRequest       `json:"request"`
@@ -64,13 +68,36 @@ func (c *CallHTTP) CallHTTPActivity(ctx context.Context, task *model.CallHTTP, i
 	stopHeartbeat := metadata.StartActivityHeartbeat(ctx, task.GetBase())
 	defer stopHeartbeat()
 
-	state = state.AddActivityInfo(ctx)
+	state = state.Clone().AddActivityInfo(ctx)
+
+	args, err := ParseHTTPArguments(task, state)
+	if err != nil {
+		return nil, err
+	}
+
+	var ref *model.ReferenceableAuthenticationPolicy
+	if e := task.With.Endpoint; e != nil && e.EndpointConfig != nil {
+		ref = e.EndpointConfig.Authentication
+	}
+
+	return c.call(ctx, args, ref, state)
+}
+
+// call makes the HTTP request and returns the output in the format the task
+// asked for. The arguments must already be interpolated.
+func (c *CallHTTP) call(
+	ctx context.Context,
+	args *model.HTTPArguments,
+	ref *model.ReferenceableAuthenticationPolicy,
+	state *utils.State,
+) (any, error) {
+	logger := activity.GetLogger(ctx)
 
 	info := activity.GetInfo(ctx)
 
-	resp, method, url, reqHeaders, err := c.callHTTPAction(ctx, task, info.StartToCloseTimeout, state)
+	resp, method, url, reqHeaders, err := c.callHTTPAction(ctx, args, ref, info.StartToCloseTimeout, state)
 	if err != nil {
-		logger.Error("Error making HTTP call", "method", method, "url", url, "error", err)
+		logger.Error("Error making HTTP call", "method", method, "url", state.RedactSecrets(url), "error", err)
 		return nil, err
 	}
 	defer func() {
@@ -82,7 +109,7 @@ func (c *CallHTTP) CallHTTPActivity(ctx context.Context, task *model.CallHTTP, i
 
 	bodyRes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		logger.Error("Error reading HTTP body", "method", method, "url", url, "error", err)
+		logger.Error("Error reading HTTP body", "method", method, "url", state.RedactSecrets(url), "error", err)
 		return nil, err
 	}
 
@@ -102,7 +129,7 @@ func (c *CallHTTP) CallHTTPActivity(ctx context.Context, task *model.CallHTTP, i
 		logger.Error("CallHTTP returned 3xx status", "statusCode", resp.StatusCode, "responseBody", content)
 		return nil, temporal.NewNonRetryableApplicationError(
 			"CallHTTP returned 3xx status code",
-			"CallHTTP error",
+			CallHTTPErrorType,
 			errors.New(resp.Status),
 			content,
 		)
@@ -113,7 +140,7 @@ func (c *CallHTTP) CallHTTPActivity(ctx context.Context, task *model.CallHTTP, i
 		logger.Error("CallHTTP returned 4xx error", "statusCode", resp.StatusCode, "responseBody", content)
 		return nil, temporal.NewNonRetryableApplicationError(
 			"CallHTTP returned 4xx status code",
-			"CallHTTP error",
+			CallHTTPErrorType,
 			errors.New(resp.Status),
 			content,
 		)
@@ -124,7 +151,7 @@ func (c *CallHTTP) CallHTTPActivity(ctx context.Context, task *model.CallHTTP, i
 		logger.Error("CallHTTP returned 5xx error", "statusCode", resp.StatusCode, "responseBody", content)
 		return nil, temporal.NewApplicationError(
 			"CallHTTP returned 5xx error",
-			"CallHTTP error",
+			CallHTTPErrorType,
 			errors.New(resp.Status),
 			map[string]any{
 				"statusCode": resp.StatusCode,
@@ -141,18 +168,24 @@ func (c *CallHTTP) CallHTTPActivity(ctx context.Context, task *model.CallHTTP, i
 	httpResponse := HTTPResponse{
 		Request: HTTPRequest{
 			Method:  method,
-			URI:     url,
-			Headers: reqHeaders,
+			URI:     state.RedactSecrets(url),
+			Headers: redactHeaders(reqHeaders, state),
 		},
 		StatusCode: resp.StatusCode,
 		Headers:    respHeader,
 		Content:    content,
 	}
 
-	return ParseOutput(task.With.Output, httpResponse, bodyRes), err
+	return ParseOutput(args.Output, httpResponse, bodyRes), err
 }
 
-func (c *CallHTTP) callHTTPAction(ctx context.Context, task *model.CallHTTP, timeout time.Duration, state *utils.State) (
+func (c *CallHTTP) callHTTPAction(
+	ctx context.Context,
+	args *model.HTTPArguments,
+	ref *model.ReferenceableAuthenticationPolicy,
+	timeout time.Duration,
+	state *utils.State,
+) (
 	resp *http.Response,
 	method, url string,
 	reqHeaders map[string]string,
@@ -160,38 +193,54 @@ func (c *CallHTTP) callHTTPAction(ctx context.Context, task *model.CallHTTP, tim
 ) {
 	logger := activity.GetLogger(ctx)
 
-	args, err := ParseHTTPArguments(task, state)
+	// Errors such as a failed request include the URL, which may contain a
+	// secret
+	defer func() {
+		err = redactError(err, state)
+	}()
+
+	auth, err := resolveAuthentication(ctx, ref, state)
 	if err != nil {
-		return resp,
-			method, url,
-			reqHeaders,
-			err
+		return resp, method, url, reqHeaders, err
 	}
 
 	method = strings.ToUpper(args.Method)
 	url = args.Endpoint.String()
 	body := args.Body
 
-	logger.Debug("Making HTTP call", "method", method, "url", url)
-	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
-	if err != nil {
-		logger.Error("Error making HTTP request", "method", method, "url", url, "error", err)
-		return resp, method, url, reqHeaders, err
-	}
-
 	// Add in headers
 	reqHeaders = map[string]string{}
 	for k, v := range args.Headers {
-		req.Header.Add(k, v)
 		reqHeaders[k] = v
 	}
 
-	// Add in query strings
-	q := req.URL.Query()
-	for k, v := range args.Query {
-		q.Add(k, v.(string))
+	// Requests may be sent again to answer an authentication challenge
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range args.Headers {
+			req.Header.Add(k, v)
+		}
+
+		// Add in query strings
+		q := req.URL.Query()
+		for k, v := range args.Query {
+			q.Add(k, v.(string))
+		}
+		req.URL.RawQuery = q.Encode()
+
+		return req, nil
+	}
+
+	logger.Debug("Making HTTP call", "method", method, "url", state.RedactSecrets(url))
+	req, err := newRequest()
+	if err != nil {
+		logger.Error("Error making HTTP request", "method", method, "url", state.RedactSecrets(url), "error", err)
+		return resp, method, url, reqHeaders, err
 	}
-	req.URL.RawQuery = q.Encode()
 
 	client := &http.Client{
 		Timeout: timeout,
@@ -203,12 +252,55 @@ func (c *CallHTTP) callHTTPAction(ctx context.Context, task *model.CallHTTP, tim
 		}
 	}
 
+	header, err := authorization(ctx, client, auth)
+	if err != nil {
+		logger.Error("Error authenticating HTTP request", "method", method, "url", state.RedactSecrets(url), "error", err)
+		return resp, method, url, reqHeaders, err
+	}
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+
 	// #nosec G704 -- URL is operator-defined in workflow YAML; SSRF is a deployment concern, not a code defect
 	resp, err = client.Do(req)
 	if err != nil {
 		return resp, method, url, reqHeaders, err
 	}
 
+	if resp.StatusCode != http.StatusUnauthorized || auth == nil || auth.Basic != nil || auth.Bearer != nil {
+		return resp, method, url, reqHeaders, err
+	}
+
+	// Answer the digest challenge or get a new token, and try once more
+	if auth.Digest != nil {
+		header, err = digestAuthorization(auth.Digest, method, req.URL.RequestURI(), resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			// Leave the response to be treated as a client error
+			logger.Warn("Unable to answer authentication challenge", "method", method, "url", state.RedactSecrets(url), "error", err)
+			return resp, method, url, reqHeaders, nil
+		}
+	} else {
+		if err = invalidateToken(ctx, auth); err == nil {
+			header, err = authorization(ctx, client, auth)
+		}
+		if err != nil {
+			logger.Error("Error authenticating HTTP request", "method", method, "url", state.RedactSecrets(url), "error", err)
+			_ = resp.Body.Close()
+			return nil, method, url, reqHeaders, err
+		}
+	}
+	_ = resp.Body.Close()
+
+	if req, err = newRequest(); err != nil {
+		return resp, method, url, reqHeaders, err
+	}
+	req.Header.Set("Authorization", header)
+
+	logger.Debug("Retrying HTTP call with authentication", "method", method, "url", state.RedactSecrets(url))
+
+	// #nosec G704 -- URL is operator-defined in workflow YAML; SSRF is a deployment concern, not a code defect
+	resp, err = client.Do(req)
+
 	return resp, method, url, reqHeaders, err
 }
 
@@ -216,38 +308,72 @@ func (c *CallHTTP) callHTTPAction(ctx context.Context, task *model.CallHTTP, tim
 // function, but this wasn't able to decode some of the more complex data types. This is
 // more heavyweight than I'd like, but it's fine for now.
 func ParseHTTPArguments(task *model.CallHTTP, state *utils.State) (*model.HTTPArguments, error) {
+	var result model.HTTPArguments
+	if err := interpolateArguments(task.With, &result, state); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// interpolateArguments evaluates the expressions in a task's arguments and
+// decodes them into result
+func interpolateArguments(with, result any, state *utils.State) error {
 	// First, we need to convert it to map[string]any
-	b, err := json.Marshal(task.With)
+	b, err := json.Marshal(with)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling object to bytes: %w", err)
+		return fmt.Errorf("error marshalling object to bytes: %w", err)
 	}
 
 	// Next, convert it to a map so we can traverse
 	var data map[string]any
 	if err := json.Unmarshal(b, &data); err != nil {
-		return nil, fmt.Errorf("error unmarshalling data to map: %w", err)
+		return fmt.Errorf("error unmarshalling data to map: %w", err)
 	}
 
 	// Clone and traverse, interpolating the data
 	cloneData := swUtil.DeepClone(data)
 	obj, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(cloneData), nil, state)
 	if err != nil {
-		return nil, fmt.Errorf("error traversing http data object: %w", err)
+		return fmt.Errorf("error traversing data object: %w", err)
 	}
 
 	// Now, put it back to a JSON string
 	e, err := json.Marshal(obj)
 	if err != nil {
-		return nil, fmt.Errorf("error marshalling object to bytes: %w", err)
+		return fmt.Errorf("error marshalling object to bytes: %w", err)
 	}
 
-	// Finally, convert back to HTTPArguments
-	var result model.HTTPArguments
-	if err := json.Unmarshal(e, &result); err != nil {
-		return nil, fmt.Errorf("error unmarshalling data to map: %w", err)
+	// Finally, convert back to the arguments
+	if err := json.Unmarshal(e, result); err != nil {
+		return fmt.Errorf("error unmarshalling data to map: %w", err)
 	}
 
-	return &result, nil
+	return nil
+}
+
+// redactHeaders hides any secrets in the request headers returned to the workflow
+func redactHeaders(headers map[string]string, state *utils.State) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for k, v := range headers {
+		redacted[k] = state.RedactSecrets(v)
+	}
+	return redacted
+}
+
+// redactError hides any secrets in the error message. The error is replaced
+// rather than wrapped, as the whole chain is recorded in the history.
+func redactError(err error, state *utils.State) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := state.RedactSecrets(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+
+	return errors.New(msg)
 }
 
 func ParseOutput(outputType string, httpResp HTTPResponse, raw []byte) any {
@@ -0,0 +1,390 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package activities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"sigs.k8s.io/yaml"
+)
+
+func init() {
+	Registry = append(Registry, &CallOpenAPI{})
+}
+
+// CallOpenAPIErrorType is the type of the error returned when the OpenAPI
+// document cannot be loaded or the operation cannot be called with the
+// parameters given. Errors from the call itself are the same as CallHTTP's.
+const CallOpenAPIErrorType = "CallOpenAPI error"
+
+// openAPIBodyParameter is the parameter that holds the request body
+const openAPIBodyParameter = "body"
+
+var openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+type openAPIDocument struct {
+	Swagger    string                      `json:"swagger"`
+	Host       string                      `json:"host"`
+	BasePath   string                      `json:"basePath"`
+	Schemes    []string                    `json:"schemes"`
+	Servers    []openAPIServer             `json:"servers"`
+	Paths      map[string]map[string]any   `json:"paths"`
+	Parameters map[string]openAPIParameter `json:"parameters"`
+	Components struct {
+		Parameters map[string]openAPIParameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type openAPIServer struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+type openAPIOperation struct {
+	OperationID string             `json:"operationId"`
+	Parameters  []openAPIParameter `json:"parameters"`
+	Servers     []openAPIServer    `json:"servers"`
+	RequestBody *struct {
+		Required bool `json:"required"`
+	} `json:"requestBody"`
+}
+
+type openAPIParameter struct {
+	Ref      string `json:"$ref"`
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+type CallOpenAPI struct{}
+
+func (c *CallOpenAPI) CallOpenAPIActivity(ctx context.Context, task *model.CallOpenAPI, input any, state *utils.State) (any, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Debug("Running call OpenAPI activity")
+
+	stopHeartbeat := metadata.StartActivityHeartbeat(ctx, task.GetBase())
+	defer stopHeartbeat()
+
+	state = state.Clone().AddActivityInfo(ctx)
+
+	var args model.OpenAPIArguments
+	if err := interpolateArguments(task.With, &args, state); err != nil {
+		return nil, temporal.NewNonRetryableApplicationError("error interpolating openapi arguments", CallOpenAPIErrorType, err)
+	}
+	if args.Document == nil || args.Document.Endpoint == nil {
+		return nil, temporal.NewNonRetryableApplicationError("openapi document is not set", CallOpenAPIErrorType, nil)
+	}
+
+	var docRef *model.ReferenceableAuthenticationPolicy
+	if e := task.With.Document.Endpoint; e != nil && e.EndpointConfig != nil {
+		docRef = e.EndpointConfig.Authentication
+	}
+
+	docURL := args.Document.Endpoint.String()
+	doc, err := c.loadDocument(ctx, args.Document.Endpoint, docRef, state)
+	if err != nil {
+		logger.Error("Error loading OpenAPI document", "url", state.RedactSecrets(docURL), "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("error loading openapi document", CallOpenAPIErrorType, err)
+	}
+
+	httpArgs, err := doc.httpArguments(docURL, args.OperationID, args.Parameters)
+	if err != nil {
+		logger.Error("Error building OpenAPI request", "operationId", args.OperationID, "error", err)
+		return nil, temporal.NewNonRetryableApplicationError("error building openapi request", CallOpenAPIErrorType, err)
+	}
+	httpArgs.Output = args.Output
+	httpArgs.Redirect = args.Redirect
+
+	return (&CallHTTP{}).call(ctx, httpArgs, task.With.Authentication, state)
+}
+
+// loadDocument reads the OpenAPI document from a file or downloads it,
+// authenticating with the document endpoint's policy
+func (c *CallOpenAPI) loadDocument(
+	ctx context.Context,
+	endpoint *model.Endpoint,
+	ref *model.ReferenceableAuthenticationPolicy,
+	state *utils.State,
+) (*openAPIDocument, error) {
+	u, err := url.Parse(endpoint.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch u.Scheme {
+	case "http", "https":
+		args := &model.HTTPArguments{Method: http.MethodGet, Endpoint: endpoint, Redirect: true}
+		resp, _, _, _, err := (&CallHTTP{}).callHTTPAction(ctx, args, ref, activity.GetInfo(ctx).StartToCloseTimeout, state)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if data, err = io.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	case "", "file":
+		if data, err = os.ReadFile(u.Path); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	}
+
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc openAPIDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// operation finds the operation with the given ID, returning its method, path
+// and the path-level parameters and servers
+func (d *openAPIDocument) operation(operationID string) (
+	method, path string,
+	op *openAPIOperation,
+	item *openAPIOperation,
+	err error,
+) {
+	for path, pathItem := range d.Paths {
+		for _, method := range openAPIMethods {
+			v, ok := pathItem[method]
+			if !ok {
+				continue
+			}
+
+			var op openAPIOperation
+			if err := utils.ToType(v, &op); err != nil {
+				return "", "", nil, nil, fmt.Errorf("invalid operation %s %s: %w", method, path, err)
+			}
+			if op.OperationID != operationID {
+				continue
+			}
+
+			var item openAPIOperation
+			if err := utils.ToType(pathItem, &item); err != nil {
+				return "", "", nil, nil, fmt.Errorf("invalid path %s: %w", path, err)
+			}
+
+			return strings.ToUpper(method), path, &op, &item, nil
+		}
+	}
+
+	return "", "", nil, nil, fmt.Errorf("operation '%s' not found", operationID)
+}
+
+// httpArguments converts a call to the operation into the HTTP call to make
+func (d *openAPIDocument) httpArguments(docURL, operationID string, params map[string]any) (*model.HTTPArguments, error) {
+	method, path, op, item, err := d.operation(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Operation parameters override the path's parameters of the same name
+	var opParams []openAPIParameter
+	for _, p := range append(item.Parameters, op.Parameters...) {
+		if p, err = d.resolveParameter(p); err != nil {
+			return nil, err
+		}
+		opParams = slices.DeleteFunc(opParams, func(o openAPIParameter) bool {
+			return o.Name == p.Name && o.In == p.In
+		})
+		opParams = append(opParams, p)
+	}
+
+	args := &model.HTTPArguments{
+		Method:  method,
+		Headers: map[string]string{},
+		Query:   map[string]any{},
+	}
+
+	var cookies []string
+	used := map[string]bool{}
+	for _, p := range opParams {
+		v, ok := params[p.Name]
+		if !ok {
+			if p.Required || p.In == "path" {
+				return nil, fmt.Errorf("required %s parameter '%s' is not set", p.In, p.Name)
+			}
+			continue
+		}
+		used[p.Name] = true
+
+		switch p.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", url.PathEscape(openAPIParameterValue(v)))
+		case "query":
+			args.Query[p.Name] = openAPIParameterValue(v)
+		case "header":
+			args.Headers[p.Name] = openAPIParameterValue(v)
+		case "cookie":
+			cookies = append(cookies, p.Name+"="+url.QueryEscape(openAPIParameterValue(v)))
+		case "body":
+			// Swagger 2 declares the request body as a parameter
+			if args.Body, err = json.Marshal(v); err != nil {
+				return nil, fmt.Errorf("error encoding body parameter '%s': %w", p.Name, err)
+			}
+			args.Headers["Content-Type"] = "application/json"
+		default:
+			return nil, fmt.Errorf("unsupported %s parameter '%s'", p.In, p.Name)
+		}
+	}
+	if len(cookies) > 0 {
+		args.Headers["Cookie"] = strings.Join(cookies, "; ")
+	}
+
+	if op.RequestBody != nil {
+		body, ok := params[openAPIBodyParameter]
+		if !ok && op.RequestBody.Required {
+			return nil, fmt.Errorf("required parameter '%s' is not set", openAPIBodyParameter)
+		}
+		if ok && !used[openAPIBodyParameter] {
+			used[openAPIBodyParameter] = true
+			if args.Body, err = json.Marshal(body); err != nil {
+				return nil, fmt.Errorf("error encoding body: %w", err)
+			}
+			args.Headers["Content-Type"] = "application/json"
+		}
+	}
+
+	for name := range params {
+		if !used[name] {
+			return nil, fmt.Errorf("'%s' is not a parameter of operation '%s'", name, operationID)
+		}
+	}
+
+	server, err := d.serverURL(docURL, op, item)
+	if err != nil {
+		return nil, err
+	}
+	args.Endpoint = model.NewEndpoint(strings.TrimSuffix(server, "/") + path)
+
+	return args, nil
+}
+
+// resolveParameter follows a reference to a parameter defined in the
+// document's components
+func (d *openAPIDocument) resolveParameter(p openAPIParameter) (openAPIParameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+
+	var found openAPIParameter
+	var ok bool
+	if name, isComponent := strings.CutPrefix(p.Ref, "#/components/parameters/"); isComponent {
+		found, ok = d.Components.Parameters[name]
+	} else if name, isDefinition := strings.CutPrefix(p.Ref, "#/parameters/"); isDefinition {
+		found, ok = d.Parameters[name]
+	}
+	if !ok {
+		return p, fmt.Errorf("unsupported parameter reference '%s'", p.Ref)
+	}
+
+	return found, nil
+}
+
+// serverURL returns the base URL of the operation. Relative URLs are resolved
+// against the document's URL.
+func (d *openAPIDocument) serverURL(docURL string, op, item *openAPIOperation) (string, error) {
+	server := "/"
+	if d.Swagger != "" {
+		if d.BasePath != "" {
+			server = d.BasePath
+		}
+		if d.Host != "" {
+			scheme := "https"
+			if len(d.Schemes) > 0 {
+				scheme = d.Schemes[0]
+			}
+			server = scheme + "://" + d.Host + d.BasePath
+		}
+	} else {
+		for _, servers := range [][]openAPIServer{op.Servers, item.Servers, d.Servers} {
+			if len(servers) == 0 {
+				continue
+			}
+			server = servers[0].URL
+			for name, v := range servers[0].Variables {
+				server = strings.ReplaceAll(server, "{"+name+"}", v.Default)
+			}
+			break
+		}
+	}
+
+	base, err := url.Parse(docURL)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server url '%s': %w", server, err)
+	}
+	u = base.ResolveReference(u)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.New("openapi document has no http server url")
+	}
+
+	return u.String(), nil
+}
+
+// openAPIParameterValue formats a parameter as a string. Arrays are comma
+// separated and objects are sent as JSON.
+func openAPIParameterValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case []any:
+		s := make([]string, 0, len(val))
+		for _, i := range val {
+			s = append(s, openAPIParameterValue(i))
+		}
+		return strings.Join(s, ",")
+	case map[string]any:
+		b, _ := json.Marshal(val)
+		return string(b)
+	default:
+		return fmt.Sprint(val)
+	}
+}
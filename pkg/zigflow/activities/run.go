@@ -197,10 +197,16 @@ func (r *Run) runExecCommand(
 
 	state = state.Clone().AddActivityInfo(ctx)
 
+	// Envvars are converted so they can be traversed
+	envMap := make(map[string]any, len(env))
+	for k, v := range env {
+		envMap[k] = v
+	}
+
 	logger.Debug("Interpolating command arguments and envvars")
 	d, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(map[string]any{
 		"args": swUtil.DeepCloneValue(args.AsSlice()),
-		"env":  swUtil.DeepCloneValue(env),
+		"env":  envMap,
 	}), nil, state)
 	if err != nil {
 		return nil, fmt.Errorf("error traversing task parameters: %w", err)
@@ -213,7 +219,7 @@ func (r *Run) runExecCommand(
 	}
 
 	envvars := os.Environ()
-	for k, v := range data["env"].(map[string]string) {
+	for k, v := range data["env"].(map[string]any) {
 		envvars = append(envvars, fmt.Sprintf("%s=%v", k, v))
 	}
 
@@ -223,6 +229,7 @@ func (r *Run) runExecCommand(
 		Logger: logger,
 		Level:  "info",
 		Msg:    "Run task response",
+		Redact: state.RedactSecrets,
 	}
 
 	//nolint:gosec // Allow dynamic commands
@@ -235,24 +242,29 @@ func (r *Run) runExecCommand(
 		cmd.Dir = dir
 	}
 
-	logger.Info("Running command on worker", "command", command)
+	redacted := make([]string, len(command))
+	for i, c := range command {
+		redacted[i] = state.RedactSecrets(c)
+	}
+
+	logger.Info("Running command on worker", "command", redacted)
 	if err := cmd.Run(); err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			// The command received an exit code above 0 - return as-is
 			logger.Error("Shell error",
 				"error", err,
-				"command", command,
-				"stderr", r.stdToString(stdout),
-				"stdout", r.stdToString(stdout),
+				"command", redacted,
+				"stderr", r.stdToString(stderr, state),
+				"stdout", r.stdToString(stdout, state),
 			)
 			return nil, temporal.NewApplicationErrorWithCause(
 				"Error calling command",
 				"command",
 				exitErr,
 				map[string]any{
-					"command": command,
-					"stderr":  r.stdToString(stderr),
-					"stdout":  r.stdToString(stdout),
+					"command": redacted,
+					"stderr":  r.stdToString(stderr, state),
+					"stdout":  r.stdToString(stdout, state),
 				},
 			)
 		}
@@ -260,9 +272,11 @@ func (r *Run) runExecCommand(
 		return nil, fmt.Errorf("error running command: %w", err)
 	}
 
-	return r.stdToString(stdout), nil
+	return r.stdToString(stdout, state), nil
 }
 
-func (r *Run) stdToString(std bytes.Buffer) string {
-	return strings.TrimSpace(std.String())
+// stdToString returns the command's output with any secrets it echoed
+// redacted, as it's logged and stored in the workflow history
+func (r *Run) stdToString(std bytes.Buffer, state *utils.State) string {
+	return state.RedactSecrets(strings.TrimSpace(std.String()))
 }
@@ -0,0 +1,401 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"sigs.k8s.io/yaml"
+)
+
+// maxDefinitionsIDLength is the longest build ID Temporal accepts
+const maxDefinitionsIDLength = 255
+
+// WorkflowFileExtensions are the files loaded when a directory is given
+var WorkflowFileExtensions = []string{".yaml", ".yml", ".json"}
+
+// Definition is a workflow document and the file it was loaded from
+type Definition struct {
+	File     string
+	Workflow *model.Workflow
+	// Where everything in the workflow is declared
+	Source *utils.Source
+}
+
+// TaskQueue is the queue the definition's workflows are registered on
+func (d *Definition) TaskQueue() string {
+	return d.Workflow.Document.Namespace
+}
+
+// key identifies the definition independently of the file it is in
+func (d *Definition) key() string {
+	return d.Workflow.Document.Namespace + "/" + d.Workflow.Document.Name
+}
+
+// ResolveFiles expands a list of files, directories and glob patterns into
+// the workflow files to load. Directories are searched recursively, skipping
+// hidden directories such as .git. The order is preserved and duplicates
+// removed.
+//
+// Files found in a directory or glob are skipped if they are test suites or
+// have no top-level document key, such as a CloudEvents config. Any file in
+// exclude is skipped, so files set as config flags are not loaded as
+// workflows.
+func ResolveFiles(paths []string, exclude ...string) ([]string, error) {
+	excluded := make([]string, 0, len(exclude))
+	for _, f := range exclude {
+		if f != "" {
+			excluded = append(excluded, absPath(f))
+		}
+	}
+
+	files := make([]string, 0)
+	add := func(f string) {
+		f = filepath.Clean(f)
+		if !slices.Contains(files, f) && !slices.Contains(excluded, absPath(f)) {
+			files = append(files, f)
+		}
+	}
+
+	for _, p := range paths {
+		if strings.ContainsAny(p, "*?[") {
+			matches, err := filepath.Glob(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob pattern %q: %w", p, err)
+			}
+
+			found := false
+			for _, m := range matches {
+				if isWorkflowFile(m) {
+					found = true
+					add(m)
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no workflow files match %q", p)
+			}
+			continue
+		}
+
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("error loading file: %w", err)
+		}
+		if !info.IsDir() {
+			add(p)
+			continue
+		}
+
+		found := false
+		err = filepath.WalkDir(p, func(f string, e fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if e.IsDir() {
+				if f != p && strings.HasPrefix(e.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !slices.Contains(WorkflowFileExtensions, filepath.Ext(e.Name())) || !isWorkflowFile(f) {
+				return nil
+			}
+			found = true
+			add(f)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading directory: %w", err)
+		}
+		if !found {
+			return nil, fmt.Errorf("no workflow files found in directory %q", p)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no workflow files set")
+	}
+
+	return files, nil
+}
+
+// isWorkflowFile reports whether a file found in a directory or glob is a
+// workflow document
+func isWorkflowFile(f string) bool {
+	name := filepath.Base(f)
+	for _, ext := range WorkflowFileExtensions {
+		if strings.HasSuffix(name, ".test"+ext) {
+			return false
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Clean(f))
+	if err != nil {
+		// Reported when the file is loaded
+		return true
+	}
+
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		// Invalid files are reported when they are loaded
+		return true
+	}
+	m, ok := doc.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = m["document"]
+
+	return ok
+}
+
+func absPath(f string) string {
+	if abs, err := filepath.Abs(f); err == nil {
+		return abs
+	}
+	return filepath.Clean(f)
+}
+
+// LoadDefinitions resolves the paths and loads every workflow file
+func LoadDefinitions(paths []string) ([]*Definition, error) {
+	files, err := ResolveFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make([]*Definition, 0, len(files))
+	for _, f := range files {
+		doc, err := LoadDocumentFromFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+
+		defs = append(defs, &Definition{
+			File:     f,
+			Workflow: doc.Workflow,
+			Source:   doc.Source,
+		})
+	}
+
+	return defs, nil
+}
+
+// GroupByTaskQueue returns the task queues in the order they are first seen
+// and the definitions registered on each
+func GroupByTaskQueue(defs []*Definition) ([]string, map[string][]*Definition) {
+	queues := make([]string, 0)
+	groups := map[string][]*Definition{}
+
+	for _, d := range defs {
+		q := d.TaskQueue()
+		if _, ok := groups[q]; !ok {
+			queues = append(queues, q)
+		}
+		groups[q] = append(groups[q], d)
+	}
+
+	return queues, groups
+}
+
+// CheckConflicts ensures that no two definitions register the same workflow
+// type on the same task queue. This includes the child workflows generated
+// for for, fork and try tasks.
+func CheckConflicts(defs []*Definition) error {
+	childPrefix := utils.GenerateChildWorkflowName("")
+
+	// Keyed by task queue then workflow type
+	registered := map[string]map[string]*Definition{}
+	// Keyed by task queue then authentication name
+	authentications := map[string]map[string]*Definition{}
+
+	for _, d := range defs {
+		q := d.TaskQueue()
+		if registered[q] == nil {
+			registered[q] = map[string]*Definition{}
+			authentications[q] = map[string]*Definition{}
+		}
+
+		// Activities look up policies by name from every workflow on the worker
+		if d.Workflow.Use != nil {
+			for _, name := range slices.Sorted(maps.Keys(d.Workflow.Use.Authentications)) {
+				existing, ok := authentications[q][name]
+				if !ok {
+					authentications[q][name] = d
+					continue
+				}
+				if !reflect.DeepEqual(existing.Workflow.Use.Authentications[name], d.Workflow.Use.Authentications[name]) {
+					return fmt.Errorf(
+						"%w: authentication %q on task queue %q is defined differently in %s and %s",
+						ErrWorkflowConflict, name, q, existing.File, d.File,
+					)
+				}
+			}
+		}
+
+		for _, name := range WorkflowTypes(d.Workflow, true) {
+			existing, ok := registered[q][name]
+			if !ok {
+				registered[q][name] = d
+				continue
+			}
+
+			kind := "workflow"
+			if strings.HasPrefix(name, childPrefix) {
+				kind = "generated child workflow"
+			}
+
+			return fmt.Errorf(
+				"%w: %s %q on task queue %q is defined in both %s and %s",
+				ErrWorkflowConflict, kind, name, q, existing.File, d.File,
+			)
+		}
+	}
+
+	return nil
+}
+
+// WithAuthentications returns a context that carries the use.authentications
+// policies of the definitions registered on a worker, for use as the worker's
+// background activity context. CheckConflicts ensures that policies with the
+// same name are the same.
+func WithAuthentications(ctx context.Context, defs []*Definition) context.Context {
+	policies := map[string]*model.AuthenticationPolicy{}
+	for _, d := range defs {
+		if d.Workflow.Use != nil {
+			maps.Copy(policies, d.Workflow.Use.Authentications)
+		}
+	}
+	return activities.WithAuthentications(ctx, policies)
+}
+
+// DefinitionsID identifies the set of definitions registered on a worker. It
+// is made from the name and version of each document and is used as the
+// worker's build ID, so every task in the history can be traced back to the
+// definition that processed it.
+func DefinitionsID(defs []*Definition) string {
+	ids := make([]string, 0, len(defs))
+	for _, d := range defs {
+		ids = append(ids, d.Workflow.Document.Name+"@"+d.Workflow.Document.Version)
+	}
+	slices.Sort(ids)
+
+	id := strings.Join(ids, ",")
+	if len(id) > maxDefinitionsIDLength {
+		sum := sha256.Sum256([]byte(id))
+		id = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return id
+}
+
+// CheckVersionBump ensures that every definition that has changed since the
+// previous load has a higher document.version, so that changes are deliberate
+// and create a new worker build ID.
+func CheckVersionBump(previous, next []*Definition) error {
+	old := map[string]*Definition{}
+	for _, d := range previous {
+		old[d.key()] = d
+	}
+
+	for _, d := range next {
+		p, ok := old[d.key()]
+		if !ok {
+			continue
+		}
+
+		changed, err := definitionChanged(p, d)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		if !versionIncreased(p.Workflow.Document.Version, d.Workflow.Document.Version) {
+			return fmt.Errorf(
+				"%w: %s changed without a document.version bump (was %s, now %s)",
+				ErrVersionNotBumped, d.File, p.Workflow.Document.Version, d.Workflow.Document.Version,
+			)
+		}
+	}
+
+	return nil
+}
+
+// DefinitionsChanged returns true if the definitions differ in any way
+func DefinitionsChanged(previous, next []*Definition) (bool, error) {
+	if len(previous) != len(next) {
+		return true, nil
+	}
+
+	for i := range previous {
+		if previous[i].File != next[i].File {
+			return true, nil
+		}
+
+		changed, err := definitionChanged(previous[i], next[i])
+		if err != nil || changed {
+			return changed, err
+		}
+	}
+
+	return false, nil
+}
+
+func definitionChanged(a, b *Definition) (bool, error) {
+	x, err := json.Marshal(a.Workflow)
+	if err != nil {
+		return false, fmt.Errorf("error encoding workflow: %w", err)
+	}
+
+	y, err := json.Marshal(b.Workflow)
+	if err != nil {
+		return false, fmt.Errorf("error encoding workflow: %w", err)
+	}
+
+	return !bytes.Equal(x, y), nil
+}
+
+func versionIncreased(previous, next string) bool {
+	p, err := semver.NewVersion(previous)
+	if err != nil {
+		return previous != next
+	}
+
+	n, err := semver.NewVersion(next)
+	if err != nil {
+		return previous != next
+	}
+
+	return n.GreaterThan(p)
+}
@@ -0,0 +1,342 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+func definitionYAML(namespace, name, loop string) string {
+	return fmt.Sprintf(`document:
+  dsl: 1.0.0
+  namespace: %s
+  name: %s
+  version: 0.0.1
+do:
+  - %s:
+      for:
+        in: ${ [1, 2] }
+      do:
+        - step:
+            set:
+              hello: world
+`, namespace, name, loop)
+}
+
+func writeDefinitions(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "definitions_test")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, os.RemoveAll(tmpDir))
+	})
+
+	for name, content := range files {
+		p := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	}
+
+	return tmpDir
+}
+
+func TestResolveFiles(t *testing.T) {
+	doc := "document: {}"
+	dir := writeDefinitions(t, map[string]string{
+		"a.yaml":            doc,
+		"a.test.yaml":       doc,
+		"b.yml":             doc,
+		"c.json":            `{"document": {}}`,
+		"cloudevents.yaml":  "clients: []",
+		"notes.txt":         doc,
+		"sub/d.yaml":        doc,
+		"sub/deeper/e.yml":  doc,
+		".hidden/f.yaml":    doc,
+		"empty/readme":      doc,
+		"config/keys.yaml":  "[]",
+		"config/other.yaml": "",
+	})
+
+	tests := []struct {
+		Name        string
+		Paths       []string
+		Exclude     []string
+		Expected    []string
+		ExpectError bool
+	}{
+		{
+			Name:     "single file",
+			Paths:    []string{filepath.Join(dir, "a.yaml")},
+			Expected: []string{"a.yaml"},
+		},
+		{
+			Name:     "directory is searched recursively",
+			Paths:    []string{dir},
+			Expected: []string{"a.yaml", "b.yml", "c.json", "sub/d.yaml", "sub/deeper/e.yml"},
+		},
+		{
+			Name:     "nested directory",
+			Paths:    []string{filepath.Join(dir, "sub", "deeper")},
+			Expected: []string{"sub/deeper/e.yml"},
+		},
+		{
+			Name:     "glob",
+			Paths:    []string{filepath.Join(dir, "*.y*ml")},
+			Expected: []string{"a.yaml", "b.yml"},
+		},
+		{
+			Name:     "test suites are loaded if set explicitly",
+			Paths:    []string{filepath.Join(dir, "a.test.yaml")},
+			Expected: []string{"a.test.yaml"},
+		},
+		{
+			Name:     "excluded files",
+			Paths:    []string{dir},
+			Exclude:  []string{filepath.Join(dir, "b.yml"), ""},
+			Expected: []string{"a.yaml", "c.json", "sub/d.yaml", "sub/deeper/e.yml"},
+		},
+		{
+			Name:        "glob without workflow files",
+			Paths:       []string{filepath.Join(dir, "config", "*.yaml")},
+			ExpectError: true,
+		},
+		{
+			Name:     "duplicates are removed",
+			Paths:    []string{filepath.Join(dir, "b.yml"), dir, filepath.Join(dir, "sub")},
+			Expected: []string{"b.yml", "a.yaml", "c.json", "sub/d.yaml", "sub/deeper/e.yml"},
+		},
+		{
+			Name:        "missing file",
+			Paths:       []string{filepath.Join(dir, "missing.yaml")},
+			ExpectError: true,
+		},
+		{
+			Name:        "glob without matches",
+			Paths:       []string{filepath.Join(dir, "*.toml")},
+			ExpectError: true,
+		},
+		{
+			Name:        "directory without workflow files",
+			Paths:       []string{filepath.Join(dir, "empty")},
+			ExpectError: true,
+		},
+		{
+			Name:        "no paths",
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			files, err := zigflow.ResolveFiles(test.Paths, test.Exclude...)
+			if test.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			expected := make([]string, 0, len(test.Expected))
+			for _, f := range test.Expected {
+				expected = append(expected, filepath.Join(dir, f))
+			}
+			assert.Equal(t, expected, files)
+		})
+	}
+}
+
+func TestCheckConflicts(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Files       map[string]string
+		ExpectError string
+	}{
+		{
+			Name: "no conflicts",
+			Files: map[string]string{
+				"a.yaml": definitionYAML("queue", "wf1", "loop1"),
+				"b.yaml": definitionYAML("queue", "wf2", "loop2"),
+			},
+		},
+		{
+			Name: "same workflow on different task queues",
+			Files: map[string]string{
+				"a.yaml": definitionYAML("queue1", "wf", "loop"),
+				"b.yaml": definitionYAML("queue2", "wf", "loop"),
+			},
+		},
+		{
+			Name: "same workflow on the same task queue",
+			Files: map[string]string{
+				"a.yaml": definitionYAML("queue", "wf", "loop1"),
+				"b.yaml": definitionYAML("queue", "wf", "loop2"),
+			},
+			ExpectError: `workflow "wf" on task queue "queue"`,
+		},
+		{
+			Name: "same generated child workflow on the same task queue",
+			Files: map[string]string{
+				"a.yaml": definitionYAML("queue", "wf1", "loop"),
+				"b.yaml": definitionYAML("queue", "wf2", "loop"),
+			},
+			ExpectError: `generated child workflow "workflow_for_loop"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			dir := writeDefinitions(t, test.Files)
+
+			defs, err := zigflow.LoadDefinitions([]string{dir})
+			require.NoError(t, err)
+
+			err = zigflow.CheckConflicts(defs)
+			if test.ExpectError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, zigflow.ErrWorkflowConflict)
+			assert.Contains(t, err.Error(), test.ExpectError)
+		})
+	}
+}
+
+func TestGroupByTaskQueue(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{
+		"a.yaml": definitionYAML("queue2", "wf1", "loop"),
+		"b.yaml": definitionYAML("queue1", "wf2", "loop"),
+		"c.yaml": definitionYAML("queue2", "wf3", "loop"),
+	})
+
+	defs, err := zigflow.LoadDefinitions([]string{dir})
+	require.NoError(t, err)
+
+	queues, groups := zigflow.GroupByTaskQueue(defs)
+	assert.Equal(t, []string{"queue2", "queue1"}, queues)
+	assert.Equal(t, []*zigflow.Definition{defs[0], defs[2]}, groups["queue2"])
+	assert.Equal(t, []*zigflow.Definition{defs[1]}, groups["queue1"])
+}
+
+func versionedYAML(version, value string) string {
+	return fmt.Sprintf(`document:
+  dsl: 1.0.0
+  namespace: queue
+  name: wf
+  version: %s
+do:
+  - step:
+      set:
+        hello: %s
+`, version, value)
+}
+
+func TestCheckVersionBump(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Previous    string
+		Next        string
+		ExpectError bool
+	}{
+		{
+			Name:     "unchanged",
+			Previous: versionedYAML("1.0.0", "world"),
+			Next:     versionedYAML("1.0.0", "world"),
+		},
+		{
+			Name:     "changed with a version bump",
+			Previous: versionedYAML("1.0.0", "world"),
+			Next:     versionedYAML("1.1.0", "ziggy"),
+		},
+		{
+			Name:        "changed without a version bump",
+			Previous:    versionedYAML("1.0.0", "world"),
+			Next:        versionedYAML("1.0.0", "ziggy"),
+			ExpectError: true,
+		},
+		{
+			Name:        "changed with a lower version",
+			Previous:    versionedYAML("1.0.0", "world"),
+			Next:        versionedYAML("0.9.0", "ziggy"),
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			previous, err := zigflow.LoadDefinitions([]string{
+				filepath.Join(writeDefinitions(t, map[string]string{"wf.yaml": test.Previous}), "wf.yaml"),
+			})
+			require.NoError(t, err)
+
+			next, err := zigflow.LoadDefinitions([]string{
+				filepath.Join(writeDefinitions(t, map[string]string{"wf.yaml": test.Next}), "wf.yaml"),
+			})
+			require.NoError(t, err)
+
+			err = zigflow.CheckVersionBump(previous, next)
+			if test.ExpectError {
+				assert.ErrorIs(t, err, zigflow.ErrVersionNotBumped)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDefinitionsChanged(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{"wf.yaml": versionedYAML("1.0.0", "world")})
+
+	previous, err := zigflow.LoadDefinitions([]string{dir})
+	require.NoError(t, err)
+
+	next, err := zigflow.LoadDefinitions([]string{dir})
+	require.NoError(t, err)
+
+	changed, err := zigflow.DefinitionsChanged(previous, next)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "wf.yaml"), []byte(versionedYAML("1.0.1", "world")), 0o600))
+
+	next, err = zigflow.LoadDefinitions([]string{dir})
+	require.NoError(t, err)
+
+	changed, err = zigflow.DefinitionsChanged(previous, next)
+	require.NoError(t, err)
+	assert.True(t, changed)
+}
+
+func TestDefinitionsID(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{
+		"a.yaml": definitionYAML("queue", "wf2", "loop1"),
+		"b.yaml": definitionYAML("queue", "wf1", "loop2"),
+	})
+
+	defs, err := zigflow.LoadDefinitions([]string{dir})
+	require.NoError(t, err)
+
+	assert.Equal(t, "wf1@0.0.1,wf2@0.0.1", zigflow.DefinitionsID(defs))
+}
@@ -0,0 +1,727 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+)
+
+// ChangeSeverity is how a change affects executions that are already running
+type ChangeSeverity string
+
+const (
+	// ChangeBreaking will fail executions that are waiting in the workflow
+	ChangeBreaking ChangeSeverity = "breaking"
+	// ChangeWarning may fail executions that are running the affected tasks
+	// when the new definition is deployed
+	ChangeWarning ChangeSeverity = "warning"
+	// ChangeSafe does not affect running executions
+	ChangeSafe ChangeSeverity = "safe"
+)
+
+// ChangeKind identifies the type of change
+type ChangeKind string
+
+const (
+	ChangeInputSchema     ChangeKind = "input-schema-changed"
+	ChangeListenerAdded   ChangeKind = "listener-added"
+	ChangeListenerChanged ChangeKind = "listener-changed"
+	ChangeListenerRemoved ChangeKind = "listener-removed"
+	ChangeTaskAdded       ChangeKind = "task-added"
+	ChangeTaskChanged     ChangeKind = "task-changed"
+	ChangeTaskQueue       ChangeKind = "task-queue-changed"
+	ChangeTaskRemoved     ChangeKind = "task-removed"
+	ChangeTaskRenamed     ChangeKind = "task-renamed"
+	ChangeTaskReordered   ChangeKind = "task-reordered"
+	ChangeWorkflowAdded   ChangeKind = "workflow-added"
+	ChangeWorkflowRemoved ChangeKind = "workflow-removed"
+)
+
+// Change is a single difference between two versions of a workflow
+type Change struct {
+	Kind     ChangeKind     `json:"kind"`
+	Severity ChangeSeverity `json:"severity"`
+	Workflow string         `json:"workflow,omitempty"`
+	Task     string         `json:"task,omitempty"`
+	Message  string         `json:"message"`
+}
+
+// DiffVersion identifies a version of a workflow in a diff
+type DiffVersion struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	TaskQueue string `json:"taskQueue"`
+}
+
+// DiffReport describes whether running executions of the previous version of
+// a workflow can safely be processed by the next version
+type DiffReport struct {
+	Previous DiffVersion `json:"previous"`
+	Next     DiffVersion `json:"next"`
+	Breaking bool        `json:"breaking"`
+	Changes  []*Change   `json:"changes"`
+}
+
+func (r *DiffReport) add(c *Change) {
+	if c.Severity == ChangeBreaking {
+		r.Breaking = true
+	}
+	r.Changes = append(r.Changes, c)
+}
+
+// diffTask is a task in the sequence of a single Temporal workflow type
+type diffTask struct {
+	Key  string
+	Kind string
+	// Child workflow types started by the task
+	Children []string
+	Item     *model.TaskItem
+}
+
+// Diff compares two versions of a workflow statically. Temporal replays the
+// history of a running execution against the new definition, so any change
+// to the commands that were issued before the point the execution is waiting
+// at breaks it. Executions usually wait at listen and wait tasks, so changes
+// up to the last of these in each workflow are breaking.
+//
+// This is a static analysis - use replay to check real histories.
+func Diff(previous, next *model.Workflow) *DiffReport {
+	report := &DiffReport{
+		Previous: diffVersion(previous),
+		Next:     diffVersion(next),
+		Changes:  []*Change{},
+	}
+
+	if report.Previous.TaskQueue != report.Next.TaskQueue {
+		// Running executions are never picked up by the new definition
+		report.add(&Change{
+			Kind:     ChangeTaskQueue,
+			Severity: ChangeSafe,
+			Message: fmt.Sprintf(
+				"task queue changed from %q to %q - running executions stay on %q and need a worker with the previous definition until they complete",
+				report.Previous.TaskQueue, report.Next.TaskQueue, report.Previous.TaskQueue,
+			),
+		})
+		return report
+	}
+
+	prevOrder, prevTasks := workflowTasks(previous)
+	nextOrder, nextTasks := workflowTasks(next)
+
+	diffWorkflowTypes(report, prevOrder, prevTasks, nextOrder, nextTasks)
+
+	prevParks := parkingWorkflows(prevTasks)
+	for _, name := range prevOrder {
+		if n, ok := nextTasks[name]; ok {
+			diffTaskSequence(report, name, prevTasks[name], n, prevParks)
+		}
+	}
+
+	diffListeners(report, previous, next)
+	diffInputSchemas(report, previous, next, prevOrder, prevTasks, nextTasks)
+
+	return report
+}
+
+func diffVersion(doc *model.Workflow) DiffVersion {
+	return DiffVersion{
+		Name:      doc.Document.Name,
+		Version:   doc.Document.Version,
+		TaskQueue: doc.Document.Namespace,
+	}
+}
+
+// workflowTasks returns the workflow types in declaration order and the tasks
+// executed directly by each
+func workflowTasks(doc *model.Workflow) ([]string, map[string][]*diffTask) {
+	order := make([]string, 0)
+	tasks := map[string][]*diffTask{}
+
+	_ = Walk(doc, func(item *model.TaskItem, workflowName string) error {
+		if item.AsDoTask() != nil {
+			return nil
+		}
+		if _, ok := tasks[workflowName]; !ok {
+			order = append(order, workflowName)
+		}
+		tasks[workflowName] = append(tasks[workflowName], &diffTask{
+			Key:      item.Key,
+			Kind:     taskKind(item),
+			Children: childWorkflows(item),
+			Item:     item,
+		})
+		return nil
+	})
+
+	return order, tasks
+}
+
+// taskKind describes what the task does in Temporal. Tasks with a different
+// kind issue different commands.
+func taskKind(item *model.TaskItem) string {
+	switch task := item.Task.(type) {
+	case *model.CallFunction:
+		kind := "call:" + task.Call
+		if name, ok := task.With["name"].(string); ok && task.Call == "activity" {
+			kind += ":" + name
+		}
+		return kind
+	case *model.CallGRPC:
+		return "call:grpc"
+	case *model.CallHTTP:
+		return "call:http"
+	case *model.CallOpenAPI:
+		return "call:openapi"
+	case *model.ForTask:
+		return "for"
+	case *model.ForkTask:
+		return "fork"
+	case *model.ListenTask:
+		return "listen"
+	case *model.RaiseTask:
+		return "raise"
+	case *model.RunTask:
+		switch {
+		case task.Run.Workflow != nil:
+			return "run:workflow:" + task.Run.Workflow.Name
+		case task.Run.Container != nil:
+			return "run:container"
+		case task.Run.Script != nil:
+			return "run:script"
+		case task.Run.Shell != nil:
+			return "run:shell"
+		}
+		return "run"
+	case *model.SetTask:
+		return "set"
+	case *model.SwitchTask:
+		return "switch"
+	case *model.TryTask:
+		return "try"
+	case *model.WaitTask:
+		return "wait"
+	}
+
+	return fmt.Sprintf("%T", item.Task)
+}
+
+// issuesCommands returns false for tasks that are evaluated entirely in the
+// workflow and leave nothing in the history
+func issuesCommands(t *diffTask) bool {
+	switch t.Kind {
+	case "raise":
+		return false
+	case "switch":
+		// Only cases that start a workflow issue commands
+		return len(t.Children) > 0
+	}
+	return true
+}
+
+// childWorkflows returns the child workflow types started by the task
+func childWorkflows(item *model.TaskItem) []string {
+	switch task := item.Task.(type) {
+	case *model.ForTask:
+		return []string{utils.GenerateChildWorkflowName("for", item.Key)}
+	case *model.ForkTask:
+		children := make([]string, 0)
+		if task.Fork.Branches != nil {
+			for _, branch := range *task.Fork.Branches {
+				children = append(children, utils.GenerateChildWorkflowName("fork", item.Key, branch.Key))
+			}
+		}
+		return children
+	case *model.RunTask:
+		if task.Run.Workflow != nil {
+			return []string{task.Run.Workflow.Name}
+		}
+	case *model.SwitchTask:
+		children := make([]string, 0)
+		for _, item := range task.Switch {
+			for _, c := range item {
+				if c.Then != nil && !c.Then.IsEnum() && !slices.Contains(children, c.Then.Value) {
+					children = append(children, c.Then.Value)
+				}
+			}
+		}
+		return children
+	case *model.TryTask:
+		children := []string{utils.GenerateChildWorkflowName("try", item.Key)}
+		if task.Catch != nil {
+			children = append(children, utils.GenerateChildWorkflowName("catch", item.Key))
+		}
+		return children
+	}
+
+	return nil
+}
+
+// parkingWorkflows returns the workflow types that executions can wait in.
+// These contain listen or wait tasks, or start a child workflow that does.
+func parkingWorkflows(tasks map[string][]*diffTask) map[string]bool {
+	parks := map[string]bool{}
+
+	for changed := true; changed; {
+		changed = false
+		for name, list := range tasks {
+			if parks[name] {
+				continue
+			}
+			for _, t := range list {
+				if isParkingTask(t, parks) {
+					parks[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return parks
+}
+
+func isParkingTask(t *diffTask, parks map[string]bool) bool {
+	if t.Kind == "listen" || t.Kind == "wait" {
+		return true
+	}
+	return slices.ContainsFunc(t.Children, func(c string) bool { return parks[c] })
+}
+
+func diffWorkflowTypes(
+	report *DiffReport,
+	prevOrder []string,
+	prevTasks map[string][]*diffTask,
+	nextOrder []string,
+	nextTasks map[string][]*diffTask,
+) {
+	childPrefix := utils.GenerateChildWorkflowName("")
+	kind := func(name string) string {
+		if strings.HasPrefix(name, childPrefix) {
+			return "generated child workflow"
+		}
+		return "workflow"
+	}
+
+	for _, name := range prevOrder {
+		if _, ok := nextTasks[name]; ok {
+			continue
+		}
+		report.add(&Change{
+			Kind:     ChangeWorkflowRemoved,
+			Severity: ChangeBreaking,
+			Workflow: name,
+			Message:  fmt.Sprintf("%s %q is no longer registered - running executions of it will fail", kind(name), name),
+		})
+	}
+
+	for _, name := range nextOrder {
+		if _, ok := prevTasks[name]; ok {
+			continue
+		}
+		report.add(&Change{
+			Kind:     ChangeWorkflowAdded,
+			Severity: ChangeSafe,
+			Workflow: name,
+			Message:  fmt.Sprintf("%s %q added", kind(name), name),
+		})
+	}
+}
+
+// diffTaskSequence compares the tasks that issue commands in a single
+// workflow type
+func diffTaskSequence(report *DiffReport, workflowName string, prevAll, nextAll []*diffTask, parks map[string]bool) {
+	prev := filterTasks(prevAll)
+	next := filterTasks(nextAll)
+
+	// Everything up to the last task an execution can wait at is in the
+	// history of a waiting execution
+	lastPark := -1
+	for i, t := range prev {
+		if isParkingTask(t, parks) {
+			lastPark = i
+		}
+	}
+	parkKey := ""
+	if lastPark >= 0 {
+		parkKey = prev[lastPark].Key
+	}
+
+	prevIndex := taskIndex(prev)
+	nextIndex := taskIndex(next)
+
+	severity := func(prevPos int) ChangeSeverity {
+		if prevPos <= lastPark {
+			return ChangeBreaking
+		}
+		return ChangeWarning
+	}
+	before := func(prevPos int) string {
+		if prevPos <= lastPark {
+			return fmt.Sprintf(" (before listen point %q)", parkKey)
+		}
+		return ""
+	}
+
+	// The common tasks that stay in the same relative order
+	common := make([]string, 0)
+	for _, t := range prev {
+		if _, ok := nextIndex[t.Key]; ok {
+			common = append(common, t.Key)
+		}
+	}
+	nextCommon := make([]string, 0)
+	for _, t := range next {
+		if _, ok := prevIndex[t.Key]; ok {
+			nextCommon = append(nextCommon, t.Key)
+		}
+	}
+	stable := longestCommonSubsequence(common, nextCommon)
+
+	// Tasks removed and added in the same place with the same kind are renamed
+	removed := make([]int, 0)
+	for i, t := range prev {
+		if _, ok := nextIndex[t.Key]; !ok {
+			removed = append(removed, i)
+		}
+	}
+	renamed := map[string]string{}
+	renamedTo := map[string]bool{}
+	for j, t := range next {
+		if _, ok := prevIndex[t.Key]; ok {
+			continue
+		}
+		for k, i := range removed {
+			if prev[i].Kind == t.Kind && stableBefore(prev, i, stable) == stableBefore(next, j, stable) {
+				renamed[prev[i].Key] = t.Key
+				renamedTo[t.Key] = true
+				removed = slices.Delete(removed, k, k+1)
+				break
+			}
+		}
+	}
+
+	for i, t := range prev {
+		if newKey, ok := renamed[t.Key]; ok {
+			c := &Change{
+				Kind:     ChangeTaskRenamed,
+				Severity: ChangeSafe,
+				Workflow: workflowName,
+				Task:     t.Key,
+				Message:  fmt.Sprintf("task %q renamed to %q", t.Key, newKey),
+			}
+			if newChildren := next[nextIndex[newKey]].Children; !slices.Equal(t.Children, newChildren) {
+				// The generated child workflow types are named after the task
+				c.Severity = severity(i)
+				c.Message = fmt.Sprintf(
+					"task %q renamed to %q%s - it starts child workflows %s which become %s",
+					t.Key, newKey, before(i), quoteList(t.Children), quoteList(newChildren),
+				)
+			}
+			report.add(c)
+			continue
+		}
+
+		j, ok := nextIndex[t.Key]
+		if !ok {
+			report.add(&Change{
+				Kind:     ChangeTaskRemoved,
+				Severity: severity(i),
+				Workflow: workflowName,
+				Task:     t.Key,
+				Message:  fmt.Sprintf("task %q removed%s", t.Key, before(i)),
+			})
+			continue
+		}
+
+		if !slices.Contains(stable, t.Key) {
+			report.add(&Change{
+				Kind:     ChangeTaskReordered,
+				Severity: severity(i),
+				Workflow: workflowName,
+				Task:     t.Key,
+				Message:  fmt.Sprintf("task %q moved%s", t.Key, before(i)),
+			})
+			continue
+		}
+
+		if next[j].Kind != t.Kind {
+			report.add(&Change{
+				Kind:     ChangeTaskChanged,
+				Severity: severity(i),
+				Workflow: workflowName,
+				Task:     t.Key,
+				Message:  fmt.Sprintf("task %q changed from %s to %s%s", t.Key, t.Kind, next[j].Kind, before(i)),
+			})
+		}
+	}
+
+	for j, t := range next {
+		if _, ok := prevIndex[t.Key]; ok || renamedTo[t.Key] {
+			continue
+		}
+
+		c := &Change{
+			Kind:     ChangeTaskAdded,
+			Severity: ChangeSafe,
+			Workflow: workflowName,
+			Task:     t.Key,
+			Message:  fmt.Sprintf("task %q added", t.Key),
+		}
+
+		// The position of the new task relative to the previous tasks
+		anchor := stableBefore(next, j, stable)
+		prevPos := len(prev)
+		if anchor < len(stable) {
+			prevPos = prevIndex[stable[anchor]]
+		}
+		if prevPos < len(prev) {
+			c.Severity = severity(prevPos)
+			c.Message = fmt.Sprintf("task %q inserted%s", t.Key, before(prevPos))
+		}
+		report.add(c)
+	}
+
+	// Tasks that leave nothing in the history can be changed freely
+	for _, t := range nextAll {
+		if issuesCommands(t) || slices.ContainsFunc(prevAll, func(p *diffTask) bool { return p.Key == t.Key }) {
+			continue
+		}
+		report.add(&Change{
+			Kind:     ChangeTaskAdded,
+			Severity: ChangeSafe,
+			Workflow: workflowName,
+			Task:     t.Key,
+			Message:  fmt.Sprintf("task %q added", t.Key),
+		})
+	}
+	for _, t := range prevAll {
+		if issuesCommands(t) || slices.ContainsFunc(nextAll, func(n *diffTask) bool { return n.Key == t.Key }) {
+			continue
+		}
+		report.add(&Change{
+			Kind:     ChangeTaskRemoved,
+			Severity: ChangeSafe,
+			Workflow: workflowName,
+			Task:     t.Key,
+			Message:  fmt.Sprintf("task %q removed", t.Key),
+		})
+	}
+}
+
+func filterTasks(list []*diffTask) []*diffTask {
+	filtered := make([]*diffTask, 0, len(list))
+	for _, t := range list {
+		if issuesCommands(t) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+func taskIndex(list []*diffTask) map[string]int {
+	index := make(map[string]int, len(list))
+	for i, t := range list {
+		index[t.Key] = i
+	}
+	return index
+}
+
+// stableBefore counts the stable tasks before the position in the list
+func stableBefore(list []*diffTask, pos int, stable []string) int {
+	count := 0
+	for _, t := range list[:pos] {
+		if slices.Contains(stable, t.Key) {
+			count++
+		}
+	}
+	return count
+}
+
+func longestCommonSubsequence(a, b []string) []string {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	result := make([]string, 0, lengths[0][0])
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, a[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return result
+}
+
+func diffListeners(report *DiffReport, previous, next *model.Workflow) {
+	key := func(l *Listener) string {
+		return l.Workflow + "/" + l.ID
+	}
+
+	nextListeners := map[string]*Listener{}
+	for _, l := range Listeners(next) {
+		nextListeners[key(l)] = l
+	}
+
+	prevListeners := map[string]*Listener{}
+	for _, l := range Listeners(previous) {
+		prevListeners[key(l)] = l
+
+		n, ok := nextListeners[key(l)]
+		if !ok {
+			report.add(&Change{
+				Kind:     ChangeListenerRemoved,
+				Severity: ChangeBreaking,
+				Workflow: l.Workflow,
+				Task:     l.Task,
+				Message: fmt.Sprintf(
+					"%s %q removed - running executions waiting for it will never receive it",
+					l.Type, l.ID,
+				),
+			})
+			continue
+		}
+
+		if n.Type != l.Type {
+			report.add(&Change{
+				Kind:     ChangeListenerChanged,
+				Severity: ChangeBreaking,
+				Workflow: l.Workflow,
+				Task:     n.Task,
+				Message:  fmt.Sprintf("%q changed from a %s to a %s", l.ID, l.Type, n.Type),
+			})
+		}
+	}
+
+	for _, l := range Listeners(next) {
+		if _, ok := prevListeners[key(l)]; ok {
+			continue
+		}
+		report.add(&Change{
+			Kind:     ChangeListenerAdded,
+			Severity: ChangeSafe,
+			Workflow: l.Workflow,
+			Task:     l.Task,
+			Message:  fmt.Sprintf("%s %q added", l.Type, l.ID),
+		})
+	}
+}
+
+// diffInputSchemas compares the workflow and task input schemas. Inputs are
+// validated again when a history is replayed, so running executions may no
+// longer be valid.
+func diffInputSchemas(
+	report *DiffReport,
+	previous, next *model.Workflow,
+	prevOrder []string,
+	prevTasks, nextTasks map[string][]*diffTask,
+) {
+	if !schemasEqual(previous.Input, next.Input) {
+		report.add(&Change{
+			Kind:     ChangeInputSchema,
+			Severity: schemaSeverity(next.Input),
+			Workflow: previous.Document.Name,
+			Message:  "workflow input schema changed - running executions are validated against the new schema",
+		})
+	}
+
+	for _, name := range prevOrder {
+		nextByKey := map[string]*diffTask{}
+		for _, t := range nextTasks[name] {
+			nextByKey[t.Key] = t
+		}
+
+		for _, t := range prevTasks[name] {
+			n, ok := nextByKey[t.Key]
+			if !ok {
+				continue
+			}
+
+			prevInput := t.Item.GetBase().Input
+			nextInput := n.Item.GetBase().Input
+			if schemasEqual(prevInput, nextInput) {
+				continue
+			}
+
+			report.add(&Change{
+				Kind:     ChangeInputSchema,
+				Severity: schemaSeverity(nextInput),
+				Workflow: name,
+				Task:     t.Key,
+				Message:  fmt.Sprintf("task %q input schema changed - running executions are validated against the new schema", t.Key),
+			})
+		}
+	}
+}
+
+// schemaSeverity returns how a changed schema affects running executions.
+// Removing the schema cannot make a valid input invalid.
+func schemaSeverity(input *model.Input) ChangeSeverity {
+	if input == nil || input.Schema == nil {
+		return ChangeSafe
+	}
+	return ChangeBreaking
+}
+
+func schemasEqual(a, b *model.Input) bool {
+	var x, y *model.Schema
+	if a != nil {
+		x = a.Schema
+	}
+	if b != nil {
+		y = b.Schema
+	}
+
+	xj, errX := json.Marshal(x)
+	yj, errY := json.Marshal(y)
+	if errX != nil || errY != nil {
+		return false
+	}
+
+	return bytes.Equal(xj, yj)
+}
+
+func quoteList(list []string) string {
+	quoted := make([]string, 0, len(list))
+	for _, s := range list {
+		quoted = append(quoted, fmt.Sprintf("%q", s))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+const diffWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: approval
+  version: 0.0.1
+input:
+  schema:
+    format: json
+    document:
+      type: object
+do:
+  - prepare:
+      set:
+        ready: true
+  - notify:
+      call: http
+      with:
+        method: post
+        endpoint: https://example.com
+  - approve:
+      listen:
+        to:
+          one:
+            with:
+              id: approve
+              type: signal
+  - loop:
+      for:
+        in: ${ [1] }
+      do:
+        - step:
+            set:
+              hello: world
+  - done:
+      call: http
+      with:
+        method: post
+        endpoint: https://example.com/done
+`
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Replace  []string
+		Breaking bool
+		Changes  map[zigflow.ChangeKind]zigflow.ChangeSeverity
+	}{
+		{
+			Name: "unchanged",
+		},
+		{
+			Name: "version bump only",
+			Replace: []string{
+				"version: 0.0.1", "version: 0.0.2",
+			},
+		},
+		{
+			Name: "tasks reordered before a listen point",
+			Replace: []string{
+				"  - prepare:\n      set:\n        ready: true\n", "",
+				"  - approve:", "  - prepare:\n      set:\n        ready: true\n  - approve:",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskReordered: zigflow.ChangeBreaking,
+			},
+		},
+		{
+			Name: "task removed before a listen point",
+			Replace: []string{
+				"  - prepare:\n      set:\n        ready: true\n", "",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskRemoved: zigflow.ChangeBreaking,
+			},
+		},
+		{
+			Name: "task removed after the last listen point",
+			Replace: []string{
+				"  - done:\n      call: http\n      with:\n        method: post\n        endpoint: https://example.com/done\n", "",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskRemoved: zigflow.ChangeWarning,
+			},
+		},
+		{
+			Name: "task appended",
+			Replace: []string{
+				"endpoint: https://example.com/done", "endpoint: https://example.com/done\n  - tidy:\n      set:\n        tidy: true",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskAdded: zigflow.ChangeSafe,
+			},
+		},
+		{
+			Name: "task inserted after the last listen point",
+			Replace: []string{
+				"  - done:", "  - tidy:\n      set:\n        tidy: true\n  - done:",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskAdded: zigflow.ChangeWarning,
+			},
+		},
+		{
+			Name: "task without commands inserted before a listen point",
+			Replace: []string{
+				"  - approve:", "  - check:\n      switch:\n        - default:\n            then: continue\n  - approve:",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskAdded: zigflow.ChangeSafe,
+			},
+		},
+		{
+			Name: "switch to a workflow inserted before a listen point",
+			Replace: []string{
+				"  - approve:", "  - check:\n      switch:\n        - default:\n            then: notify\n  - approve:",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskAdded: zigflow.ChangeBreaking,
+			},
+		},
+		{
+			Name: "activity task renamed",
+			Replace: []string{
+				"  - notify:", "  - alert:",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskRenamed: zigflow.ChangeSafe,
+			},
+		},
+		{
+			Name: "task with a generated child workflow renamed",
+			Replace: []string{
+				"  - loop:", "  - iterate:",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskRenamed:     zigflow.ChangeWarning,
+				zigflow.ChangeWorkflowRemoved: zigflow.ChangeBreaking,
+				zigflow.ChangeWorkflowAdded:   zigflow.ChangeSafe,
+			},
+		},
+		{
+			Name: "signal renamed",
+			Replace: []string{
+				"id: approve", "id: accept",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeListenerRemoved: zigflow.ChangeBreaking,
+				zigflow.ChangeListenerAdded:   zigflow.ChangeSafe,
+			},
+		},
+		{
+			Name: "signal changed to an update",
+			Replace: []string{
+				"type: signal", "type: update",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeListenerChanged: zigflow.ChangeBreaking,
+			},
+		},
+		{
+			Name: "input schema changed",
+			Replace: []string{
+				"type: object", "type: object\n      required:\n        - id",
+			},
+			Breaking: true,
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeInputSchema: zigflow.ChangeBreaking,
+			},
+		},
+		{
+			Name: "input schema removed",
+			Replace: []string{
+				"input:\n  schema:\n    format: json\n    document:\n      type: object\n", "",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeInputSchema: zigflow.ChangeSafe,
+			},
+		},
+		{
+			Name: "task queue changed",
+			Replace: []string{
+				"namespace: zigflow", "namespace: zigflow-v2",
+				"  - prepare:\n      set:\n        ready: true\n", "",
+			},
+			Changes: map[zigflow.ChangeKind]zigflow.ChangeSeverity{
+				zigflow.ChangeTaskQueue: zigflow.ChangeSafe,
+			},
+		},
+	}
+
+	dir := writeDefinitions(t, map[string]string{"previous.yaml": diffWorkflow})
+	previous, err := zigflow.LoadFromFile(filepath.Join(dir, "previous.yaml"))
+	require.NoError(t, err)
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Replacements are applied in turn so they can build on each other
+			content := diffWorkflow
+			for i := 0; i < len(test.Replace); i += 2 {
+				content = strings.Replace(content, test.Replace[i], test.Replace[i+1], 1)
+			}
+
+			dir := writeDefinitions(t, map[string]string{"next.yaml": content})
+			next, err := zigflow.LoadFromFile(filepath.Join(dir, "next.yaml"))
+			require.NoError(t, err)
+
+			report := zigflow.Diff(previous, next)
+
+			changes := map[zigflow.ChangeKind]zigflow.ChangeSeverity{}
+			for _, c := range report.Changes {
+				changes[c.Kind] = c.Severity
+			}
+			if test.Changes == nil {
+				test.Changes = map[zigflow.ChangeKind]zigflow.ChangeSeverity{}
+			}
+
+			assert.Equal(t, test.Changes, changes, report.Changes)
+			assert.Equal(t, test.Breaking, report.Breaking)
+		})
+	}
+}
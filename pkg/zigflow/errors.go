@@ -19,3 +19,7 @@ package zigflow
 import "fmt"
 
 var ErrUnsupportedDSL = fmt.Errorf("unsupported dsl version")
+
+var ErrWorkflowConflict = fmt.Errorf("conflicting workflow definitions")
+
+var ErrVersionNotBumped = fmt.Errorf("document version not bumped")
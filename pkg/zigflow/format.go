@@ -0,0 +1,412 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Encoding is the representation a workflow file is written in.
+type Encoding string
+
+const (
+	EncodingYAML Encoding = "yaml"
+	EncodingJSON Encoding = "json"
+)
+
+// Encodings lists every supported encoding.
+var Encodings = []Encoding{EncodingYAML, EncodingJSON}
+
+var (
+	// Keys in the order they're written. Keys not listed keep the order they
+	// were written in, before the workflow keys (so anchors declared in them
+	// stay first) and after the document and task keys.
+	workflowKeyOrder = []string{"document", "input", "use", "timeout", "schedule", "output", "do"}
+	documentKeyOrder = []string{"dsl", "namespace", "name", "version", "title", "summary", "tags", "metadata"}
+	taskKeyOrder     = []string{
+		"if", "metadata", "input", "output", "export", "timeout",
+		"call", "with", "emit", "for", "while", "do", "fork", "listen",
+		"raise", "run", "set", "switch", "try", "catch", "wait",
+		"then",
+	}
+
+	// expression matches a single line runtime expression
+	expression = regexp.MustCompile(`^\$\{\s*(.*?)\s*\}$`)
+)
+
+// DetectEncoding returns the encoding of a workflow file from its extension,
+// or its content if the extension isn't known.
+func DetectEncoding(file string, data []byte) Encoding {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return EncodingJSON
+	case ".yaml", ".yml":
+		return EncodingYAML
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return EncodingJSON
+	}
+	return EncodingYAML
+}
+
+// Format rewrites a workflow in canonical form. The workflow, document and
+// task keys are put in a fixed order, collections are written in block style
+// and runtime expressions are written as "${ expr }", only quoted if needed.
+// Comments are kept.
+func Format(data []byte, enc Encoding) ([]byte, error) {
+	return Convert(data, enc)
+}
+
+// Convert rewrites a workflow in canonical form in the given encoding.
+// Comments are lost when converting to JSON. The result is loaded as
+// LoadFromFile would to check it's the same workflow.
+func Convert(data []byte, to Encoding) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing workflow: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("workflow must be a single object")
+	}
+
+	root := doc.Content[0]
+	// JSON strings are all quoted, so let the encoder decide
+	canonical(&doc, DetectEncoding("", data) == EncodingJSON)
+	sortKeys(root, workflowKeyOrder, true)
+	sortKeys(mappingValue(root, "document"), documentKeyOrder, false)
+	sortTaskList(mappingValue(root, "do"))
+	anchorsFirst(root, map[*yaml.Node]*yaml.Node{}, map[*yaml.Node]bool{})
+
+	var out []byte
+	var err error
+	switch to {
+	case EncodingYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err = enc.Encode(&doc); err == nil {
+			err = enc.Close()
+		}
+		out = buf.Bytes()
+	case EncodingJSON:
+		var buf bytes.Buffer
+		if err = writeJSON(&buf, root, ""); err == nil {
+			buf.WriteString("\n")
+		}
+		out = buf.Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %q", to)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error writing workflow: %w", err)
+	}
+
+	if err := sameWorkflow(data, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// sameWorkflow checks two workflow files load as the same workflow. The
+// space inside a runtime expression's "${ }" is ignored.
+func sameWorkflow(original, formatted []byte) error {
+	load := func(data []byte) ([]byte, error) {
+		wf, err := LoadFromBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(wf)
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		return json.Marshal(normaliseExpressions(v))
+	}
+
+	want, err := load(original)
+	if err != nil {
+		return err
+	}
+	got, err := load(formatted)
+	if err != nil {
+		return fmt.Errorf("formatted workflow can't be loaded: %w", err)
+	}
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("formatted workflow is not the same as the original")
+	}
+	return nil
+}
+
+// normaliseExpressions writes every runtime expression as "${ expr }".
+func normaliseExpressions(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = normaliseExpressions(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = normaliseExpressions(item)
+		}
+	case string:
+		if m := expression.FindStringSubmatch(val); m != nil && !strings.Contains(val, "\n") {
+			return "${ " + m[1] + " }"
+		}
+	}
+	return v
+}
+
+// canonical writes collections in block style and normalises expressions.
+// Quoting is removed from every scalar if unquote is set.
+func canonical(node *yaml.Node, unquote bool) {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		node.Style = 0
+	case yaml.ScalarNode:
+		if node.Tag == "!!merge" {
+			// Written as an explicit tag otherwise
+			node.Tag = ""
+		}
+		if unquote {
+			node.Style = 0
+		}
+		if node.Tag == "!!str" && !strings.Contains(node.Value, "\n") {
+			if m := expression.FindStringSubmatch(node.Value); m != nil {
+				node.Value = "${ " + m[1] + " }"
+				// Quoted by the encoder if it needs to be
+				node.Style = 0
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		canonical(child, unquote)
+	}
+}
+
+// sortKeys orders the keys of a mapping. Keys not in the order keep their
+// relative position, either before or after those that are.
+func sortKeys(node *yaml.Node, order []string, unknownFirst bool) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+
+	type pair struct{ key, value *yaml.Node }
+	pairs := make([]pair, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, pair{node.Content[i], node.Content[i+1]})
+	}
+
+	rank := func(p pair) int {
+		if i := slices.Index(order, p.key.Value); i >= 0 {
+			return i
+		}
+		if unknownFirst {
+			return -1
+		}
+		return len(order)
+	}
+	slices.SortStableFunc(pairs, func(a, b pair) int {
+		return rank(a) - rank(b)
+	})
+
+	node.Content = node.Content[:0]
+	for _, p := range pairs {
+		node.Content = append(node.Content, p.key, p.value)
+	}
+}
+
+// sortTaskList orders the keys of each task in a list, and the tasks nested
+// in them.
+func sortTaskList(list *yaml.Node) {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 1; i < len(item.Content); i += 2 {
+			task := item.Content[i]
+			sortKeys(task, taskKeyOrder, false)
+
+			sortTaskList(mappingValue(task, "do"))
+			sortTaskList(mappingValue(task, "try"))
+			sortTaskList(mappingValue(mappingValue(task, "catch"), "do"))
+			sortTaskList(mappingValue(mappingValue(task, "fork"), "branches"))
+		}
+	}
+}
+
+// anchorsFirst moves an anchor to the first place it's used, so the
+// definition is still before its aliases once the keys have been ordered.
+func anchorsFirst(node *yaml.Node, moved map[*yaml.Node]*yaml.Node, seen map[*yaml.Node]bool) {
+	if node.Kind == yaml.AliasNode {
+		target := node.Alias
+		if m, ok := moved[target]; ok {
+			target = m
+		}
+		if seen[target] {
+			node.Alias = target
+			return
+		}
+
+		// Swap the definition and the alias over
+		*node = *target
+		*target = yaml.Node{Kind: yaml.AliasNode, Value: node.Anchor, Alias: node}
+		moved[target] = node
+	}
+
+	if node.Anchor != "" {
+		seen[node] = true
+	}
+	for _, child := range node.Content {
+		anchorsFirst(child, moved, seen)
+	}
+}
+
+// mappingValue returns the value of a key in a mapping.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mergedPairs returns the keys and values of a mapping with any merge keys
+// ("<<") replaced by the keys they merge in. Keys set in the mapping win.
+func mergedPairs(node *yaml.Node) []*yaml.Node {
+	set := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !isMerge(node.Content[i]) {
+			set[node.Content[i].Value] = true
+		}
+	}
+
+	pairs := make([]*yaml.Node, 0, len(node.Content))
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if !isMerge(key) {
+			pairs = append(pairs, key, value)
+			continue
+		}
+
+		sources := []*yaml.Node{value}
+		if resolveAlias(value).Kind == yaml.SequenceNode {
+			sources = resolveAlias(value).Content
+		}
+		for _, source := range sources {
+			merged := mergedPairs(resolveAlias(source))
+			for j := 0; j+1 < len(merged); j += 2 {
+				if !set[merged[j].Value] {
+					set[merged[j].Value] = true
+					pairs = append(pairs, merged[j], merged[j+1])
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+func isMerge(key *yaml.Node) bool {
+	return key.Kind == yaml.ScalarNode && key.Value == "<<" && (key.Tag == "!!merge" || key.Tag == "")
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// writeJSON writes a node as indented JSON, keeping the order of the keys.
+func writeJSON(buf *bytes.Buffer, node *yaml.Node, indent string) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJSON(buf, node.Alias, indent)
+	case yaml.MappingNode:
+		pairs := mergedPairs(node)
+		if len(pairs) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		buf.WriteString("{\n")
+		for i := 0; i+1 < len(pairs); i += 2 {
+			key, err := json.Marshal(pairs[i].Value)
+			if err != nil {
+				return err
+			}
+			buf.WriteString(indent + "  ")
+			buf.Write(key)
+			buf.WriteString(": ")
+			if err := writeJSON(buf, pairs[i+1], indent+"  "); err != nil {
+				return err
+			}
+			if i+2 < len(pairs) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "}")
+	case yaml.SequenceNode:
+		if len(node.Content) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteString("[\n")
+		for i, item := range node.Content {
+			buf.WriteString(indent + "  ")
+			if err := writeJSON(buf, item, indent+"  "); err != nil {
+				return err
+			}
+			if i+1 < len(node.Content) {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString(indent + "]")
+	default:
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	return nil
+}
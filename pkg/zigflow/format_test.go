@@ -0,0 +1,274 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+const formattedYAML = `# A workflow
+
+document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+do:
+  # Set some data
+  - step:
+      if: ${ $input.run }
+      export:
+        as: '${ $context + { step: . } }'
+      set:
+        hello: world # inline
+        list:
+          - 1
+          - 2
+  - check:
+      try:
+        - fail:
+            output:
+              as: '${ "quoted: " + .hello }'
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/runtime
+                status: 500
+      catch:
+        do:
+          - recover:
+              set:
+                recovered: true
+`
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Input       string
+		Expected    string
+		ExpectError bool
+	}{
+		{
+			Name:     "already formatted",
+			Input:    formattedYAML,
+			Expected: formattedYAML,
+		},
+		{
+			Name: "orders keys, expands flow style and normalises expressions",
+			Input: `# A workflow
+
+do:
+  # Set some data
+  - step:
+      set:
+        hello: world # inline
+        list: [1, 2]
+      export:
+        as: "${$context + { step: . }}"
+      if: "${ $input.run}"
+  - check:
+      catch:
+        do:
+          - recover:
+              set: {recovered: true}
+      try:
+        - fail:
+            raise:
+              error:
+                type: https://serverlessworkflow.io/spec/1.0.0/errors/runtime
+                status: 500
+            output:
+              as: '${"quoted: " + .hello}'
+document:
+  version: 0.0.1
+  name: test
+  dsl: 1.0.0
+  namespace: default
+`,
+			Expected: formattedYAML,
+		},
+		{
+			Name: "keeps anchors before aliases",
+			Input: `.anchors:
+  hello: &hello
+    set:
+      hello: world
+do:
+  - first: *hello
+document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+`,
+			Expected: `.anchors:
+  hello: &hello
+    set:
+      hello: world
+document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+do:
+  - first: *hello
+`,
+		},
+		{
+			Name:        "invalid YAML",
+			Input:       "invalid: [",
+			ExpectError: true,
+		},
+		{
+			Name:        "not an object",
+			Input:       "- hello",
+			ExpectError: true,
+		},
+		{
+			Name: "invalid workflow",
+			Input: `document:
+  dsl: 0.9.0
+  namespace: default
+  name: test
+  version: 0.0.1
+do: []
+`,
+			ExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			out, err := zigflow.Format([]byte(test.Input), zigflow.EncodingYAML)
+			if test.ExpectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, string(out))
+
+			// Formatting is idempotent
+			again, err := zigflow.Format(out, zigflow.EncodingYAML)
+			require.NoError(t, err)
+			assert.Equal(t, string(out), string(again))
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	input := `.anchors:
+  base: &base
+    hello: world
+document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+do:
+  - step:
+      set:
+        <<: *base
+        list: [1, 2]
+`
+
+	out, err := zigflow.Convert([]byte(input), zigflow.EncodingJSON)
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  ".anchors": {
+    "base": {
+      "hello": "world"
+    }
+  },
+  "document": {
+    "dsl": "1.0.0",
+    "namespace": "default",
+    "name": "test",
+    "version": "0.0.1"
+  },
+  "do": [
+    {
+      "step": {
+        "set": {
+          "hello": "world",
+          "list": [
+            1,
+            2
+          ]
+        }
+      }
+    }
+  ]
+}
+`, string(out))
+	assert.True(t, json.Valid(out))
+
+	back, err := zigflow.Convert(out, zigflow.EncodingYAML)
+	require.NoError(t, err)
+
+	original, err := zigflow.LoadFromBytes([]byte(input))
+	require.NoError(t, err)
+	converted, err := zigflow.LoadFromBytes(back)
+	require.NoError(t, err)
+	assert.Equal(t, original, converted)
+
+	_, err = zigflow.Convert([]byte(input), zigflow.Encoding("toml"))
+	assert.Error(t, err)
+}
+
+func TestConvert_Examples(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "examples", "*", "workflow.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			for _, enc := range zigflow.Encodings {
+				_, err := zigflow.Convert(data, enc)
+				assert.NoError(t, err, enc)
+			}
+		})
+	}
+}
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		File     string
+		Data     string
+		Expected zigflow.Encoding
+	}{
+		{File: "workflow.yaml", Expected: zigflow.EncodingYAML},
+		{File: "workflow.YML", Expected: zigflow.EncodingYAML},
+		{File: "workflow.json", Expected: zigflow.EncodingJSON},
+		{File: "workflow", Data: `  {"document": {}}`, Expected: zigflow.EncodingJSON},
+		{File: "workflow", Data: "document: {}", Expected: zigflow.EncodingYAML},
+	}
+
+	for _, test := range tests {
+		t.Run(test.File+test.Data, func(t *testing.T) {
+			assert.Equal(t, test.Expected, zigflow.DetectEncoding(test.File, []byte(test.Data)))
+		})
+	}
+}
@@ -0,0 +1,376 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nexus-rpc/sdk-go/nexus"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+	"github.com/zigflow/zigflow/pkg/zigflow/models"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Plan is what a workflow document compiles to in Temporal
+type Plan struct {
+	Name       string          `json:"name"`
+	Version    string          `json:"version"`
+	TaskQueue  string          `json:"taskQueue"`
+	Workflows  []*PlanWorkflow `json:"workflows"`
+	Activities []string        `json:"activities"`
+	Schedule   *PlanSchedule   `json:"schedule,omitempty"`
+}
+
+// PlanWorkflow is a registered Temporal workflow type
+type PlanWorkflow struct {
+	Name string `json:"name"`
+	// Child workflows started by the workflow's tasks
+	Children []string    `json:"children"`
+	Handlers []*Listener `json:"handlers"`
+	Tasks    []*PlanTask `json:"tasks"`
+}
+
+// PlanTask is a task and, if it runs one, the activity it schedules
+type PlanTask struct {
+	Name            string               `json:"name"`
+	Type            string               `json:"type"`
+	Activity        string               `json:"activity,omitempty"`
+	ActivityOptions *PlanActivityOptions `json:"activityOptions,omitempty"`
+	Children        []string             `json:"children,omitempty"`
+}
+
+// PlanActivityOptions are the activity options a task runs with once the
+// defaults, document and task metadata have been merged
+type PlanActivityOptions struct {
+	TaskQueue              string           `json:"taskQueue,omitempty"`
+	StartToCloseTimeout    string           `json:"startToCloseTimeout,omitempty"`
+	ScheduleToCloseTimeout string           `json:"scheduleToCloseTimeout,omitempty"`
+	ScheduleToStartTimeout string           `json:"scheduleToStartTimeout,omitempty"`
+	HeartbeatTimeout       string           `json:"heartbeatTimeout,omitempty"`
+	RetryPolicy            *PlanRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type PlanRetryPolicy struct {
+	InitialInterval        string   `json:"initialInterval,omitempty"`
+	BackoffCoefficient     float64  `json:"backoffCoefficient"`
+	MaximumInterval        string   `json:"maximumInterval,omitempty"`
+	MaximumAttempts        int32    `json:"maximumAttempts"`
+	NonRetryableErrorTypes []string `json:"nonRetryableErrorTypes,omitempty"`
+}
+
+// PlanSchedule is the Temporal schedule created for the document
+type PlanSchedule struct {
+	ID           string `json:"id"`
+	WorkflowName string `json:"workflowName"`
+	Cron         string `json:"cron,omitempty"`
+	Every        string `json:"every,omitempty"`
+	Input        []any  `json:"input"`
+}
+
+// Inspect compiles the document against a recording worker and returns the
+// workflows, handlers, activities and schedule it registers with Temporal.
+// Nothing is connected to or executed.
+func Inspect(doc *model.Workflow, envvars map[string]any) (*Plan, error) {
+	rec := newRecordingWorker()
+	if err := NewWorkflow(rec, doc, envvars, &cloudevents.Events{}, nil); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{
+		Name:       doc.Document.Name,
+		Version:    doc.Document.Version,
+		TaskQueue:  doc.Document.Namespace,
+		Workflows:  make([]*PlanWorkflow, 0, len(rec.workflows)),
+		Activities: rec.activities,
+	}
+
+	// List the workflows in the order they are declared
+	names := WorkflowTypes(doc, true)
+	for _, name := range rec.workflows {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	workflows := map[string]*PlanWorkflow{}
+	for _, name := range names {
+		w := &PlanWorkflow{
+			Name:     name,
+			Children: make([]string, 0),
+			Handlers: make([]*Listener, 0),
+			Tasks:    make([]*PlanTask, 0),
+		}
+		workflows[name] = w
+		plan.Workflows = append(plan.Workflows, w)
+	}
+
+	err := Walk(doc, func(item *model.TaskItem, workflowName string) error {
+		w, ok := workflows[workflowName]
+		if !ok || item.AsDoTask() != nil {
+			// Do tasks are listed as workflows in their own right
+			return nil
+		}
+
+		task, err := inspectTask(doc, item)
+		if err != nil {
+			return utils.NewSourceError(item.Task, err)
+		}
+		w.Tasks = append(w.Tasks, task)
+
+		for _, child := range task.Children {
+			if !slices.Contains(w.Children, child) {
+				w.Children = append(w.Children, child)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range Listeners(doc) {
+		if w, ok := workflows[l.Workflow]; ok {
+			w.Handlers = append(w.Handlers, l)
+		}
+	}
+
+	if doc.Schedule != nil {
+		schedule, err := inspectSchedule(doc, envvars)
+		if err != nil {
+			return nil, err
+		}
+		plan.Schedule = schedule
+	}
+
+	return plan, nil
+}
+
+func inspectTask(doc *model.Workflow, item *model.TaskItem) (*PlanTask, error) {
+	task := &PlanTask{
+		Name:     item.Key,
+		Type:     TaskType(item.Task),
+		Children: ChildWorkflows(item),
+	}
+
+	// Custom functions are inspected as the task they run
+	run := item.Task
+	if fn, ok := run.(*model.CallFunction); ok && fn.Call != "activity" {
+		resolved, err := tasks.ResolveFunction(doc, fn)
+		if err != nil {
+			return nil, err
+		}
+		run = resolved
+	}
+
+	var taskQueue string
+	switch t := run.(type) {
+	case *model.CallHTTP:
+		task.Activity = functionName((*activities.CallHTTP).CallHTTPActivity)
+	case *model.CallOpenAPI:
+		task.Activity = functionName((*activities.CallOpenAPI).CallOpenAPIActivity)
+	case *model.CallGRPC:
+		task.Activity = functionName((*activities.CallGRPC).CallGRPCActivity)
+	case *model.CallFunction:
+		var with models.ActivityCallWith
+		if err := utils.ToType(t.With, &with); err != nil {
+			return nil, fmt.Errorf("error decoding activity call: %w", err)
+		}
+		task.Activity = with.Name
+		taskQueue = with.TaskQueue
+	case *model.RunTask:
+		switch {
+		case t.Run.Container != nil:
+			task.Activity = functionName((*activities.Run).CallContainerActivity)
+		case t.Run.Script != nil:
+			task.Activity = functionName((*activities.Run).CallScriptActivity)
+		case t.Run.Shell != nil:
+			task.Activity = functionName((*activities.Run).CallShellActivity)
+		}
+	}
+
+	if task.Activity == "" {
+		return task, nil
+	}
+
+	ao, err := metadata.ActivityOptionsFor(doc, run.GetBase(), item.Key, workflow.ActivityOptions{})
+	if err != nil {
+		return nil, err
+	}
+	task.ActivityOptions = &PlanActivityOptions{
+		TaskQueue:              taskQueue,
+		StartToCloseTimeout:    formatDuration(ao.StartToCloseTimeout),
+		ScheduleToCloseTimeout: formatDuration(ao.ScheduleToCloseTimeout),
+		ScheduleToStartTimeout: formatDuration(ao.ScheduleToStartTimeout),
+		HeartbeatTimeout:       formatDuration(ao.HeartbeatTimeout),
+	}
+	if r := ao.RetryPolicy; r != nil {
+		task.ActivityOptions.RetryPolicy = &PlanRetryPolicy{
+			InitialInterval:        formatDuration(r.InitialInterval),
+			BackoffCoefficient:     r.BackoffCoefficient,
+			MaximumInterval:        formatDuration(r.MaximumInterval),
+			MaximumAttempts:        r.MaximumAttempts,
+			NonRetryableErrorTypes: r.NonRetryableErrorTypes,
+		}
+	}
+
+	return task, nil
+}
+
+func inspectSchedule(doc *model.Workflow, envvars map[string]any) (*PlanSchedule, error) {
+	if doc.Schedule.After != nil {
+		return nil, fmt.Errorf("schedule.after not supported")
+	}
+
+	info, err := metadata.GetScheduleInfo(doc, envvars)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &PlanSchedule{
+		ID:           info.ID,
+		WorkflowName: info.WorkflowName,
+		Cron:         doc.Schedule.Cron,
+		Input:        info.Input,
+	}
+	if doc.Schedule.Every != nil {
+		schedule.Every = formatDuration(utils.ToDuration(doc.Schedule.Every))
+	}
+
+	return schedule, nil
+}
+
+// TaskType describes the task as it is written in the DSL, such as "set" or
+// "call http"
+func TaskType(task model.Task) string {
+	switch t := task.(type) {
+	case *model.CallHTTP:
+		return "call http"
+	case *model.CallGRPC:
+		return "call grpc"
+	case *model.CallOpenAPI:
+		return "call openapi"
+	case *model.CallAsyncAPI:
+		return "call asyncapi"
+	case *model.CallFunction:
+		return "call " + t.Call
+	case *model.RunTask:
+		switch {
+		case t.Run.Container != nil:
+			return "run container"
+		case t.Run.Script != nil:
+			return "run script"
+		case t.Run.Shell != nil:
+			return "run shell"
+		case t.Run.Workflow != nil:
+			return "run workflow"
+		}
+		return "run"
+	}
+
+	name := reflect.TypeOf(task).Elem().Name()
+	return strings.ToLower(strings.TrimSuffix(name, "Task"))
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// functionName returns the name Temporal registers a workflow or activity
+// function under
+func functionName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = name[strings.LastIndex(name, ".")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
+
+// recordingWorker is a worker.Worker that records what is registered with it
+// rather than polling Temporal
+type recordingWorker struct {
+	workflows  []string
+	activities []string
+}
+
+func newRecordingWorker() *recordingWorker {
+	return &recordingWorker{
+		workflows:  make([]string, 0),
+		activities: make([]string, 0),
+	}
+}
+
+func (w *recordingWorker) RegisterWorkflow(wf any) {
+	w.workflows = append(w.workflows, functionName(wf))
+}
+
+func (w *recordingWorker) RegisterWorkflowWithOptions(_ any, opts workflow.RegisterOptions) {
+	w.workflows = append(w.workflows, opts.Name)
+}
+
+func (w *recordingWorker) RegisterDynamicWorkflow(any, workflow.DynamicRegisterOptions) {}
+
+// RegisterActivity records an activity function, or every exported method of
+// an activity struct, as Temporal does
+func (w *recordingWorker) RegisterActivity(a any) {
+	w.RegisterActivityWithOptions(a, activity.RegisterOptions{})
+}
+
+func (w *recordingWorker) RegisterActivityWithOptions(a any, opts activity.RegisterOptions) {
+	t := reflect.TypeOf(a)
+	if t.Kind() == reflect.Func {
+		name := opts.Name
+		if name == "" {
+			name = functionName(a)
+		}
+		w.activities = append(w.activities, name)
+		return
+	}
+
+	for i := range t.NumMethod() {
+		w.activities = append(w.activities, opts.Name+t.Method(i).Name)
+	}
+}
+
+func (w *recordingWorker) RegisterDynamicActivity(any, activity.DynamicRegisterOptions) {}
+
+func (w *recordingWorker) RegisterNexusService(*nexus.Service) {}
+
+func (w *recordingWorker) Start() error {
+	return nil
+}
+
+func (w *recordingWorker) Run(<-chan any) error {
+	return nil
+}
+
+func (w *recordingWorker) Stop() {}
+
+var _ worker.Worker = &recordingWorker{}
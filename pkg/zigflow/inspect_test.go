@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+)
+
+const inspectWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: example
+  version: 0.0.1
+  metadata:
+    activityOptions:
+      startToCloseTimeout:
+        seconds: 30
+do:
+  - fetch:
+      call: http
+      metadata:
+        activityOptions:
+          retryPolicy:
+            maximumAttempts: 2
+      with:
+        method: get
+        endpoint: https://example.com
+  - approve:
+      listen:
+        to:
+          one:
+            with:
+              id: approve
+              type: signal
+  - loop:
+      for:
+        in: ${ [1, 2] }
+      do:
+        - echo:
+            run:
+              shell:
+                command: echo hello
+  - charge:
+      call: activity
+      with:
+        name: ChargeCard
+        taskQueue: payments
+`
+
+func TestInspect(t *testing.T) {
+	doc, err := zigflow.LoadFromBytes([]byte(inspectWorkflow))
+	require.NoError(t, err)
+
+	plan, err := zigflow.Inspect(doc, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "zigflow", plan.TaskQueue)
+	assert.Nil(t, plan.Schedule)
+	assert.ElementsMatch(t, []string{
+		"CallContainerActivity",
+		"CallGRPCActivity",
+		"CallHTTPActivity",
+		"CallOpenAPIActivity",
+		"CallScriptActivity",
+		"CallShellActivity",
+	}, plan.Activities)
+
+	require.Len(t, plan.Workflows, 2)
+
+	root := plan.Workflows[0]
+	assert.Equal(t, "example", root.Name)
+	assert.Equal(t, []string{"workflow_for_loop"}, root.Children)
+	require.Len(t, root.Handlers, 1)
+	assert.Equal(t, "approve", root.Handlers[0].ID)
+
+	require.Len(t, root.Tasks, 4)
+
+	fetch := root.Tasks[0]
+	assert.Equal(t, "call http", fetch.Type)
+	assert.Equal(t, "CallHTTPActivity", fetch.Activity)
+	require.NotNil(t, fetch.ActivityOptions)
+	assert.Equal(t, "30s", fetch.ActivityOptions.StartToCloseTimeout)
+	assert.Equal(t, int32(2), fetch.ActivityOptions.RetryPolicy.MaximumAttempts)
+
+	assert.Nil(t, root.Tasks[1].ActivityOptions)
+	assert.Equal(t, []string{"workflow_for_loop"}, root.Tasks[2].Children)
+
+	charge := root.Tasks[3]
+	assert.Equal(t, "ChargeCard", charge.Activity)
+	assert.Equal(t, "payments", charge.ActivityOptions.TaskQueue)
+	// Task overrides must not leak into other tasks
+	assert.Equal(t, int32(5), charge.ActivityOptions.RetryPolicy.MaximumAttempts)
+
+	loop := plan.Workflows[1]
+	assert.Equal(t, "workflow_for_loop", loop.Name)
+	require.Len(t, loop.Tasks, 1)
+	assert.Equal(t, "run shell", loop.Tasks[0].Type)
+	assert.Equal(t, "CallShellActivity", loop.Tasks[0].Activity)
+}
+
+const inspectFunctionsWorkflow = `document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: example
+  version: 0.0.1
+use:
+  functions:
+    getWeather:
+      call: http
+      metadata:
+        activityOptions:
+          startToCloseTimeout:
+            seconds: 10
+      with:
+        method: get
+        endpoint: ${ "https://weather.example.com/" + $arguments.city }
+do:
+  - london:
+      call: getWeather
+      with:
+        city: london
+  - paris:
+      call: getWeather
+      metadata:
+        activityOptions:
+          startToCloseTimeout:
+            seconds: 20
+      with:
+        city: paris
+`
+
+func TestInspect_Functions(t *testing.T) {
+	doc, err := zigflow.LoadFromBytes([]byte(inspectFunctionsWorkflow))
+	require.NoError(t, err)
+
+	plan, err := zigflow.Inspect(doc, nil)
+	require.NoError(t, err)
+
+	root := plan.Workflows[0]
+	require.Len(t, root.Tasks, 2)
+
+	london := root.Tasks[0]
+	assert.Equal(t, "call getWeather", london.Type)
+	assert.Equal(t, "CallHTTPActivity", london.Activity)
+	assert.Equal(t, "10s", london.ActivityOptions.StartToCloseTimeout)
+
+	paris := root.Tasks[1]
+	assert.Equal(t, "CallHTTPActivity", paris.Activity)
+	assert.Equal(t, "20s", paris.ActivityOptions.StartToCloseTimeout)
+}
+
+func TestInspectSchedule(t *testing.T) {
+	doc, err := zigflow.LoadFromBytes([]byte(`document:
+  dsl: 1.0.0
+  namespace: zigflow
+  name: example
+  version: 0.0.1
+  metadata:
+    scheduleWorkflowName: example
+    scheduleInput:
+      - ${ $env.NAME }
+schedule:
+  cron: 0 * * * *
+do:
+  - step:
+      set:
+        hello: world
+`))
+	require.NoError(t, err)
+
+	plan, err := zigflow.Inspect(doc, map[string]any{"NAME": "zigflow"})
+	require.NoError(t, err)
+
+	assert.Equal(t, &zigflow.PlanSchedule{
+		ID:           "zigflow_example",
+		WorkflowName: "example",
+		Cron:         "0 * * * *",
+		Input:        []any{"zigflow"},
+	}, plan.Schedule)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+)
+
+// Listener is a Temporal signal, query or update handler declared by a listen task
+type Listener struct {
+	ID       string               `json:"id"`
+	Type     tasks.ListenTaskType `json:"type"`
+	Task     string               `json:"task"`
+	Workflow string               `json:"workflow"`
+	Event    *model.EventFilter   `json:"-"`
+}
+
+// Listeners returns every handler declared in the document, in declaration order
+func Listeners(doc *model.Workflow) []*Listener {
+	listeners := make([]*Listener, 0)
+
+	_ = Walk(doc, func(item *model.TaskItem, workflowName string) error {
+		listen := item.AsListenTask()
+		if listen == nil || listen.Listen.To == nil {
+			return nil
+		}
+
+		to := listen.Listen.To
+		events := append(append([]*model.EventFilter{}, to.All...), to.Any...)
+		if to.One != nil {
+			events = append(events, to.One)
+		}
+
+		for _, event := range events {
+			if event == nil || event.With == nil {
+				continue
+			}
+			listeners = append(listeners, &Listener{
+				ID:       event.With.ID,
+				Type:     tasks.ListenTaskType(event.With.Type),
+				Task:     item.Key,
+				Workflow: workflowName,
+				Event:    event,
+			})
+		}
+
+		return nil
+	})
+
+	return listeners
+}
+
+// FindListener returns the first handler of the given type and ID, or nil
+func FindListener(doc *model.Workflow, listenerType tasks.ListenTaskType, id string) *Listener {
+	for _, l := range Listeners(doc) {
+		if l.Type == listenerType && l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
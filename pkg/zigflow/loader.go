@@ -24,18 +24,67 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
 	"sigs.k8s.io/yaml"
 )
 
+// Document is a workflow and where everything in it is declared in the file
+type Document struct {
+	Workflow *model.Workflow
+	Source   *utils.Source
+}
+
 func LoadFromFile(file string) (*model.Workflow, error) {
+	doc, err := LoadDocumentFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Workflow, nil
+}
+
+// LoadFromBytes loads a YAML or JSON workflow, such as an unsaved file in an
+// editor
+func LoadFromBytes(data []byte) (*model.Workflow, error) {
+	doc, err := LoadDocumentFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Workflow, nil
+}
+
+// LoadDocumentFromFile loads a workflow file, keeping where everything is
+// declared so errors can be located
+func LoadDocumentFromFile(file string) (*Document, error) {
 	data, err := os.ReadFile(filepath.Clean(file))
 	if err != nil {
 		return nil, fmt.Errorf("error loading file: %w", err)
 	}
 
+	return LoadDocumentFromBytes(data)
+}
+
+// LoadDocumentFromBytes loads a YAML or JSON workflow, keeping where
+// everything is declared so errors can be located
+func LoadDocumentFromBytes(data []byte) (*Document, error) {
+	doc, err := DecodeDocumentFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := newWorkflowPostLoad(doc.Workflow); err != nil {
+		return nil, fmt.Errorf("error preparing workflow: %w", doc.Source.Locate(err))
+	}
+
+	return doc, nil
+}
+
+// DecodeDocumentFromBytes decodes a YAML or JSON workflow without preparing
+// its tasks. Use LoadDocumentFromBytes unless the workflow is only inspected,
+// such as to report why it can't be built.
+func DecodeDocumentFromBytes(data []byte) (*Document, error) {
 	// Load the workflow without validating - we'll do that later
-	var jsonBytes []byte
-	if jsonBytes, err = yaml.YAMLToJSON(data); err != nil {
+	jsonBytes, err := yaml.YAMLToJSON(data)
+	if err != nil {
 		return nil, fmt.Errorf("error converting yaml to json: %w", err)
 	}
 
@@ -43,10 +92,19 @@ func LoadFromFile(file string) (*model.Workflow, error) {
 	if err := json.Unmarshal(jsonBytes, &wf); err != nil {
 		return nil, fmt.Errorf("error unmarshaling json to workflow: %w", err)
 	}
+	if wf == nil {
+		return nil, fmt.Errorf("workflow is empty")
+	}
+	if err := loadOIDCAuthentications(wf, jsonBytes); err != nil {
+		return nil, fmt.Errorf("error loading authentications: %w", err)
+	}
 
-	if err := newWorkflowPostLoad(wf); err != nil {
-		return nil, fmt.Errorf("error preparing workflow: %w", err)
+	// Keep track of where everything is declared so errors can be located
+	src, err := utils.ParseSource(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing workflow source: %w", err)
 	}
+	src.AddTasks(wf)
 
 	c, err := semver.NewConstraint(">= 1.0.0, <2.0.0")
 	if err != nil {
@@ -62,5 +120,36 @@ func LoadFromFile(file string) (*model.Workflow, error) {
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDSL, wf.Document.DSL)
 	}
 
-	return wf, nil
+	return &Document{
+		Workflow: wf,
+		Source:   src,
+	}, nil
+}
+
+// loadOIDCAuthentications reads the properties of the OIDC policies in
+// use.authentications, which the SDK doesn't unmarshal
+func loadOIDCAuthentications(wf *model.Workflow, data []byte) error {
+	if wf.Use == nil || len(wf.Use.Authentications) == 0 {
+		return nil
+	}
+
+	var raw struct {
+		Use struct {
+			Authentications map[string]struct {
+				OIDC *model.OAuth2AuthenticationProperties `json:"oidc"`
+			} `json:"authentications"`
+		} `json:"use"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for name, policy := range wf.Use.Authentications {
+		if policy == nil || policy.OIDC == nil || policy.OIDC.Properties != nil || policy.OIDC.Use != "" {
+			continue
+		}
+		policy.OIDC.Properties = raw.Use.Authentications[name].OIDC
+	}
+
+	return nil
 }
@@ -22,7 +22,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
 )
 
 func TestLoadWorkflowFile(t *testing.T) {
@@ -31,6 +34,7 @@ func TestLoadWorkflowFile(t *testing.T) {
 		Content     string
 		Error       error
 		ExpectError bool
+		Position    *utils.Position
 	}{
 		{
 			Name: "Load valid workflow file",
@@ -58,11 +62,65 @@ do:
 			Error:       zigflow.ErrUnsupportedDSL,
 			ExpectError: true,
 		},
+		{
+			Name: "Unsupported task is located",
+			Content: `document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+do:
+  - step:
+      set:
+        hello: world
+  - nested:
+      do:
+        - unknown:
+            call: myFunction`,
+			ExpectError: true,
+			Position:    &utils.Position{Pointer: "/do/1/nested/do/0/unknown", Line: 12, Column: 11},
+		},
+		{
+			// The same file fails to register on the worker
+			Name: "Task retry Temporal cannot run",
+			Content: `document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+use:
+  retries:
+    conditional:
+      when: ${ .status == 503 }
+do:
+  - step:
+      metadata:
+        retry: conditional
+      set:
+        hello: world`,
+			Error:       metadata.ErrRetryNotSupported,
+			ExpectError: true,
+			Position:    &utils.Position{Pointer: "/do/0/step", Line: 11, Column: 5},
+		},
 		{
 			Name:        "Invalid YAML",
 			Content:     `invalid content: [`,
 			ExpectError: true,
 		},
+		{
+			Name:        "Empty file",
+			Content:     ``,
+			ExpectError: true,
+		},
+		{
+			// Left for the validator to report
+			Name: "Missing tasks",
+			Content: `document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1`,
+		},
 	}
 
 	for _, test := range tests {
@@ -85,6 +143,13 @@ do:
 				if test.Error != nil {
 					assert.ErrorIs(t, err, test.Error)
 				}
+
+				if test.Position != nil {
+					var srcErr *utils.SourceError
+					if assert.ErrorAs(t, err, &srcErr) {
+						assert.Equal(t, *test.Position, srcErr.Position)
+					}
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, workflow)
@@ -92,3 +157,86 @@ do:
 		})
 	}
 }
+
+func TestLoadWorkflowFileValidationPositions(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "workflow_test")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	filePath := filepath.Join(tmpDir, "zigflow.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte(`document:
+  dsl: 1.0.0
+  namespace: default
+  name: test
+  version: 0.0.1
+do:
+  - fetch:
+      call: http
+      with:
+        method: ""
+        endpoint: https://example.com
+  - attempt:
+      try:
+        - empty:
+            set: {}
+      catch:
+        do:
+          - fallback:
+              set:
+                hello: world`), 0o600))
+
+	doc, err := zigflow.LoadDocumentFromFile(filePath)
+	require.NoError(t, err)
+
+	validator, err := utils.NewValidator()
+	require.NoError(t, err)
+
+	res, err := validator.ValidateStructSource(doc.Workflow, doc.Source)
+	require.NoError(t, err)
+
+	positions := make([]utils.Position, 0, len(res))
+	for _, e := range res {
+		positions = append(positions, e.Position)
+	}
+	assert.Equal(t, []utils.Position{
+		{Pointer: "/do/0/fetch/with/method", Line: 10, Column: 9},
+		{Pointer: "/do/1/attempt/try/0/empty/set", Line: 15, Column: 13},
+	}, positions)
+}
+
+func TestLoadFromBytes_OIDCAuthentication(t *testing.T) {
+	wf, err := zigflow.LoadFromBytes([]byte(`document:
+  dsl: 1.0.0
+  namespace: default
+  name: auth
+  version: 0.0.1
+use:
+  authentications:
+    sso:
+      oidc:
+        authority: https://sso.example.com
+        grant: client_credentials
+        client:
+          id: zigflow
+        scopes:
+          - read
+do:
+  - getUser:
+      call: http
+      with:
+        method: get
+        endpoint:
+          uri: https://api.example.com/users/1
+          authentication:
+            use: sso
+`))
+	require.NoError(t, err)
+
+	props := wf.Use.Authentications["sso"].OIDC.Properties
+	require.NotNil(t, props)
+	assert.Equal(t, "https://sso.example.com", props.Authority.String())
+	assert.Equal(t, "zigflow", props.Client.ID)
+	assert.Equal(t, []string{"read"}, props.Scopes)
+}
@@ -26,14 +26,14 @@ import (
 )
 
 type ActivityOptions struct {
-	HeartbeatTimeout       *model.Duration   `json:"heartbeatTimeout,omitempty"`
-	ScheduleToCloseTimeout *model.Duration   `json:"scheduleToCloseTimeout"`
-	ScheduleToStartTimeout *model.Duration   `json:"scheduleToStartTimeout"`
-	StartToCloseTimeout    *model.Duration   `json:"startToCloseTimeout"`
-	RetryPolicy            *RetryPolicy      `json:"retryPolicy"`
-	DisableEagerExecution  *bool             `json:"disableEagerExecution"`
-	Summary                string            `json:"summary"`
-	Priority               *ActivityPriority `json:"priority"`
+	HeartbeatTimeout       *model.Duration   `json:"heartbeatTimeout,omitempty" description:"Maximum time between heartbeats before the activity is failed"`
+	ScheduleToCloseTimeout *model.Duration   `json:"scheduleToCloseTimeout" description:"Maximum time for the activity, including retries"`
+	ScheduleToStartTimeout *model.Duration   `json:"scheduleToStartTimeout" description:"Maximum time the activity can wait in the task queue before a worker picks it up"`
+	StartToCloseTimeout    *model.Duration   `json:"startToCloseTimeout" description:"Maximum time for a single attempt of the activity"`
+	RetryPolicy            *RetryPolicy      `json:"retryPolicy" description:"How the activity is retried when it fails"`
+	DisableEagerExecution  *bool             `json:"disableEagerExecution" description:"Stop the activity being run by the worker that scheduled it"`
+	Summary                string            `json:"summary" description:"Summary shown in the Temporal UI. Defaults to the task name"`
+	Priority               *ActivityPriority `json:"priority" description:"Priority and fairness of the activity in the task queue"`
 }
 
 func (a *ActivityOptions) ToTemporal(opts *workflow.ActivityOptions) workflow.ActivityOptions {
@@ -73,9 +73,9 @@ func (a *ActivityOptions) ToTemporal(opts *workflow.ActivityOptions) workflow.Ac
 }
 
 type ActivityPriority struct {
-	PriorityKey    *int     `json:"priorityKey"`
-	FairnessKey    string   `json:"fairnessKey"`
-	FairnessWeight *float32 `json:"fairnessWeight"`
+	PriorityKey    *int     `json:"priorityKey" description:"Priority of the activity, where lower numbers are run first"`
+	FairnessKey    string   `json:"fairnessKey" description:"Key used to share the task queue fairly between groups of activities"`
+	FairnessWeight *float32 `json:"fairnessWeight" description:"Weight of the fairness key, relative to the other keys"`
 }
 
 func (a *ActivityPriority) ToTemporal(priority temporal.Priority) temporal.Priority {
@@ -92,11 +92,11 @@ func (a *ActivityPriority) ToTemporal(priority temporal.Priority) temporal.Prior
 }
 
 type RetryPolicy struct {
-	InitialInterval        *model.Duration `json:"initialInterval"`
-	BackoffCoefficient     *float64        `json:"backoffCoefficient"`
-	MaximumInterval        *model.Duration `json:"maximumInterval"`
-	MaximumAttempts        *int32          `json:"maximumAttempts"`
-	NonRetryableErrorTypes []string        `json:"nonRetryableErrorTypes"`
+	InitialInterval        *model.Duration `json:"initialInterval" description:"Time to wait before the first retry"`
+	BackoffCoefficient     *float64        `json:"backoffCoefficient" description:"Multiplier applied to the interval after each retry"`
+	MaximumInterval        *model.Duration `json:"maximumInterval" description:"Longest time to wait between retries"`
+	MaximumAttempts        *int32          `json:"maximumAttempts" description:"Maximum number of attempts, where 0 is unlimited"`
+	NonRetryableErrorTypes []string        `json:"nonRetryableErrorTypes" description:"Error types that are not retried"`
 }
 
 func (r *RetryPolicy) ToTemporal(retry *temporal.RetryPolicy) *temporal.RetryPolicy {
@@ -130,11 +130,27 @@ func SetActivityOptions(ctx workflow.Context, wf *model.Workflow, task *model.Ta
 	logger := workflow.GetLogger(ctx)
 
 	// Get any options already set
-	ao := workflow.GetActivityOptions(ctx)
+	ao, err := ActivityOptionsFor(wf, task, taskName, workflow.GetActivityOptions(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("Setting activity options", "options", ao)
+
+	// Create the new context with the options set
+	return workflow.WithActivityOptions(ctx, ao), nil
+}
 
-	// Set default values
+// ActivityOptionsFor returns the activity options for a task. The defaults
+// are overridden by the document's activity options, the task's retry policy
+// and then the task's activity options.
+func ActivityOptionsFor(
+	wf *model.Workflow, task *model.TaskBase, taskName string, ao workflow.ActivityOptions,
+) (workflow.ActivityOptions, error) {
+	// Set default values. The retry policy is copied as overrides modify it.
+	retryPolicy := *defaultRetryPolicy
 	ao.Summary = taskName
-	ao.RetryPolicy = defaultRetryPolicy
+	ao.RetryPolicy = &retryPolicy
 	ao.StartToCloseTimeout = defaultWorkflowTimeout
 
 	// Convert the timeout
@@ -146,26 +162,30 @@ func SetActivityOptions(ctx workflow.Context, wf *model.Workflow, task *model.Ta
 	if a, ok := wf.Document.Metadata[MetadataActivityOptions]; ok {
 		var opts ActivityOptions
 		if err := utils.ToType(a, &opts); err != nil {
-			return nil, fmt.Errorf("error decoding global activity options metadata: %w", err)
+			return ao, fmt.Errorf("error decoding global activity options metadata: %w", err)
 		}
 
-		logger.Debug("Adding global activity options", "options", opts)
 		ao = opts.ToTemporal(&ao)
 	}
 
+	// Override with any task retry policy
+	retry, err := RetryFor(wf, task)
+	if err != nil {
+		return ao, err
+	}
+	if retry != nil {
+		ao = retry.ToActivityOptions(&ao)
+	}
+
 	// Override any task-specific activity options
 	if a, ok := task.Metadata[MetadataActivityOptions]; ok {
 		var opts ActivityOptions
 		if err := utils.ToType(a, &opts); err != nil {
-			return nil, fmt.Errorf("error decoding task activity options metadata: %w", err)
+			return ao, fmt.Errorf("error decoding task activity options metadata: %w", err)
 		}
 
-		logger.Debug("Adding task activity options", "options", opts)
 		ao = opts.ToTemporal(&ao)
 	}
 
-	logger.Debug("Setting activity options", "options", ao)
-
-	// Create the new context with the options set
-	return workflow.WithActivityOptions(ctx, ao), nil
+	return ao, nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
 	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
 )
 
 func TestConvertRetryPolicy(t *testing.T) {
@@ -64,3 +65,90 @@ func TestConvertRetryPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestActivityOptionsFor(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Document map[string]any
+		Task     map[string]any
+		Expected workflow.ActivityOptions
+	}{
+		{
+			Name: "Defaults",
+			Expected: workflow.ActivityOptions{
+				Summary:             "task",
+				StartToCloseTimeout: time.Minute * 5,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:    time.Second,
+					BackoffCoefficient: 2.0,
+					MaximumInterval:    time.Minute,
+					MaximumAttempts:    5,
+				},
+			},
+		},
+		{
+			Name: "Task overrides document",
+			Document: map[string]any{
+				metadata.MetadataActivityOptions: map[string]any{
+					"startToCloseTimeout": map[string]any{"seconds": 30},
+					"retryPolicy":         map[string]any{"maximumAttempts": 2},
+				},
+			},
+			Task: map[string]any{
+				metadata.MetadataActivityOptions: map[string]any{
+					"retryPolicy": map[string]any{"maximumAttempts": 3},
+				},
+			},
+			Expected: workflow.ActivityOptions{
+				Summary:             "task",
+				StartToCloseTimeout: time.Second * 30,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:    time.Second,
+					BackoffCoefficient: 2.0,
+					MaximumInterval:    time.Minute,
+					MaximumAttempts:    3,
+				},
+			},
+		},
+		{
+			Name: "Task retry policy",
+			Task: map[string]any{
+				metadata.MetadataRetry: map[string]any{
+					"delay":   map[string]any{"seconds": 2},
+					"backoff": map[string]any{"constant": map[string]any{}},
+					"limit": map[string]any{
+						"attempt": map[string]any{
+							"count":    3,
+							"duration": map[string]any{"seconds": 10},
+						},
+					},
+				},
+				metadata.MetadataActivityOptions: map[string]any{
+					"retryPolicy": map[string]any{"nonRetryableErrorTypes": []string{"error1"}},
+				},
+			},
+			Expected: workflow.ActivityOptions{
+				Summary:             "task",
+				StartToCloseTimeout: time.Second * 10,
+				RetryPolicy: &temporal.RetryPolicy{
+					InitialInterval:        time.Second * 2,
+					BackoffCoefficient:     1.0,
+					MaximumAttempts:        4,
+					NonRetryableErrorTypes: []string{"error1"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			wf := &model.Workflow{
+				Document: model.Document{Metadata: test.Document},
+			}
+
+			ao, err := metadata.ActivityOptionsFor(wf, &model.TaskBase{Metadata: test.Task}, "task", workflow.ActivityOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, ao)
+		})
+	}
+}
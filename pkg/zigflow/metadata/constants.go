@@ -26,6 +26,8 @@ const MetadataActivityOptions string = "activityOptions"
 
 const MetadataHeartbeat string = "heartbeat"
 
+const MetadataRetry string = "retry"
+
 const MetadataSearchAttribute string = "searchAttributes"
 
 const (
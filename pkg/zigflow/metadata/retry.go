@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Longest delay between retries, as a multiple of the initial delay. This
+// matches Temporal's default maximum interval.
+const maxRetryDelayMultiplier = 100
+
+var ErrRetryNotSupported = errors.New("only supported by try.catch.retry")
+
+// TaskRetryError is returned for task retry metadata that can't be used
+type TaskRetryError struct {
+	Err error
+}
+
+func (e *TaskRetryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TaskRetryError) Unwrap() error {
+	return e.Err
+}
+
+// Retry is a Serverless Workflow retry policy converted for Temporal
+type Retry struct {
+	Policy *temporal.RetryPolicy
+	// Maximum time for all attempts, including retries
+	Timeout time.Duration
+	// Maximum time for a single attempt
+	AttemptTimeout time.Duration
+}
+
+// NewRetry converts a resolved retry policy to a Temporal retry policy. Linear
+// backoff, jitter and when/exceptWhen conditions have no Temporal equivalent
+// so are rejected.
+func NewRetry(policy *model.RetryPolicy) (*Retry, error) {
+	switch {
+	case policy.Ref != "":
+		return nil, fmt.Errorf("retry policy %q is not resolved", policy.Ref)
+	case policy.When != nil || policy.ExceptWhen != nil:
+		return nil, fmt.Errorf("retry when and exceptWhen are %w", ErrRetryNotSupported)
+	case policy.Jitter != nil:
+		return nil, fmt.Errorf("retry jitter is %w", ErrRetryNotSupported)
+	case policy.Backoff != nil && policy.Backoff.Linear != nil:
+		return nil, fmt.Errorf("linear retry backoff is %w", ErrRetryNotSupported)
+	}
+
+	retry := &Retry{
+		Policy: &temporal.RetryPolicy{
+			InitialInterval:    RetryDelay(policy, 0),
+			BackoffCoefficient: 1.0,
+		},
+		Timeout: utils.ToDuration(policy.Limit.Duration),
+	}
+
+	if policy.Backoff != nil && policy.Backoff.Exponential != nil {
+		retry.Policy.BackoffCoefficient = 2.0
+	}
+
+	if a := policy.Limit.Attempt; a != nil {
+		if a.Count > 0 {
+			// The count is the number of retries - Temporal includes the first attempt
+			retry.Policy.MaximumAttempts = int32(a.Count) + 1
+		}
+		retry.AttemptTimeout = utils.ToDuration(a.Duration)
+	}
+
+	return retry, nil
+}
+
+func (r *Retry) ToActivityOptions(opts *workflow.ActivityOptions) workflow.ActivityOptions {
+	opts.RetryPolicy = r.Policy
+
+	if r.Timeout > 0 {
+		opts.ScheduleToCloseTimeout = r.Timeout
+	}
+
+	if r.AttemptTimeout > 0 {
+		opts.StartToCloseTimeout = r.AttemptTimeout
+	}
+
+	return *opts
+}
+
+func (r *Retry) ToChildWorkflowOptions(opts *workflow.ChildWorkflowOptions) workflow.ChildWorkflowOptions {
+	opts.RetryPolicy = r.Policy
+
+	if r.Timeout > 0 {
+		opts.WorkflowExecutionTimeout = r.Timeout
+	}
+
+	if r.AttemptTimeout > 0 {
+		opts.WorkflowRunTimeout = r.AttemptTimeout
+	}
+
+	return *opts
+}
+
+// ************** //
+// Static Methods //
+// ************** //
+
+// ResolveRetryPolicy returns a copy of the policy, replacing a reference with
+// the named policy from use.retries
+func ResolveRetryPolicy(wf *model.Workflow, policy *model.RetryPolicy) (*model.RetryPolicy, error) {
+	resolved := *policy
+	if resolved.Ref == "" {
+		return &resolved, nil
+	}
+
+	var retries map[string]*model.RetryPolicy
+	if wf.Use != nil {
+		retries = wf.Use.Retries
+	}
+
+	if p, ok := retries[resolved.Ref]; !ok || p == nil {
+		return nil, fmt.Errorf("retry policy %q is not defined in use.retries", resolved.Ref)
+	}
+
+	if err := resolved.ResolveReference(retries); err != nil {
+		return nil, err
+	}
+
+	return &resolved, nil
+}
+
+// RetryPolicyFor returns the resolved retry policy set in the task's metadata,
+// or nil if there isn't one
+func RetryPolicyFor(wf *model.Workflow, task *model.TaskBase) (*model.RetryPolicy, error) {
+	r, ok := task.Metadata[MetadataRetry]
+	if !ok {
+		return nil, nil
+	}
+
+	var policy model.RetryPolicy
+	if err := utils.ToType(r, &policy); err != nil {
+		return nil, &TaskRetryError{Err: fmt.Errorf("error decoding task retry metadata: %w", err)}
+	}
+
+	resolved, err := ResolveRetryPolicy(wf, &policy)
+	if err != nil {
+		return nil, &TaskRetryError{Err: err}
+	}
+
+	return resolved, nil
+}
+
+// RetryFor returns the task's retry metadata converted for Temporal, or nil if
+// it isn't set
+func RetryFor(wf *model.Workflow, task *model.TaskBase) (*Retry, error) {
+	policy, err := RetryPolicyFor(wf, task)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+
+	retry, err := NewRetry(policy)
+	if err != nil {
+		return nil, &TaskRetryError{Err: fmt.Errorf("error converting task retry metadata: %w", err)}
+	}
+
+	return retry, nil
+}
+
+// ChildWorkflowOptionsFor applies the task's retry metadata to the options
+// used to run it as a child workflow
+func ChildWorkflowOptionsFor(
+	wf *model.Workflow, task *model.TaskBase, opts workflow.ChildWorkflowOptions,
+) (workflow.ChildWorkflowOptions, error) {
+	retry, err := RetryFor(wf, task)
+	if err != nil {
+		return opts, err
+	}
+
+	if retry != nil {
+		opts = retry.ToChildWorkflowOptions(&opts)
+	}
+
+	return opts, nil
+}
+
+// RetryDelay returns the time to wait before a retry, ignoring any jitter. The
+// attempt is zero for the first retry. Without a backoff, the delay is constant.
+func RetryDelay(policy *model.RetryPolicy, attempt int) time.Duration {
+	delay := defaultRetryPolicy.InitialInterval
+	if policy.Delay != nil {
+		delay = utils.ToDuration(policy.Delay)
+	}
+
+	multiplier := 1.0
+	if b := policy.Backoff; b != nil {
+		switch {
+		case b.Exponential != nil:
+			multiplier = math.Pow(2, float64(attempt))
+		case b.Linear != nil:
+			multiplier = float64(attempt + 1)
+		}
+	}
+
+	return time.Duration(float64(delay) * min(multiplier, maxRetryDelayMultiplier))
+}
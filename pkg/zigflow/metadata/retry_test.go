@@ -0,0 +1,242 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+func seconds(s int32) *model.Duration {
+	return &model.Duration{Value: model.DurationInline{Seconds: s}}
+}
+
+func TestNewRetry(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Policy   *model.RetryPolicy
+		Expected *metadata.Retry
+		Err      string
+	}{
+		{
+			Name:   "Empty",
+			Policy: &model.RetryPolicy{},
+			Expected: &metadata.Retry{
+				Policy: &temporal.RetryPolicy{
+					InitialInterval:    time.Second,
+					BackoffCoefficient: 1.0,
+				},
+			},
+		},
+		{
+			Name: "Exponential",
+			Policy: &model.RetryPolicy{
+				Delay:   seconds(3),
+				Backoff: &model.RetryBackoff{Exponential: &model.BackoffDefinition{}},
+				Limit: model.RetryLimit{
+					Attempt:  &model.RetryLimitAttempt{Count: 5, Duration: seconds(10)},
+					Duration: seconds(60),
+				},
+			},
+			Expected: &metadata.Retry{
+				Policy: &temporal.RetryPolicy{
+					InitialInterval:    time.Second * 3,
+					BackoffCoefficient: 2.0,
+					MaximumAttempts:    6,
+				},
+				Timeout:        time.Minute,
+				AttemptTimeout: time.Second * 10,
+			},
+		},
+		{
+			Name:   "Linear",
+			Policy: &model.RetryPolicy{Backoff: &model.RetryBackoff{Linear: &model.BackoffDefinition{}}},
+			Err:    "linear retry backoff is only supported by try.catch.retry",
+		},
+		{
+			Name:   "Jitter",
+			Policy: &model.RetryPolicy{Jitter: &model.RetryPolicyJitter{From: seconds(1), To: seconds(2)}},
+			Err:    "retry jitter is only supported by try.catch.retry",
+		},
+		{
+			Name:   "When",
+			Policy: &model.RetryPolicy{When: model.NewExpr("${ true }")},
+			Err:    "retry when and exceptWhen are only supported by try.catch.retry",
+		},
+		{
+			Name:   "Unresolved",
+			Policy: &model.RetryPolicy{Ref: "default"},
+			Err:    `retry policy "default" is not resolved`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			retry, err := metadata.NewRetry(test.Policy)
+			if test.Err != "" {
+				assert.EqualError(t, err, test.Err)
+				assert.Nil(t, retry)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, retry)
+		})
+	}
+}
+
+func TestResolveRetryPolicy(t *testing.T) {
+	named := &model.RetryPolicy{Delay: seconds(5)}
+
+	tests := []struct {
+		Name     string
+		Use      *model.Use
+		Policy   *model.RetryPolicy
+		Expected *model.RetryPolicy
+		Err      string
+	}{
+		{
+			Name:     "Inline",
+			Policy:   &model.RetryPolicy{Delay: seconds(1)},
+			Expected: &model.RetryPolicy{Delay: seconds(1)},
+		},
+		{
+			Name:     "Reference",
+			Use:      &model.Use{Retries: map[string]*model.RetryPolicy{"default": named}},
+			Policy:   &model.RetryPolicy{Ref: "default"},
+			Expected: named,
+		},
+		{
+			Name:   "Missing reference",
+			Use:    &model.Use{Retries: map[string]*model.RetryPolicy{"default": named}},
+			Policy: &model.RetryPolicy{Ref: "other"},
+			Err:    `retry policy "other" is not defined in use.retries`,
+		},
+		{
+			Name:   "No use block",
+			Policy: &model.RetryPolicy{Ref: "default"},
+			Err:    `retry policy "default" is not defined in use.retries`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			policy, err := metadata.ResolveRetryPolicy(&model.Workflow{Use: test.Use}, test.Policy)
+			if test.Err != "" {
+				assert.EqualError(t, err, test.Err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, policy)
+			assert.NotSame(t, named, policy, "resolved policies must be copies")
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Backoff  *model.RetryBackoff
+		Expected []time.Duration
+	}{
+		{
+			Name:     "No backoff",
+			Expected: []time.Duration{time.Second * 2, time.Second * 2, time.Second * 2},
+		},
+		{
+			Name:     "Constant",
+			Backoff:  &model.RetryBackoff{Constant: &model.BackoffDefinition{}},
+			Expected: []time.Duration{time.Second * 2, time.Second * 2, time.Second * 2},
+		},
+		{
+			Name:     "Linear",
+			Backoff:  &model.RetryBackoff{Linear: &model.BackoffDefinition{}},
+			Expected: []time.Duration{time.Second * 2, time.Second * 4, time.Second * 6},
+		},
+		{
+			Name:     "Exponential",
+			Backoff:  &model.RetryBackoff{Exponential: &model.BackoffDefinition{}},
+			Expected: []time.Duration{time.Second * 2, time.Second * 4, time.Second * 8},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			policy := &model.RetryPolicy{Delay: seconds(2), Backoff: test.Backoff}
+
+			for attempt, expected := range test.Expected {
+				assert.Equal(t, expected, metadata.RetryDelay(policy, attempt))
+			}
+		})
+	}
+
+	t.Run("Capped", func(t *testing.T) {
+		policy := &model.RetryPolicy{
+			Delay:   seconds(1),
+			Backoff: &model.RetryBackoff{Exponential: &model.BackoffDefinition{}},
+		}
+
+		assert.Equal(t, time.Second*100, metadata.RetryDelay(policy, 50))
+	})
+}
+
+func TestChildWorkflowOptionsFor(t *testing.T) {
+	wf := &model.Workflow{
+		Use: &model.Use{
+			Retries: map[string]*model.RetryPolicy{
+				"default": {
+					Delay: seconds(1),
+					Limit: model.RetryLimit{
+						Attempt:  &model.RetryLimitAttempt{Count: 2, Duration: seconds(30)},
+						Duration: seconds(90),
+					},
+				},
+			},
+		},
+	}
+
+	opts, err := metadata.ChildWorkflowOptionsFor(wf, &model.TaskBase{}, workflow.ChildWorkflowOptions{WorkflowID: "id"})
+	assert.NoError(t, err)
+	assert.Equal(t, workflow.ChildWorkflowOptions{WorkflowID: "id"}, opts)
+
+	opts, err = metadata.ChildWorkflowOptionsFor(wf, &model.TaskBase{
+		Metadata: map[string]any{metadata.MetadataRetry: "default"},
+	}, workflow.ChildWorkflowOptions{WorkflowID: "id"})
+	assert.NoError(t, err)
+	assert.Equal(t, workflow.ChildWorkflowOptions{
+		WorkflowID:               "id",
+		WorkflowExecutionTimeout: time.Second * 90,
+		WorkflowRunTimeout:       time.Second * 30,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 1.0,
+			MaximumAttempts:    3,
+		},
+	}, opts)
+
+	_, err = metadata.ChildWorkflowOptionsFor(wf, &model.TaskBase{
+		Metadata: map[string]any{metadata.MetadataRetry: "missing"},
+	}, workflow.ChildWorkflowOptions{})
+	assert.EqualError(t, err, `retry policy "missing" is not defined in use.retries`)
+}
@@ -39,9 +39,20 @@ const (
 	SearchAttributeBooleanType     string = "bool"
 )
 
+// SearchAttributeTypes lists every search attribute type
+var SearchAttributeTypes = []string{
+	SearchAttributeBooleanType,
+	SearchAttributeDateTimeType,
+	SearchAttributeDoubleType,
+	SearchAttributeIntType,
+	SearchAttributeKeywordType,
+	SearchAttributeKeywordListType,
+	SearchAttributeTextType,
+}
+
 type SearchAttribute struct {
-	Type  string `json:"type"`
-	Value any    `json:"value"` // If nil then the value is unset
+	Type  string `json:"type" validate:"required" description:"Type of the search attribute, as registered in Temporal"`
+	Value any    `json:"value" description:"Value to set. The attribute is unset if this is null"` // If nil then the value is unset
 }
 
 func (v *SearchAttribute) newBooleanUpdate(key string) (temporal.SearchAttributeUpdate, error) {
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package models
+
+// Authentication is the authentication policy of a call, resolved by the
+// activity making it. It is never sent through the workflow history.
+type Authentication struct {
+	Basic  *BasicAuthentication  `json:"basic,omitempty"`
+	Bearer *BearerAuthentication `json:"bearer,omitempty"`
+	Digest *BasicAuthentication  `json:"digest,omitempty"`
+	OAuth2 *OAuth2Authentication `json:"oauth2,omitempty"`
+	OIDC   *OAuth2Authentication `json:"oidc,omitempty"`
+}
+
+type BasicAuthentication struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type BearerAuthentication struct {
+	Token string `json:"token"`
+}
+
+type OAuth2Authentication struct {
+	Authority string        `json:"authority"`
+	Grant     string        `json:"grant"`
+	Client    *OAuth2Client `json:"client,omitempty"`
+	Encoding  string        `json:"encoding,omitempty"`
+	Scopes    []string      `json:"scopes,omitempty"`
+	Audiences []string      `json:"audiences,omitempty"`
+	Username  string        `json:"username,omitempty"`
+	Password  string        `json:"password,omitempty"`
+	// Only used by OAuth2 - OIDC discovers the token endpoint from the authority
+	TokenEndpoint string `json:"tokenEndpoint,omitempty"`
+}
+
+type OAuth2Client struct {
+	ID             string `json:"id,omitempty"`
+	Secret         string `json:"secret,omitempty"`
+	Authentication string `json:"authentication,omitempty"`
+}
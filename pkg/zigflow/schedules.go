@@ -27,6 +27,94 @@ import (
 	"go.temporal.io/sdk/client"
 )
 
+// ReconcileSchedules updates the schedules for every definition. A definition
+// without a schedule never deletes a schedule owned by another definition and
+// two definitions that resolve to the same schedule ID are rejected.
+func ReconcileSchedules(ctx context.Context, temporalClient client.Client, defs []*Definition, envvars map[string]any) error {
+	owners := map[string]*Definition{}
+	for _, d := range defs {
+		if d.Workflow.Schedule == nil {
+			continue
+		}
+
+		info, err := metadata.GetScheduleInfo(d.Workflow, envvars)
+		if err != nil {
+			return fmt.Errorf("%s: error getting schedule metadata: %w", d.File, err)
+		}
+
+		if existing, ok := owners[info.ID]; ok {
+			return fmt.Errorf(
+				"%w: schedule %q is defined in both %s and %s",
+				ErrWorkflowConflict, info.ID, existing.File, d.File,
+			)
+		}
+		owners[info.ID] = d
+	}
+
+	for _, d := range defs {
+		info, err := metadata.GetScheduleInfo(d.Workflow, envvars)
+		if err != nil {
+			return fmt.Errorf("%s: error getting schedule metadata: %w", d.File, err)
+		}
+
+		// Never delete a schedule that another definition owns
+		if owner, ok := owners[info.ID]; ok && owner != d {
+			continue
+		}
+
+		if err := UpdateSchedules(ctx, temporalClient, d.Workflow, envvars); err != nil {
+			return fmt.Errorf("%s: %w", d.File, err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteRemovedSchedules deletes the schedules of the previous definitions
+// that no longer exist in the next ones, for example when a workflow file is
+// removed or its schedule ID changes
+func DeleteRemovedSchedules(
+	ctx context.Context,
+	temporalClient client.Client,
+	previous, next []*Definition,
+	envvars map[string]any,
+) error {
+	kept := map[string]bool{}
+	for _, d := range next {
+		if d.Workflow.Schedule == nil {
+			continue
+		}
+
+		info, err := metadata.GetScheduleInfo(d.Workflow, envvars)
+		if err != nil {
+			return fmt.Errorf("%s: error getting schedule metadata: %w", d.File, err)
+		}
+		kept[info.ID] = true
+	}
+
+	scheduleClient := temporalClient.ScheduleClient()
+	for _, d := range previous {
+		if d.Workflow.Schedule == nil {
+			continue
+		}
+
+		info, err := metadata.GetScheduleInfo(d.Workflow, envvars)
+		if err != nil {
+			return fmt.Errorf("%s: error getting schedule metadata: %w", d.File, err)
+		}
+		if kept[info.ID] {
+			continue
+		}
+
+		log.Info().Str("scheduleID", info.ID).Str("file", d.File).Msg("Deleting schedule of removed definition")
+		if err := deleteOldSchedules(ctx, scheduleClient, info.ID); err != nil {
+			return fmt.Errorf("%s: %w", d.File, err)
+		}
+	}
+
+	return nil
+}
+
 func UpdateSchedules(ctx context.Context, temporalClient client.Client, workflow *model.Workflow, envvars map[string]any) error {
 	info, err := metadata.GetScheduleInfo(workflow, envvars)
 	if err != nil {
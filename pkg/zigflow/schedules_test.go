@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"go.temporal.io/sdk/client"
+)
+
+// schedulesClient gives access to the schedules held in memory
+type schedulesClient struct {
+	client.Client
+
+	schedules *scheduleClient
+}
+
+func (c *schedulesClient) ScheduleClient() client.ScheduleClient {
+	return c.schedules
+}
+
+type scheduleClient struct {
+	client.ScheduleClient
+
+	ids     []string
+	deleted []string
+}
+
+func (c *scheduleClient) List(context.Context, client.ScheduleListOptions) (client.ScheduleListIterator, error) {
+	return &scheduleIterator{ids: c.ids}, nil
+}
+
+func (c *scheduleClient) GetHandle(_ context.Context, id string) client.ScheduleHandle {
+	return &scheduleHandle{client: c, id: id}
+}
+
+type scheduleIterator struct {
+	ids []string
+}
+
+func (i *scheduleIterator) HasNext() bool {
+	return len(i.ids) > 0
+}
+
+func (i *scheduleIterator) Next() (*client.ScheduleListEntry, error) {
+	id := i.ids[0]
+	i.ids = i.ids[1:]
+
+	return &client.ScheduleListEntry{ID: id}, nil
+}
+
+type scheduleHandle struct {
+	client.ScheduleHandle
+
+	client *scheduleClient
+	id     string
+}
+
+func (h *scheduleHandle) Delete(context.Context) error {
+	h.client.deleted = append(h.client.deleted, h.id)
+	return nil
+}
+
+func scheduledYAML(name string) string {
+	return fmt.Sprintf(`document:
+  dsl: 1.0.0
+  namespace: queue
+  name: %s
+  version: 0.0.1
+schedule:
+  every:
+    minutes: 5
+do:
+  - step:
+      set:
+        hello: world
+`, name)
+}
+
+func TestDeleteRemovedSchedules(t *testing.T) {
+	previousDir := writeDefinitions(t, map[string]string{
+		"a.yaml": scheduledYAML("wf1"),
+		"b.yaml": scheduledYAML("wf2"),
+		"c.yaml": definitionYAML("queue", "wf3", "loop1"),
+	})
+	previous, err := zigflow.LoadDefinitions([]string{previousDir})
+	require.NoError(t, err)
+
+	// wf2's file is removed
+	nextDir := writeDefinitions(t, map[string]string{
+		"a.yaml": scheduledYAML("wf1"),
+		"c.yaml": definitionYAML("queue", "wf3", "loop1"),
+	})
+	next, err := zigflow.LoadDefinitions([]string{nextDir})
+	require.NoError(t, err)
+
+	c := &scheduleClient{ids: []string{"zigflow_wf1", "zigflow_wf2", "other"}}
+
+	require.NoError(t, zigflow.DeleteRemovedSchedules(
+		context.Background(), &schedulesClient{schedules: c}, previous, next, nil,
+	))
+
+	assert.Equal(t, []string{"zigflow_wf2"}, c.deleted)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+)
+
+// checkAuthentication returns the authentication policy for a call, or an
+// error if the activity would be unable to use it. Policies referenced by name
+// are looked up in the document's use.authentications.
+//
+// Only the reference is sent to the activity, which resolves the policy and
+// its secrets itself, so credentials never end up in the workflow history.
+func checkAuthentication(
+	doc *model.Workflow,
+	ref *model.ReferenceableAuthenticationPolicy,
+) (*model.AuthenticationPolicy, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	policy := ref.AuthenticationPolicy
+	if ref.Use != nil {
+		policy = nil
+		if doc != nil && doc.Use != nil {
+			policy = doc.Use.Authentications[*ref.Use]
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("authentication '%s' is not defined in use.authentications", *ref.Use)
+		}
+	} else if policy != nil && policy.OAuth2 != nil {
+		if e := policy.OAuth2.Endpoints; e != nil && e.Token != "" && e.Token != model.OAuth2DefaultTokenURI {
+			// The SDK drops the endpoints when the task is sent to the activity
+			return nil, fmt.Errorf("oauth2 authentication with endpoints must be declared in use.authentications")
+		}
+	}
+	if policy == nil {
+		return nil, nil
+	}
+
+	if name := activities.PolicySecret(policy); name != "" {
+		// The policy is read from the secret when the call is made
+		if doc == nil || doc.Use == nil || !slices.Contains(doc.Use.Secrets, name) {
+			return nil, fmt.Errorf("authentication secret %q is not declared in use.secrets", name)
+		}
+		return policy, nil
+	}
+
+	if _, err := activities.NewAuthentication(policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
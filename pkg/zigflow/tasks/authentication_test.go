@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/zigflow/zigflow/pkg/utils"
+)
+
+func TestCheckAuthentication(t *testing.T) {
+	doc := &model.Workflow{
+		Use: &model.Use{
+			Secrets: []string{"mySecret"},
+			Authentications: map[string]*model.AuthenticationPolicy{
+				"bearer": {Bearer: &model.BearerAuthenticationPolicy{Token: "${ $secrets.token }"}},
+				"oauth2": {OAuth2: &model.OAuth2AuthenticationPolicy{
+					Properties: &model.OAuth2AuthenticationProperties{
+						Authority: &model.LiteralUri{Value: "https://auth.example.com"},
+						Grant:     model.ClientCredentialsGrant,
+						Client:    &model.OAuth2AutenthicationDataClient{ID: "zigflow", Secret: "secret"},
+					},
+					Endpoints: &model.OAuth2Endpoints{Token: "/connect/token"},
+				}},
+				"authCode": {OAuth2: &model.OAuth2AuthenticationPolicy{
+					Properties: &model.OAuth2AuthenticationProperties{
+						Authority: &model.LiteralUri{Value: "https://auth.example.com"},
+						Grant:     model.AuthorizationCodeGrant,
+					},
+				}},
+				"secret":        {Basic: &model.BasicAuthenticationPolicy{Use: "mySecret"}},
+				"missingSecret": {OIDC: &model.OpenIdConnectAuthenticationPolicy{Use: "otherSecret"}},
+				"unknownClient": {OAuth2: &model.OAuth2AuthenticationPolicy{
+					Properties: &model.OAuth2AuthenticationProperties{
+						Authority: &model.LiteralUri{Value: "https://auth.example.com"},
+						Grant:     model.ClientCredentialsGrant,
+						Client:    &model.OAuth2AutenthicationDataClient{Authentication: model.OAuthClientAuthPrivateKeyJWT},
+					},
+				}},
+				"missingAuthority": {OAuth2: &model.OAuth2AuthenticationPolicy{
+					Properties: &model.OAuth2AuthenticationProperties{Grant: model.ClientCredentialsGrant},
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		ref           *model.ReferenceableAuthenticationPolicy
+		errorContains string
+	}{
+		{
+			name: "no authentication",
+		},
+		{
+			name: "inline basic",
+			ref: &model.ReferenceableAuthenticationPolicy{
+				AuthenticationPolicy: model.NewBasicAuth("user", "${ $secrets.password }"),
+			},
+		},
+		{
+			name: "bearer by name",
+			ref:  &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("bearer")},
+		},
+		{
+			name: "oauth2 by name",
+			ref:  &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("oauth2")},
+		},
+		{
+			name: "policy from a secret",
+			ref:  &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("secret")},
+		},
+		{
+			name:          "undefined policy",
+			ref:           &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("missing")},
+			errorContains: "authentication 'missing' is not defined in use.authentications",
+		},
+		{
+			name:          "unsupported grant",
+			ref:           &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("authCode")},
+			errorContains: "unsupported grant 'authorization_code'",
+		},
+		{
+			name:          "unsupported client authentication",
+			ref:           &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("unknownClient")},
+			errorContains: "unsupported client authentication 'private_key_jwt'",
+		},
+		{
+			name:          "missing authority",
+			ref:           &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("missingAuthority")},
+			errorContains: "authority is required",
+		},
+		{
+			name:          "undeclared secret",
+			ref:           &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("missingSecret")},
+			errorContains: `authentication secret "otherSecret" is not declared in use.secrets`,
+		},
+		{
+			name: "inline oidc",
+			ref: &model.ReferenceableAuthenticationPolicy{
+				AuthenticationPolicy: &model.AuthenticationPolicy{OIDC: &model.OpenIdConnectAuthenticationPolicy{}},
+			},
+			errorContains: "oidc authentication must be declared in use.authentications",
+		},
+		{
+			name: "inline oauth2 with endpoints",
+			ref: &model.ReferenceableAuthenticationPolicy{
+				AuthenticationPolicy: doc.Use.Authentications["oauth2"],
+			},
+			errorContains: "oauth2 authentication with endpoints must be declared in use.authentications",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := checkAuthentication(doc, tc.ref)
+			if tc.errorContains != "" {
+				assert.ErrorContains(t, err, tc.errorContains)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ref != nil, policy != nil)
+		})
+	}
+}
+
+func TestNewCallGRPCTaskBuilder_DigestAuthentication(t *testing.T) {
+	task := &model.CallGRPC{
+		Call: "grpc",
+		With: model.GRPCArguments{
+			Authentication: &model.ReferenceableAuthenticationPolicy{
+				AuthenticationPolicy: &model.AuthenticationPolicy{
+					Digest: &model.DigestAuthenticationPolicy{Username: "user", Password: "pass"},
+				},
+			},
+		},
+	}
+
+	_, err := NewCallGRPCTaskBuilder(nil, task, "grpc", testWorkflow, testEvents)
+	assert.ErrorContains(t, err, "digest authentication is not supported for grpc calls")
+}
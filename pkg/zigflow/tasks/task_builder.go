@@ -87,6 +87,16 @@ func (d *builder[T]) GetTaskName() string {
 	return d.name
 }
 
+// reference returns the JSON pointer of the task, such as /do/0/callApi, which
+// is used as the instance of its errors. Tasks that aren't declared in the
+// workflow use the workflow ID instead.
+func (d *builder[T]) reference(ctx workflow.Context) string {
+	if ref := utils.TaskReference(d.doc, d.task); ref != "" {
+		return ref
+	}
+	return workflow.GetInfo(ctx).WorkflowExecution.ID
+}
+
 // Some tasks should never be skipped when doing Continue-As-New
 func (d *builder[T]) NeverSkipCAN() bool {
 	return d.neverSkipCAN
@@ -130,24 +140,46 @@ func (d *builder[T]) ShouldRun(state *utils.State) (bool, error) {
 	return utils.CheckIfStatement(d.task.GetBase().If, state)
 }
 
-// Factory to create a TaskBuilder instance, or die trying
+// Factory to create a TaskBuilder instance, or die trying. Errors are located
+// at the task in the workflow file.
 func NewTaskBuilder(
 	taskName string,
 	task model.Task,
 	temporalWorker worker.Worker,
 	doc *model.Workflow,
 	emitter *cloudevents.Events,
+) (TaskBuilder, error) {
+	// Catch invalid retry metadata before the workflow runs
+	if _, err := metadata.RetryFor(doc, task.GetBase()); err != nil {
+		return nil, utils.NewSourceError(task, err)
+	}
+
+	b, err := newTaskBuilder(taskName, task, temporalWorker, doc, emitter)
+	if err != nil {
+		return nil, utils.NewSourceError(task, err)
+	}
+	return b, nil
+}
+
+func newTaskBuilder(
+	taskName string,
+	task model.Task,
+	temporalWorker worker.Worker,
+	doc *model.Workflow,
+	emitter *cloudevents.Events,
 ) (TaskBuilder, error) {
 	switch t := task.(type) {
 	case *model.CallFunction:
 		if t.Call == customCallFunctionActivity {
 			return NewCallActivityTaskBuilder(temporalWorker, t, taskName, doc, emitter)
 		}
-		return nil, fmt.Errorf("unsupported call type '%s' for task '%s'", t.Call, taskName)
+		return NewCallFunctionTaskBuilder(temporalWorker, t, taskName, doc, emitter)
 	case *model.CallGRPC:
 		return NewCallGRPCTaskBuilder(temporalWorker, t, taskName, doc, emitter)
 	case *model.CallHTTP:
 		return NewCallHTTPTaskBuilder(temporalWorker, t, taskName, doc, emitter)
+	case *model.CallOpenAPI:
+		return NewCallOpenAPITaskBuilder(temporalWorker, t, taskName, doc, emitter)
 	case *model.DoTask:
 		return NewDoTaskBuilder(temporalWorker, t, taskName, doc, emitter)
 	case *model.ForTask:
@@ -176,6 +208,7 @@ func NewTaskBuilder(
 // Ensure the tasks meets the TaskBuilder type
 var (
 	_ TaskBuilder = &CallActivityTaskBuilder{}
+	_ TaskBuilder = &CallFunctionTaskBuilder{}
 	_ TaskBuilder = &CallGRPCTaskBuilder{}
 	_ TaskBuilder = &CallHTTPTaskBuilder{}
 	_ TaskBuilder = &DoTaskBuilder{}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	swUtil "github.com/serverlessworkflow/sdk-go/v3/impl/utils"
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ResolveFunction returns the task that a custom function call runs. The
+// function's body is taken from the document's use.functions section and the
+// calling task's base (if, input, output, export, then and timeout) is applied
+// to it. Metadata is merged, with the calling task's keys taking precedence.
+func ResolveFunction(doc *model.Workflow, task *model.CallFunction) (model.Task, error) {
+	if strings.Contains(task.Call, "@") {
+		return nil, fmt.Errorf("function catalogs are not supported: %s", task.Call)
+	}
+
+	var fn model.Task
+	if doc != nil && doc.Use != nil {
+		fn = doc.Use.Functions[task.Call]
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("function '%s' is not defined in use.functions", task.Call)
+	}
+
+	switch f := fn.(type) {
+	case *model.CallGRPC, *model.CallHTTP, *model.CallOpenAPI, *model.RunTask:
+	case *model.CallFunction:
+		if f.Call != customCallFunctionActivity {
+			return nil, fmt.Errorf("function '%s' cannot call another function '%s'", task.Call, f.Call)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported function type '%T' for function '%s'", fn, task.Call)
+	}
+
+	// Clone the function so each call gets its own copy
+	payload, err := json.Marshal(map[string]any{task.Call: fn})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling function '%s': %w", task.Call, err)
+	}
+	var clone model.NamedTaskMap
+	if err := json.Unmarshal(payload, &clone); err != nil {
+		return nil, fmt.Errorf("error unmarshalling function '%s': %w", task.Call, err)
+	}
+	resolved := clone[task.Call]
+
+	base := resolved.GetBase()
+	meta := maps.Clone(base.Metadata)
+	if meta == nil {
+		meta = map[string]any{}
+	}
+	maps.Copy(meta, task.Metadata)
+
+	*base = task.TaskBase
+	base.Metadata = meta
+
+	return resolved, nil
+}
+
+func NewCallFunctionTaskBuilder(
+	temporalWorker worker.Worker,
+	task *model.CallFunction,
+	taskName string,
+	doc *model.Workflow,
+	emitter *cloudevents.Events,
+) (*CallFunctionTaskBuilder, error) {
+	fn, err := ResolveFunction(doc, task)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := newTaskBuilder(taskName, fn, temporalWorker, doc, emitter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CallFunctionTaskBuilder{
+		TaskBuilder: b,
+		call:        task,
+	}, nil
+}
+
+// CallFunctionTaskBuilder runs a function defined in use.functions. Everything
+// other than the arguments is delegated to the function's own task builder.
+type CallFunctionTaskBuilder struct {
+	TaskBuilder
+
+	call *model.CallFunction
+}
+
+func (t *CallFunctionTaskBuilder) Build() (TemporalWorkflowFunc, error) {
+	fn, err := t.TaskBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+		logger := workflow.GetLogger(ctx)
+
+		args, err := t.parseArgs(state)
+		if err != nil {
+			logger.Error("Error parsing function arguments", "function", t.call.Call, "error", err)
+			return nil, err
+		}
+
+		// Make the arguments available as $arguments for the duration of the call
+		previous := state.Arguments
+		state.Arguments = args
+		defer func() {
+			state.Arguments = previous
+		}()
+
+		logger.Debug("Calling function", "function", t.call.Call, "task", t.GetTaskName())
+
+		return fn(ctx, input, state)
+	}, nil
+}
+
+func (t *CallFunctionTaskBuilder) parseArgs(state *utils.State) (map[string]any, error) {
+	if len(t.call.With) == 0 {
+		return map[string]any{}, nil
+	}
+
+	parsed, err := utils.TraverseAndEvaluateObj(model.NewObjectOrRuntimeExpr(swUtil.DeepClone(t.call.With)), nil, state)
+	if err != nil {
+		return nil, fmt.Errorf("error interpolating function arguments: %w", err)
+	}
+
+	args, ok := parsed.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("function arguments must be an object")
+	}
+
+	return args, nil
+}
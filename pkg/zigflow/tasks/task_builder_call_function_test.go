@@ -0,0 +1,208 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func TestResolveFunction(t *testing.T) {
+	doc := &model.Workflow{
+		Use: &model.Use{
+			Functions: model.NamedTaskMap{
+				"getWeather": &model.CallHTTP{
+					TaskBase: model.TaskBase{
+						Metadata: map[string]any{
+							"activityOptions": map[string]any{"startToCloseTimeout": "10s"},
+							"heartbeat":       "5s",
+						},
+					},
+					Call: "http",
+					With: model.HTTPArguments{
+						Method:   "get",
+						Endpoint: model.NewEndpoint("${ \"https://weather.example.com/\" + $arguments.city }"),
+					},
+				},
+				"greet": &model.CallFunction{
+					Call: customCallFunctionActivity,
+					With: map[string]any{"name": "greet", "taskQueue": "greeter"},
+				},
+				"nested": &model.CallFunction{Call: "getWeather"},
+				"setter": &model.SetTask{Set: map[string]any{"a": 1}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		task          *model.CallFunction
+		expectedType  any
+		errorContains string
+	}{
+		{
+			name:         "http function",
+			task:         &model.CallFunction{Call: "getWeather"},
+			expectedType: &model.CallHTTP{},
+		},
+		{
+			name:         "activity function",
+			task:         &model.CallFunction{Call: "greet"},
+			expectedType: &model.CallFunction{},
+		},
+		{
+			name:          "undefined function",
+			task:          &model.CallFunction{Call: "getForecast"},
+			errorContains: "function 'getForecast' is not defined in use.functions",
+		},
+		{
+			name:          "catalog function",
+			task:          &model.CallFunction{Call: "getWeather:1.0.0@shared"},
+			errorContains: "function catalogs are not supported",
+		},
+		{
+			name:          "function calling a function",
+			task:          &model.CallFunction{Call: "nested"},
+			errorContains: "cannot call another function",
+		},
+		{
+			name:          "unsupported function type",
+			task:          &model.CallFunction{Call: "setter"},
+			errorContains: "unsupported function type",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fn, err := ResolveFunction(doc, tc.task)
+			if tc.errorContains != "" {
+				assert.ErrorContains(t, err, tc.errorContains)
+				assert.Nil(t, fn)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expectedType, fn)
+		})
+	}
+
+	t.Run("undefined use section", func(t *testing.T) {
+		_, err := ResolveFunction(&model.Workflow{}, &model.CallFunction{Call: "getWeather"})
+		assert.ErrorContains(t, err, "is not defined in use.functions")
+	})
+
+	t.Run("calling task base is applied", func(t *testing.T) {
+		task := &model.CallFunction{
+			TaskBase: model.TaskBase{
+				If: &model.RuntimeExpression{Value: "${ $input.enabled }"},
+				Metadata: map[string]any{
+					"activityOptions": map[string]any{"startToCloseTimeout": "1m"},
+				},
+			},
+			Call: "getWeather",
+		}
+
+		fn, err := ResolveFunction(doc, task)
+		require.NoError(t, err)
+
+		base := fn.GetBase()
+		assert.Equal(t, task.If, base.If)
+		assert.Equal(t, map[string]any{
+			"activityOptions": map[string]any{"startToCloseTimeout": "1m"},
+			"heartbeat":       "5s",
+		}, base.Metadata)
+
+		// The function in the document is left alone
+		assert.Nil(t, doc.Use.Functions["getWeather"].GetBase().If)
+		assert.Equal(t, "${ \"https://weather.example.com/\" + $arguments.city }",
+			fn.(*model.CallHTTP).With.Endpoint.String())
+	})
+}
+
+func TestCallFunctionTaskBuilderExecute(t *testing.T) {
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+
+	const activityName = "dslTestFunctionActivity"
+	env.RegisterActivityWithOptions(func(ctx context.Context, value string, times float64) (string, error) {
+		return value + "-" + time.Duration(times).String(), nil
+	}, activity.RegisterOptions{Name: activityName})
+
+	doc := &model.Workflow{
+		Use: &model.Use{
+			Functions: model.NamedTaskMap{
+				"process": &model.CallFunction{
+					Call: customCallFunctionActivity,
+					With: map[string]any{
+						"name":      activityName,
+						"arguments": []any{"${ $arguments.message }", "${ $arguments.times }"},
+						"taskQueue": "some-task-queue",
+					},
+				},
+			},
+		},
+	}
+
+	task := &model.CallFunction{
+		Call: "process",
+		With: map[string]any{
+			"message": "${ $input.message }",
+			"times":   3,
+		},
+	}
+
+	b, err := NewCallFunctionTaskBuilder(nil, task, "callFunction", doc, testEvents)
+	require.NoError(t, err)
+	assert.Equal(t, "callFunction", b.GetTaskName())
+
+	fn, err := b.Build()
+	require.NoError(t, err)
+
+	workflowFunc := func(ctx workflow.Context) (map[string]any, error) {
+		state := utils.NewState().AddWorkflowInfo(ctx)
+		input := map[string]any{"message": "ping"}
+		state.Input = input
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+		result, err := fn(ctx, input, state)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"result":    result,
+			"arguments": state.Arguments,
+			"data":      state.Data["callFunction"],
+		}, nil
+	}
+
+	env.ExecuteWorkflow(workflowFunc)
+
+	var got map[string]any
+	assert.NoError(t, env.GetWorkflowError())
+	assert.NoError(t, env.GetWorkflowResult(&got))
+	assert.Equal(t, "ping-3ns", got["result"])
+	assert.Equal(t, "ping-3ns", got["data"])
+	assert.Nil(t, got["arguments"])
+}
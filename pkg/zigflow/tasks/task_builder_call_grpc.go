@@ -17,6 +17,8 @@
 package tasks
 
 import (
+	"fmt"
+
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/zigflow/zigflow/pkg/cloudevents"
 	"github.com/zigflow/zigflow/pkg/utils"
@@ -32,6 +34,19 @@ func NewCallGRPCTaskBuilder(
 	doc *model.Workflow,
 	emitter *cloudevents.Events,
 ) (*CallGRPCTaskBuilder, error) {
+	ref := task.With.Authentication
+	if ref == nil {
+		ref = task.With.Service.Authentication
+	}
+
+	policy, err := checkAuthentication(doc, ref)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil && policy.Digest != nil {
+		return nil, fmt.Errorf("digest authentication is not supported for grpc calls")
+	}
+
 	return &CallGRPCTaskBuilder{
 		builder: builder[*model.CallGRPC]{
 			doc:            doc,
@@ -32,6 +32,15 @@ func NewCallHTTPTaskBuilder(
 	doc *model.Workflow,
 	emitter *cloudevents.Events,
 ) (*CallHTTPTaskBuilder, error) {
+	var ref *model.ReferenceableAuthenticationPolicy
+	if e := task.With.Endpoint; e != nil && e.EndpointConfig != nil {
+		ref = e.EndpointConfig.Authentication
+	}
+
+	if _, err := checkAuthentication(doc, ref); err != nil {
+		return nil, err
+	}
+
 	return &CallHTTPTaskBuilder{
 		builder: builder[*model.CallHTTP]{
 			doc:            doc,
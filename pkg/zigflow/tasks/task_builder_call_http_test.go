@@ -17,13 +17,31 @@
 package tasks
 
 import (
+	"context"
+	"crypto/md5" // #nosec G501 -- used to check digest authentication
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/secrets"
 	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
 )
 
 func TestParseHTTPArguments(t *testing.T) {
@@ -92,3 +110,545 @@ func TestParseOutput(t *testing.T) {
 		})
 	}
 }
+
+// tokenServer is a stand-in for an OAuth2/OIDC authorisation server. It issues
+// a new token for each request and counts how many it has issued.
+func tokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var issued atomic.Int32
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":         srv.URL,
+			"token_endpoint": srv.URL + "/protocol/token",
+		})
+	})
+
+	token := func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+
+		id, secret, ok := r.BasicAuth()
+		if !ok {
+			id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+		if id != "zigflow" || secret != "top-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		n := issued.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+			"scope":        r.PostForm.Get("scope"),
+		})
+	}
+	mux.HandleFunc("/oauth2/token", token)
+	mux.HandleFunc("/protocol/token", token)
+
+	t.Cleanup(srv.Close)
+
+	return srv, &issued
+}
+
+func TestCallHTTPActivityAuthentication(t *testing.T) {
+	// Accepts the most recent token issued
+	var wantToken atomic.Value
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/basic":
+			if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		case "/digest":
+			if !checkDigest(r, "user", "pass") {
+				w.Header().Set("WWW-Authenticate", `Digest realm="zigflow", nonce="abc123", qop="auth,auth-int", opaque="xyz"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		default:
+			if r.Header.Get("Authorization") != "Bearer "+wantToken.Load().(string) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	}))
+	t.Cleanup(api.Close)
+
+	oauth, oauthIssued := tokenServer(t, 3600)
+	oidc, oidcIssued := tokenServer(t, 3600)
+
+	t.Setenv(testSecretPrefix+"clientSecret", "top-secret")
+	t.Setenv(testSecretPrefix+"basicAuth", `{"username": "user", "password": "pass"}`)
+	t.Setenv(testSecretPrefix+"token", "secret-token")
+
+	state := utils.NewState()
+	state.Secrets = []string{"basicAuth", "clientSecret", "token"}
+
+	oauth2 := &model.AuthenticationPolicy{OAuth2: &model.OAuth2AuthenticationPolicy{
+		Properties: &model.OAuth2AuthenticationProperties{
+			Authority: &model.LiteralUri{Value: oauth.URL},
+			Grant:     model.ClientCredentialsGrant,
+			Client:    &model.OAuth2AutenthicationDataClient{ID: "zigflow", Secret: "${ $secrets.clientSecret }"},
+			Scopes:    []string{"read"},
+		},
+		Endpoints: &model.OAuth2Endpoints{Token: "/oauth2/token"},
+	}}
+
+	tests := []struct {
+		name    string
+		path    string
+		token   string
+		auth    *model.AuthenticationPolicy
+		wantErr bool
+	}{
+		{
+			name: "basic",
+			path: "/basic",
+			auth: model.NewBasicAuth("user", "pass"),
+		},
+		{
+			name:    "basic with the wrong password",
+			path:    "/basic",
+			auth:    model.NewBasicAuth("user", "nope"),
+			wantErr: true,
+		},
+		{
+			name: "basic from a secret",
+			path: "/basic",
+			auth: &model.AuthenticationPolicy{Basic: &model.BasicAuthenticationPolicy{Use: "basicAuth"}},
+		},
+		{
+			name:  "bearer",
+			path:  "/bearer",
+			token: "env-token",
+			auth:  &model.AuthenticationPolicy{Bearer: &model.BearerAuthenticationPolicy{Token: `${ "env-" + "token" }`}},
+		},
+		{
+			name:  "bearer from a secret",
+			path:  "/bearer",
+			token: "secret-token",
+			auth:  &model.AuthenticationPolicy{Bearer: &model.BearerAuthenticationPolicy{Use: "token"}},
+		},
+		{
+			name: "digest",
+			path: "/digest",
+			auth: &model.AuthenticationPolicy{Digest: &model.DigestAuthenticationPolicy{Username: "user", Password: "pass"}},
+		},
+		{
+			name:  "oauth2 client credentials",
+			path:  "/oauth2",
+			token: "token-1",
+			auth:  oauth2,
+		},
+		{
+			name:  "oidc with client secret basic",
+			path:  "/oidc",
+			token: "token-1",
+			auth: &model.AuthenticationPolicy{OIDC: &model.OpenIdConnectAuthenticationPolicy{
+				Properties: &model.OAuth2AuthenticationProperties{
+					Authority: &model.LiteralUri{Value: oidc.URL},
+					Grant:     model.ClientCredentialsGrant,
+					Client: &model.OAuth2AutenthicationDataClient{
+						ID:             "zigflow",
+						Secret:         "${ $secrets.clientSecret }",
+						Authentication: model.OAuthClientAuthClientSecretBasic,
+					},
+				},
+			}},
+		},
+		{
+			name: "oauth2 with the wrong secret",
+			path: "/oauth2",
+			auth: &model.AuthenticationPolicy{OAuth2: &model.OAuth2AuthenticationPolicy{
+				Properties: &model.OAuth2AuthenticationProperties{
+					Authority: &model.LiteralUri{Value: oauth.URL},
+					Grant:     model.ClientCredentialsGrant,
+					Client:    &model.OAuth2AutenthicationDataClient{ID: "zigflow", Secret: "wrong"},
+				},
+				Endpoints: &model.OAuth2Endpoints{Token: "/oauth2/token"},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wantToken.Store(tc.token)
+
+			_, err := executeCallHTTP(t, newCallHTTPEnvironment(t, tc.auth), api.URL+tc.path, state)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	// Each worker has its own tokens
+	env := newCallHTTPEnvironment(t, oauth2)
+
+	t.Run("tokens are cached", func(t *testing.T) {
+		wantToken.Store("token-2")
+
+		for range 2 {
+			_, err := executeCallHTTP(t, env, api.URL+"/oauth2", state)
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), oauthIssued.Load())
+		assert.Equal(t, int32(1), oidcIssued.Load())
+	})
+
+	t.Run("rejected tokens are replaced", func(t *testing.T) {
+		wantToken.Store("token-3")
+
+		_, err := executeCallHTTP(t, env, api.URL+"/oauth2", state)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), oauthIssued.Load())
+	})
+
+	t.Run("undefined policy", func(t *testing.T) {
+		var s testsuite.WorkflowTestSuite
+		env := s.NewTestActivityEnvironment()
+		env.RegisterActivity(&activities.CallHTTP{})
+
+		_, err := env.ExecuteActivity("CallHTTPActivity", authenticatedTask(api.URL), nil, state)
+		assert.ErrorContains(t, err, "authentication 'api' is not defined in use.authentications")
+	})
+}
+
+func TestCallHTTPActivityAuthentication_ExpiredToken(t *testing.T) {
+	// Tokens that expire within the expiry delta are never reused
+	oauth, issued := tokenServer(t, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer token-"))
+	}))
+	t.Cleanup(api.Close)
+
+	auth := &model.AuthenticationPolicy{OAuth2: &model.OAuth2AuthenticationPolicy{
+		Properties: &model.OAuth2AuthenticationProperties{
+			Authority: &model.LiteralUri{Value: oauth.URL},
+			Grant:     model.ClientCredentialsGrant,
+			Client:    &model.OAuth2AutenthicationDataClient{ID: "zigflow", Secret: "top-secret"},
+			Request:   &model.OAuth2TokenRequest{Encoding: model.EncodingTypeFormUrlEncoded},
+		},
+		Endpoints: &model.OAuth2Endpoints{Token: oauth.URL + "/oauth2/token"},
+	}}
+
+	env := newCallHTTPEnvironment(t, auth)
+	for range 2 {
+		_, err := executeCallHTTP(t, env, api.URL, utils.NewState())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), issued.Load())
+}
+
+func TestCallHTTPActivityAuthentication_ConcurrentCalls(t *testing.T) {
+	// Calls made while a token is being requested wait for it
+	oauth, issued := tokenServer(t, 3600)
+	token := oauth.Config.Handler
+	oauth.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		token.ServeHTTP(w, r)
+	})
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(api.Close)
+
+	auth := &model.AuthenticationPolicy{OAuth2: &model.OAuth2AuthenticationPolicy{
+		Properties: &model.OAuth2AuthenticationProperties{
+			Authority: &model.LiteralUri{Value: oauth.URL},
+			Grant:     model.ClientCredentialsGrant,
+			Client:    &model.OAuth2AutenthicationDataClient{ID: "zigflow", Secret: "top-secret"},
+		},
+		Endpoints: &model.OAuth2Endpoints{Token: "/oauth2/token"},
+	}}
+
+	// The activities share the worker's background context
+	workerCtx := activities.WithAuthentications(context.Background(), map[string]*model.AuthenticationPolicy{"api": auth})
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.SetWorkerOptions(worker.Options{BackgroundActivityContext: workerCtx})
+			env.RegisterActivity(&activities.CallHTTP{})
+
+			_, err := executeCallHTTP(t, env, api.URL, utils.NewState())
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), issued.Load())
+}
+
+func TestCallHTTPTaskBuilder_AuthenticationHistory(t *testing.T) {
+	// #nosec G101 -- test credential
+	const password = "history-password"
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "user" || p != password {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	policies := map[string]*model.AuthenticationPolicy{"api": model.NewBasicAuth("user", password)}
+	doc := &model.Workflow{
+		Document: testWorkflow.Document,
+		Use:      &model.Use{Authentications: policies},
+	}
+
+	builder, err := NewCallHTTPTaskBuilder(nil, authenticatedTask(api.URL), "call", doc, testEvents)
+	require.NoError(t, err)
+	wf, err := builder.Build()
+	require.NoError(t, err)
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: activities.WithAuthentications(context.Background(), policies),
+	})
+	env.RegisterActivity(&activities.CallHTTP{})
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+		ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+		return wf(ctx, nil, utils.NewState())
+	}, workflow.RegisterOptions{Name: "authentication"})
+
+	var args []byte
+	env.SetOnActivityStartedListener(func(_ *activity.Info, _ context.Context, a converter.EncodedValues) {
+		var task, input, state any
+		require.NoError(t, a.Get(&task, &input, &state))
+		args, err = json.Marshal([]any{task, input, state})
+		require.NoError(t, err)
+	})
+
+	env.ExecuteWorkflow("authentication")
+	require.NoError(t, env.GetWorkflowError())
+
+	// Only the name of the policy is sent to the activity
+	assert.Contains(t, string(args), `"authentication":{"use":"api"}`)
+	assert.NotContains(t, string(args), password)
+}
+
+func TestCallHTTPActivitySecrets(t *testing.T) {
+	t.Setenv("ZIGFLOW_TEST_SECRET_apiKey", "s3cret")
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "s3cret", r.Header.Get("X-Api-Key"))
+	}))
+	t.Cleanup(api.Close)
+
+	tests := []struct {
+		Name     string
+		Provider secrets.Provider
+		Declared []string
+		Error    string
+	}{
+		{
+			Name:     "declared secret",
+			Provider: &secrets.EnvProvider{Prefix: "ZIGFLOW_TEST_SECRET_"},
+			Declared: []string{"apiKey"},
+		},
+		{
+			Name:     "undeclared secret",
+			Provider: &secrets.EnvProvider{Prefix: "ZIGFLOW_TEST_SECRET_"},
+			Error:    `secret "apiKey" is not declared in use.secrets`,
+		},
+		{
+			Name:     "missing secret",
+			Provider: &secrets.EnvProvider{Prefix: "ZIGFLOW_MISSING_SECRET_"},
+			Declared: []string{"apiKey"},
+			Error:    "secret not found: apiKey",
+		},
+		{
+			Name:     "no provider",
+			Declared: []string{"apiKey"},
+			Error:    "$secrets is only available to tasks that run as activities",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&activities.CallHTTP{})
+			if test.Provider != nil {
+				env.SetWorkerOptions(worker.Options{
+					BackgroundActivityContext: secrets.WithProvider(context.Background(), test.Provider),
+				})
+			}
+
+			task := &model.CallHTTP{
+				Call: "http",
+				With: model.HTTPArguments{
+					Method:   "GET",
+					Endpoint: model.NewEndpoint(api.URL),
+					Headers: map[string]string{
+						"X-Api-Key": "${ $secrets.apiKey }",
+					},
+					Output: "response",
+				},
+			}
+
+			state := utils.NewState()
+			state.Secrets = test.Declared
+
+			val, err := env.ExecuteActivity("CallHTTPActivity", task, nil, state)
+			if test.Error != "" {
+				assert.ErrorContains(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+
+			var res activities.HTTPResponse
+			require.NoError(t, val.Get(&res))
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			// The value must not be returned to the workflow
+			assert.Equal(t, "********", res.Request.Headers["X-Api-Key"])
+		})
+	}
+}
+
+func TestCallHTTPActivitySecrets_RequestError(t *testing.T) {
+	t.Setenv(testSecretPrefix+"apiKey", "s3cret/key+1")
+
+	// Nothing is listening, so the request fails with an error that has the URL
+	api := httptest.NewServer(http.NotFoundHandler())
+	api.Close()
+
+	tests := []struct {
+		Name     string
+		Endpoint string
+		Query    map[string]any
+	}{
+		{
+			Name:     "secret in the endpoint",
+			Endpoint: `${ "` + api.URL + `/?key=" + $secrets.apiKey }`,
+		},
+		{
+			Name:     "secret in the query",
+			Endpoint: api.URL,
+			Query:    map[string]any{"key": "${ $secrets.apiKey }"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&activities.CallHTTP{})
+			env.SetWorkerOptions(worker.Options{
+				BackgroundActivityContext: secrets.WithProvider(
+					context.Background(), &secrets.EnvProvider{Prefix: testSecretPrefix},
+				),
+			})
+
+			task := &model.CallHTTP{
+				Call: "http",
+				With: model.HTTPArguments{
+					Method:   "GET",
+					Endpoint: model.NewEndpoint(test.Endpoint),
+					Query:    test.Query,
+				},
+			}
+
+			state := utils.NewState()
+			state.Secrets = []string{"apiKey"}
+
+			_, err := env.ExecuteActivity("CallHTTPActivity", task, nil, state)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "********")
+			assert.NotContains(t, err.Error(), "s3cret")
+		})
+	}
+}
+
+// testSecretPrefix is the prefix of the environment variables that secrets
+// are read from in the activity tests
+const testSecretPrefix = "ZIGFLOW_TEST_SECRET_"
+
+// authenticatedTask calls the URL using the "api" authentication policy
+func authenticatedTask(url string) *model.CallHTTP {
+	return &model.CallHTTP{
+		Call: "http",
+		With: model.HTTPArguments{
+			Method: "GET",
+			Endpoint: &model.Endpoint{
+				EndpointConfig: &model.EndpointConfiguration{
+					URI:            &model.LiteralUri{Value: url},
+					Authentication: &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("api")},
+				},
+			},
+		},
+	}
+}
+
+// newCallHTTPEnvironment returns an activity environment for a worker with the
+// auth policy named "api"
+func newCallHTTPEnvironment(t *testing.T, auth *model.AuthenticationPolicy) *testsuite.TestActivityEnvironment {
+	t.Helper()
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: activities.WithAuthentications(
+			secrets.WithProvider(context.Background(), &secrets.EnvProvider{Prefix: testSecretPrefix}),
+			map[string]*model.AuthenticationPolicy{"api": auth},
+		),
+	})
+	env.RegisterActivity(&activities.CallHTTP{})
+
+	return env
+}
+
+func executeCallHTTP(t *testing.T, env *testsuite.TestActivityEnvironment, url string, state *utils.State) (any, error) {
+	t.Helper()
+
+	val, err := env.ExecuteActivity("CallHTTPActivity", authenticatedTask(url), nil, state)
+	if err != nil {
+		return nil, err
+	}
+
+	var res any
+	return res, val.Get(&res)
+}
+
+// checkDigest validates a digest response using qop=auth and MD5
+func checkDigest(r *http.Request, username, password string) bool {
+	header, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Digest ")
+	if !ok {
+		return false
+	}
+
+	params := map[string]string{}
+	for field := range strings.SplitSeq(header, ", ") {
+		k, v, _ := strings.Cut(field, "=")
+		params[k] = strings.Trim(v, `"`)
+	}
+
+	h := func(s string) string {
+		sum := md5.Sum([]byte(s)) // #nosec G401 -- used to check digest authentication
+		return hex.EncodeToString(sum[:])
+	}
+
+	ha1 := h(username + ":zigflow:" + password)
+	ha2 := h(r.Method + ":" + params["uri"])
+	want := h(strings.Join([]string{ha1, "abc123", params["nc"], params["cnonce"], "auth", ha2}, ":"))
+
+	return params["username"] == username &&
+		params["uri"] == r.URL.RequestURI() &&
+		params["qop"] == "auth" &&
+		params["opaque"] == "xyz" &&
+		params["response"] == want
+}
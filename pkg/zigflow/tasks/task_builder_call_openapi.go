@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/cloudevents"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func NewCallOpenAPITaskBuilder(
+	temporalWorker worker.Worker,
+	task *model.CallOpenAPI,
+	taskName string,
+	doc *model.Workflow,
+	emitter *cloudevents.Events,
+) (*CallOpenAPITaskBuilder, error) {
+	if _, err := checkAuthentication(doc, task.With.Authentication); err != nil {
+		return nil, err
+	}
+
+	// The document can be downloaded with its own authentication
+	if d := task.With.Document; d != nil && d.Endpoint != nil && d.Endpoint.EndpointConfig != nil {
+		if _, err := checkAuthentication(doc, d.Endpoint.EndpointConfig.Authentication); err != nil {
+			return nil, err
+		}
+	}
+
+	return &CallOpenAPITaskBuilder{
+		builder: builder[*model.CallOpenAPI]{
+			doc:            doc,
+			eventEmitter:   emitter,
+			name:           taskName,
+			task:           task,
+			temporalWorker: temporalWorker,
+		},
+	}, nil
+}
+
+type CallOpenAPITaskBuilder struct {
+	builder[*model.CallOpenAPI]
+}
+
+func (t *CallOpenAPITaskBuilder) Build() (TemporalWorkflowFunc, error) {
+	return func(ctx workflow.Context, input any, state *utils.State) (any, error) {
+		return t.executeActivity(ctx, (*activities.CallOpenAPI).CallOpenAPIActivity, input, state)
+	}, nil
+}
@@ -0,0 +1,316 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/secrets"
+	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+const petstoreDocument = `openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+servers:
+  - url: /{version}
+    variables:
+      version:
+        default: v1
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: "#/components/parameters/petId"
+    get:
+      operationId: getPet
+      parameters:
+        - name: fields
+          in: query
+        - name: X-Request-Id
+          in: header
+          required: true
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json: {}
+components:
+  parameters:
+    petId:
+      name: petId
+      in: path
+      required: true
+`
+
+func petstoreServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer docs-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(petstoreDocument))
+	})
+	mux.HandleFunc("GET /v1/pets/{petId}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":        r.PathValue("petId"),
+			"fields":    r.URL.Query().Get("fields"),
+			"requestId": r.Header.Get("X-Request-Id"),
+		})
+	})
+	mux.HandleFunc("POST /v1/pets", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		_, _ = w.Write(body)
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func executeCallOpenAPI(t *testing.T, task *model.CallOpenAPI) (any, error) {
+	t.Helper()
+
+	t.Setenv(testSecretPrefix+"token", "secret-token")
+
+	state := utils.NewState()
+	state.Secrets = []string{"token"}
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: activities.WithAuthentications(
+			secrets.WithProvider(context.Background(), &secrets.EnvProvider{Prefix: testSecretPrefix}),
+			map[string]*model.AuthenticationPolicy{
+				"api":  {Bearer: &model.BearerAuthenticationPolicy{Use: "token"}},
+				"docs": {Bearer: &model.BearerAuthenticationPolicy{Token: "docs-token"}},
+			},
+		),
+	})
+	env.RegisterActivity(&activities.CallOpenAPI{})
+
+	val, err := env.ExecuteActivity("CallOpenAPIActivity", task, nil, state)
+	if err != nil {
+		return nil, err
+	}
+
+	var res any
+	return res, val.Get(&res)
+}
+
+func TestCallOpenAPIActivity(t *testing.T) {
+	api := petstoreServer(t)
+
+	document := &model.ExternalResource{
+		Endpoint: &model.Endpoint{
+			EndpointConfig: &model.EndpointConfiguration{
+				URI:            &model.LiteralUri{Value: api.URL + "/openapi.yaml"},
+				Authentication: &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("docs")},
+			},
+		},
+	}
+	auth := &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("api")}
+
+	tests := []struct {
+		name      string
+		args      model.OpenAPIArguments
+		expected  any
+		expectErr string
+	}{
+		{
+			name: "path, query and header parameters",
+			args: model.OpenAPIArguments{
+				OperationID: "getPet",
+				Parameters: map[string]any{
+					"petId":        `${ "pet-" + "1" }`,
+					"fields":       []any{"name", "age"},
+					"X-Request-Id": 123,
+				},
+				Authentication: auth,
+			},
+			expected: map[string]any{"id": "pet-1", "fields": "name,age", "requestId": "123"},
+		},
+		{
+			name: "request body",
+			args: model.OpenAPIArguments{
+				OperationID: "createPet",
+				Parameters:  map[string]any{"body": map[string]any{"name": "Rex"}},
+			},
+			expected: map[string]any{"name": "Rex"},
+		},
+		{
+			name: "unauthenticated",
+			args: model.OpenAPIArguments{
+				OperationID: "getPet",
+				Parameters:  map[string]any{"petId": "1", "X-Request-Id": "1"},
+			},
+			expectErr: "CallHTTP returned 4xx status code",
+		},
+		{
+			name: "unknown operation",
+			args: model.OpenAPIArguments{
+				OperationID: "deletePet",
+			},
+			expectErr: "operation 'deletePet' not found",
+		},
+		{
+			name: "missing required parameter",
+			args: model.OpenAPIArguments{
+				OperationID: "getPet",
+				Parameters:  map[string]any{"petId": "1"},
+			},
+			expectErr: "required header parameter 'X-Request-Id' is not set",
+		},
+		{
+			name: "unknown parameter",
+			args: model.OpenAPIArguments{
+				OperationID: "createPet",
+				Parameters:  map[string]any{"body": map[string]any{}, "name": "Rex"},
+			},
+			expectErr: "'name' is not a parameter of operation 'createPet'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.args.Document = document
+
+			res, err := executeCallOpenAPI(t, &model.CallOpenAPI{Call: "openapi", With: tc.args})
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res)
+		})
+	}
+}
+
+func TestCallOpenAPIActivity_Swagger(t *testing.T) {
+	api := petstoreServer(t)
+
+	// Swagger 2 documents set the host instead of the servers
+	file := filepath.Join(t.TempDir(), "swagger.json")
+	doc, err := json.Marshal(map[string]any{
+		"swagger":  "2.0",
+		"host":     api.Listener.Addr().String(),
+		"basePath": "/v1",
+		"schemes":  []string{"http"},
+		"paths": map[string]any{
+			"/pets": map[string]any{
+				"post": map[string]any{
+					"operationId": "createPet",
+					"parameters":  []any{map[string]any{"name": "pet", "in": "body", "required": true}},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, doc, 0o600))
+
+	res, err := executeCallOpenAPI(t, &model.CallOpenAPI{
+		Call: "openapi",
+		With: model.OpenAPIArguments{
+			Document:    &model.ExternalResource{Endpoint: model.NewEndpoint("file://" + file)},
+			OperationID: "createPet",
+			Parameters:  map[string]any{"pet": map[string]any{"name": "Rex"}},
+			Output:      "response",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Rex"}, res.(map[string]any)["content"])
+	assert.Equal(t, "POST", res.(map[string]any)["request"].(map[string]any)["method"])
+}
+
+func TestNewCallOpenAPITaskBuilder(t *testing.T) {
+	doc := &model.Workflow{
+		Document: testWorkflow.Document,
+		Use: &model.Use{
+			Authentications: map[string]*model.AuthenticationPolicy{"api": model.NewBasicAuth("user", "pass")},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		args      model.OpenAPIArguments
+		expectErr string
+	}{
+		{
+			name: "defined authentication",
+			args: model.OpenAPIArguments{
+				Document:       &model.ExternalResource{Endpoint: model.NewEndpoint("https://example.com/openapi.yaml")},
+				Authentication: &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("api")},
+			},
+		},
+		{
+			name: "undefined authentication",
+			args: model.OpenAPIArguments{
+				Document:       &model.ExternalResource{Endpoint: model.NewEndpoint("https://example.com/openapi.yaml")},
+				Authentication: &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("missing")},
+			},
+			expectErr: "authentication 'missing' is not defined in use.authentications",
+		},
+		{
+			name: "undefined document authentication",
+			args: model.OpenAPIArguments{
+				Document: &model.ExternalResource{Endpoint: &model.Endpoint{
+					EndpointConfig: &model.EndpointConfiguration{
+						URI:            &model.LiteralUri{Value: "https://example.com/openapi.yaml"},
+						Authentication: &model.ReferenceableAuthenticationPolicy{Use: utils.Ptr("docs")},
+					},
+				}},
+			},
+			expectErr: "authentication 'docs' is not defined in use.authentications",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.args.OperationID = "getPet"
+
+			_, err := NewCallOpenAPITaskBuilder(nil, &model.CallOpenAPI{Call: "openapi", With: tc.args}, "openapi", doc, testEvents)
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
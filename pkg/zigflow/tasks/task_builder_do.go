@@ -37,6 +37,7 @@ type DoTaskOpts struct {
 	DisableRegisterWorkflow bool
 	Envvars                 map[string]any
 	MaxHistoryLength        int
+	ReportFaultedTask       bool
 	Telemetry               *telemetry.Telemetry
 	Validator               *utils.Validator
 }
@@ -109,7 +110,7 @@ func (t *DoTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 		l.Debug().Msg("Building task")
 		fn, err := builder.Build()
 		if err != nil {
-			return nil, fmt.Errorf("error building task: %w", err)
+			return nil, fmt.Errorf("error building task: %w", utils.NewSourceError(task.Task, err))
 		}
 		if fn != nil && addTasks {
 			l.Debug().Msg("Adding task to workflow")
@@ -137,6 +138,11 @@ func (t *DoTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 }
 
 func (t *DoTaskBuilder) PostLoad() error {
+	if t.task.Do == nil {
+		// Reported by the validator
+		return nil
+	}
+
 	for _, task := range *t.task.Do {
 		l := log.With().Str("task", task.Key).Logger()
 
@@ -150,7 +156,7 @@ func (t *DoTaskBuilder) PostLoad() error {
 		// Run the postload task
 		l.Debug().Msg("Run post load task")
 		if err := builder.PostLoad(); err != nil {
-			return fmt.Errorf("error running task post load: %w", err)
+			return fmt.Errorf("error running task post load: %w", utils.NewSourceError(task.Task, err))
 		}
 	}
 	return nil
@@ -195,6 +201,9 @@ func (t *DoTaskBuilder) workflowExecutor(tasks []workflowFunc) TemporalWorkflowF
 			state = utils.NewState().AddWorkflowInfo(ctx)
 			state.Env = t.opts.Envvars
 			state.Input = input
+			if t.doc.Use != nil {
+				state.Secrets = t.doc.Use.Secrets
+			}
 
 			// Validate input for the whole document
 			logger.Debug("Validating input against document")
@@ -214,6 +223,12 @@ func (t *DoTaskBuilder) workflowExecutor(tasks []workflowFunc) TemporalWorkflowF
 
 		// Iterate through the tasks to create the workflow
 		if err := t.iterateTasks(ctx, tasks, input, state); err != nil {
+			// A raised error that isn't caught ends the workflow as it was raised
+			if workflow.GetInfo(ctx).ParentWorkflowExecution == nil {
+				if raised := raisedError(err); raised != nil {
+					return nil, raised
+				}
+			}
 			return nil, err
 		}
 
@@ -287,7 +302,7 @@ func (t *DoTaskBuilder) iterateTasks(
 		logger.Debug("Check if task should be run", "task", task.Name)
 		if toRun, err := task.ShouldRun(state); err != nil {
 			logger.Error("Error checking if statement", "error", err, "name", task.Name)
-			return err
+			return t.taskError(task, err)
 		} else if !toRun {
 			logger.Debug("Skipping task as if statement resolve as false", "name", task.Name)
 			continue
@@ -297,23 +312,23 @@ func (t *DoTaskBuilder) iterateTasks(
 		logger.Debug("Validating input against task", "name", task.Name)
 		if err := t.validateInput(ctx, taskBase.Input, state); err != nil {
 			logger.Debug("Task input validation error", "error", err)
-			return err
+			return t.taskError(task, err)
 		}
 
 		logger.Debug("Parse metadata", "name", task.Name)
 		if err := task.ParseMetadata(ctx, state); err != nil {
 			logger.Error("Error parsing metadata", "error", err)
-			return err
+			return t.taskError(task, err)
 		}
 
 		if wCtx, err := metadata.SetActivityOptions(ctx, t.doc, taskBase, task.Name); err != nil {
-			return err
+			return t.taskError(task, err)
 		} else {
 			ctx = wCtx
 		}
 
 		if err := t.runTask(ctx, task, input, state); err != nil {
-			return err
+			return t.taskError(task, err)
 		}
 
 		next, terminate := t.handleFlowDirective(ctx, taskBase)
@@ -334,6 +349,15 @@ func (t *DoTaskBuilder) iterateTasks(
 	return nil
 }
 
+// taskError adds the task's reference to the error if the workflow reports
+// the faulted task. A try task uses it as the caught error's instance.
+func (t *DoTaskBuilder) taskError(task workflowFunc, err error) error {
+	if !t.opts.ReportFaultedTask {
+		return err
+	}
+	return withFaultedTask(utils.TaskReference(t.doc, task.GetTask()), err)
+}
+
 func (t *DoTaskBuilder) handleFlowDirective(
 	ctx workflow.Context, taskBase *model.TaskBase,
 ) (next *string, terminate bool) {
@@ -407,9 +431,10 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 		e.SetID(workflowID)
 		e.SetSubject(task.Name)
 		_ = e.SetData(ceSDK.ApplicationJSON, map[string]any{
-			"attempt": info.Attempt,
-			"input":   input,
-			"state":   state,
+			"attempt":      info.Attempt,
+			"input":        input,
+			"state":        state,
+			"workflowTime": workflow.Now(ctx),
 		})
 	})
 
@@ -431,6 +456,9 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 			t.eventEmitter.Emit(cctx, "task.cancelled", func(e *ceSDK.Event) {
 				e.SetID(workflowID)
 				e.SetSubject(task.Name)
+				_ = e.SetData(ceSDK.ApplicationJSON, map[string]any{
+					"workflowTime": workflow.Now(ctx),
+				})
 			})
 			return nil
 		}
@@ -439,7 +467,8 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 			e.SetID(workflowID)
 			e.SetSubject(task.Name)
 			_ = e.SetData(ceSDK.ApplicationJSON, map[string]any{
-				"error": err.Error(),
+				"error":        err.Error(),
+				"workflowTime": workflow.Now(ctx),
 			})
 		})
 
@@ -461,9 +490,10 @@ func (t *DoTaskBuilder) runTask(ctx workflow.Context, task workflowFunc, input a
 		e.SetID(workflowID)
 		e.SetSubject(task.Name)
 		_ = e.SetData(ceSDK.ApplicationJSON, map[string]any{
-			"input":  input,
-			"output": output,
-			"state":  state,
+			"input":        input,
+			"output":       output,
+			"state":        state,
+			"workflowTime": workflow.Now(ctx),
 		})
 	})
 
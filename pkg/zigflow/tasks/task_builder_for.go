@@ -26,6 +26,7 @@ import (
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/zigflow/zigflow/pkg/cloudevents"
 	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
 	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
@@ -233,9 +234,12 @@ func (t *ForTaskBuilder) iterator(ctx workflow.Context, key, value any, state *u
 	}
 
 	// Run the tasks
-	opts := workflow.ChildWorkflowOptions{
+	opts, err := metadata.ChildWorkflowOptionsFor(t.doc, t.task.GetBase(), workflow.ChildWorkflowOptions{
 		// key may be an integer or a string - use %v to let Go figure out how to represent it
 		WorkflowID: fmt.Sprintf("%s_for_%v", workflow.GetInfo(ctx).WorkflowExecution.ID, key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error setting for workflow options: %w", err)
 	}
 	childCtx := workflow.WithChildOptions(ctx, opts)
 
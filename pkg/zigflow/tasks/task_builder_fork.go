@@ -24,6 +24,7 @@ import (
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/zigflow/zigflow/pkg/cloudevents"
 	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
@@ -159,8 +160,11 @@ func (t *ForkTaskBuilder) exec(forkedTasks []*forkedTask) (TemporalWorkflowFunc,
 
 		// Run the child workflows in parallel
 		for _, branch := range forkedTasks {
-			opts := workflow.ChildWorkflowOptions{
+			opts, err := metadata.ChildWorkflowOptionsFor(t.doc, t.task.GetBase(), workflow.ChildWorkflowOptions{
 				WorkflowID: fmt.Sprintf("%s_fork_%s", workflow.GetInfo(ctx).WorkflowExecution.ID, branch.task.Key),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error setting fork workflow options: %w", err)
 			}
 			if isCompeting {
 				// Allow cancellation of children
@@ -38,6 +38,10 @@ const (
 	ListenTaskTypeUpdate ListenTaskType = "update"
 )
 
+// listenAnyCompleteChangeID versions the "any" strategy finishing as soon as
+// one listener completes
+const listenAnyCompleteChangeID = "listen-any-complete"
+
 func NewListenTaskBuilder(
 	temporalWorker worker.Worker,
 	task *model.ListenTask,
@@ -125,8 +129,17 @@ func (t *ListenTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 			}
 		}
 
+		anyComplete := &areAnyComplete
+		if !isAll && workflow.GetVersion(ctx, listenAnyCompleteChangeID, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+			// Workflows started before this change only saw the listeners'
+			// state when the wait began, so finished on cancellation or
+			// timeout. Keep that when they replay.
+			started := areAnyComplete
+			anyComplete = &started
+		}
+
 		if await {
-			if err := t.await(ctx, timeout, isAll, areAnyComplete, areAllComplete); err != nil {
+			if err := t.await(ctx, timeout, isAll, anyComplete, areAllComplete); err != nil {
 				return nil, err
 			}
 		}
@@ -136,7 +149,7 @@ func (t *ListenTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 }
 
 func (t *ListenTaskBuilder) await(
-	ctx workflow.Context, timeout time.Duration, isAll, areAnyComplete bool, areAllComplete []bool,
+	ctx workflow.Context, timeout time.Duration, isAll bool, areAnyComplete *bool, areAllComplete []bool,
 ) error {
 	logger := workflow.GetLogger(ctx)
 
@@ -150,8 +163,9 @@ func (t *ListenTaskBuilder) await(
 			logger.Debug("Waiting for all listeners to complete", "status", areAllComplete)
 			return utils.SlicesEqual(areAllComplete, true)
 		} else {
-			logger.Debug("Waiting for first listening to complete", "state", areAnyComplete)
-			return areAnyComplete
+			// Read through the pointer as the listeners complete after this is called
+			logger.Debug("Waiting for first listening to complete", "state", *areAnyComplete)
+			return *areAnyComplete
 		}
 	})
 	if err != nil {
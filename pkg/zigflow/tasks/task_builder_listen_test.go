@@ -18,6 +18,7 @@ package tasks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
@@ -169,3 +170,78 @@ func TestListenTaskBuilderProcessReply(t *testing.T) {
 
 	assert.Equal(t, map[string]any{"result": "hello"}, result)
 }
+
+func TestListenTaskBuilderAnyCompletesWhileWaiting(t *testing.T) {
+	newEvent := func(id string) *model.EventFilter {
+		return &model.EventFilter{
+			With: &model.EventProperties{
+				ID:   id,
+				Type: string(ListenTaskTypeSignal),
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		version   workflow.Version
+		expectErr string
+		duration  time.Duration
+	}{
+		{
+			name:     "completes when a listener completes",
+			version:  1,
+			duration: 30 * time.Second,
+		},
+		{
+			name:      "workflows started before the change wait for the timeout",
+			version:   workflow.DefaultVersion,
+			expectErr: "timeout",
+			duration:  time.Minute,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			builder, err := NewListenTaskBuilder(nil, &model.ListenTask{
+				Listen: model.ListenTaskConfiguration{
+					To: &model.EventConsumptionStrategy{
+						Any: []*model.EventFilter{newEvent("approve"), newEvent("reject")},
+					},
+				},
+			}, "decision", nil, nil)
+			assert.NoError(t, err)
+
+			wf, err := builder.Build()
+			assert.NoError(t, err)
+
+			state := utils.NewState()
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+
+			start := time.Now().UTC()
+			env.SetStartTime(start)
+
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return wf(ctx, nil, state)
+			}, workflow.RegisterOptions{Name: "listen-any"})
+			env.OnGetVersion(listenAnyCompleteChangeID, workflow.DefaultVersion, 1).Return(tc.version)
+
+			// The listener completes after the task has started waiting
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow("reject", "rejected")
+			}, 30*time.Second)
+
+			env.ExecuteWorkflow("listen-any")
+
+			assert.True(t, env.IsWorkflowCompleted())
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, env.GetWorkflowError(), tc.expectErr)
+			} else {
+				assert.NoError(t, env.GetWorkflowError())
+			}
+			assert.Equal(t, "rejected", state.Data["decision"])
+			assert.True(t, env.Now().UTC().Equal(start.Add(tc.duration)))
+		})
+	}
+}
@@ -18,6 +18,7 @@ package tasks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/serverlessworkflow/sdk-go/v3/impl/expr"
@@ -85,7 +86,7 @@ func (t *RaiseTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 
 		gtx := context.Background()
 		info := workflow.GetInfo(ctx)
-		instanceID := info.WorkflowExecution.ID
+		instance := t.reference(ctx)
 
 		var raiseErr *model.Error
 		var titleResult any = ""
@@ -121,21 +122,46 @@ func (t *RaiseTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 			}
 
 			if raiseErrF, ok := raiseErrFuncMapping[definition.Type.String()]; ok {
-				raiseErr = raiseErrF(fmt.Errorf("%v", detailResult), instanceID)
+				raiseErr = raiseErrF(fmt.Errorf("%v", detailResult), instance)
 			} else if temporalErrF, ok := temporalErrMapping[definition.Title.String()]; ok {
-				return nil, temporalErrF(fmt.Errorf("%v", detailResult), instanceID)
+				return nil, temporalErrF(fmt.Errorf("%v", detailResult), info.WorkflowExecution.ID)
 			} else {
 				raiseErr = definition
 				raiseErr.Detail = model.NewStringOrRuntimeExpr(fmt.Sprintf("%v", detailResult))
 				raiseErr.Instance = &model.JsonPointerOrRuntimeExpression{
-					Value: instanceID,
+					Value: instance,
 				}
 			}
 
 			raiseErr.Title = model.NewStringOrRuntimeExpr(fmt.Sprintf("%v", titleResult))
 			raiseErr.Status = definition.Status
+
+			// The error is kept in the details so a try task can catch it
+			return nil, temporal.NewApplicationErrorWithOptions(raiseErr.Error(), raiseErr.Type.String(), temporal.ApplicationErrorOptions{
+				Details: []any{raiseErr},
+			})
 		}
 
 		return nil, raiseErr
 	}, nil
 }
+
+// raisedError finds the error raised by a raise task. It is kept in the details
+// of the application error so that it survives the child workflows between the
+// raise and a try task.
+func raisedError(err error) *model.Error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if modelErr, ok := e.(*model.Error); ok {
+			return modelErr
+		}
+
+		if appErr, ok := e.(*temporal.ApplicationError); ok && appErr.HasDetails() {
+			var raised *model.Error
+			if appErr.Details(&raised) == nil && raised != nil && raised.Type != nil && raised.Status != 0 {
+				return raised
+			}
+		}
+	}
+
+	return nil
+}
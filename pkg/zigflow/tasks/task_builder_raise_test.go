@@ -46,6 +46,14 @@ func TestRaiseTaskBuilderBuild(t *testing.T) {
 				assert.ErrorAs(t, err, &appErr)
 				if assert.NotNil(t, appErr) {
 					assert.Contains(t, appErr.Error(), "Validation error")
+					assert.Equal(t, model.ErrorTypeValidation, appErr.Type())
+
+					var raised model.Error
+					assert.NoError(t, appErr.Details(&raised))
+					assert.Equal(t, model.ErrorTypeValidation, raised.Type.String())
+					assert.Equal(t, 400, raised.Status)
+					assert.Equal(t, "Validation error", raised.Title.String())
+					assert.Equal(t, "Invalid payload", raised.Detail.String())
 				}
 			},
 		},
@@ -101,3 +109,63 @@ func TestRaiseTaskBuilderBuild(t *testing.T) {
 		})
 	}
 }
+
+func TestRaiseTaskBuilderOutsideTry(t *testing.T) {
+	raise := &RaiseTaskBuilder{
+		builder: builder[*model.RaiseTask]{
+			name: "raise-task",
+			task: &model.RaiseTask{
+				Raise: model.RaiseTaskConfiguration{
+					Error: model.RaiseTaskError{
+						Definition: &model.Error{
+							Type:   model.NewUriTemplate(model.ErrorTypeValidation),
+							Status: 400,
+							Title:  model.NewStringOrRuntimeExpr("Validation error"),
+							Detail: model.NewStringOrRuntimeExpr("Invalid payload"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	fn, err := raise.Build()
+	assert.NoError(t, err)
+
+	do := &DoTaskBuilder{
+		builder: builder[*model.DoTask]{
+			doc:          testWorkflow,
+			eventEmitter: testEvents,
+			name:         "test-workflow",
+			task:         &model.DoTask{},
+		},
+	}
+
+	wf := do.workflowExecutor([]workflowFunc{
+		{
+			TaskBuilder: newFakeTaskBuilder("raise-task", &model.TaskBase{}),
+			Name:        "raise-task",
+			Func:        fn,
+		},
+	})
+
+	var s testsuite.WorkflowTestSuite
+	env := s.NewTestWorkflowEnvironment()
+
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+		return wf(ctx, nil, nil)
+	}, workflow.RegisterOptions{Name: "raise-outside-try"})
+
+	env.ExecuteWorkflow("raise-outside-try")
+
+	// An error that isn't caught fails the workflow as it was raised, rather
+	// than as the application error used to carry it to a try task
+	var appErr *temporal.ApplicationError
+	err = env.GetWorkflowError()
+	assert.ErrorAs(t, err, &appErr)
+	if assert.NotNil(t, appErr) {
+		assert.Equal(t, "Error", appErr.Type())
+		assert.False(t, appErr.HasDetails())
+		assert.Contains(t, appErr.Error(), "Validation error")
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/zigflow/zigflow/pkg/cloudevents"
 	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
 	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
@@ -144,7 +145,10 @@ func (t *RunTaskBuilder) runWorkflow(ctx workflow.Context, input any, state *uti
 
 	await := *t.task.Run.Await
 
-	opts := workflow.ChildWorkflowOptions{}
+	opts, err := metadata.ChildWorkflowOptionsFor(t.doc, t.task.GetBase(), workflow.ChildWorkflowOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error setting child workflow options: %w", err)
+	}
 	if !await {
 		opts.ParentClosePolicy = enums.PARENT_CLOSE_POLICY_ABANDON
 	}
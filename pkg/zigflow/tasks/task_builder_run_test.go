@@ -17,15 +17,20 @@
 package tasks
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/secrets"
 	"github.com/zigflow/zigflow/pkg/utils"
 	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
 
@@ -287,3 +292,74 @@ func TestRunTaskBuilderRunShellExecutesActivity(t *testing.T) {
 
 	assert.Equal(t, "shell-success", state.Data["shell-task"])
 }
+
+func TestCallShellActivitySecrets(t *testing.T) {
+	t.Setenv("ZIGFLOW_TEST_SECRET_token", "abc123")
+
+	tests := []struct {
+		Name          string
+		Script        string
+		ExpectResult  string
+		ExpectDetails map[string]any
+	}{
+		{
+			Name:         "output is redacted",
+			Script:       `printf %s "$TOKEN"`,
+			ExpectResult: "********",
+		},
+		{
+			Name:   "error details are redacted",
+			Script: `printf "out %s" "$TOKEN"; printf "err %s" "$TOKEN" >&2; exit 1`,
+			ExpectDetails: map[string]any{
+				"command": []any{"sh", "-c", `printf "out %s" "$TOKEN"; printf "err %s" "$TOKEN" >&2; exit 1`},
+				"stderr":  "err ********",
+				"stdout":  "out ********",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestActivityEnvironment()
+			env.RegisterActivity(&activities.Run{})
+			env.SetWorkerOptions(worker.Options{
+				BackgroundActivityContext: secrets.WithProvider(
+					context.Background(),
+					&secrets.EnvProvider{Prefix: "ZIGFLOW_TEST_SECRET_"},
+				),
+			})
+
+			task := &model.RunTask{
+				Run: model.RunTaskConfiguration{
+					Shell: &model.Shell{
+						Command:   "sh",
+						Arguments: &model.RunArguments{Value: []string{"-c", test.Script}},
+						Environment: map[string]string{
+							"TOKEN": "${ $secrets.token }",
+						},
+					},
+				},
+			}
+
+			state := utils.NewState()
+			state.Secrets = []string{"token"}
+
+			val, err := env.ExecuteActivity("CallShellActivity", task, nil, state)
+			if test.ExpectDetails != nil {
+				var appErr *temporal.ApplicationError
+				require.ErrorAs(t, err, &appErr)
+
+				var details map[string]any
+				require.NoError(t, appErr.Details(&details))
+				assert.Equal(t, test.ExpectDetails, details)
+				return
+			}
+			require.NoError(t, err)
+
+			var res string
+			require.NoError(t, val.Get(&res))
+			assert.Equal(t, test.ExpectResult, res)
+		})
+	}
+}
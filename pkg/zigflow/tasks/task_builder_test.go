@@ -105,7 +105,13 @@ func TestShouldRun(t *testing.T) {
 }
 
 func TestNewTaskBuilderFactory(t *testing.T) {
-	doc := &model.Workflow{}
+	doc := &model.Workflow{
+		Use: &model.Use{
+			Functions: model.NamedTaskMap{
+				"getWeather": &model.CallHTTP{Call: "http"},
+			},
+		},
+	}
 
 	tests := []struct {
 		name         string
@@ -118,6 +124,16 @@ func TestNewTaskBuilderFactory(t *testing.T) {
 			task:         &model.CallHTTP{},
 			expectedType: &CallHTTPTaskBuilder{},
 		},
+		{
+			name:         "call function",
+			task:         &model.CallFunction{Call: "getWeather"},
+			expectedType: &CallFunctionTaskBuilder{},
+		},
+		{
+			name:      "undefined function",
+			task:      &model.CallFunction{Call: "getForecast"},
+			expectErr: true,
+		},
 		{
 			name:         "do task",
 			task:         &model.DoTask{},
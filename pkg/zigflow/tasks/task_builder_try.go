@@ -17,12 +17,20 @@
 package tasks
 
 import (
+	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/zigflow/zigflow/pkg/cloudevents"
 	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"github.com/zigflow/zigflow/pkg/zigflow/metadata"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
@@ -34,6 +42,14 @@ func NewTryTaskBuilder(
 	doc *model.Workflow,
 	emitter *cloudevents.Events,
 ) (*TryTaskBuilder, error) {
+	var retry *model.RetryPolicy
+	if task.Catch != nil && task.Catch.Retry != nil {
+		var err error
+		if retry, err = metadata.ResolveRetryPolicy(doc, task.Catch.Retry); err != nil {
+			return nil, fmt.Errorf("error resolving catch retry policy: %w", err)
+		}
+	}
+
 	return &TryTaskBuilder{
 		builder: builder[*model.TryTask]{
 			doc:            doc,
@@ -42,6 +58,7 @@ func NewTryTaskBuilder(
 			task:           task,
 			temporalWorker: temporalWorker,
 		},
+		retry: retry,
 	}, nil
 }
 
@@ -50,6 +67,9 @@ type TryTaskBuilder struct {
 
 	tryChildWorkflowName   string
 	catchChildWorkflowName string
+
+	// Resolved catch.retry policy
+	retry *model.RetryPolicy
 }
 
 func (t *TryTaskBuilder) Build() (TemporalWorkflowFunc, error) {
@@ -58,6 +78,9 @@ func (t *TryTaskBuilder) Build() (TemporalWorkflowFunc, error) {
 		if err != nil {
 			return nil, fmt.Errorf("erroring registering %s tasks for %s: %w", taskType, t.GetTaskName(), err)
 		}
+		if builder == nil {
+			continue
+		}
 
 		if _, err = builder.Build(); err != nil {
 			log.Error().Str("task", t.GetTaskName()).Str("taskType", taskType).Msg("Error building for workflow")
@@ -80,6 +103,9 @@ func (t *TryTaskBuilder) PostLoad() error {
 		if err != nil {
 			return fmt.Errorf("erroring registering %s post load tasks for %s: %w", taskType, t.GetTaskName(), err)
 		}
+		if builder == nil {
+			continue
+		}
 
 		if err = builder.PostLoad(); err != nil {
 			log.Error().Str("task", t.GetTaskName()).Str("taskType", taskType).Msg("Error building for workflow")
@@ -93,31 +119,306 @@ func (t *TryTaskBuilder) PostLoad() error {
 func (t *TryTaskBuilder) exec() (TemporalWorkflowFunc, error) {
 	return func(ctx workflow.Context, input any, state *utils.State) (output any, err error) {
 		logger := workflow.GetLogger(ctx)
+		started := workflow.Now(ctx)
+
+		var res map[string]any
+		var catchState *utils.State
+		for attempt := 0; ; attempt++ {
+			tryErr := t.runTry(ctx, state, attempt, &res)
+			if tryErr == nil {
+				return res, nil
+			}
+
+			var errObj map[string]any
+			var caught bool
+			errObj, catchState, caught, err = t.catchError(ctx, state, tryErr)
+			if err != nil {
+				logger.Error("Error checking if the error is caught", "error", err)
+				return nil, fmt.Errorf("error checking try catch: %w", err)
+			}
+			if !caught {
+				// Errors that aren't caught are returned as they are
+				logger.Warn("Workflow failed, error not caught", "tryWorkflow", t.tryChildWorkflowName, "error", tryErr)
+				return nil, tryErr
+			}
+
+			delay, retry, err := t.nextRetry(ctx, catchState, errObj, attempt, started)
+			if err != nil {
+				logger.Error("Error checking the retry policy", "error", err)
+				return nil, fmt.Errorf("error checking try retry policy: %w", err)
+			}
+			if !retry {
+				break
+			}
 
+			logger.Warn("Workflow failed, retrying", "tryWorkflow", t.tryChildWorkflowName, "attempt", attempt+1, "delay", delay)
+			if err := workflow.Sleep(ctx, delay); err != nil {
+				return nil, fmt.Errorf("error waiting to retry try workflow: %w", err)
+			}
+		}
+
+		if t.catchChildWorkflowName == "" {
+			logger.Warn("Workflow failed, error caught with no catch tasks", "tryWorkflow", t.tryChildWorkflowName)
+			return nil, nil
+		}
+
+		logger.Warn("Workflow failed, catching the error", "tryWorkflow", t.tryChildWorkflowName, "catchWorkflow", t.catchChildWorkflowName)
+		// The try workflow has failed - let's run the catch workflow
 		opts := workflow.ChildWorkflowOptions{
-			WorkflowID: fmt.Sprintf("%s_try", workflow.GetInfo(ctx).WorkflowExecution.ID),
+			WorkflowID: fmt.Sprintf("%s_catch", workflow.GetInfo(ctx).WorkflowExecution.ID),
 		}
+
 		childCtx := workflow.WithChildOptions(ctx, opts)
 
-		var res map[string]any
-		if err := workflow.ExecuteChildWorkflow(childCtx, t.tryChildWorkflowName, state.Input, state).Get(ctx, &res); err != nil {
-			logger.Warn("Workflow failed, catching the error", "tryWorkflow", t.tryChildWorkflowName, "catchWorkflow", t.catchChildWorkflowName)
-			// The try workflow has failed - let's run the catch workflow
-			opts := workflow.ChildWorkflowOptions{
-				WorkflowID: fmt.Sprintf("%s_catch", workflow.GetInfo(ctx).WorkflowExecution.ID),
+		if err := workflow.ExecuteChildWorkflow(childCtx, t.catchChildWorkflowName, catchState.Input, catchState).Get(ctx, &res); err != nil {
+			// Everything has failed
+			logger.Error("Error calling catch workflow", "error", err)
+			return nil, fmt.Errorf("error calling catch workflow: %w", err)
+		}
+
+		return res, nil
+	}, nil
+}
+
+// catchError decides if the try workflow's error is caught by the errors.with
+// filter and the when and exceptWhen conditions. The error is added to the
+// state's data, under catch.as, for the conditions and the catch tasks.
+func (t *TryTaskBuilder) catchError(
+	ctx workflow.Context, state *utils.State, tryErr error,
+) (errObj map[string]any, catchState *utils.State, caught bool, err error) {
+	catch := t.task.Catch
+
+	instance := faultedTaskReference(tryErr)
+	if instance == "" {
+		// The try workflow failed outside of its tasks, such as by timing out
+		instance = t.reference(ctx)
+	}
+
+	modelErr := toModelError(tryErr, instance)
+	if !matchesErrorFilter(catch.Errors.With, modelErr) {
+		return
+	}
+
+	if err = utils.ToType(modelErr, &errObj); err != nil {
+		err = fmt.Errorf("error converting caught error: %w", err)
+		return
+	}
+
+	as := catch.As
+	if as == "" {
+		as = "error"
+	}
+	catchState = state.Clone().AddData(map[string]any{as: errObj})
+
+	if catch.When != nil {
+		if caught, err = utils.EvaluateBool(catch.When.String(), errObj, catchState); err != nil || !caught {
+			return
+		}
+	}
+
+	if catch.ExceptWhen != nil {
+		var except bool
+		if except, err = utils.EvaluateBool(catch.ExceptWhen.String(), errObj, catchState); err != nil || except {
+			return errObj, catchState, false, err
+		}
+	}
+
+	return errObj, catchState, true, nil
+}
+
+// runTry runs the try tasks as a child workflow. Retries are given their own
+// workflow ID as each attempt is a separate execution.
+func (t *TryTaskBuilder) runTry(ctx workflow.Context, state *utils.State, attempt int, res *map[string]any) error {
+	workflowID := fmt.Sprintf("%s_try", workflow.GetInfo(ctx).WorkflowExecution.ID)
+	if attempt > 0 {
+		workflowID = fmt.Sprintf("%s_%d", workflowID, attempt)
+	}
+
+	opts, err := metadata.ChildWorkflowOptionsFor(t.doc, t.task.GetBase(), workflow.ChildWorkflowOptions{
+		WorkflowID: workflowID,
+	})
+	if err != nil {
+		return temporal.NewNonRetryableApplicationError("Error setting try workflow options", "Try error", err)
+	}
+
+	if t.retry != nil && t.retry.Limit.Attempt != nil && t.retry.Limit.Attempt.Duration != nil {
+		opts.WorkflowRunTimeout = utils.ToDuration(t.retry.Limit.Attempt.Duration)
+	}
+
+	childCtx := workflow.WithChildOptions(ctx, opts)
+
+	return workflow.ExecuteChildWorkflow(childCtx, t.tryChildWorkflowName, state.Input, state).Get(ctx, res)
+}
+
+// nextRetry decides if the failed try workflow should be run again and how
+// long to wait before doing so. The when and exceptWhen conditions receive
+// the caught error as their input.
+func (t *TryTaskBuilder) nextRetry(
+	ctx workflow.Context, state *utils.State, errObj map[string]any, attempt int, started time.Time,
+) (delay time.Duration, retry bool, err error) {
+	policy := t.retry
+	if policy == nil {
+		return
+	}
+
+	// The attempt count is the number of retries
+	if a := policy.Limit.Attempt; a != nil && a.Count > 0 && attempt >= a.Count {
+		return
+	}
+
+	if policy.When != nil {
+		if ok, err := utils.EvaluateBool(policy.When.String(), errObj, state); err != nil || !ok {
+			return 0, false, err
+		}
+	}
+
+	if policy.ExceptWhen != nil {
+		if ok, err := utils.EvaluateBool(policy.ExceptWhen.String(), errObj, state); err != nil || ok {
+			return 0, false, err
+		}
+	}
+
+	delay = metadata.RetryDelay(policy, attempt)
+
+	if j := policy.Jitter; j != nil {
+		from := utils.ToDuration(j.From)
+		to := utils.ToDuration(j.To)
+
+		var jitter time.Duration
+		if to > from {
+			if err := workflow.SideEffect(ctx, func(workflow.Context) any {
+				return from + rand.N(to-from)
+			}).Get(&jitter); err != nil {
+				return 0, false, fmt.Errorf("error calculating retry jitter: %w", err)
 			}
+		} else {
+			jitter = from
+		}
+
+		delay += jitter
+	}
+
+	// Don't start a retry that would be beyond the total duration
+	if limit := utils.ToDuration(policy.Limit.Duration); limit > 0 && workflow.Now(ctx).Add(delay).Sub(started) >= limit {
+		return
+	}
+
+	return delay, true, nil
+}
+
+// toModelError converts the try workflow's error back into a Serverless
+// Workflow error. Raised errors keep their definition and other errors are
+// given the closest standard error type.
+func toModelError(err error, instance string) *model.Error {
+	if raised := raisedError(err); raised != nil {
+		return raised
+	}
+
+	var timeoutErr *temporal.TimeoutError
+	var activityErr *temporal.ActivityError
+	var appErr *temporal.ApplicationError
 
-			childCtx := workflow.WithChildOptions(ctx, opts)
+	switch {
+	case errors.As(err, &timeoutErr):
+		return model.NewErrTimeout(timeoutErr, instance)
+	case errors.As(err, &activityErr) && errors.As(activityErr.Unwrap(), &appErr):
+		detail := errors.New(appErr.Message())
 
-			if err := workflow.ExecuteChildWorkflow(childCtx, t.catchChildWorkflowName, state.Input, state).Get(ctx, &res); err != nil {
-				// Everything has failed
-				logger.Error("Error calling try workflow", "error", err)
-				return nil, fmt.Errorf("error calling catcg workflow: %w", err)
+		if appErr.Type() == activities.CallHTTPErrorType {
+			modelErr := model.NewErrCommunication(detail, instance)
+			// The cause is the response status, such as "404 Not Found"
+			if cause := errors.Unwrap(appErr); cause != nil {
+				if status, _, _ := strings.Cut(cause.Error(), " "); status != "" {
+					if code, err := strconv.Atoi(status); err == nil {
+						modelErr.Status = code
+					}
+				}
 			}
+			return modelErr
 		}
 
-		return res, nil
-	}, nil
+		modelErr := model.NewErrRuntime(detail, instance)
+		modelErr.Title = model.NewStringOrRuntimeExpr(appErr.Type())
+		return modelErr
+	}
+
+	// Use the original error rather than the wrapping added by each workflow
+	inner := err
+	for e := errors.Unwrap(inner); e != nil; e = errors.Unwrap(inner) {
+		inner = e
+	}
+
+	return model.NewErrRuntime(errors.New(inner.Error()), instance)
+}
+
+const faultedTaskErrorType = "FaultedTask"
+
+// faultedTask is kept in the details of a try workflow's error so the caught
+// error's instance is the task that failed rather than the try task
+type faultedTask struct {
+	Reference string `json:"faultedTask"`
+}
+
+// withFaultedTask wraps the error of a task run by a try workflow with the
+// task's reference. Raised errors already have their instance and an error
+// from a nested try keeps the innermost task.
+func withFaultedTask(reference string, err error) error {
+	if reference == "" || temporal.IsCanceledError(err) || raisedError(err) != nil || faultedTaskReference(err) != "" {
+		return err
+	}
+
+	errType := faultedTaskErrorType
+	var nonRetryable bool
+	if appErr, ok := err.(*temporal.ApplicationError); ok {
+		errType = appErr.Type()
+		nonRetryable = appErr.NonRetryable()
+	}
+
+	return temporal.NewApplicationErrorWithOptions(err.Error(), errType, temporal.ApplicationErrorOptions{
+		NonRetryable: nonRetryable,
+		Cause:        err,
+		Details:      []any{faultedTask{Reference: reference}},
+	})
+}
+
+// faultedTaskReference returns the reference of the task that failed in the
+// try workflow, or an empty string if the error didn't come from a task
+func faultedTaskReference(err error) string {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if appErr, ok := e.(*temporal.ApplicationError); ok && appErr.HasDetails() {
+			var task faultedTask
+			if appErr.Details(&task) == nil && task.Reference != "" {
+				return task.Reference
+			}
+		}
+	}
+
+	return ""
+}
+
+// matchesErrorFilter checks the error against every property set in the filter
+func matchesErrorFilter(filter *model.ErrorFilter, err *model.Error) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.Type != "" && (err.Type == nil || err.Type.String() != filter.Type) {
+		return false
+	}
+	if filter.Status != 0 && err.Status != filter.Status {
+		return false
+	}
+	if filter.Title != "" && (err.Title == nil || err.Title.String() != filter.Title) {
+		return false
+	}
+	if filter.Details != "" && (err.Detail == nil || err.Detail.String() != filter.Details) {
+		return false
+	}
+	if filter.Instance != "" && (err.Instance == nil || err.Instance.String() != filter.Instance) {
+		return false
+	}
+
+	return true
 }
 
 func (t *TryTaskBuilder) getTasks() map[string]*model.TaskList {
@@ -132,14 +433,16 @@ func (t *TryTaskBuilder) createBuilder(
 ) (childWorkflowName string, builder TaskBuilder, err error) {
 	l := log.With().Str("task", t.GetTaskName()).Str("taskType", taskType).Logger()
 
-	if len(*list) == 0 {
+	if list == nil || len(*list) == 0 {
 		l.Warn().Msg("No tasks detected")
 		return
 	}
 
 	childWorkflowName = utils.GenerateChildWorkflowName(taskType, t.GetTaskName())
 
-	b, err := NewTaskBuilder(childWorkflowName, &model.DoTask{Do: list}, t.temporalWorker, t.doc, t.eventEmitter)
+	b, err := NewDoTaskBuilder(t.temporalWorker, &model.DoTask{Do: list}, childWorkflowName, t.doc, t.eventEmitter, DoTaskOpts{
+		ReportFaultedTask: taskType == "try",
+	})
 	if err != nil {
 		l.Error().Msg("Error creating the for task builder")
 		err = fmt.Errorf("error creating the for task builder: %w", err)
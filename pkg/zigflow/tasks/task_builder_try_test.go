@@ -17,12 +17,19 @@
 package tasks
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/serverlessworkflow/sdk-go/v3/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/zigflow/zigflow/pkg/utils"
+	"github.com/zigflow/zigflow/pkg/zigflow/activities"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/testsuite"
 	"go.temporal.io/sdk/workflow"
 )
@@ -94,3 +101,424 @@ func TestTryTaskBuilderExecRunsCatchOnError(t *testing.T) {
 	assert.NoError(t, env.GetWorkflowResult(&result))
 	assert.Equal(t, map[string]any{"handled": true}, result)
 }
+
+func TestTryTaskBuilderExecRetries(t *testing.T) {
+	tests := []struct {
+		Name             string
+		Retry            *model.RetryPolicy
+		Failures         int
+		ExpectedAttempts int
+		Expected         map[string]any
+	}{
+		{
+			Name:             "No retry policy",
+			Failures:         1,
+			ExpectedAttempts: 1,
+			Expected:         map[string]any{"handled": true},
+		},
+		{
+			Name: "Succeeds on retry",
+			Retry: &model.RetryPolicy{
+				Delay:   &model.Duration{Value: model.DurationInline{Seconds: 1}},
+				Backoff: &model.RetryBackoff{Linear: &model.BackoffDefinition{}},
+				Limit:   model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: 3}},
+			},
+			Failures:         2,
+			ExpectedAttempts: 3,
+			Expected:         map[string]any{"attempt": float64(3)},
+		},
+		{
+			Name: "Attempts exhausted",
+			Retry: &model.RetryPolicy{
+				Jitter: &model.RetryPolicyJitter{
+					From: &model.Duration{Value: model.DurationInline{Seconds: 1}},
+					To:   &model.Duration{Value: model.DurationInline{Seconds: 2}},
+				},
+				Limit: model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: 2}},
+			},
+			Failures:         5,
+			ExpectedAttempts: 3,
+			Expected:         map[string]any{"handled": true},
+		},
+		{
+			Name: "Total duration exceeded",
+			Retry: &model.RetryPolicy{
+				Delay: &model.Duration{Value: model.DurationInline{Seconds: 10}},
+				Limit: model.RetryLimit{Duration: &model.Duration{Value: model.DurationInline{Seconds: 15}}},
+			},
+			Failures:         5,
+			ExpectedAttempts: 2,
+			Expected:         map[string]any{"handled": true},
+		},
+		{
+			Name: "When condition matches",
+			Retry: &model.RetryPolicy{
+				When:  model.NewExpr(`${ .detail == "boom" }`),
+				Limit: model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: 3}},
+			},
+			Failures:         1,
+			ExpectedAttempts: 2,
+			Expected:         map[string]any{"attempt": float64(2)},
+		},
+		{
+			Name: "When condition does not match",
+			Retry: &model.RetryPolicy{
+				When:  model.NewExpr(`${ .detail == "other" }`),
+				Limit: model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: 3}},
+			},
+			Failures:         1,
+			ExpectedAttempts: 1,
+			Expected:         map[string]any{"handled": true},
+		},
+		{
+			Name: "Except when condition matches",
+			Retry: &model.RetryPolicy{
+				ExceptWhen: model.NewExpr(`${ .detail | startswith("bo") }`),
+				Limit:      model.RetryLimit{Attempt: &model.RetryLimitAttempt{Count: 3}},
+			},
+			Failures:         1,
+			ExpectedAttempts: 1,
+			Expected:         map[string]any{"handled": true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			builder := &TryTaskBuilder{
+				builder: builder[*model.TryTask]{
+					name: "try-task",
+					task: &model.TryTask{
+						Try:   &model.TaskList{},
+						Catch: &model.TryTaskCatch{Do: &model.TaskList{}},
+					},
+				},
+				tryChildWorkflowName:   "try-child",
+				catchChildWorkflowName: "catch-child",
+				retry:                  test.Retry,
+			}
+
+			fn, err := builder.exec()
+			assert.NoError(t, err)
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+
+			attempts := 0
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input any, st *utils.State) (map[string]any, error) {
+				attempts++
+				if attempts <= test.Failures {
+					return nil, errors.New("boom")
+				}
+				return map[string]any{"attempt": attempts}, nil
+			}, workflow.RegisterOptions{Name: builder.tryChildWorkflowName})
+
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input any, st *utils.State) (map[string]any, error) {
+				return map[string]any{"handled": true}, nil
+			}, workflow.RegisterOptions{Name: builder.catchChildWorkflowName})
+
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return fn(ctx, nil, utils.NewState())
+			}, workflow.RegisterOptions{Name: "try-exec"})
+
+			env.ExecuteWorkflow("try-exec")
+			assert.NoError(t, env.GetWorkflowError())
+
+			var result map[string]any
+			assert.NoError(t, env.GetWorkflowResult(&result))
+			assert.Equal(t, test.Expected, result)
+			assert.Equal(t, test.ExpectedAttempts, attempts)
+		})
+	}
+}
+
+func TestNewTryTaskBuilderResolvesRetry(t *testing.T) {
+	named := &model.RetryPolicy{Delay: &model.Duration{Value: model.DurationInline{Seconds: 5}}}
+	doc := &model.Workflow{
+		Use: &model.Use{Retries: map[string]*model.RetryPolicy{"default": named}},
+	}
+
+	builder, err := NewTryTaskBuilder(nil, &model.TryTask{
+		Try:   &model.TaskList{},
+		Catch: &model.TryTaskCatch{Retry: &model.RetryPolicy{Ref: "default"}},
+	}, "try-task", doc, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, named, builder.retry)
+
+	_, err = NewTryTaskBuilder(nil, &model.TryTask{
+		Try:   &model.TaskList{},
+		Catch: &model.TryTaskCatch{Retry: &model.RetryPolicy{Ref: "missing"}},
+	}, "try-task", doc, nil)
+	assert.EqualError(t, err, `error resolving catch retry policy: retry policy "missing" is not defined in use.retries`)
+}
+
+func TestTryTaskBuilderExecCatch(t *testing.T) {
+	raised := func() error {
+		err := &model.Error{
+			Type:     model.NewUriTemplate(model.ErrorTypeValidation),
+			Status:   400,
+			Title:    model.NewStringOrRuntimeExpr("Invalid"),
+			Detail:   model.NewStringOrRuntimeExpr("name is required"),
+			Instance: &model.JsonPointerOrRuntimeExpression{Value: "wf_try"},
+		}
+		// Mirror the wrapping added by the do task
+		return fmt.Errorf("error running task: %w", temporal.NewApplicationErrorWithOptions(
+			err.Error(), model.ErrorTypeValidation, temporal.ApplicationErrorOptions{Details: []any{err}},
+		))
+	}
+	raisedObj := map[string]any{
+		"type":     model.ErrorTypeValidation,
+		"status":   float64(400),
+		"title":    "Invalid",
+		"detail":   "name is required",
+		"instance": "wf_try",
+	}
+
+	tests := []struct {
+		Name     string
+		Catch    *model.TryTaskCatch
+		Err      func() error
+		Expected map[string]any
+		Caught   bool
+	}{
+		{
+			Name:     "Catch all",
+			Catch:    &model.TryTaskCatch{},
+			Err:      raised,
+			Expected: map[string]any{"caught": raisedObj},
+			Caught:   true,
+		},
+		{
+			Name:     "Catch as",
+			Catch:    &model.TryTaskCatch{As: "failure"},
+			Err:      raised,
+			Expected: map[string]any{"caught": raisedObj},
+			Caught:   true,
+		},
+		{
+			Name: "Filter matches",
+			Catch: func() *model.TryTaskCatch {
+				c := &model.TryTaskCatch{}
+				c.Errors.With = &model.ErrorFilter{Type: model.ErrorTypeValidation, Status: 400}
+				return c
+			}(),
+			Err:      raised,
+			Expected: map[string]any{"caught": raisedObj},
+			Caught:   true,
+		},
+		{
+			Name: "Filter does not match",
+			Catch: func() *model.TryTaskCatch {
+				c := &model.TryTaskCatch{}
+				c.Errors.With = &model.ErrorFilter{Status: 500}
+				return c
+			}(),
+			Err: raised,
+		},
+		{
+			Name:     "When matches",
+			Catch:    &model.TryTaskCatch{When: model.NewExpr("${ $data.error.status == 400 }")},
+			Err:      raised,
+			Expected: map[string]any{"caught": raisedObj},
+			Caught:   true,
+		},
+		{
+			Name:  "When does not match",
+			Catch: &model.TryTaskCatch{When: model.NewExpr(`${ .title == "Other" }`)},
+			Err:   raised,
+		},
+		{
+			Name:  "Except when matches",
+			Catch: &model.TryTaskCatch{ExceptWhen: model.NewExpr(`${ .type | endswith("/validation") }`)},
+			Err:   raised,
+		},
+		{
+			Name:  "Unknown errors are runtime errors",
+			Catch: &model.TryTaskCatch{},
+			Err: func() error {
+				return errors.New("boom")
+			},
+			Expected: map[string]any{
+				"caught": map[string]any{
+					"type":     model.ErrorTypeRuntime,
+					"status":   float64(500),
+					"title":    "Runtime Error",
+					"detail":   "boom",
+					"instance": "default-test-workflow-id",
+				},
+			},
+			Caught: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Catch.Do = &model.TaskList{}
+			builder := &TryTaskBuilder{
+				builder: builder[*model.TryTask]{
+					name: "try-task",
+					task: &model.TryTask{
+						Try:   &model.TaskList{},
+						Catch: test.Catch,
+					},
+				},
+				tryChildWorkflowName:   "try-child",
+				catchChildWorkflowName: "catch-child",
+			}
+
+			fn, err := builder.exec()
+			assert.NoError(t, err)
+
+			var s testsuite.WorkflowTestSuite
+			env := s.NewTestWorkflowEnvironment()
+
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input any, st *utils.State) (map[string]any, error) {
+				return nil, test.Err()
+			}, workflow.RegisterOptions{Name: builder.tryChildWorkflowName})
+
+			as := test.Catch.As
+			if as == "" {
+				as = "error"
+			}
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input any, st *utils.State) (map[string]any, error) {
+				return map[string]any{"caught": st.Data[as]}, nil
+			}, workflow.RegisterOptions{Name: builder.catchChildWorkflowName})
+
+			env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (any, error) {
+				return fn(ctx, nil, utils.NewState())
+			}, workflow.RegisterOptions{Name: "try-exec"})
+
+			env.ExecuteWorkflow("try-exec")
+
+			if !test.Caught {
+				// The error is returned as it is
+				found := false
+				for e := env.GetWorkflowError(); e != nil; e = errors.Unwrap(e) {
+					if appErr, ok := e.(*temporal.ApplicationError); ok && appErr.Type() == model.ErrorTypeValidation {
+						found = true
+					}
+				}
+				assert.True(t, found, "raised error not returned")
+				return
+			}
+
+			assert.NoError(t, env.GetWorkflowError())
+
+			var result map[string]any
+			assert.NoError(t, env.GetWorkflowResult(&result))
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestToModelError(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		err := toModelError(fmt.Errorf("wrapped: %w", temporal.NewTimeoutError(enums.TIMEOUT_TYPE_START_TO_CLOSE, nil)), "wf")
+		assert.Equal(t, model.ErrorTypeTimeout, err.Type.String())
+		assert.Equal(t, 408, err.Status)
+	})
+
+	t.Run("Serverless Workflow error", func(t *testing.T) {
+		raised := model.NewErrAuthorization(errors.New("denied"), "wf")
+		assert.Same(t, raised, toModelError(fmt.Errorf("wrapped: %w", raised), "wf"))
+	})
+
+	t.Run("Activity errors", func(t *testing.T) {
+		tests := []struct {
+			Name     string
+			Err      error
+			Type     string
+			Status   int
+			Title    string
+			Detail   string
+			Instance string
+		}{
+			{
+				Name: "HTTP",
+				Err: temporal.NewNonRetryableApplicationError(
+					"CallHTTP returned 4xx status code", activities.CallHTTPErrorType, errors.New("404 Not Found"),
+				),
+				Type:   model.ErrorTypeCommunication,
+				Status: 404,
+				Title:  "Communication Error",
+				Detail: "CallHTTP returned 4xx status code",
+			},
+			{
+				Name:   "Other",
+				Err:    temporal.NewNonRetryableApplicationError("Docker not installed", "container", nil),
+				Type:   model.ErrorTypeRuntime,
+				Status: 500,
+				Title:  "container",
+				Detail: "Docker not installed",
+			},
+		}
+
+		for _, test := range tests {
+			t.Run(test.Name, func(t *testing.T) {
+				var s testsuite.WorkflowTestSuite
+				env := s.NewTestWorkflowEnvironment()
+
+				env.RegisterActivityWithOptions(func(context.Context) error {
+					return test.Err
+				}, activity.RegisterOptions{Name: "fail"})
+
+				env.RegisterWorkflowWithOptions(func(ctx workflow.Context) (*model.Error, error) {
+					ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+					err := workflow.ExecuteActivity(ctx, "fail").Get(ctx, nil)
+					return toModelError(err, "wf"), nil
+				}, workflow.RegisterOptions{Name: "activity"})
+
+				env.ExecuteWorkflow("activity")
+				assert.NoError(t, env.GetWorkflowError())
+
+				var result model.Error
+				assert.NoError(t, env.GetWorkflowResult(&result))
+				assert.Equal(t, test.Type, result.Type.String())
+				assert.Equal(t, test.Status, result.Status)
+				assert.Equal(t, test.Title, result.Title.String())
+				assert.Equal(t, test.Detail, result.Detail.String())
+				assert.Equal(t, "wf", result.Instance.String())
+			})
+		}
+	})
+}
+
+func TestMatchesErrorFilter(t *testing.T) {
+	err := &model.Error{
+		Type:     model.NewUriTemplate(model.ErrorTypeCommunication),
+		Status:   404,
+		Title:    model.NewStringOrRuntimeExpr("Not Found"),
+		Detail:   model.NewStringOrRuntimeExpr("user not found"),
+		Instance: &model.JsonPointerOrRuntimeExpression{Value: "/do/0/getUser"},
+	}
+
+	tests := []struct {
+		Name     string
+		Filter   *model.ErrorFilter
+		Expected bool
+	}{
+		{Name: "No filter", Expected: true},
+		{Name: "Empty filter", Filter: &model.ErrorFilter{}, Expected: true},
+		{
+			Name: "All match",
+			Filter: &model.ErrorFilter{
+				Type:     model.ErrorTypeCommunication,
+				Status:   404,
+				Title:    "Not Found",
+				Details:  "user not found",
+				Instance: "/do/0/getUser",
+			},
+			Expected: true,
+		},
+		{Name: "Type", Filter: &model.ErrorFilter{Type: model.ErrorTypeTimeout}},
+		{Name: "Status", Filter: &model.ErrorFilter{Status: 500}},
+		{Name: "Title", Filter: &model.ErrorFilter{Title: "Other"}},
+		{Name: "Details", Filter: &model.ErrorFilter{Details: "other"}},
+		{Name: "Instance", Filter: &model.ErrorFilter{Instance: "/do/1/other"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, matchesErrorFilter(test.Filter, err))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// currentVersionRetryInterval is how long to wait between attempts to make a
+// version current. Temporal only knows about a version once its workers have
+// polled.
+const currentVersionRetryInterval = time.Second
+
+// currentVersionTimeout is how long to keep trying to make a version current
+const currentVersionTimeout = 30 * time.Second
+
+// DeploymentOptions opts a worker into Worker Versioning. The task queue is
+// the deployment and the definitions registered on it are the build ID, so
+// changing a definition creates a new version. Workflows are pinned to the
+// version they started on and are never given to a worker with different
+// definitions.
+func DeploymentOptions(taskQueue string, defs []*Definition) worker.DeploymentOptions {
+	return worker.DeploymentOptions{
+		UseVersioning: true,
+		Version: worker.WorkerDeploymentVersion{
+			DeploymentName: taskQueue,
+			BuildID:        DefinitionsID(defs),
+		},
+		DefaultVersioningBehavior: workflow.VersioningBehaviorPinned,
+	}
+}
+
+// SetCurrentVersion routes new workflows on the task queue to the build ID. An
+// empty build ID routes them to unversioned workers, so that a version whose
+// definitions have been removed can drain.
+func SetCurrentVersion(ctx context.Context, deployments client.WorkerDeploymentClient, taskQueue, buildID string) error {
+	ctx, cancel := context.WithTimeout(ctx, currentVersionTimeout)
+	defer cancel()
+
+	handle := deployments.GetHandle(taskQueue)
+	for {
+		_, err := handle.SetCurrentVersion(ctx, client.WorkerDeploymentSetCurrentVersionOptions{
+			BuildID: buildID,
+		})
+		if err == nil {
+			log.Debug().Str("task-queue", taskQueue).Str("buildId", buildID).Msg("Current version set")
+			return nil
+		}
+
+		log.Debug().Err(err).Str("task-queue", taskQueue).Str("buildId", buildID).Msg("Unable to set current version - retrying")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error setting current version of %q to %q: %w", taskQueue, buildID, err)
+		case <-time.After(currentVersionRetryInterval):
+		}
+	}
+}
+
+// VersionDrained returns true once no open workflow is pinned to the build ID,
+// so its workers can be stopped. Temporal refreshes this periodically, so it
+// can lag behind the workflows finishing by a few minutes.
+func VersionDrained(ctx context.Context, deployments client.WorkerDeploymentClient, taskQueue, buildID string) (bool, error) {
+	res, err := deployments.GetHandle(taskQueue).DescribeVersion(ctx, client.WorkerDeploymentDescribeVersionOptions{
+		BuildID: buildID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error describing version %q of %q: %w", buildID, taskQueue, err)
+	}
+
+	drainage := res.Info.DrainageInfo
+	return drainage != nil && drainage.DrainageStatus == client.WorkerDeploymentVersionDrainageStatusDrained, nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+)
+
+// fakeDeployments records the versions set as current. Setting a version
+// fails until it has been attempted failures+1 times.
+type fakeDeployments struct {
+	client.WorkerDeploymentClient
+
+	failures int
+	drainage *client.WorkerDeploymentVersionDrainageInfo
+	current  map[string]string
+	attempts int
+}
+
+func (f *fakeDeployments) GetHandle(name string) client.WorkerDeploymentHandle {
+	return &fakeDeploymentHandle{deployments: f, name: name}
+}
+
+type fakeDeploymentHandle struct {
+	client.WorkerDeploymentHandle
+
+	deployments *fakeDeployments
+	name        string
+}
+
+func (h *fakeDeploymentHandle) SetCurrentVersion(
+	_ context.Context, options client.WorkerDeploymentSetCurrentVersionOptions,
+) (client.WorkerDeploymentSetCurrentVersionResponse, error) {
+	h.deployments.attempts++
+	if h.deployments.attempts <= h.deployments.failures {
+		return client.WorkerDeploymentSetCurrentVersionResponse{}, errors.New("version not found")
+	}
+
+	if h.deployments.current == nil {
+		h.deployments.current = map[string]string{}
+	}
+	h.deployments.current[h.name] = options.BuildID
+
+	return client.WorkerDeploymentSetCurrentVersionResponse{}, nil
+}
+
+func (h *fakeDeploymentHandle) DescribeVersion(
+	context.Context, client.WorkerDeploymentDescribeVersionOptions,
+) (client.WorkerDeploymentVersionDescription, error) {
+	return client.WorkerDeploymentVersionDescription{
+		Info: client.WorkerDeploymentVersionInfo{DrainageInfo: h.deployments.drainage},
+	}, nil
+}
+
+func TestDeploymentOptions(t *testing.T) {
+	dir := writeDefinitions(t, map[string]string{
+		"a.yaml": definitionYAML("queue", "wf1", "loop1"),
+	})
+
+	defs, err := zigflow.LoadDefinitions([]string{dir})
+	require.NoError(t, err)
+
+	opts := zigflow.DeploymentOptions("queue", defs)
+
+	assert.True(t, opts.UseVersioning)
+	assert.Equal(t, "queue", opts.Version.DeploymentName)
+	assert.Equal(t, "wf1@0.0.1", opts.Version.BuildID)
+	assert.Equal(t, workflow.VersioningBehaviorPinned, opts.DefaultVersioningBehavior)
+}
+
+func TestSetCurrentVersion(t *testing.T) {
+	// The version is unknown until the new workers have polled
+	deployments := &fakeDeployments{failures: 1}
+
+	require.NoError(t, zigflow.SetCurrentVersion(context.Background(), deployments, "queue", "wf1@0.0.2"))
+
+	assert.Equal(t, 2, deployments.attempts)
+	assert.Equal(t, map[string]string{"queue": "wf1@0.0.2"}, deployments.current)
+}
+
+func TestSetCurrentVersionCancelled(t *testing.T) {
+	deployments := &fakeDeployments{failures: 100}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := zigflow.SetCurrentVersion(ctx, deployments, "queue", "wf1@0.0.2")
+	assert.ErrorContains(t, err, "version not found")
+	assert.Empty(t, deployments.current)
+}
+
+func TestVersionDrained(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Drainage *client.WorkerDeploymentVersionDrainageInfo
+		Expected bool
+	}{
+		{
+			Name:     "current version",
+			Expected: false,
+		},
+		{
+			Name: "draining",
+			Drainage: &client.WorkerDeploymentVersionDrainageInfo{
+				DrainageStatus: client.WorkerDeploymentVersionDrainageStatusDraining,
+			},
+			Expected: false,
+		},
+		{
+			Name: "drained",
+			Drainage: &client.WorkerDeploymentVersionDrainageInfo{
+				DrainageStatus: client.WorkerDeploymentVersionDrainageStatusDrained,
+			},
+			Expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			drained, err := zigflow.VersionDrained(
+				context.Background(), &fakeDeployments{drainage: test.Drainage}, "queue", "wf1@0.0.1",
+			)
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, drained)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/zigflow/zigflow/pkg/utils"
+)
+
+// WalkFunc is called for every task in a workflow document. The workflow name
+// is the Temporal workflow type the task executes in - child workflows
+// generated for for, fork and try tasks have their own type.
+type WalkFunc func(task *model.TaskItem, workflowName string) error
+
+// Walk visits every task in the document depth-first, in the order they are
+// declared. Returning an error stops the walk.
+func Walk(doc *model.Workflow, fn WalkFunc) error {
+	return walkTaskList(doc.Do, doc.Document.Name, fn)
+}
+
+func walkTaskList(list *model.TaskList, workflowName string, fn WalkFunc) error {
+	if list == nil {
+		return nil
+	}
+
+	for _, item := range *list {
+		if err := fn(item, workflowName); err != nil {
+			return err
+		}
+
+		if err := walkChildren(item, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkChildren(item *model.TaskItem, fn WalkFunc) error {
+	switch task := item.Task.(type) {
+	case *model.DoTask:
+		// Do tasks are registered as a workflow in their own right
+		return walkTaskList(task.Do, item.Key, fn)
+	case *model.ForTask:
+		return walkTaskList(task.Do, utils.GenerateChildWorkflowName("for", item.Key), fn)
+	case *model.ForkTask:
+		if task.Fork.Branches == nil {
+			return nil
+		}
+		for _, branch := range *task.Fork.Branches {
+			childWorkflowName := utils.GenerateChildWorkflowName("fork", item.Key, branch.Key)
+			if do := branch.AsDoTask(); do != nil {
+				if err := fn(branch, childWorkflowName); err != nil {
+					return err
+				}
+				if err := walkTaskList(do.Do, childWorkflowName, fn); err != nil {
+					return err
+				}
+				continue
+			}
+			// Single task branches are wrapped in their own workflow
+			if err := walkTaskList(&model.TaskList{branch}, childWorkflowName, fn); err != nil {
+				return err
+			}
+		}
+	case *model.TryTask:
+		if err := walkTaskList(task.Try, utils.GenerateChildWorkflowName("try", item.Key), fn); err != nil {
+			return err
+		}
+		if task.Catch != nil {
+			return walkTaskList(task.Catch.Do, utils.GenerateChildWorkflowName("catch", item.Key), fn)
+		}
+	}
+
+	return nil
+}
+
+// WorkflowTypes returns the Temporal workflow types registered by the document
+// in declaration order. A workflow is registered when it directly contains a
+// task that isn't a do task. Generated child workflows are only included if
+// includeChildren is set.
+func WorkflowTypes(doc *model.Workflow, includeChildren bool) []string {
+	types := make([]string, 0)
+	seen := map[string]bool{}
+
+	_ = Walk(doc, func(item *model.TaskItem, workflowName string) error {
+		if seen[workflowName] || item.AsDoTask() != nil {
+			return nil
+		}
+		if !includeChildren && strings.HasPrefix(workflowName, utils.GenerateChildWorkflowName("")) {
+			return nil
+		}
+
+		seen[workflowName] = true
+		types = append(types, workflowName)
+
+		return nil
+	})
+
+	return types
+}
+
+// ChildWorkflows returns the workflows that the task starts
+func ChildWorkflows(item *model.TaskItem) []string {
+	names := make([]string, 0)
+
+	switch task := item.Task.(type) {
+	case *model.ForTask:
+		names = append(names, utils.GenerateChildWorkflowName("for", item.Key))
+	case *model.ForkTask:
+		if task.Fork.Branches != nil {
+			for _, branch := range *task.Fork.Branches {
+				names = append(names, utils.GenerateChildWorkflowName("fork", item.Key, branch.Key))
+			}
+		}
+	case *model.TryTask:
+		names = append(names, utils.GenerateChildWorkflowName("try", item.Key))
+		if task.Catch != nil {
+			names = append(names, utils.GenerateChildWorkflowName("catch", item.Key))
+		}
+	case *model.RunTask:
+		if task.Run.Workflow != nil {
+			names = append(names, task.Run.Workflow.Name)
+		}
+	case *model.SwitchTask:
+		for _, switchItem := range task.Switch {
+			for _, switchCase := range switchItem {
+				if switchCase.Then != nil && !switchCase.Then.IsEnum() && !slices.Contains(names, switchCase.Then.Value) {
+					names = append(names, switchCase.Then.Value)
+				}
+			}
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 - 2026 Zigflow authors <https://github.com/zigflow/zigflow/graphs/contributors>
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package zigflow_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/serverlessworkflow/sdk-go/v3/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zigflow/zigflow/pkg/zigflow"
+	"github.com/zigflow/zigflow/pkg/zigflow/tasks"
+)
+
+const nestedWorkflow = `document:
+  dsl: 1.0.0
+  namespace: default
+  name: parent
+  version: 0.0.1
+do:
+  - approve:
+      listen:
+        to:
+          all:
+            - with:
+                id: approve
+                type: signal
+            - with:
+                id: status
+                type: query
+  - loop:
+      for:
+        in: ${ [1, 2] }
+      do:
+        - step:
+            set:
+              hello: world
+  - parallel:
+      fork:
+        branches:
+          - single:
+              wait:
+                seconds: 1
+          - multi:
+              do:
+                - confirm:
+                    listen:
+                      to:
+                        one:
+                          with:
+                            id: confirm
+                            type: update
+  - attempt:
+      try:
+        - risky:
+            set:
+              hello: world
+      catch:
+        do:
+          - recover:
+              set:
+                hello: world
+`
+
+const multipleWorkflows = `document:
+  dsl: 1.0.0
+  namespace: default
+  name: ignored
+  version: 0.0.1
+do:
+  - workflow1:
+      do:
+        - step:
+            set:
+              hello: world
+  - workflow2:
+      do:
+        - step:
+            set:
+              hello: world
+`
+
+func loadTestWorkflow(t *testing.T, content string) *model.Workflow {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "walk_test")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	filePath := filepath.Join(tmpDir, "workflow.yaml")
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+
+	wf, err := zigflow.LoadFromFile(filePath)
+	require.NoError(t, err)
+
+	return wf
+}
+
+func TestWalk(t *testing.T) {
+	wf := loadTestWorkflow(t, nestedWorkflow)
+
+	visited := []string{}
+	err := zigflow.Walk(wf, func(item *model.TaskItem, workflowName string) error {
+		visited = append(visited, workflowName+"/"+item.Key)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"parent/approve",
+		"parent/loop",
+		"workflow_for_loop/step",
+		"parent/parallel",
+		"workflow_fork_parallel_single/single",
+		"workflow_fork_parallel_multi/multi",
+		"workflow_fork_parallel_multi/confirm",
+		"parent/attempt",
+		"workflow_try_attempt/risky",
+		"workflow_catch_attempt/recover",
+	}, visited)
+}
+
+func TestWorkflowTypes(t *testing.T) {
+	tests := []struct {
+		Name            string
+		Content         string
+		IncludeChildren bool
+		Expected        []string
+	}{
+		{
+			Name:     "single workflow",
+			Content:  nestedWorkflow,
+			Expected: []string{"parent"},
+		},
+		{
+			Name:            "single workflow with children",
+			Content:         nestedWorkflow,
+			IncludeChildren: true,
+			Expected: []string{
+				"parent",
+				"workflow_for_loop",
+				"workflow_fork_parallel_single",
+				"workflow_fork_parallel_multi",
+				"workflow_try_attempt",
+				"workflow_catch_attempt",
+			},
+		},
+		{
+			Name:     "multiple workflows",
+			Content:  multipleWorkflows,
+			Expected: []string{"workflow1", "workflow2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			wf := loadTestWorkflow(t, test.Content)
+
+			assert.Equal(t, test.Expected, zigflow.WorkflowTypes(wf, test.IncludeChildren))
+		})
+	}
+}
+
+func TestListeners(t *testing.T) {
+	wf := loadTestWorkflow(t, nestedWorkflow)
+
+	listeners := zigflow.Listeners(wf)
+	require.Len(t, listeners, 3)
+
+	assert.Equal(t, "approve", listeners[0].ID)
+	assert.Equal(t, tasks.ListenTaskTypeSignal, listeners[0].Type)
+	assert.Equal(t, "parent", listeners[0].Workflow)
+
+	assert.Equal(t, "status", listeners[1].ID)
+	assert.Equal(t, tasks.ListenTaskTypeQuery, listeners[1].Type)
+
+	assert.Equal(t, "confirm", listeners[2].ID)
+	assert.Equal(t, "confirm", listeners[2].Task)
+	assert.Equal(t, "workflow_fork_parallel_multi", listeners[2].Workflow)
+
+	assert.NotNil(t, zigflow.FindListener(wf, tasks.ListenTaskTypeSignal, "approve"))
+	assert.Nil(t, zigflow.FindListener(wf, tasks.ListenTaskTypeUpdate, "approve"))
+}
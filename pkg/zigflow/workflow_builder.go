@@ -34,6 +34,25 @@ func NewWorkflow(
 	envvars map[string]any,
 	emitter *cloudevents.Events,
 	telem *telemetry.Telemetry,
+) error {
+	if err := RegisterWorkflow(temporalWorker, doc, envvars, emitter, telem); err != nil {
+		return err
+	}
+
+	RegisterActivities(temporalWorker)
+
+	return nil
+}
+
+// RegisterWorkflow builds the document's workflows and registers them on the
+// worker. Activities are not registered - use RegisterActivities once per
+// worker when registering several documents.
+func RegisterWorkflow(
+	temporalWorker worker.Worker,
+	doc *model.Workflow,
+	envvars map[string]any,
+	emitter *cloudevents.Events,
+	telem *telemetry.Telemetry,
 ) error {
 	workflowName := doc.Document.Name
 	l := log.With().Str("workflowName", workflowName).Logger()
@@ -70,12 +89,15 @@ func NewWorkflow(
 		return fmt.Errorf("error building workflow: %w", err)
 	}
 
+	return nil
+}
+
+// RegisterActivities registers the Zigflow activities on the worker
+func RegisterActivities(temporalWorker worker.Worker) {
 	for _, a := range tasks.ActivitiesList() {
-		l.Debug().Msg("Registering activity")
+		log.Debug().Msg("Registering activity")
 		temporalWorker.RegisterActivity(a)
 	}
-
-	return nil
 }
 
 func newWorkflowPostLoad(doc *model.Workflow) error {